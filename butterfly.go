@@ -0,0 +1,133 @@
+package fixedincome
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Weighting is the weighting of the wings of a butterfly
+type Weighting int
+
+const (
+	// FiftyFifty splits the DV01 of the body equally between the wings
+	FiftyFifty Weighting = iota
+	// DurationNeutral weights the wings such that the butterfly is cash and
+	// DV01 neutral
+	DurationNeutral
+)
+
+// String returns the name of the weighting
+func (w Weighting) String() string {
+	switch w {
+	case FiftyFifty:
+		return "50/50"
+	case DurationNeutral:
+		return "duration-neutral"
+	}
+	return fmt.Sprintf("Weighting(%d)", int(w))
+}
+
+// ErrInvalidButterfly is returned when the wings of a butterfly cannot be weighted
+var ErrInvalidButterfly = errors.New("invalid butterfly")
+
+// Butterfly is a trade that buys the short and long wing and sells the body
+type Butterfly struct {
+	Short       Priced
+	Body        Priced
+	Long        Priced
+	BodyNominal float64
+	Weighting   Weighting
+}
+
+// Leg is a weighted position of a butterfly
+type Leg struct {
+	Analytics
+	// Nominal is the nominal of the leg (negative for the sold body)
+	Nominal float64
+	// Value is the dirty market value of the leg
+	Value float64
+	// DV01 is the value change of the leg for a decline of its yield by one bp
+	DV01 float64
+}
+
+// Fly are the analytics of a butterfly
+type Fly struct {
+	Weighting Weighting
+	Short     Leg
+	Body      Leg
+	Long      Leg
+	// Spread is the butterfly spread 2 * body less wings yield in bps
+	Spread float64
+	// Cash is the net dirty market value of the legs
+	Cash float64
+	// DV01 is the net DV01 of the legs
+	DV01 float64
+}
+
+// Analyze weights the wings of the butterfly and returns its analytics
+func (b *Butterfly) Analyze(ts term.Structure) (Fly, error) {
+	f := Fly{Weighting: b.Weighting}
+	var err error
+	if f.Short.Analytics, err = Analyze(b.Short, ts, 0.0); err != nil {
+		return f, fmt.Errorf("short wing: %w", err)
+	}
+	if f.Body.Analytics, err = Analyze(b.Body, ts, 0.0); err != nil {
+		return f, fmt.Errorf("body: %w", err)
+	}
+	if f.Long.Analytics, err = Analyze(b.Long, ts, 0.0); err != nil {
+		return f, fmt.Errorf("long wing: %w", err)
+	}
+
+	// values and DV01 per unit of nominal
+	vs := f.Short.Price / par(b.Short.Security)
+	vb := f.Body.Price / par(b.Body.Security)
+	vl := f.Long.Price / par(b.Long.Security)
+	ds, db, dl := -vs*f.Short.Duration*0.0001, -vb*f.Body.Duration*0.0001, -vl*f.Long.Duration*0.0001
+	if ds == 0.0 || dl == 0.0 {
+		return f, fmt.Errorf("%w: wings without duration", ErrInvalidButterfly)
+	}
+	body := b.BodyNominal
+
+	switch b.Weighting {
+	case FiftyFifty:
+		f.Short.Nominal = 0.5 * body * db / ds
+		f.Long.Nominal = 0.5 * body * db / dl
+	case DurationNeutral:
+		// solve the wings for the value and the DV01 of the body
+		det := vs*dl - vl*ds
+		if det == 0.0 {
+			return f, fmt.Errorf("%w: wings with equal duration", ErrInvalidButterfly)
+		}
+		f.Short.Nominal = body * (vb*dl - vl*db) / det
+		f.Long.Nominal = body * (vs*db - vb*ds) / det
+	default:
+		return f, fmt.Errorf("%w: unknown weighting %v", ErrInvalidButterfly, b.Weighting)
+	}
+	f.Body.Nominal = -body
+
+	for _, leg := range []struct {
+		leg  *Leg
+		v, d float64
+	}{{&f.Short, vs, ds}, {&f.Body, vb, db}, {&f.Long, vl, dl}} {
+		leg.leg.Value = leg.leg.Nominal * leg.v
+		leg.leg.DV01 = leg.leg.Nominal * leg.d
+		f.Cash += leg.leg.Value
+		f.DV01 += leg.leg.DV01
+	}
+	f.Spread = (2.0*f.Body.Yield - f.Short.Yield - f.Long.Yield) * 100.0
+	return f, nil
+}
+
+// PnL returns the profit and loss of the butterfly for the changes of the
+// yields of the legs in bps with the second-order approximation of the prices
+func (f *Fly) PnL(short, body, long float64) float64 {
+	return f.Short.pnl(short) + f.Body.pnl(body) + f.Long.pnl(long)
+}
+
+// pnl returns the profit and loss of the leg for the change of its yield in bps
+func (l *Leg) pnl(dy float64) float64 {
+	dy *= 0.0001
+	return l.Value * (l.Duration*dy + 0.5*l.Convexity*dy*dy)
+}
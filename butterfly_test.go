@@ -0,0 +1,89 @@
+package fixedincome_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestButterfly_Analyze(t *testing.T) {
+	settlement := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	straight := func(years int, coupon float64) *bond.Straight {
+		return &bond.Straight{
+			Schedule:   maturity.Schedule{Settlement: settlement, Maturity: settlement.AddDate(years, 0, 0), Frequency: 1},
+			Redemption: 100.0,
+			Coupon:     coupon,
+		}
+	}
+	ts := term.NelsonSiegelSvensson{-0.43381, -0.31901, 0.01, 0.01, 2.24036, 0.0, 0.0}
+
+	var testData = []struct {
+		Weighting fixedincome.Weighting
+		Cash      bool
+	}{
+		{fixedincome.FiftyFifty, false},
+		{fixedincome.DurationNeutral, true},
+	}
+	for _, test := range testData {
+		b := fixedincome.Butterfly{
+			Short:       fixedincome.Priced{Security: straight(2, 0.5)},
+			Body:        fixedincome.Priced{Security: straight(5, 1.0)},
+			Long:        fixedincome.Priced{Security: straight(10, 1.5)},
+			BodyNominal: 1e6,
+			Weighting:   test.Weighting,
+		}
+		f, err := b.Analyze(&ts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(f.DV01) > 1e-6 {
+			t.Errorf("wrong net DV01 for %v; got: %v, expected: %v", test.Weighting, f.DV01, 0.0)
+		}
+		if test.Cash && math.Abs(f.Cash) > 1e-6 {
+			t.Errorf("wrong net cash for %v; got: %v, expected: %v", test.Weighting, f.Cash, 0.0)
+		}
+		if test.Weighting == fixedincome.FiftyFifty && math.Abs(f.Short.DV01-f.Long.DV01) > 1e-6 {
+			t.Errorf("wrong wing DV01 for %v; got: %v, expected: %v", test.Weighting, f.Short.DV01, f.Long.DV01)
+		}
+		if f.Body.Nominal != -1e6 {
+			t.Errorf("wrong body nominal; got: %v, expected: %v", f.Body.Nominal, -1e6)
+		}
+		expected := (2.0*f.Body.Yield - f.Short.Yield - f.Long.Yield) * 100.0
+		if math.Abs(f.Spread-expected) > 1e-9 {
+			t.Errorf("wrong fly spread; got: %v, expected: %v", f.Spread, expected)
+		}
+		// a parallel shift is hedged to first order
+		if pnl := f.PnL(10.0, 10.0, 10.0); math.Abs(pnl) > 0.05*math.Abs(10.0*f.Body.DV01) {
+			t.Errorf("wrong P&L of parallel shift for %v; got: %v", test.Weighting, pnl)
+		}
+		// the body cheapens relative to the wings
+		if pnl := f.PnL(0.0, 10.0, 0.0); math.Abs(pnl-10.0*-f.Body.DV01) > 0.01*math.Abs(pnl) {
+			t.Errorf("wrong P&L of body shift for %v; got: %v, expected: %v", test.Weighting, pnl, 10.0*-f.Body.DV01)
+		}
+	}
+}
+
+func TestButterfly_Errors(t *testing.T) {
+	settlement := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	wing := &bond.Straight{
+		Schedule:   maturity.Schedule{Settlement: settlement, Maturity: settlement.AddDate(3, 0, 0), Frequency: 1},
+		Redemption: 100.0,
+		Coupon:     1.0,
+	}
+	b := fixedincome.Butterfly{
+		Short:       fixedincome.Priced{Security: wing},
+		Body:        fixedincome.Priced{Security: wing},
+		Long:        fixedincome.Priced{Security: wing},
+		BodyNominal: 1e6,
+		Weighting:   fixedincome.DurationNeutral,
+	}
+	if _, err := b.Analyze(&term.Flat{R: 1.0}); !errors.Is(err, fixedincome.ErrInvalidButterfly) {
+		t.Errorf("wrong error; got: %v, expected: %v", err, fixedincome.ErrInvalidButterfly)
+	}
+}
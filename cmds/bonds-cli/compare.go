@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+)
+
+// compare prints the side-by-side analytics of two fixed-coupon bonds
+// (usage: bonds-cli compare -maturity1 ... -maturity2 ...)
+func compare(args []string) {
+	fs := flag.NewFlagSet("compare", flag.ExitOnError)
+	settlementDate := fs.String("settlement", time.Now().Format("2006-01-02"), "valuation date / settlement date")
+	file := fs.String("f", "term.json", "json file containing the parameters for term structure")
+	n := fs.Int("n", 1, "compounding frequency per year")
+	basis := fs.String("daycount", "30E360", "day count convention for accured interest")
+	horizon := fs.Float64("horizon", 0.25, "horizon in years for the carry")
+	maturities := [2]*string{
+		fs.String("maturity1", "", "maturity date of the first bond"),
+		fs.String("maturity2", "", "maturity date of the second bond"),
+	}
+	coupons := [2]*float64{
+		fs.Float64("coupon1", 0.0, "coupon of the first bond in percent"),
+		fs.Float64("coupon2", 0.0, "coupon of the second bond in percent"),
+	}
+	quotes := [2]*float64{
+		fs.Float64("quote1", 0.0, "quoted clean price of the first bond (0 for the model price)"),
+		fs.Float64("quote2", 0.0, "quoted clean price of the second bond (0 for the model price)"),
+	}
+	verbose := fs.Bool("v", false, "verbose output with debug tracing of the solvers and cash flows")
+	quiet := fs.Bool("q", false, "only log warnings and errors")
+	fs.Parse(args)
+	setupLogging(*verbose, *quiet)
+
+	ts, err := readTerm(*file)
+	if err != nil {
+		fatal(err)
+	}
+	settlement, err := time.Parse("2006-01-02", *settlementDate)
+	if err != nil {
+		fatal(err)
+	}
+
+	priced := [2]fixedincome.Priced{}
+	for i := range priced {
+		m, err := time.Parse("2006-01-02", *maturities[i])
+		if err != nil {
+			fatal(err)
+		}
+		b := &bond.Straight{
+			Schedule: maturity.Schedule{
+				Settlement: settlement,
+				Maturity:   m,
+				Frequency:  *n,
+				Basis:      *basis,
+			},
+			Coupon:     *coupons[i],
+			Redemption: 100.0,
+		}
+		if err := b.Validate(); err != nil {
+			fatal(err)
+		}
+		priced[i] = fixedincome.Priced{Security: b}
+		if *quotes[i] != 0.0 {
+			priced[i].Price = *quotes[i] + b.Accrued()
+		}
+	}
+
+	c, err := fixedincome.Compare(priced[0], priced[1], ts, *horizon)
+	if err != nil {
+		fatal(err)
+	}
+	fmt.Printf("                        %10s %10s %10s\n", "First", "Second", "Diff")
+	rows := []struct {
+		name   string
+		values func(a fixedincome.Analytics) float64
+	}{
+		{"Dirty Price", func(a fixedincome.Analytics) float64 { return a.Price }},
+		{"Yield-to-Maturity [%]", func(a fixedincome.Analytics) float64 { return a.Yield }},
+		{"Spread [bps]", func(a fixedincome.Analytics) float64 { return a.Spread }},
+		{"Modified duration", func(a fixedincome.Analytics) float64 { return a.Duration }},
+		{"Convexity", func(a fixedincome.Analytics) float64 { return a.Convexity }},
+		{"Carry", func(a fixedincome.Analytics) float64 { return a.Carry }},
+		{"Breakeven [bps]", func(a fixedincome.Analytics) float64 { return a.Breakeven }},
+	}
+	for _, row := range rows {
+		fmt.Printf("%-23s %10.4f %10.4f %10.4f\n", row.name, row.values(c.First), row.values(c.Second), row.values(c.Difference))
+	}
+}
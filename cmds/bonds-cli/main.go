@@ -5,14 +5,16 @@ import (
 	"flag"
 	"fmt"
 	"io/ioutil"
-	"log"
+	"log/slog"
+	"os"
 	"strings"
 	"time"
 
-	"github.com/konimarti/daycount"
 	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/conventions"
 	"github.com/konimarti/fixedincome/pkg/instrument/bond"
 	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/rounding"
 	"github.com/konimarti/fixedincome/pkg/term"
 )
 
@@ -21,44 +23,61 @@ var (
 	maturityFlag   = flag.String("maturity", time.Now().AddDate(1, 0, 0).Format("2006-01-02"), "maturity date of bond")
 	coupon         = flag.Float64("coupon", 0.0, "coupon in percent of par value")
 	frequency      = flag.Int("n", 1, "compounding frequency per year")
-	price          = flag.Float64("quote", 0.0, "quoted bond price at settlement date")
+	price          = flag.Float64("quote", 0.0, "quoted clean bond price (or yield/discount rate in percent, see -quotetype) at settlement date")
+	quoteType      = flag.String("quotetype", "price", "type of the quote, available: price, yield, discount")
+	compounding    = flag.Int("compounding", 0, "compounding frequency per year of a yield quote (0 for continuous compounding)")
 	redemption     = flag.Float64("redemption", 100.0, "redemption value of bond at maturity")
+	par            = flag.Float64("par", 100.0, "notional base for prices and accrued interest (e.g. 1, 100, 1000)")
 	spread         = flag.Float64("spread", 0.0, "Static (zero-volatility) spread in basepoints for valuing risky bonds")
 	fileFlag       = flag.String("f", "term.json", "json file containing the parameters for term structure")
-	option         = strings.Join([]string{"day count convention for accured interest, available: ", strings.Join(daycount.Implemented(), ", ")}, "")
+	option         = strings.Join([]string{"day count convention for accured interest, available: ", strings.Join(maturity.Conventions(), ", ")}, "")
 	daycountname   = flag.String("daycount", "30E360", option)
+	nominal        = flag.Float64("nominal", 0.0, "nominal amount for the settlement amount (deactivate it by setting it to 0.0)")
+	currency       = flag.String("currency", "CHF", "currency of the bond for rounding the settlement amount")
+	endOfMonth     = flag.Bool("eom", false, "roll the coupon dates to the end of the month for bonds maturing on the last day of a month")
+	exDividend     = flag.Int("exdiv", 0, "ex-dividend period in business days before a coupon date (0 for none)")
+	market         = flag.String("market", "", "market preset for day count, frequency, end-of-month rule, ex-dividend period and currency unless set explicitly, available: "+strings.Join(conventions.Markets(), ", "))
+	verbose        = flag.Bool("v", false, "verbose output with debug tracing of the solvers and cash flows")
+	quiet          = flag.Bool("q", false, "only log warnings and errors")
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "compare" {
+		compare(os.Args[2:])
+		return
+	}
 	flag.Parse()
+	setupLogging(*verbose, *quiet)
 
-	// read term structure parameters and create NSS model
-	termData, err := ioutil.ReadFile(*fileFlag)
-	if err != nil {
-		log.Println(err)
+	// apply market conventions
+	if *market != "" {
+		if err := applyMarket(*market); err != nil {
+			fatal(err)
+		}
 	}
 
-	ts, err := term.Parse(termData)
+	// read term structure parameters
+	ts, err := readTerm(*fileFlag)
 	if err != nil {
-		log.Println(err)
-		log.Println("no file given for term structure parameters. Use template for e.g. Nelson-Siegel-Svensson:")
-		data, err := json.MarshalIndent(term.NelsonSiegelSvensson{}, " ", "")
-		if err != nil {
-			panic(err)
-		}
-		fmt.Println(string(data))
-		return
+		slog.Error(err.Error())
+		fmt.Fprintln(os.Stderr, "Use the following template for e.g. the Nelson-Siegel-Svensson term structure:")
+		data, _ := json.MarshalIndent(term.NelsonSiegelSvensson{}, " ", "")
+		fmt.Fprintln(os.Stderr, string(data))
+		os.Exit(1)
 	}
 	fmt.Println("Term model read from", *fileFlag)
+	for _, warning := range term.Diagnose(ts, 50.0).Warnings() {
+		slog.Warn(warning)
+	}
 
 	// parse quote and maturity dates
 	quoteDate, err := time.Parse("2006-01-02", *settlementFlag)
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
 	maturityDate, err := time.Parse("2006-01-02", *maturityFlag)
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
 
 	// create fixed-coupon bond
@@ -68,9 +87,16 @@ func main() {
 			Maturity:   maturityDate,
 			Frequency:  *frequency,
 			Basis:      *daycountname,
+			EndOfMonth: *endOfMonth,
 		},
 		Coupon:     *coupon,
 		Redemption: *redemption,
+		Par:        *par,
+		ExDividend: *exDividend,
+	}
+
+	if err := bond.Validate(); err != nil {
+		fatal(err)
 	}
 
 	// set spread
@@ -90,9 +116,12 @@ func main() {
 	fmt.Printf("Coupon           : %.2f\n", *coupon)
 	fmt.Printf("Frequency        : %d\n", *frequency)
 	fmt.Printf("Day Convention   : %s\n", *daycountname)
-	if days, err := daycount.Days(quoteDate, maturityDate, *daycountname); err == nil {
-		fmt.Printf("Days             : %d\n", int(days))
+	if dc, err := maturity.Lookup(*daycountname); err == nil {
+		fmt.Printf("Days             : %d\n", int(dc.Days(quoteDate, maturityDate)))
 	}
+	fmt.Printf("Previous Coupon  : %s\n", bond.PreviousCoupon().Format("2006-01-02"))
+	fmt.Printf("Next Coupon      : %s\n", bond.NextCoupon().Format("2006-01-02"))
+	fmt.Printf("Days Accrued     : %d of %d\n", int(bond.DaysAccrued()), int(bond.DaysInPeriod()))
 	fmt.Println("")
 	fmt.Printf("Spread           : %.2f\n", *spread)
 
@@ -105,25 +134,74 @@ func main() {
 	fmt.Println("")
 
 	bondPrice := clean
-	if *price > 0.0 {
-		bondPrice = *price
-		fmt.Println("Yields for the quoted price:")
+	if *price != 0.0 {
+		qt, err := fixedincome.ParseQuoteType(*quoteType)
+		if err != nil {
+			fatal(err)
+		}
+		quote := fixedincome.Quote{Type: qt, Value: *price, Compounding: *compounding}
+		bondPrice = quote.CleanPrice(&bond)
+		fmt.Printf("Yields for the quoted %s:\n", qt)
 	} else {
 		fmt.Println("Yields for the calculated clean price:")
 	}
 
 	fmt.Printf("  Quoted Price        %10.4f\n", bondPrice)
 	fmt.Printf("  Invoice Price       %10.4f\n", bondPrice+bond.Accrued())
+	if *nominal > 0.0 {
+		policy := rounding.ForCurrency(*currency)
+		// amounts are calculated per 100 of par
+		perPar := 100.0 / bond.ParValue()
+		fmt.Printf("  Accrued Amount      %10.2f %s\n", policy.Accrued(*nominal, perPar*bond.Accrued()), *currency)
+		fmt.Printf("  Settlement Amount   %10.2f %s\n", policy.Invoice(*nominal, perPar*bondPrice, perPar*bond.Accrued()), *currency)
+	}
 	irr, err := fixedincome.Irr(bondPrice+bond.Accrued(), &bond)
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
 	fmt.Printf("  Yield-to-Maturity   %10.4f %%\n", irr)
 
 	spread, err := fixedincome.Spread(bondPrice+bond.Accrued(), &bond, ts)
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
 	fmt.Printf("  Implied spread      %10.1f bps\n", spread)
 
 }
+
+// applyMarket sets the day count convention, frequency, end-of-month rule,
+// ex-dividend period and currency of the market preset for the flags that
+// are not set on the command line
+func applyMarket(name string) error {
+	c, err := conventions.Lookup(name)
+	if err != nil {
+		return err
+	}
+	set := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	if !set["daycount"] {
+		*daycountname = c.Basis
+	}
+	if !set["n"] {
+		*frequency = c.Frequency
+	}
+	if !set["eom"] {
+		*endOfMonth = c.EndOfMonth
+	}
+	if !set["exdiv"] {
+		*exDividend = c.ExDividend
+	}
+	if !set["currency"] {
+		*currency = c.Currency
+	}
+	return nil
+}
+
+// readTerm reads the term structure parameters from a json file
+func readTerm(file string) (term.Structure, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading term structure failed: %w", err)
+	}
+	return term.Parse(data)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+var (
+	addr     = flag.String("addr", "localhost:8080", "address of the http server")
+	fileFlag = flag.String("f", "term.json", "json file containing the parameters for term structure")
+	profile  = flag.Bool("pprof", false, "serve the pprof profiling endpoints under /debug/pprof/")
+	verbose  = flag.Bool("v", false, "verbose output with debug tracing of the solvers and cash flows")
+	quiet    = flag.Bool("q", false, "only log warnings and errors")
+)
+
+func main() {
+	flag.Parse()
+	setupLogging(*verbose, *quiet)
+
+	data, err := ioutil.ReadFile(*fileFlag)
+	if err != nil {
+		fatal(err)
+	}
+	ts, err := term.Parse(data)
+	if err != nil {
+		fatal(err)
+	}
+	slog.Info("term model read", "file", *fileFlag)
+
+	mux := http.NewServeMux()
+	mux.Handle("/price", &pricer{ts: ts})
+	mux.Handle("/debug/vars", metrics.handler())
+	mux.Handle("/metrics", metrics)
+	if *profile {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+		slog.Info("profiling endpoints enabled", "path", "/debug/pprof/")
+	}
+
+	slog.Info("listening", "addr", *addr)
+	fatal(http.ListenAndServe(*addr, mux))
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// histogram counts observations in cumulative buckets with upper bounds
+type histogram struct {
+	bounds []float64
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+func newHistogram(bounds ...float64) *histogram {
+	return &histogram{bounds: bounds, counts: make([]uint64, len(bounds))}
+}
+
+func (h *histogram) observe(x float64) {
+	for i, b := range h.bounds {
+		if x <= b {
+			h.counts[i] += 1
+		}
+	}
+	h.sum += x
+	h.count += 1
+}
+
+// write writes the histogram in the Prometheus text format
+func (h *histogram) write(w io.Writer, name, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", name, help, name)
+	for i, b := range h.bounds {
+		fmt.Fprintf(w, "%s_bucket{le=\"%s\"} %d\n", name, strconv.FormatFloat(b, 'g', -1, 64), h.counts[i])
+	}
+	fmt.Fprintf(w, "%s_bucket{le=\"+Inf\"} %d\n", name, h.count)
+	fmt.Fprintf(w, "%s_sum %s\n", name, strconv.FormatFloat(h.sum, 'g', -1, 64))
+	fmt.Fprintf(w, "%s_count %d\n", name, h.count)
+}
+
+// timing holds the timing metrics of the server, published as JSON under
+// /debug/vars and in the Prometheus text format under /metrics
+type timing struct {
+	mu          sync.Mutex
+	requests    uint64
+	errors      uint64
+	latency     *histogram
+	evaluations *histogram
+}
+
+var metrics = newTiming()
+
+func newTiming() *timing {
+	m := &timing{
+		latency:     newHistogram(0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5),
+		evaluations: newHistogram(10, 20, 50, 100, 200, 500),
+	}
+	expvar.Publish("pricing", expvar.Func(m.vars))
+	return m
+}
+
+// observe records a pricing request with its latency and the number of
+// present value evaluations of the solvers
+func (m *timing) observe(elapsed time.Duration, evaluations int, failed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests += 1
+	if failed {
+		m.errors += 1
+	}
+	m.latency.observe(elapsed.Seconds())
+	m.evaluations.observe(float64(evaluations))
+}
+
+// vars returns the metrics for expvar
+func (m *timing) vars() interface{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return map[string]interface{}{
+		"requests":           m.requests,
+		"errors":             m.errors,
+		"seconds":            m.latency.sum,
+		"solver_evaluations": m.evaluations.sum,
+	}
+}
+
+// ServeHTTP writes the metrics in the Prometheus text format
+func (m *timing) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprintf(w, "# HELP bonds_pricing_requests_total Number of pricing requests.\n# TYPE bonds_pricing_requests_total counter\nbonds_pricing_requests_total %d\n", m.requests)
+	fmt.Fprintf(w, "# HELP bonds_pricing_errors_total Number of failed pricing requests.\n# TYPE bonds_pricing_errors_total counter\nbonds_pricing_errors_total %d\n", m.errors)
+	m.latency.write(w, "bonds_pricing_duration_seconds", "Latency of the pricing requests in seconds.")
+	m.evaluations.write(w, "bonds_solver_evaluations", "Present value evaluations of the yield and spread solvers per request.")
+}
+
+// handler returns the handler for the metrics published with expvar
+func (m *timing) handler() http.Handler {
+	return expvar.Handler()
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// request are the terms of a fixed-coupon bond to price
+type request struct {
+	Settlement string  `json:"settlement"`
+	Maturity   string  `json:"maturity"`
+	Coupon     float64 `json:"coupon"`
+	Frequency  int     `json:"frequency"`
+	Basis      string  `json:"basis"`
+	Redemption float64 `json:"redemption"`
+	// Quote is the quoted clean price (default: 0 for the model price)
+	Quote float64 `json:"quote"`
+	// Spread is the static spread in bps for the model price
+	Spread float64 `json:"spread"`
+}
+
+// response are the price and yields of the bond
+type response struct {
+	Dirty       float64 `json:"dirty"`
+	Clean       float64 `json:"clean"`
+	Accrued     float64 `json:"accrued"`
+	Duration    float64 `json:"duration"`
+	Yield       float64 `json:"yield"`
+	Spread      float64 `json:"spread"`
+	Evaluations int     `json:"evaluations"`
+}
+
+// pricer serves the pricing requests against the term structure
+type pricer struct {
+	ts term.Structure
+}
+
+func (p *pricer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		p.fail(w, start, 0, http.StatusBadRequest, err)
+		return
+	}
+	res, err := p.price(req)
+	if err != nil {
+		p.fail(w, start, res.Evaluations, http.StatusUnprocessableEntity, err)
+		return
+	}
+	elapsed := time.Since(start)
+	metrics.observe(elapsed, res.Evaluations, false)
+	slog.Info("price", "maturity", req.Maturity, "coupon", req.Coupon, "elapsed", elapsed, "evaluations", res.Evaluations)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+// fail records the failed request and writes the error
+func (p *pricer) fail(w http.ResponseWriter, start time.Time, evaluations, status int, err error) {
+	elapsed := time.Since(start)
+	metrics.observe(elapsed, evaluations, true)
+	slog.Warn("price failed", "error", err, "elapsed", elapsed)
+	http.Error(w, err.Error(), status)
+}
+
+// price values the bond of the request
+func (p *pricer) price(req request) (response, error) {
+	settlement, err := time.Parse("2006-01-02", req.Settlement)
+	if err != nil {
+		return response{}, fmt.Errorf("settlement: %w", err)
+	}
+	date, err := time.Parse("2006-01-02", req.Maturity)
+	if err != nil {
+		return response{}, fmt.Errorf("maturity: %w", err)
+	}
+	if req.Frequency == 0 {
+		req.Frequency = 1
+	}
+	if req.Basis == "" {
+		req.Basis = "30E360"
+	}
+	if req.Redemption == 0.0 {
+		req.Redemption = 100.0
+	}
+	b := &counting{Straight: &bond.Straight{
+		Schedule:   maturity.Schedule{Settlement: settlement, Maturity: date, Frequency: req.Frequency, Basis: req.Basis},
+		Coupon:     req.Coupon,
+		Redemption: req.Redemption,
+	}}
+	if err := b.Validate(); err != nil {
+		return response{}, err
+	}
+
+	ts := term.WithSpread(p.ts, req.Spread)
+	res := response{Accrued: b.Accrued(), Duration: b.Duration(ts)}
+	res.Dirty = b.Straight.PresentValue(ts)
+	res.Clean = res.Dirty - res.Accrued
+	if req.Quote != 0.0 {
+		res.Clean = req.Quote
+		res.Dirty = req.Quote + res.Accrued
+	}
+
+	if res.Yield, err = fixedincome.Irr(res.Dirty, b); err != nil {
+		res.Evaluations = b.evaluations
+		return res, fmt.Errorf("yield: %w", err)
+	}
+	if res.Spread, err = fixedincome.Spread(res.Dirty, b, p.ts); err != nil {
+		res.Evaluations = b.evaluations
+		return res, fmt.Errorf("spread: %w", err)
+	}
+	res.Evaluations = b.evaluations
+	return res, nil
+}
+
+// counting counts the present value evaluations of the solvers
+type counting struct {
+	*bond.Straight
+	evaluations int
+}
+
+func (c *counting) PresentValue(ts term.Structure) float64 {
+	c.evaluations += 1
+	return c.Straight.PresentValue(ts)
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/konimarti/fixedincome/pkg/logging"
+)
+
+// setupLogging sets the structured logger of the command and the library
+// for the verbosity flags
+func setupLogging(verbose, quiet bool) {
+	l := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logging.Level(verbose, quiet)}))
+	slog.SetDefault(l)
+	logging.SetLogger(l)
+}
+
+// fatal logs the error and exits
+func fatal(err error) {
+	slog.Error(err.Error())
+	os.Exit(1)
+}
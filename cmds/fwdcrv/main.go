@@ -5,7 +5,7 @@ import (
 	"flag"
 	"fmt"
 	"io/ioutil"
-	"log"
+	"log/slog"
 	"math"
 	"os"
 
@@ -16,21 +16,24 @@ var (
 	fileFlag = flag.String("f", "term.json", "json file containing the parameters for term structure")
 	maturity = flag.Float64("m", 1.0, "term maturity of forward rate in decimal years")
 	t        = flag.Float64("t", 0.0, "start time for forward rate in decimal years")
+	verbose  = flag.Bool("v", false, "verbose output with debug tracing")
+	quiet    = flag.Bool("q", false, "only log warnings and errors")
 )
 
 func main() {
 	// read input files
 	flag.Parse()
+	setupLogging(*verbose, *quiet)
 
 	// read smetarting term structure
 	termData, err := ioutil.ReadFile(*fileFlag)
 	if err != nil {
-		log.Println(err)
+		slog.Error(err.Error())
 	}
 
 	ts, err := term.Parse(termData)
 	if err != nil {
-		log.Println(err)
+		slog.Error(err.Error())
 	}
 
 	// term maturity
@@ -84,7 +87,7 @@ func main() {
 	name := "forward.csv"
 	fout, err := os.Create(name)
 	if err != nil {
-		log.Fatal("Unable to read output file:", name, err)
+		fatal(fmt.Errorf("unable to create output file %s: %w", name, err))
 	}
 	defer fout.Close()
 	w := csv.NewWriter(fout)
@@ -6,7 +6,7 @@ import (
 	"flag"
 	"fmt"
 	"io/ioutil"
-	"log"
+	"log/slog"
 	"math"
 	"math/rand"
 	"os"
@@ -20,15 +20,18 @@ var (
 	nInput        = flag.Int("n", 1000, "number of time steps")
 	maturityInput = flag.Float64("m", 10.0, "maturity in years for simulation")
 	sigmaInput    = flag.Float64("s", 0.02, "standard deviation of interest rates")
+	verbose       = flag.Bool("v", false, "verbose output with debug tracing")
+	quiet         = flag.Bool("q", false, "only log warnings and errors")
 )
 
 func main() {
 	flag.Parse()
+	setupLogging(*verbose, *quiet)
 
 	// read term structure
 	nssData, err := ioutil.ReadFile(*fileFlag)
 	if err != nil {
-		log.Println(err)
+		slog.Error(err.Error())
 	}
 
 	var ts term.NelsonSiegelSvensson
@@ -100,7 +103,7 @@ func main() {
 	// print out parameters for analysis in R
 	fout, err := os.Create("result.csv")
 	if err != nil {
-		log.Fatal("Unable to read input file: result.csv", err)
+		fatal(fmt.Errorf("unable to create output file result.csv: %w", err))
 	}
 	defer fout.Close()
 	w := csv.NewWriter(fout)
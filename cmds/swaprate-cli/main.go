@@ -5,7 +5,7 @@ import (
 	"flag"
 	"fmt"
 	"io/ioutil"
-	"log"
+	"os"
 
 	"github.com/konimarti/fixedincome/pkg/instrument/swap"
 	"github.com/konimarti/fixedincome/pkg/term"
@@ -20,21 +20,13 @@ func main() {
 	flag.Parse()
 
 	// read term structure parameters
-	termData, err := ioutil.ReadFile(*fileFlag)
+	ts, err := readTerm(*fileFlag)
 	if err != nil {
-		log.Println(err)
-	}
-
-	ts, err := term.Parse(termData)
-	if err != nil {
-		log.Println(err)
-		log.Println("Use the following template for the Nelson-Siegel-Svensson yield curve:")
-		data, err := json.MarshalIndent(term.NelsonSiegelSvensson{}, " ", "")
-		if err != nil {
-			panic(err)
-		}
-		fmt.Println(string(data))
-		return
+		fmt.Fprintln(os.Stderr, "error:", err)
+		fmt.Fprintln(os.Stderr, "Use the following template for the Nelson-Siegel-Svensson term structure:")
+		data, _ := json.MarshalIndent(term.NelsonSiegelSvensson{}, " ", "")
+		fmt.Fprintln(os.Stderr, string(data))
+		os.Exit(1)
 	}
 
 	// add spread to term structure
@@ -54,3 +46,12 @@ func main() {
 		fmt.Printf("%2.1f\t\t%6.2f\n", t, swaprate)
 	}
 }
+
+// readTerm reads the term structure parameters from a json file
+func readTerm(file string) (term.Structure, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading term structure failed: %w", err)
+	}
+	return term.Parse(data)
+}
@@ -1,12 +1,13 @@
 package main
 
 import (
+	"bytes"
 	"encoding/csv"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"io/ioutil"
-	"log"
+	"log/slog"
 	"math"
 	"os"
 	"sort"
@@ -34,23 +35,26 @@ var (
 	settlement = flag.String("date", time.Now().Format(DateFmt), fmt.Sprintf("date of the bond prices (format: %s)", DateFmt))
 	onRate     = flag.Float64("onrate", 0.0, "Overnight rate (e.g. Swiss Average Rate Overnight) in % (deactivate it by setting it to 0.0)")
 	fileFlag   = flag.String("f", "term.json", "json file containing the parameters for term structure")
+	verbose    = flag.Bool("v", false, "verbose output with debug tracing of the solvers")
+	quiet      = flag.Bool("q", false, "only log warnings and errors")
 )
 
 func main() {
 	// read input files
 	flag.Parse()
+	setupLogging(*verbose, *quiet)
 
 	// read bond data from CSV file
 	filePath := *file
 	f, err := os.Open(filePath)
 	if err != nil {
-		log.Fatal("Unable to read input file "+filePath, err)
+		fatal(fmt.Errorf("unable to read input file %s: %w", filePath, err))
 	}
 	defer f.Close()
 	csvReader := csv.NewReader(f)
 	records, err := csvReader.ReadAll()
 	if err != nil {
-		log.Fatal("Unable to parse file as CSV for "+filePath, err)
+		fatal(fmt.Errorf("unable to parse file as CSV for %s: %w", filePath, err))
 	}
 
 	lastTradingDay, err := time.Parse(DateFmt, *settlement)
@@ -58,12 +62,12 @@ func main() {
 	// read starting term structure
 	termData, err := ioutil.ReadFile(*fileFlag)
 	if err != nil {
-		log.Println(err)
+		slog.Error(err.Error())
 	}
 
 	ts, err := term.Parse(termData)
 	if err != nil {
-		log.Println(err)
+		slog.Error(err.Error())
 	}
 
 	// convert annual O/N rate to a continuously compounded rate
@@ -73,14 +77,14 @@ func main() {
 	// procedure
 	conOpt := math.Abs(onCC) > 1e-7
 	if conOpt {
-		log.Println("using O/N constraint")
+		slog.Info("using O/N constraint", "rate", *onRate)
 	}
 
 	// initial term structure parameters for the optimization procedure
 	x := []float64{-0.421199, -0.32659, 5.02375, -4.15252, 4.7229, 3.36644}
 
 	if nss, ok := ts.(*term.NelsonSiegelSvensson); ok {
-		log.Println("using model from file")
+		slog.Info("using model from file", "file", *fileFlag)
 		x[0], x[1], x[2], x[3], x[4], x[5] = nss.B0, nss.B1, nss.B2, nss.B3, nss.T1, nss.T2
 	}
 
@@ -123,7 +127,7 @@ func main() {
 		prices = append(prices, dirty)
 		yield, err := fixedincome.Irr(dirty, &bnd)
 		if err != nil {
-			log.Println(err)
+			slog.Warn("yield not converged", "bond", len(bonds)-1, "error", err)
 			yield = math.NaN()
 		}
 		yields = append(yields, yield)
@@ -161,8 +165,7 @@ func main() {
 			value := bond.PresentValue(ts)
 			est, err := fixedincome.Irr(value, &bond)
 			if err != nil {
-				log.Printf("yield for bond [%d] not "+
-					"converged\n", i)
+				slog.Debug("yield not converged", "bond", i)
 				sst += penalty
 				continue
 			}
@@ -182,18 +185,18 @@ func main() {
 		Func: fun,
 	}
 
-	log.Println("minimize squared error of yields..")
+	slog.Info("minimize squared error of yields")
 
 	result, err := optimize.Minimize(p, x, nil, nil)
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
 	if err = result.Status.Err(); err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
 	printResult(result)
 
-	log.Println("..done")
+	slog.Info("minimization done")
 
 	// store optmizated parameters
 	termNss := term.NelsonSiegelSvensson{
@@ -247,8 +250,7 @@ func main() {
 			value := bond.PresentValue(ts)
 			est, err := fixedincome.Irr(value, &bond)
 			if err != nil {
-				log.Printf("yield for bond [%d] not "+
-					"converged\n", i)
+				slog.Debug("yield not converged", "bond", i)
 				sst += penalty
 				continue
 			}
@@ -271,10 +273,10 @@ func main() {
 
 	result, err = optimize.Minimize(p, y, nil, nil)
 	if err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
 	if err = result.Status.Err(); err != nil {
-		log.Fatal(err)
+		fatal(err)
 	}
 	printResult(result)
 
@@ -311,7 +313,7 @@ func main() {
 	// write to comparison to result.csv
 	fout, err := os.Create("result.csv")
 	if err != nil {
-		log.Fatal("Unable to read output file: result.csv", err)
+		fatal(fmt.Errorf("unable to create output file result.csv: %w", err))
 	}
 	defer fout.Close()
 	w := csv.NewWriter(fout)
@@ -330,18 +332,22 @@ func printResult(result *optimize.Result) {
 func printTerm(ts term.Structure) {
 	text, err := json.MarshalIndent(ts, " ", "")
 	if err != nil {
-		log.Println(err)
+		slog.Error(err.Error())
 		return
 	}
 	fmt.Println(string(text))
 }
 
 func printTermToFile(ts term.Structure, name string) error {
-	data, err := json.MarshalIndent(ts, " ", "")
+	data, err := term.Marshal(ts)
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(name, data, 0644)
+	var text bytes.Buffer
+	if err := json.Indent(&text, data, " ", ""); err != nil {
+		return err
+	}
+	return os.WriteFile(name, text.Bytes(), 0644)
 }
 
 // determine_weights returns n weights that will split t in (almost) equal
@@ -0,0 +1,89 @@
+package fixedincome
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Priced is a security with its dirty market price
+type Priced struct {
+	Security TermSecurity
+	// Price is the dirty price (default: 0 for the model price on the term structure)
+	Price float64
+}
+
+// Analytics are the relative value analytics of a security at its dirty price
+type Analytics struct {
+	Price float64
+	// Yield is the continuously compounded yield to maturity in percent
+	Yield float64
+	// Spread is the static spread in bps over the term structure
+	Spread float64
+	// Duration and Convexity are calculated on the term structure with the spread
+	Duration  float64
+	Convexity float64
+	// Carry is the income over the horizon at an unchanged yield less the
+	// funding at the spot rate of the term structure (per unit of price)
+	Carry float64
+	// Breakeven is the rise of the yield in bps over the horizon that offsets the carry
+	Breakeven float64
+}
+
+// Comparison is the side-by-side of two securities with the differences of
+// the second to the first security
+type Comparison struct {
+	Horizon    float64
+	First      Analytics
+	Second     Analytics
+	Difference Analytics
+}
+
+// Compare returns the side-by-side analytics of two securities for switches
+// and relative value trades; the carry is calculated over the horizon in years
+func Compare(first, second Priced, ts term.Structure, horizon float64) (Comparison, error) {
+	c := Comparison{Horizon: horizon}
+	var err error
+	if c.First, err = Analyze(first, ts, horizon); err != nil {
+		return c, fmt.Errorf("first security: %w", err)
+	}
+	if c.Second, err = Analyze(second, ts, horizon); err != nil {
+		return c, fmt.Errorf("second security: %w", err)
+	}
+	c.Difference = Analytics{
+		Price:     c.Second.Price - c.First.Price,
+		Yield:     c.Second.Yield - c.First.Yield,
+		Spread:    c.Second.Spread - c.First.Spread,
+		Duration:  c.Second.Duration - c.First.Duration,
+		Convexity: c.Second.Convexity - c.First.Convexity,
+		Carry:     c.Second.Carry - c.First.Carry,
+		Breakeven: c.Second.Breakeven - c.First.Breakeven,
+	}
+	return c, nil
+}
+
+// Analyze returns the relative value analytics of the security at its price
+func Analyze(p Priced, ts term.Structure, horizon float64) (Analytics, error) {
+	a := Analytics{Price: p.Price}
+	if a.Price == 0.0 {
+		a.Price = p.Security.PresentValue(ts)
+	}
+	var err error
+	if a.Yield, err = Irr(a.Price, p.Security); err != nil {
+		return a, err
+	}
+	if a.Spread, err = Spread(a.Price, p.Security, ts); err != nil {
+		return a, err
+	}
+	spreaded := term.WithSpread(ts, a.Spread)
+	a.Duration = p.Security.Duration(spreaded)
+	a.Convexity = p.Security.Convexity(spreaded)
+	if horizon > 0.0 {
+		a.Carry = a.Price * (math.Exp(a.Yield*0.01*horizon) - math.Exp(ts.Rate(horizon)*0.01*horizon))
+		if a.Duration != 0.0 {
+			a.Breakeven = a.Carry / (a.Price * math.Abs(a.Duration) * 0.0001)
+		}
+	}
+	return a, nil
+}
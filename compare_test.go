@@ -0,0 +1,68 @@
+package fixedincome_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestCompare(t *testing.T) {
+	settlement := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	short := bond.Straight{
+		Schedule:   maturity.Schedule{Settlement: settlement, Maturity: settlement.AddDate(5, 0, 0), Frequency: 1},
+		Redemption: 100.0,
+		Coupon:     1.0,
+	}
+	long := bond.Straight{
+		Schedule:   maturity.Schedule{Settlement: settlement, Maturity: settlement.AddDate(10, 0, 0), Frequency: 1},
+		Redemption: 100.0,
+		Coupon:     2.0,
+	}
+	ts := term.Flat{R: 1.0}
+	// the long bond trades 50 bps cheap to the curve
+	first := fixedincome.Priced{Security: &short}
+	second := fixedincome.Priced{Security: &long, Price: long.PresentValue(&term.Flat{R: 1.5})}
+
+	c, err := fixedincome.Compare(first, second, &ts, 0.25)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var testData = []struct {
+		Name     string
+		Value    float64
+		Expected float64
+	}{
+		{"price of the first bond", c.First.Price, short.PresentValue(&ts)},
+		{"yield of the first bond", c.First.Yield, 1.0},
+		{"spread of the first bond", c.First.Spread, 0.0},
+		{"yield of the second bond", c.Second.Yield, 1.5},
+		{"spread of the second bond", c.Second.Spread, 50.0},
+		{"yield pickup", c.Difference.Yield, 0.5},
+		{"spread pickup", c.Difference.Spread, 50.0},
+		{"duration of the second bond", c.Second.Duration, long.Duration(&term.Flat{R: 1.5})},
+		{"duration extension", c.Difference.Duration, long.Duration(&term.Flat{R: 1.5}) - short.Duration(&ts)},
+		// yield equal to the funding rate
+		{"carry of the first bond", c.First.Carry, 0.0},
+		{"carry of the second bond", c.Second.Carry, c.Second.Price * (math.Exp(0.015*0.25) - math.Exp(0.01*0.25))},
+		{"breakeven of the second bond", c.Second.Breakeven, c.Second.Carry / (c.Second.Price * math.Abs(c.Second.Duration) * 0.0001)},
+	}
+	for _, test := range testData {
+		if math.Abs(test.Value-test.Expected) > 1e-4 {
+			t.Errorf("wrong %s; got: %v, expected: %v", test.Name, test.Value, test.Expected)
+		}
+	}
+	// about 12.5 bps of carry for a duration of about 9 years
+	if c.Second.Breakeven < 1.0 || c.Second.Breakeven > 2.0 {
+		t.Errorf("wrong breakeven; got: %v", c.Second.Breakeven)
+	}
+
+	if _, err := fixedincome.Compare(first, fixedincome.Priced{Security: &bond.Straight{}}, &ts, 0.25); err == nil {
+		t.Error("expected error for an invalid bond")
+	}
+}
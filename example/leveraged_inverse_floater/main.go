@@ -115,6 +115,17 @@ func (b *bootstrap) SetSpread(t float64) term.Structure {
 	return b
 }
 
+// DiscountFactor returns the discount factor for the maturity t with an
+// additional spread in bps
+func (b *bootstrap) DiscountFactor(t, spread float64) float64 {
+	return term.DiscountFactor(b, t, spread)
+}
+
+// Shift returns the term structure shifted in parallel by dr in bps
+func (b *bootstrap) Shift(dr float64) term.Structure {
+	return term.Shift(b, dr)
+}
+
 func (b *bootstrap) Rate(t float64) float64 {
 	return -math.Log(b.Z(t)) / t * 100.0
 }
@@ -1,6 +1,6 @@
 module github.com/konimarti/fixedincome
 
-go 1.16
+go 1.21
 
 require (
 	github.com/cnkei/gospline v0.0.0-20191204072713-842a72f86331
@@ -8,3 +8,8 @@ require (
 	github.com/konimarti/daycount v0.0.3-0.20211210225146-e3e1587af758
 	gonum.org/v1/gonum v0.9.3
 )
+
+require (
+	golang.org/x/exp v0.0.0-20191002040644-a1355ae1e2c3 // indirect
+	golang.org/x/tools v0.0.0-20190927191325-030b2cf1153e // indirect
+)
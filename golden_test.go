@@ -0,0 +1,123 @@
+package fixedincome_test
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// golden is a reference value with the accepted tolerance
+type golden struct {
+	Value     float64 `json:"value"`
+	Tolerance float64 `json:"tolerance"`
+}
+
+func (g *golden) check(t *testing.T, id, name string, got float64) {
+	if g == nil {
+		return
+	}
+	if math.IsNaN(got) || math.Abs(got-g.Value) > g.Tolerance {
+		t.Errorf("%s: wrong %s; got: %v, expected: %v (+/- %v)", id, name, got, g.Value, g.Tolerance)
+	}
+}
+
+// goldenBond is an entry of the regression corpus in testdata/golden.json
+type goldenBond struct {
+	Isin        string          `json:"isin"`
+	Description string          `json:"description"`
+	Source      string          `json:"source"`
+	Settlement  string          `json:"settlement"`
+	Maturity    string          `json:"maturity"`
+	Issue       string          `json:"issue"`
+	Coupon      float64         `json:"coupon"`
+	Frequency   int             `json:"frequency"`
+	Basis       string          `json:"basis"`
+	Redemption  float64         `json:"redemption"`
+	Quote       float64         `json:"quote"`
+	Term        json.RawMessage `json:"term"`
+	Expected    struct {
+		Price   *golden `json:"price"`
+		Accrued *golden `json:"accrued"`
+		Yield   *golden `json:"yield"`
+		Spread  *golden `json:"spread"`
+	} `json:"expected"`
+}
+
+func parseDate(t *testing.T, value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return date
+}
+
+func TestGolden(t *testing.T) {
+	data, err := os.ReadFile("testdata/golden.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	corpus := []goldenBond{}
+	if err := json.Unmarshal(data, &corpus); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, g := range corpus {
+		id := g.Isin
+		if id == "" {
+			id = g.Description
+		}
+		b := bond.Straight{
+			Schedule: maturity.Schedule{
+				Settlement: parseDate(t, g.Settlement),
+				Maturity:   parseDate(t, g.Maturity),
+				Issue:      parseDate(t, g.Issue),
+				Frequency:  g.Frequency,
+				Basis:      g.Basis,
+			},
+			Coupon:     g.Coupon,
+			Redemption: g.Redemption,
+		}
+		if err := b.Validate(); err != nil {
+			t.Errorf("%s: %v", id, err)
+			continue
+		}
+
+		g.Expected.Accrued.check(t, id, "accrued interest", b.Accrued())
+
+		invoice := g.Quote + b.Accrued()
+		if g.Expected.Yield != nil {
+			irr, err := fixedincome.Irr(invoice, &b)
+			if err != nil {
+				t.Errorf("%s: %v", id, err)
+			}
+			g.Expected.Yield.check(t, id, "yield", irr)
+		}
+
+		if len(g.Term) == 0 {
+			continue
+		}
+		ts, err := term.Parse(g.Term)
+		if err != nil {
+			t.Errorf("%s: %v", id, err)
+			continue
+		}
+		g.Expected.Price.check(t, id, "clean price", b.PresentValue(ts)-b.Accrued())
+		if g.Expected.Spread != nil {
+			spread, err := fixedincome.Spread(invoice, &b, ts)
+			if err != nil {
+				t.Errorf("%s: %v", id, err)
+			}
+			g.Expected.Spread.check(t, id, "spread", spread)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package fixedincome
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Hasher is implemented by securities and term structures that provide their
+// own hash for the memoization (see Memo); all other values are hashed by
+// their Go-syntax representation
+type Hasher interface {
+	Hash() uint64
+}
+
+// memoKey identifies a pricing by the hashes of the security and the term
+// structure and the spread
+type memoKey struct {
+	security uint64
+	curve    uint64
+	spread   float64
+}
+
+// Memo memoizes present values by security, term structure and spread for
+// repeated pricing calls (e.g. in interactive or server contexts). A changed
+// security or term structure has a different hash and is priced again;
+// securities and term structures that are modified through pointers which
+// are not dereferenced in the hash (e.g. a shared coupon rounding rule) must
+// be invalidated with Reset. Memo is safe for concurrent use.
+type Memo struct {
+	// Max is the maximum number of memoized values; the memo is cleared when
+	// it is exceeded (default: 0 for no limit)
+	Max    int
+	mu     sync.RWMutex
+	date   time.Time
+	values map[memoKey]float64
+}
+
+// NewMemo returns an empty memo for the valuation date
+func NewMemo(date time.Time) *Memo {
+	return &Memo{date: date, values: make(map[memoKey]float64)}
+}
+
+// PresentValue returns the memoized value of the security for the term
+// structure with the spread in bps; ts is not modified
+func (m *Memo) PresentValue(s Security, ts term.Structure, spread float64) float64 {
+	key := memoKey{hash(s), hash(ts), spread}
+	m.mu.RLock()
+	v, ok := m.values[key]
+	m.mu.RUnlock()
+	if ok {
+		return v
+	}
+
+	curve := ts
+	if spread != 0.0 {
+		curve = term.WithSpread(ts, spread)
+	}
+	v = s.PresentValue(curve)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.values == nil || (m.Max > 0 && len(m.values) >= m.Max) {
+		m.values = make(map[memoKey]float64)
+	}
+	m.values[key] = v
+	return v
+}
+
+// Date returns the valuation date of the memo
+func (m *Memo) Date() time.Time {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.date
+}
+
+// SetDate sets the valuation date and removes all memoized values if the date changes
+func (m *Memo) SetDate(date time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if !date.Equal(m.date) {
+		m.date = date
+		m.values = make(map[memoKey]float64)
+	}
+}
+
+// Len returns the number of memoized values
+func (m *Memo) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.values)
+}
+
+// Reset removes all memoized values
+func (m *Memo) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values = make(map[memoKey]float64)
+}
+
+// hash returns the FNV-1a hash of the value
+func hash(x interface{}) uint64 {
+	if h, ok := x.(Hasher); ok {
+		return h.Hash()
+	}
+	f := fnv.New64a()
+	fmt.Fprintf(f, "%#v", x)
+	return f.Sum64()
+}
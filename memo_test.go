@@ -0,0 +1,74 @@
+package fixedincome_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// counted counts the pricing calls of a bond
+type counted struct {
+	bond.Straight
+	calls *int
+}
+
+func (c counted) PresentValue(ts term.Structure) float64 {
+	*c.calls += 1
+	return c.Straight.PresentValue(ts)
+}
+
+func TestMemo(t *testing.T) {
+	date := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	b := bond.Straight{
+		Schedule:   maturity.Schedule{Settlement: date, Maturity: date.AddDate(5, 0, 0), Frequency: 1, Basis: "30E360"},
+		Coupon:     1.25,
+		Redemption: 100.0,
+	}
+	ts := term.Flat{R: 1.0}
+	calls := 0
+	memo := fixedincome.NewMemo(date)
+
+	testData := []struct {
+		Name     string
+		Change   func()
+		Spread   float64
+		Calls    int
+		Expected func() float64
+	}{
+		{"first", func() {}, 0.0, 1, func() float64 { return b.PresentValue(&ts) }},
+		{"memoized", func() {}, 0.0, 1, func() float64 { return b.PresentValue(&ts) }},
+		{"spread", func() {}, 50.0, 2, func() float64 { return b.PresentValue(&term.Flat{R: 1.0, Spread: 50.0}) }},
+		{"spread memoized", func() {}, 50.0, 2, func() float64 { return b.PresentValue(&term.Flat{R: 1.0, Spread: 50.0}) }},
+		{"curve changed", func() { ts.R = 1.5 }, 0.0, 3, func() float64 { return b.PresentValue(&ts) }},
+		{"bond changed", func() { b.Coupon = 2.0 }, 0.0, 4, func() float64 { return b.PresentValue(&ts) }},
+		{"same date", func() { memo.SetDate(date) }, 0.0, 4, func() float64 { return b.PresentValue(&ts) }},
+		{"new date", func() { memo.SetDate(date.AddDate(0, 0, 1)) }, 0.0, 5, func() float64 { return b.PresentValue(&ts) }},
+	}
+	for _, test := range testData {
+		test.Change()
+		value := memo.PresentValue(counted{b, &calls}, &ts, test.Spread)
+		if math.Abs(value-test.Expected()) > 1e-12 {
+			t.Errorf("%s: wrong value; got: %v, expected: %v", test.Name, value, test.Expected())
+		}
+		if calls != test.Calls {
+			t.Errorf("%s: wrong number of pricings; got: %d, expected: %d", test.Name, calls, test.Calls)
+		}
+	}
+	if ts.Spread != 0.0 {
+		t.Errorf("term structure was modified; got spread: %v, expected: %v", ts.Spread, 0.0)
+	}
+
+	memo.Reset()
+	memo.Max = 2
+	for _, spread := range []float64{10.0, 20.0, 30.0} {
+		memo.PresentValue(&b, &ts, spread)
+	}
+	if memo.Len() != 1 || !memo.Date().Equal(date.AddDate(0, 0, 1)) {
+		t.Errorf("wrong number of memoized values; got: %d, expected: %d", memo.Len(), 1)
+	}
+}
@@ -0,0 +1,61 @@
+package fixedincome
+
+// Metadata is descriptive information on a security for reporting
+// (e.g. sustainability classifications) that does not affect pricing
+type Metadata struct {
+	ISIN   string
+	Issuer string
+	// Labels are the ESG or sustainability labels, e.g. "green", "social",
+	// "sustainability" or "sustainability-linked"
+	Labels []string
+	// UseOfProceeds are the categories of the financed projects,
+	// e.g. "renewable energy" or "clean transportation"
+	UseOfProceeds []string
+	// Framework is the standard the labels refer to, e.g. "ICMA GBP" or "EU GBS"
+	Framework string
+	// Fields are further reporting fields
+	Fields map[string]string
+}
+
+// Described is implemented by securities that carry metadata
+type Described interface {
+	Describe() *Metadata
+}
+
+// MetadataOf returns the metadata of the security (or nil if there is none)
+func MetadataOf(s Security) *Metadata {
+	if d, ok := s.(Described); ok {
+		return d.Describe()
+	}
+	return nil
+}
+
+// HasLabel reports whether the metadata contains the label
+func (m *Metadata) HasLabel(label string) bool {
+	if m == nil {
+		return false
+	}
+	for _, l := range m.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// Field returns the reporting field by name; "isin", "issuer" and "framework"
+// return the corresponding metadata
+func (m *Metadata) Field(name string) string {
+	if m == nil {
+		return ""
+	}
+	switch name {
+	case "isin":
+		return m.ISIN
+	case "issuer":
+		return m.Issuer
+	case "framework":
+		return m.Framework
+	}
+	return m.Fields[name]
+}
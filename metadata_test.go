@@ -0,0 +1,33 @@
+package fixedincome_test
+
+import (
+	"testing"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+)
+
+func TestMetadata(t *testing.T) {
+	b := bond.Straight{Metadata: &fixedincome.Metadata{
+		ISIN:      "XS0000000001",
+		Issuer:    "Issuer",
+		Labels:    []string{"green"},
+		Framework: "ICMA GBP",
+		Fields:    map[string]string{"sector": "utilities"},
+	}}
+
+	m := fixedincome.MetadataOf(&b)
+	if m == nil || !m.HasLabel("green") || m.HasLabel("social") {
+		t.Errorf("wrong labels; got: %v", m)
+	}
+	for name, expected := range map[string]string{"isin": "XS0000000001", "issuer": "Issuer", "framework": "ICMA GBP", "sector": "utilities", "rating": ""} {
+		if got := m.Field(name); got != expected {
+			t.Errorf("wrong field %s; got: %v, expected: %v", name, got, expected)
+		}
+	}
+
+	// securities without metadata
+	if m := fixedincome.MetadataOf(&bond.Straight{}); m != nil || m.HasLabel("green") || m.Field("isin") != "" {
+		t.Errorf("expected no metadata; got: %v", m)
+	}
+}
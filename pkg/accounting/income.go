@@ -0,0 +1,187 @@
+package accounting
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/rate"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Method is the method to amortize the premium or discount of a bond
+type Method int
+
+const (
+	// EffectiveInterest amortizes with the constant effective interest rate at
+	// purchase (amortized cost under IFRS 9 and US GAAP)
+	EffectiveInterest Method = iota
+	// StraightLine amortizes linearly in time up to the maturity
+	StraightLine
+)
+
+// Holding is a bond bought at a premium or discount and held at amortized cost
+type Holding struct {
+	Bond *bond.Straight
+	// Purchase is the settlement date of the purchase
+	Purchase time.Time
+	// Clean is the clean purchase price per 100 of par (including fees and costs)
+	Clean float64
+	// Nominal is the face value held (default: 0 for amounts per 100 of par)
+	Nominal float64
+}
+
+// Income is the interest income of a holding between two dates; the amounts
+// are for the nominal of the holding
+type Income struct {
+	From time.Time
+	To   time.Time
+	// Coupons are the coupons paid after From up to and including To
+	Coupons float64
+	// AccruedChange is the change in accrued interest from From to To
+	AccruedChange float64
+	// Earned is the coupon income earned, i.e. the coupons plus the accrued change
+	Earned float64
+	// Amortization is the amortization of the premium (negative) or discount (positive)
+	Amortization float64
+	// Interest is the interest income, i.e. the earned coupons plus the amortization
+	Interest float64
+	// CarryingFrom and CarryingTo are the clean amortized costs at From and To
+	CarryingFrom float64
+	CarryingTo   float64
+}
+
+// Validate checks the bond and the dates of the holding
+func (h *Holding) Validate() error {
+	if h.Bond == nil {
+		return fmt.Errorf("holding without bond")
+	}
+	if err := h.Bond.Validate(); err != nil {
+		return err
+	}
+	if !h.Purchase.Before(h.Bond.Maturity) {
+		return fmt.Errorf("purchase on %s is not before maturity on %s", h.Purchase.Format("2006-01-02"), h.Bond.Maturity.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// EffectiveRate returns the annually compounded effective interest rate in percent
+// of the purchase, i.e. the yield of the dirty purchase price
+func (h *Holding) EffectiveRate() (float64, error) {
+	y, err := h.yield()
+	if err != nil {
+		return 0.0, err
+	}
+	return rate.Annual(y, 1), nil
+}
+
+// yield returns the continuously compounded yield in percent of the dirty purchase price
+func (h *Holding) yield() (float64, error) {
+	if err := h.Validate(); err != nil {
+		return 0.0, err
+	}
+	b := h.at(h.Purchase)
+	return fixedincome.Irr(h.Clean+b.Accrued(), b)
+}
+
+// at returns a copy of the bond settling at the date
+func (h *Holding) at(date time.Time) *bond.Straight {
+	b := *h.Bond
+	b.Settlement = date
+	return &b
+}
+
+// scale converts an amount per 100 of par to the nominal of the holding
+func (h *Holding) scale(amount float64) float64 {
+	if h.Nominal == 0.0 {
+		return amount
+	}
+	return amount * h.Nominal / 100.0
+}
+
+// Carrying returns the clean amortized cost per 100 of par at the date
+// (the redemption value at or after the maturity)
+func (h *Holding) Carrying(date time.Time, method Method) (float64, error) {
+	if err := h.Validate(); err != nil {
+		return 0.0, err
+	}
+	if !date.Before(h.Bond.Maturity) {
+		return h.Bond.Redemption, nil
+	}
+	if date.Before(h.Purchase) {
+		return 0.0, fmt.Errorf("date %s before purchase on %s", date.Format("2006-01-02"), h.Purchase.Format("2006-01-02"))
+	}
+	switch method {
+	case StraightLine:
+		elapsed := date.Sub(h.Purchase).Hours()
+		total := h.Bond.Maturity.Sub(h.Purchase).Hours()
+		return h.Clean + (h.Bond.Redemption-h.Clean)*elapsed/total, nil
+	case EffectiveInterest:
+		y, err := h.yield()
+		if err != nil {
+			return 0.0, err
+		}
+		b := h.at(date)
+		return b.PresentValue(&term.Flat{R: y}) - b.Accrued(), nil
+	}
+	return 0.0, fmt.Errorf("unknown amortization method %d", method)
+}
+
+// Income returns the interest income between the dates (from the purchase at
+// the earliest) with the amortization of the premium or discount
+func (h *Holding) Income(from, to time.Time, method Method) (Income, error) {
+	if from.Before(h.Purchase) {
+		from = h.Purchase
+	}
+	if to.Before(from) {
+		return Income{}, fmt.Errorf("end %s before start %s", to.Format("2006-01-02"), from.Format("2006-01-02"))
+	}
+	carryingFrom, err := h.Carrying(from, method)
+	if err != nil {
+		return Income{}, err
+	}
+	carryingTo, err := h.Carrying(to, method)
+	if err != nil {
+		return Income{}, err
+	}
+
+	// coupons paid in (from, to]; the final coupon on an adjusted maturity
+	// date after the maturity (see MaturityAdjusted) is paid at the maturity
+	coupons := 0.0
+	b := h.at(from)
+	cfs := b.Cashflows()
+	for i, cf := range cfs {
+		last := i == len(cfs)-1
+		if cf.Date.After(to) && (!last || to.Before(b.Maturity)) {
+			continue
+		}
+		amount := cf.Amount
+		if last {
+			amount -= b.RedemptionValue()
+		}
+		coupons += amount
+	}
+	accrued := h.accrued(to) - h.accrued(from)
+
+	income := Income{
+		From:          from,
+		To:            to,
+		Coupons:       h.scale(coupons),
+		AccruedChange: h.scale(accrued),
+		Earned:        h.scale(coupons + accrued),
+		Amortization:  h.scale(carryingTo - carryingFrom),
+		CarryingFrom:  h.scale(carryingFrom),
+		CarryingTo:    h.scale(carryingTo),
+	}
+	income.Interest = income.Earned + income.Amortization
+	return income, nil
+}
+
+// accrued returns the accrued interest per 100 of par at the date (zero at or after the maturity)
+func (h *Holding) accrued(date time.Time) float64 {
+	if !date.Before(h.Bond.Maturity) {
+		return 0.0
+	}
+	return h.at(date).Accrued()
+}
@@ -0,0 +1,141 @@
+package accounting_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/accounting"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+)
+
+// holding returns 1'000'000 nominal of a 4% annual bond bought at 104 three
+// months after the coupon date with three years and nine months to maturity
+func holding() accounting.Holding {
+	return accounting.Holding{
+		Bond: &bond.Straight{
+			Schedule: maturity.Schedule{
+				Maturity:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+				Frequency: 1,
+				Basis:     "30E360",
+			},
+			Coupon:     4.0,
+			Redemption: 100.0,
+		},
+		Purchase: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+		Clean:    104.0,
+		Nominal:  1000000.0,
+	}
+}
+
+func TestHolding_Income(t *testing.T) {
+	h := holding()
+	from := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	for _, method := range []accounting.Method{accounting.StraightLine, accounting.EffectiveInterest} {
+		income, err := h.Income(from, to, method)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// no coupon paid, accrued from 90 to 359 days (30E360)
+		if income.Coupons != 0.0 {
+			t.Errorf("wrong coupons; got: %v, expected: %v", income.Coupons, 0.0)
+		}
+		if expected := 1000000.0 * 0.04 * (359.0 - 90.0) / 360.0; math.Abs(income.AccruedChange-expected) > 1e-6 {
+			t.Errorf("wrong accrued change; got: %v, expected: %v", income.AccruedChange, expected)
+		}
+		if income.Amortization >= 0.0 || income.CarryingFrom != 1040000.0 {
+			t.Errorf("wrong amortization of the premium; got: %v", income)
+		}
+		if math.Abs(income.Interest-income.Earned-income.Amortization) > 1e-9 {
+			t.Errorf("wrong interest income; got: %v", income)
+		}
+	}
+
+	// straight line amortization of 4% over 1371 days (2021-04-01 to 2025-01-01)
+	income, err := h.Income(from, to, accounting.StraightLine)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := -40000.0 * 274.0 / 1371.0; math.Abs(income.Amortization-expected) > 1e-6 {
+		t.Errorf("wrong straight-line amortization; got: %v, expected: %v", income.Amortization, expected)
+	}
+
+	// over the whole life the premium is amortized and all coupons are earned
+	for _, method := range []accounting.Method{accounting.StraightLine, accounting.EffectiveInterest} {
+		income, err := h.Income(from, h.Bond.Maturity, method)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(income.Amortization+40000.0) > 1e-6 || income.CarryingTo != 1000000.0 {
+			t.Errorf("wrong amortization over the life; got: %v, expected: %v", income.Amortization, -40000.0)
+		}
+		if math.Abs(income.Coupons-160000.0) > 1e-6 {
+			t.Errorf("wrong coupons over the life; got: %v, expected: %v", income.Coupons, 160000.0)
+		}
+		if expected := 160000.0 - 10000.0; math.Abs(income.Earned-expected) > 1e-6 {
+			t.Errorf("wrong earned coupons over the life; got: %v, expected: %v", income.Earned, expected)
+		}
+	}
+}
+
+func TestHolding_MaturityAdjusted(t *testing.T) {
+	// maturity on Saturday 2026-05-30, final payment on Monday 2026-06-01
+	h := accounting.Holding{
+		Bond: &bond.Straight{
+			Schedule: maturity.Schedule{
+				Maturity:  time.Date(2026, 5, 30, 0, 0, 0, 0, time.UTC),
+				Frequency: 1,
+				Basis:     "30E360",
+			},
+			Coupon:           2.0,
+			Redemption:       100.0,
+			MaturityAdjusted: true,
+		},
+		Purchase: time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC),
+		Clean:    99.0,
+	}
+	income, err := h.Income(h.Purchase, h.Bond.Maturity, accounting.EffectiveInterest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(income.Coupons-2.0) > 1e-12 {
+		t.Errorf("wrong coupons up to the maturity; got: %v, expected: %v", income.Coupons, 2.0)
+	}
+	if income, err := h.Income(h.Purchase, time.Date(2026, 5, 29, 0, 0, 0, 0, time.UTC), accounting.EffectiveInterest); err != nil || income.Coupons != 0.0 {
+		t.Errorf("wrong coupons before the maturity; got: %v (%v), expected: %v", income.Coupons, err, 0.0)
+	}
+
+	periods, err := h.Schedule()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last := periods[len(periods)-1]; math.Abs(last.Cash-102.0) > 1e-12 || last.Closing != 0.0 {
+		t.Errorf("wrong final period; got: %v", last)
+	}
+}
+
+func TestHolding_EffectiveRate(t *testing.T) {
+	h := holding()
+	eir, err := h.EffectiveRate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eir <= 0.0 || eir >= 4.0 {
+		t.Errorf("wrong effective interest rate for a premium bond; got: %v", eir)
+	}
+
+	// the carrying value at the effective rate is the dirty price less accrued
+	carrying, err := h.Carrying(h.Purchase, accounting.EffectiveInterest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(carrying-104.0) > 1e-4 {
+		t.Errorf("wrong carrying value at purchase; got: %v, expected: %v", carrying, 104.0)
+	}
+	if _, err := h.Carrying(h.Purchase.AddDate(0, -1, 0), accounting.EffectiveInterest); err == nil {
+		t.Errorf("expected error before purchase")
+	}
+}
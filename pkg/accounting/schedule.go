@@ -0,0 +1,56 @@
+package accounting
+
+import "time"
+
+// Period is a line of the amortized cost schedule from one coupon date to the
+// next; the amounts are for the nominal of the holding and the carrying amounts
+// include the accrued interest
+type Period struct {
+	Start time.Time
+	End   time.Time
+	// Opening is the amortized cost at the start
+	Opening float64
+	// Interest is the interest income at the effective interest rate
+	Interest float64
+	// Cash is the coupon (and the redemption at maturity) received at the end
+	Cash float64
+	// Amortization is the interest income less the coupon income earned in the period
+	Amortization float64
+	// Closing is the amortized cost at the end after the payment
+	Closing float64
+}
+
+// Schedule returns the amortized cost schedule of the holding from the purchase
+// to the maturity with the effective interest method
+func (h *Holding) Schedule() ([]Period, error) {
+	if err := h.Validate(); err != nil {
+		return nil, err
+	}
+	dates := h.at(h.Purchase).Dates()
+	periods := make([]Period, 0, len(dates))
+	start := h.Purchase
+	for i := len(dates) - 1; i >= 0; i -= 1 {
+		end := dates[i]
+		income, err := h.Income(start, end, EffectiveInterest)
+		if err != nil {
+			return nil, err
+		}
+		p := Period{
+			Start:        start,
+			End:          end,
+			Opening:      income.CarryingFrom + h.scale(h.accrued(start)),
+			Cash:         income.Coupons,
+			Amortization: income.Amortization,
+			Closing:      income.CarryingTo + h.scale(h.accrued(end)),
+		}
+		if i == 0 {
+			// redemption at maturity
+			p.Cash += income.CarryingTo
+			p.Closing = 0.0
+		}
+		p.Interest = p.Closing - p.Opening + p.Cash
+		periods = append(periods, p)
+		start = end
+	}
+	return periods, nil
+}
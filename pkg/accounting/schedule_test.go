@@ -0,0 +1,56 @@
+package accounting_test
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHolding_Schedule(t *testing.T) {
+	h := holding()
+	periods, err := h.Schedule()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(periods) != 4 {
+		t.Fatalf("wrong number of periods; got: %d, expected: %d", len(periods), 4)
+	}
+
+	// dirty purchase price of 104 plus 90 days of accrued interest
+	if expected := 1040000.0 + 10000.0; math.Abs(periods[0].Opening-expected) > 1e-6 {
+		t.Errorf("wrong opening carrying amount; got: %v, expected: %v", periods[0].Opening, expected)
+	}
+
+	eir, err := h.EffectiveRate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	interest, amortization := 0.0, 0.0
+	for i, p := range periods {
+		if i > 0 && math.Abs(p.Opening-periods[i-1].Closing) > 1e-6 {
+			t.Errorf("opening of period %d does not match closing of previous period; got: %v, expected: %v", i, p.Opening, periods[i-1].Closing)
+		}
+		if i > 0 {
+			// full years (30E360) at the constant effective rate
+			if expected := p.Opening * eir / 100.0; math.Abs(p.Interest-expected) > 1e-4 {
+				t.Errorf("wrong interest income in period %d; got: %v, expected: %v", i, p.Interest, expected)
+			}
+			if expected := p.Interest - 40000.0; math.Abs(p.Amortization-expected) > 1e-4 {
+				t.Errorf("wrong amortization in period %d; got: %v, expected: %v", i, p.Amortization, expected)
+			}
+		}
+		interest += p.Interest
+		amortization += p.Amortization
+	}
+
+	last := periods[len(periods)-1]
+	if last.Closing != 0.0 || math.Abs(last.Cash-1040000.0) > 1e-6 {
+		t.Errorf("wrong final period; got: %v", last)
+	}
+	// total interest income: coupons received less the premium and the accrued paid
+	if expected := 160000.0 - 40000.0 - 10000.0; math.Abs(interest-expected) > 1e-6 {
+		t.Errorf("wrong total interest income; got: %v, expected: %v", interest, expected)
+	}
+	if math.Abs(amortization+40000.0) > 1e-6 {
+		t.Errorf("wrong total amortization; got: %v, expected: %v", amortization, -40000.0)
+	}
+}
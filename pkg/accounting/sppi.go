@@ -0,0 +1,152 @@
+package accounting
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/instrument/forward"
+	"github.com/konimarti/fixedincome/pkg/instrument/option"
+	"github.com/konimarti/fixedincome/pkg/instrument/swap"
+	"github.com/konimarti/fixedincome/pkg/mc"
+)
+
+// Classification is the outcome of the test whether the contractual cash flows
+// are solely payments of principal and interest (SPPI, IFRS 9 4.1.2)
+type Classification int
+
+const (
+	// SPPI are cash flows consistent with a basic lending arrangement
+	// (amortized cost or FVOCI depending on the business model)
+	SPPI Classification = iota
+	// Review are features that need a further assessment, e.g. a benchmark test
+	Review
+	// NotSPPI are cash flows that fail the test (FVTPL)
+	NotSPPI
+)
+
+func (c Classification) String() string {
+	switch c {
+	case SPPI:
+		return "SPPI"
+	case Review:
+		return "review"
+	default:
+		return "not SPPI"
+	}
+}
+
+// Feature is a contractual cash flow feature with its classification
+type Feature struct {
+	Name           string
+	Classification Classification
+}
+
+// Assessment is the result of the SPPI test of an instrument; the
+// classification is the most severe classification of the features
+type Assessment struct {
+	Classification Classification
+	Features       []Feature
+}
+
+func (a *Assessment) add(name string, c Classification) {
+	a.Features = append(a.Features, Feature{Name: name, Classification: c})
+	if c > a.Classification {
+		a.Classification = c
+	}
+}
+
+// String returns the classification with the features that are not SPPI
+func (a Assessment) String() string {
+	reasons := []string{}
+	for _, f := range a.Features {
+		if f.Classification != SPPI {
+			reasons = append(reasons, f.Name)
+		}
+	}
+	if len(reasons) == 0 {
+		return a.Classification.String()
+	}
+	return fmt.Sprintf("%s (%s)", a.Classification, strings.Join(reasons, ", "))
+}
+
+// Assess examines the contractual cash flow features of the instrument (bonds,
+// structured notes and derivatives of this module). It is an aid for plain
+// instruments and does not replace the assessment of the contractual terms, e.g.
+// of modified time value, non-recourse or contractually linked features.
+func Assess(instrument interface{}) Assessment {
+	var a Assessment
+	switch s := instrument.(type) {
+	case *bond.Straight:
+		a.add("fixed coupon", SPPI)
+	case *bond.Sinking:
+		a.add("fixed coupon", SPPI)
+		a.add("sinking fund", SPPI)
+	case *bond.Amortizing:
+		a.add("fixed coupon", SPPI)
+		a.add("amortizing principal", SPPI)
+		if s.Prepayment != nil {
+			a.add("prepayment", SPPI)
+		}
+	case *bond.Floating:
+		a.add("floating coupon", SPPI)
+		if s.Cap != nil {
+			a.add("cap on the coupon", SPPI)
+		}
+		if s.Floor != nil {
+			a.add("floor on the coupon", SPPI)
+		}
+	case *bond.Inverse:
+		a.add("fixed coupon", SPPI)
+		a.add("inverse floating coupon", NotSPPI)
+		if s.Leverage != 1.0 {
+			a.add("leverage", NotSPPI)
+		}
+	case *bond.Linker:
+		a.add("fixed coupon", SPPI)
+		a.add("inflation indexation", SPPI)
+		if s.Floor {
+			a.add("deflation floor", SPPI)
+		}
+	case *bond.Custom:
+		a.add("custom cash flows", Review)
+	case *mc.Note:
+		assessCoupon(&a, s.Coupon)
+	case mc.Note:
+		assessCoupon(&a, s.Coupon)
+	case *option.European, *option.Black, *option.BondOption, *option.Swaption, *option.Cap,
+		*swap.InterestRateSwap, *forward.Contract, *forward.RateAgreement:
+		a.add("derivative", NotSPPI)
+	default:
+		a.add(fmt.Sprintf("unknown instrument %T", instrument), Review)
+	}
+	return a
+}
+
+// assessCoupon classifies the coupon of a structured note
+func assessCoupon(a *Assessment, c mc.Coupon) {
+	switch c := c.(type) {
+	case mc.Fixed:
+		a.add("fixed coupon", SPPI)
+	case mc.Floating:
+		switch {
+		case c.Leverage == 1.0:
+			a.add("floating coupon", SPPI)
+		case c.Leverage < 0.0:
+			a.add("inverse floating coupon", NotSPPI)
+		default:
+			a.add("leverage", NotSPPI)
+		}
+	case mc.Collar:
+		assessCoupon(a, c.Coupon)
+		a.add("collar on the coupon", SPPI)
+	case mc.SpreadLinked:
+		a.add("spread-linked coupon", NotSPPI)
+	case mc.Digital:
+		a.add("digital coupon", NotSPPI)
+	case mc.RangeAccrual:
+		a.add("range accrual", NotSPPI)
+	default:
+		a.add(fmt.Sprintf("unknown coupon %T", c), Review)
+	}
+}
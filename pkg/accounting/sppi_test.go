@@ -0,0 +1,43 @@
+package accounting_test
+
+import (
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/accounting"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/instrument/option"
+	"github.com/konimarti/fixedincome/pkg/mc"
+)
+
+func TestAssess(t *testing.T) {
+	var testData = []struct {
+		Name       string
+		Instrument interface{}
+		Expected   accounting.Classification
+		Features   int
+	}{
+		{"straight", &bond.Straight{}, accounting.SPPI, 1},
+		{"custom", &bond.Custom{}, accounting.Review, 1},
+		{"capped floater", &bond.Floating{Cap: &bond.Bound{Rate: 5.0}}, accounting.SPPI, 2},
+		{"linker", &bond.Linker{Floor: true}, accounting.SPPI, 3},
+		{"inverse floater", &bond.Inverse{Leverage: 2.0}, accounting.NotSPPI, 3},
+		{"collared note", mc.Note{Coupon: mc.Collar{Coupon: mc.Floating{Index: mc.Short, Leverage: 1.0}, Cap: 0.05}}, accounting.SPPI, 2},
+		{"leveraged note", &mc.Note{Coupon: mc.Floating{Index: mc.Short, Leverage: 2.0}}, accounting.NotSPPI, 1},
+		{"range accrual", mc.Note{Coupon: mc.RangeAccrual{Coupon: mc.Fixed(0.04)}}, accounting.NotSPPI, 1},
+		{"custom coupon", mc.Note{Coupon: mc.CouponFunc(nil)}, accounting.Review, 1},
+		{"option", &option.Black{}, accounting.NotSPPI, 1},
+		{"unknown", 1.0, accounting.Review, 1},
+	}
+
+	for _, test := range testData {
+		a := accounting.Assess(test.Instrument)
+		if a.Classification != test.Expected || len(a.Features) != test.Features {
+			t.Errorf("wrong assessment of %s; got: %v (%d features), expected: %v (%d features)",
+				test.Name, a, len(a.Features), test.Expected, test.Features)
+		}
+	}
+
+	if s := accounting.Assess(&bond.Inverse{Leverage: 2.0}).String(); s != "not SPPI (inverse floating coupon, leverage)" {
+		t.Errorf("wrong description; got: %s", s)
+	}
+}
@@ -0,0 +1,93 @@
+package ad
+
+import "math"
+
+// Dual is a (multi-)dual number used for forward-mode algorithmic
+// differentiation. It carries the value of a function and its gradient
+// with respect to n independent variables.
+type Dual struct {
+	// Value is the function value
+	Value float64
+	// Grad is the gradient with respect to the independent variables
+	Grad []float64
+}
+
+// Constant returns a dual number for a constant with a zero gradient of size n
+func Constant(v float64, n int) Dual {
+	return Dual{Value: v, Grad: make([]float64, n)}
+}
+
+// Variable returns a dual number for the i-th of n independent variables
+func Variable(v float64, i, n int) Dual {
+	d := Constant(v, n)
+	d.Grad[i] = 1.0
+	return d
+}
+
+// Add returns a + b
+func Add(a, b Dual) Dual {
+	d := Dual{Value: a.Value + b.Value, Grad: make([]float64, len(a.Grad))}
+	for i := range d.Grad {
+		d.Grad[i] = a.Grad[i] + b.Grad[i]
+	}
+	return d
+}
+
+// Sub returns a - b
+func Sub(a, b Dual) Dual {
+	return Add(a, Scale(b, -1.0))
+}
+
+// Mul returns a * b
+func Mul(a, b Dual) Dual {
+	d := Dual{Value: a.Value * b.Value, Grad: make([]float64, len(a.Grad))}
+	for i := range d.Grad {
+		d.Grad[i] = a.Grad[i]*b.Value + a.Value*b.Grad[i]
+	}
+	return d
+}
+
+// Div returns a / b
+func Div(a, b Dual) Dual {
+	d := Dual{Value: a.Value / b.Value, Grad: make([]float64, len(a.Grad))}
+	for i := range d.Grad {
+		d.Grad[i] = (a.Grad[i]*b.Value - a.Value*b.Grad[i]) / (b.Value * b.Value)
+	}
+	return d
+}
+
+// Scale returns k * a for a constant k
+func Scale(a Dual, k float64) Dual {
+	d := Dual{Value: k * a.Value, Grad: make([]float64, len(a.Grad))}
+	for i := range d.Grad {
+		d.Grad[i] = k * a.Grad[i]
+	}
+	return d
+}
+
+// Shift returns a + k for a constant k
+func Shift(a Dual, k float64) Dual {
+	d := Dual{Value: a.Value + k, Grad: make([]float64, len(a.Grad))}
+	copy(d.Grad, a.Grad)
+	return d
+}
+
+// Inv returns k / a for a constant k
+func Inv(k float64, a Dual) Dual {
+	return Scale(Div(Constant(1.0, len(a.Grad)), a), k)
+}
+
+// Exp returns exp(a)
+func Exp(a Dual) Dual {
+	v := math.Exp(a.Value)
+	return Scale(Dual{Value: 1.0, Grad: a.Grad}, v)
+}
+
+// Log returns the natural logarithm of a
+func Log(a Dual) Dual {
+	d := Dual{Value: math.Log(a.Value), Grad: make([]float64, len(a.Grad))}
+	for i := range d.Grad {
+		d.Grad[i] = a.Grad[i] / a.Value
+	}
+	return d
+}
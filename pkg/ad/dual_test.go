@@ -0,0 +1,59 @@
+package ad_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/ad"
+)
+
+func TestDual(t *testing.T) {
+	// f(x, y) = exp(-x*y) * (1 - y) / x + log(x)
+	f := func(x, y float64) float64 {
+		return math.Exp(-x*y)*(1-y)/x + math.Log(x)
+	}
+	x0, y0 := 1.5, 0.3
+
+	x := ad.Variable(x0, 0, 2)
+	y := ad.Variable(y0, 1, 2)
+	d := ad.Add(ad.Div(ad.Mul(ad.Exp(ad.Scale(ad.Mul(x, y), -1.0)), ad.Shift(ad.Scale(y, -1.0), 1.0)), x), ad.Log(x))
+
+	if math.Abs(d.Value-f(x0, y0)) > 1e-12 {
+		t.Errorf("wrong function value; got: %v, expected: %v", d.Value, f(x0, y0))
+	}
+
+	// compare with central differences
+	h := 1e-6
+	dx := (f(x0+h, y0) - f(x0-h, y0)) / (2 * h)
+	dy := (f(x0, y0+h) - f(x0, y0-h)) / (2 * h)
+	if math.Abs(d.Grad[0]-dx) > 1e-6 {
+		t.Errorf("wrong derivative for x; got: %v, expected: %v", d.Grad[0], dx)
+	}
+	if math.Abs(d.Grad[1]-dy) > 1e-6 {
+		t.Errorf("wrong derivative for y; got: %v, expected: %v", d.Grad[1], dy)
+	}
+
+	// inverse and subtraction
+	inv := ad.Sub(ad.Inv(2.0, x), y)
+	if math.Abs(inv.Grad[0]-(-2.0/(x0*x0))) > 1e-12 || math.Abs(inv.Grad[1]+1.0) > 1e-12 {
+		t.Errorf("wrong gradient for 2/x - y; got: %v", inv.Grad)
+	}
+}
+
+func TestSecond(t *testing.T) {
+	// f(x) = 3 * exp(-2x) * x, f'(x) = 3 * exp(-2x) * (1 - 2x), f''(x) = 3 * exp(-2x) * (4x - 4)
+	x0 := 0.7
+	x := ad.SecondVariable(x0)
+	d := ad.MulSecond(ad.ScaleSecond(ad.ExpSecond(ad.ScaleSecond(x, -2.0)), 3.0), x)
+
+	e := 3.0 * math.Exp(-2.0*x0)
+	expected := ad.Second{Value: e * x0, D1: e * (1.0 - 2.0*x0), D2: e * (4.0*x0 - 4.0)}
+	if math.Abs(d.Value-expected.Value) > 1e-12 || math.Abs(d.D1-expected.D1) > 1e-12 || math.Abs(d.D2-expected.D2) > 1e-12 {
+		t.Errorf("wrong second-order dual; got: %v, expected: %v", d, expected)
+	}
+
+	s := ad.AddSecond(d, x)
+	if math.Abs(s.D1-expected.D1-1.0) > 1e-12 || math.Abs(s.D2-expected.D2) > 1e-12 {
+		t.Errorf("wrong sum; got: %v", s)
+	}
+}
@@ -0,0 +1,44 @@
+package ad
+
+import "math"
+
+// Second is a second-order dual number in a single independent variable. It
+// carries the value of a function and its first and second derivative.
+type Second struct {
+	// Value is the function value
+	Value float64
+	// D1 is the first derivative
+	D1 float64
+	// D2 is the second derivative
+	D2 float64
+}
+
+// SecondVariable returns a second-order dual number for the independent variable
+func SecondVariable(v float64) Second {
+	return Second{Value: v, D1: 1.0}
+}
+
+// AddSecond returns a + b
+func AddSecond(a, b Second) Second {
+	return Second{Value: a.Value + b.Value, D1: a.D1 + b.D1, D2: a.D2 + b.D2}
+}
+
+// MulSecond returns a * b
+func MulSecond(a, b Second) Second {
+	return Second{
+		Value: a.Value * b.Value,
+		D1:    a.D1*b.Value + a.Value*b.D1,
+		D2:    a.D2*b.Value + 2.0*a.D1*b.D1 + a.Value*b.D2,
+	}
+}
+
+// ScaleSecond returns k * a for a constant k
+func ScaleSecond(a Second, k float64) Second {
+	return Second{Value: k * a.Value, D1: k * a.D1, D2: k * a.D2}
+}
+
+// ExpSecond returns exp(a)
+func ExpSecond(a Second) Second {
+	v := math.Exp(a.Value)
+	return Second{Value: v, D1: a.D1 * v, D2: (a.D2 + a.D1*a.D1) * v}
+}
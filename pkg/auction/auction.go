@@ -0,0 +1,156 @@
+// Package auction converts between the stop yields and prices of government
+// bond auctions with the formulas of the issuers
+package auction
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/khezen/rootfinding"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+)
+
+// ErrInvalidSecurity is returned when the dates of the auctioned security are not valid
+var ErrInvalidSecurity = errors.New("invalid auctioned security")
+
+// Security is a fixed-coupon note or bond in an auction
+type Security struct {
+	// Settlement is the issue date of the auction
+	Settlement time.Time
+	// Dated is the date from which interest accrues; it is before the
+	// settlement date for reopenings (default: zero for the settlement date)
+	Dated time.Time
+	// FirstCoupon is the first coupon date after the dated date; a first
+	// period other than a regular coupon period is a short or long first coupon
+	FirstCoupon time.Time
+	Maturity    time.Time
+	// Coupon is the annual coupon rate in percent
+	Coupon float64
+}
+
+// Method is the price-yield formula of an issuer
+type Method int
+
+const (
+	// Treasury is the formula for US Treasury notes and bonds (31 CFR 356,
+	// Appendix B) with semi-annual coupons and simple interest for the
+	// fractional first period
+	Treasury Method = iota
+	// Bund is the formula for German federal securities with annual coupons,
+	// ACT/ACT (ICMA) and compound interest for the fractional first period
+	Bund
+)
+
+// String returns the name of the method
+func (m Method) String() string {
+	switch m {
+	case Treasury:
+		return "treasury"
+	case Bund:
+		return "bund"
+	}
+	return fmt.Sprintf("Method(%d)", int(m))
+}
+
+// frequency returns the number of coupons per year
+func (m Method) frequency() int {
+	if m == Treasury {
+		return 2
+	}
+	return 1
+}
+
+// Price returns the clean price and the accrued interest per 100 of par for
+// the yield in percent (unrounded)
+func (m Method) Price(s Security, yield float64) (float64, float64, error) {
+	if m != Treasury && m != Bund {
+		return 0.0, 0.0, fmt.Errorf("%w: unknown method %v", ErrInvalidSecurity, m)
+	}
+	dated := s.Dated
+	if dated.IsZero() {
+		dated = s.Settlement
+	}
+	if dated.After(s.Settlement) || !s.FirstCoupon.After(s.Settlement) || s.Maturity.Before(s.FirstCoupon) {
+		return 0.0, 0.0, fmt.Errorf("%w: dated %s, settlement %s, first coupon %s and maturity %s", ErrInvalidSecurity,
+			dated.Format("2006-01-02"), s.Settlement.Format("2006-01-02"), s.FirstCoupon.Format("2006-01-02"), s.Maturity.Format("2006-01-02"))
+	}
+
+	// coupon dates rolled back from the maturity date; maturities on the last
+	// day of a month keep the coupon dates on the last day of the month
+	schedule := maturity.Schedule{Maturity: s.Maturity, Frequency: m.frequency(), EndOfMonth: true}
+	if _, err := schedule.CouponDate(0); err != nil {
+		return 0.0, 0.0, fmt.Errorf("%w: %v", ErrInvalidSecurity, err)
+	}
+	date := func(k int) time.Time {
+		d, _ := schedule.CouponDate(k)
+		return d
+	}
+	n := 0
+	for date(n).After(s.FirstCoupon) {
+		n += 1
+	}
+	if !date(n).Equal(s.FirstCoupon) {
+		return 0.0, 0.0, fmt.Errorf("%w: first coupon %s not on the schedule of the maturity", ErrInvalidSecurity, s.FirstCoupon.Format("2006-01-02"))
+	}
+
+	c := s.Coupon / float64(m.frequency())
+	i := yield * 0.01 / float64(m.frequency())
+	v := 1.0 / (1.0 + i)
+	vn := math.Pow(v, float64(n))
+	an := float64(n)
+	if i != 0.0 {
+		an = (1.0 - vn) / i
+	}
+	days := func(from, to time.Time) float64 { return to.Sub(from).Hours() / 24.0 }
+
+	// quasi-coupon period containing the settlement date and the whole
+	// periods from its end to the first coupon date
+	k := n + 1
+	for date(k).After(s.Settlement) {
+		k += 1
+	}
+	whole := k - 1 - n
+	r := days(s.Settlement, date(k-1)) / days(date(k), date(k-1))
+
+	// first coupon and accrued interest of the (quasi-coupon) periods from the
+	// dated date to the first coupon date
+	first, accrued := 0.0, 0.0
+	for j := n; date(j).After(dated); j += 1 {
+		b, e := date(j+1), date(j)
+		period := days(b, e)
+		from := b
+		if dated.After(b) {
+			from = dated
+		}
+		first += c * days(from, e) / period
+		if s.Settlement.After(from) {
+			to := e
+			if s.Settlement.Before(e) {
+				to = s.Settlement
+			}
+			accrued += c * days(from, to) / period
+		}
+	}
+
+	value := (first + c*an + 100.0*vn) * math.Pow(v, float64(whole))
+	if m == Treasury {
+		value /= 1.0 + r*i
+	} else {
+		value *= math.Pow(v, r)
+	}
+	return value - accrued, accrued, nil
+}
+
+// Yield returns the yield in percent for the clean auction price per 100 of par
+func (m Method) Yield(s Security, price float64) (float64, error) {
+	if _, _, err := m.Price(s, 0.0); err != nil {
+		return 0.0, err
+	}
+	f := func(y float64) float64 {
+		p, _, _ := m.Price(s, y)
+		return p - price
+	}
+	return rootfinding.Brent(f, -50.0, 100.0, 10)
+}
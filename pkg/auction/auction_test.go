@@ -0,0 +1,102 @@
+package auction_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/auction"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestMethod_Price(t *testing.T) {
+	// ten-year note issued on the dated date with a regular first coupon
+	note := auction.Security{
+		Settlement:  date(2021, 2, 15),
+		FirstCoupon: date(2021, 8, 15),
+		Maturity:    date(2031, 2, 15),
+		Coupon:      1.125,
+	}
+	// reopening one month later with 28 of 181 days accrued
+	reopening := note
+	reopening.Dated, reopening.Settlement = note.Settlement, date(2021, 3, 15)
+	// short first coupon of 153 of 181 days
+	short := note
+	short.Settlement = date(2021, 3, 15)
+	// long first coupon from the dated date over 31 + 181 days
+	long := note
+	long.Settlement, long.FirstCoupon = date(2021, 1, 15), date(2021, 8, 15)
+	// zero-coupon bund in the quasi-coupon period of 277 of 365 days before
+	// the first coupon date
+	bund := auction.Security{
+		Settlement:  date(2021, 5, 14),
+		Dated:       date(2021, 2, 15),
+		FirstCoupon: date(2022, 2, 15),
+		Maturity:    date(2031, 2, 15),
+		Coupon:      0.0,
+	}
+	bundPar := bund
+	bundPar.Coupon = 1.0
+
+	// The expected values are evaluated by hand from the price formulas of
+	// 31 CFR 356, Appendix B (Treasury) and of the Finance Agency (Bund):
+	//   Treasury: P = (C/2*f + C/2*sum(v^k, k=1..19) + 100*v^19) / (1 + r/s*i) - AI
+	//             with f = 1 (regular), 153/181 (short) and 1 + 31/184
+	//             discounted for one more period (long)
+	//   Bund:     P = (C + C*sum(v^k, k=1..9) + 100*v^9) * v^(277/365) - AI
+	var testData = []struct {
+		Name     string
+		Method   auction.Method
+		Security auction.Security
+		Yield    float64
+		Price    float64
+		Accrued  float64
+	}{
+		{"par", auction.Treasury, note, 1.125, 100.0, 0.0},
+		{"regular", auction.Treasury, note, 1.2, 99.29524071032002, 0.0},
+		{"reopening", auction.Treasury, reopening, 1.2, 99.2999225956962, 0.08701657458563536},
+		{"short first coupon", auction.Treasury, short, 1.2, 99.3003617013123, 0.0},
+		{"long first coupon", auction.Treasury, long, 1.2, 99.28907620397233, 0.0},
+		{"zero-coupon bund", auction.Bund, bund, -0.2, 101.97294551759435, 0.0},
+		{"bund par", auction.Bund, bundPar, 1.0, 99.99909048370948, 0.2410958904109589},
+	}
+	for _, test := range testData {
+		price, accrued, err := test.Method.Price(test.Security, test.Yield)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(price-test.Price) > 1e-9 || math.Abs(accrued-test.Accrued) > 1e-12 {
+			t.Errorf("wrong price for %s; got: %v (accrued %v), expected: %v (accrued %v)", test.Name, price, accrued, test.Price, test.Accrued)
+		}
+		yield, err := test.Method.Yield(test.Security, price)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(yield-test.Yield) > 1e-8 {
+			t.Errorf("wrong yield for %s; got: %v, expected: %v", test.Name, yield, test.Yield)
+		}
+	}
+}
+
+func TestMethod_Errors(t *testing.T) {
+	var testData = []auction.Security{
+		// first coupon before settlement
+		{Settlement: date(2021, 9, 1), FirstCoupon: date(2021, 8, 15), Maturity: date(2031, 2, 15)},
+		// first coupon off the schedule
+		{Settlement: date(2021, 2, 15), FirstCoupon: date(2021, 8, 16), Maturity: date(2031, 2, 15)},
+		// dated after settlement
+		{Settlement: date(2021, 2, 15), Dated: date(2021, 3, 1), FirstCoupon: date(2021, 8, 15), Maturity: date(2031, 2, 15)},
+	}
+	for _, s := range testData {
+		if _, _, err := auction.Treasury.Price(s, 1.0); !errors.Is(err, auction.ErrInvalidSecurity) {
+			t.Errorf("wrong error for %v; got: %v, expected: %v", s, err, auction.ErrInvalidSecurity)
+		}
+	}
+	if _, err := auction.Method(5).Yield(testData[0], 100.0); !errors.Is(err, auction.ErrInvalidSecurity) {
+		t.Errorf("wrong error for unknown method; got: %v, expected: %v", err, auction.ErrInvalidSecurity)
+	}
+}
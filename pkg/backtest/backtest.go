@@ -0,0 +1,167 @@
+package backtest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/history"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// ErrNoData is returned if the history has no term structures in the backtest period
+var ErrNoData = errors.New("no term structures in backtest period")
+
+// Holding is a position in a bond
+type Holding struct {
+	Bond    *bond.Straight
+	Nominal float64
+}
+
+// value returns the dirty market value of the holding at the date
+func (h Holding) value(date time.Time, ts term.Structure) float64 {
+	return h.Nominal / h.Bond.ParValue() * h.Bond.PresentValueAt(date, ts)
+}
+
+// Strategy decides on the holdings at each rebalancing date
+type Strategy interface {
+	// Rebalance returns the new holdings given the current holdings and
+	// the available cash; trades are settled at the model prices of ts
+	Rebalance(date time.Time, ts term.Structure, holdings []Holding, cash float64) []Holding
+}
+
+// Backtest walks the dates of a curve history and rolls the holdings of a strategy
+type Backtest struct {
+	// History provides the term structures for the valuation
+	History history.History
+	// Strategy decides on the holdings
+	Strategy Strategy
+	// Start and End define the backtest period
+	Start, End time.Time
+	// Capital is the initial cash amount
+	Capital float64
+	// Every is the number of dates between rebalancing (default: 0 for every date)
+	Every int
+}
+
+// Result reports the performance of the backtest
+type Result struct {
+	// Dates are the valuation dates
+	Dates []time.Time
+	// Values are the portfolio values (holdings plus cash) at the dates
+	Values []float64
+	// TotalReturn is the cumulative return in percent
+	TotalReturn float64
+	// MaxDrawdown is the largest decline from a previous peak in percent
+	MaxDrawdown float64
+	// Turnover is the traded amount (half of the buys and sells) relative to
+	// the average portfolio value
+	Turnover float64
+}
+
+// Run executes the backtest: at each date, coupons and redemptions are
+// received in cash, the holdings are valued and, at the rebalancing dates,
+// the strategy trades. Cash earns no interest.
+func (b *Backtest) Run() (Result, error) {
+	entries := b.History.Range(b.Start, b.End)
+	if len(entries) == 0 {
+		return Result{}, fmt.Errorf("%w: %s to %s", ErrNoData, b.Start.Format("2006-01-02"), b.End.Format("2006-01-02"))
+	}
+
+	result := Result{}
+	cash := b.Capital
+	holdings := []Holding{}
+	traded := 0.0
+	prev := entries[0].Date
+	for i, e := range entries {
+		// coupons and redemptions
+		held := []Holding{}
+		for _, h := range holdings {
+			cash += h.income(prev, e.Date)
+			if h.Bond.Maturity.After(e.Date) {
+				held = append(held, h)
+			}
+		}
+		holdings = held
+
+		// rebalancing
+		if b.Every <= 0 || i%b.Every == 0 {
+			target := b.Strategy.Rebalance(e.Date, e.Curve, holdings, cash)
+			amount := trades(e.Date, e.Curve, holdings, target)
+			cash -= amount.net
+			traded += amount.gross
+			holdings = target
+		}
+
+		value := cash
+		for _, h := range holdings {
+			value += h.value(e.Date, e.Curve)
+		}
+		result.Dates = append(result.Dates, e.Date)
+		result.Values = append(result.Values, value)
+		prev = e.Date
+	}
+
+	result.TotalReturn, result.MaxDrawdown = performance(result.Values)
+	average := 0.0
+	for _, v := range result.Values {
+		average += v / float64(len(result.Values))
+	}
+	if average != 0.0 {
+		result.Turnover = traded / 2.0 / average
+	}
+	return result, nil
+}
+
+// income returns the payments of the holding after from and up to date
+func (h Holding) income(from, date time.Time) float64 {
+	c := *h.Bond
+	c.Settlement = from
+	amount := 0.0
+	for _, cf := range c.Cashflows() {
+		if !cf.Date.After(date) {
+			amount += h.Nominal / c.ParValue() * cf.Amount
+		}
+	}
+	return amount
+}
+
+type amount struct {
+	net, gross float64
+}
+
+// trades returns the net amount paid and the gross traded amount for moving
+// from the current to the target holdings
+func trades(date time.Time, ts term.Structure, current, target []Holding) amount {
+	nominal := map[*bond.Straight]float64{}
+	for _, h := range target {
+		nominal[h.Bond] += h.Nominal
+	}
+	for _, h := range current {
+		nominal[h.Bond] -= h.Nominal
+	}
+	a := amount{}
+	for b, n := range nominal {
+		v := Holding{Bond: b, Nominal: n}.value(date, ts)
+		a.net += v
+		a.gross += math.Abs(v)
+	}
+	return a
+}
+
+// performance returns the total return and the maximum drawdown in percent
+func performance(values []float64) (float64, float64) {
+	if len(values) == 0 || values[0] == 0.0 {
+		return 0.0, 0.0
+	}
+	peak, drawdown := values[0], 0.0
+	for _, v := range values {
+		peak = math.Max(peak, v)
+		if peak > 0.0 {
+			drawdown = math.Max(drawdown, (peak-v)/peak*100.0)
+		}
+	}
+	return (values[len(values)-1]/values[0] - 1.0) * 100.0, drawdown
+}
@@ -0,0 +1,84 @@
+package backtest_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/backtest"
+	"github.com/konimarti/fixedincome/pkg/history"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+var start = time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// store returns a daily history with a jump of the flat rate from r1 to r2 after jump days
+func store(days, jump int, r1, r2 float64) *history.Store {
+	s := history.Store{}
+	for d := 0; d < days; d += 1 {
+		r := r1
+		if d >= jump {
+			r = r2
+		}
+		s.Add(start.AddDate(0, 0, d), &term.Flat{R: r})
+	}
+	return &s
+}
+
+func TestBacktest_Roll(t *testing.T) {
+	days := 3 * 365
+	bt := backtest.Backtest{
+		History:  store(days, days, 1.0, 1.0),
+		Strategy: &backtest.Roll{Buy: 10, Sell: 8},
+		Start:    start,
+		End:      start.AddDate(3, 0, 0),
+		Capital:  1e6,
+	}
+	result, err := bt.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Values) != days {
+		t.Fatalf("wrong number of values; got: %d, expected: %d", len(result.Values), days)
+	}
+
+	// with a constant curve the portfolio grows at the continuously compounded rate
+	expected := (math.Exp(0.03) - 1.0) * 100.0
+	if math.Abs(result.TotalReturn-expected) > 0.05 {
+		t.Errorf("wrong total return; got: %v, expected: %v", result.TotalReturn, expected)
+	}
+	// small drawdowns only due to the 30E/360 day count at month ends
+	if result.MaxDrawdown > 0.1 {
+		t.Errorf("unexpected drawdown with constant curve; got: %v", result.MaxDrawdown)
+	}
+	// the initial purchase and the roll after two years
+	if result.Turnover < 1.0 || result.Turnover > 2.0 {
+		t.Errorf("wrong turnover; got: %v", result.Turnover)
+	}
+}
+
+func TestBacktest_Drawdown(t *testing.T) {
+	bt := backtest.Backtest{
+		History:  store(365, 100, 1.0, 2.0),
+		Strategy: &backtest.Roll{Buy: 10, Sell: 8},
+		Start:    start,
+		End:      start.AddDate(1, 0, 0),
+		Capital:  1e6,
+		Every:    30,
+	}
+	result, err := bt.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a 100bp rate increase with a duration of about 9 years
+	if result.MaxDrawdown < 7.0 || result.MaxDrawdown > 10.0 {
+		t.Errorf("wrong drawdown; got: %v", result.MaxDrawdown)
+	}
+
+	bt.Start = start.AddDate(5, 0, 0)
+	bt.End = start.AddDate(6, 0, 0)
+	if _, err := bt.Run(); !errors.Is(err, backtest.ErrNoData) {
+		t.Errorf("expected ErrNoData; got: %v", err)
+	}
+}
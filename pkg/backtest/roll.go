@@ -0,0 +1,75 @@
+package backtest
+
+import (
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Roll is a roll-down strategy: it buys a new bond with Buy years to
+// maturity at the par coupon of the term structure, sells it when its
+// remaining life falls to Sell years and reinvests the coupons in the
+// current bond
+type Roll struct {
+	// Buy is the initial term in years of the purchased bonds (e.g. 10)
+	Buy int
+	// Sell is the remaining term in years at which bonds are sold (e.g. 8)
+	Sell int
+	// Frequency is the coupon frequency of the purchased bonds (default: 1 per year)
+	Frequency int
+	// Basis is the day count convention of the purchased bonds (default: "" for 30E/360 ISDA)
+	Basis string
+}
+
+// Rebalance implements the Strategy interface
+func (r *Roll) Rebalance(date time.Time, ts term.Structure, holdings []Holding, cash float64) []Holding {
+	target := []Holding{}
+	sellDate := date.AddDate(r.Sell, 0, 0)
+	for _, h := range holdings {
+		if h.Bond.Maturity.After(sellDate) {
+			target = append(target, h)
+		} else {
+			// sold at the model price
+			cash += h.value(date, ts)
+		}
+	}
+	if cash <= 0.0 {
+		return target
+	}
+
+	// reinvest in the current bond or buy a new one
+	if len(target) == 0 {
+		target = append(target, Holding{Bond: r.newBond(date, ts)})
+	}
+	current := &target[len(target)-1]
+	price := current.Bond.PresentValueAt(date, ts) / current.Bond.ParValue()
+	if price > 0.0 {
+		current.Nominal += cash / price
+	}
+	return target
+}
+
+// newBond returns a bond issued at the date with the par coupon, i.e. with
+// a market value of 100 with the term structure
+func (r *Roll) newBond(date time.Time, ts term.Structure) *bond.Straight {
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: date,
+			Maturity:   date.AddDate(r.Buy, 0, 0),
+			Issue:      date,
+			Frequency:  r.Frequency,
+			Basis:      r.Basis,
+		},
+		Redemption: 100.0,
+	}
+	annuity := 0.0
+	for _, m := range b.M() {
+		annuity += ts.Z(m) / float64(b.Compounding())
+	}
+	if annuity > 0.0 {
+		b.Coupon = (100.0 - 100.0*ts.Z(b.Last())) / annuity
+	}
+	return &b
+}
@@ -0,0 +1,100 @@
+package calendar
+
+import (
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+)
+
+// Convention is the business day convention for dates falling on holidays
+type Convention int
+
+const (
+	// Following moves the date to the next business day
+	Following Convention = iota
+	// ModifiedFollowing moves the date to the next business day unless it is
+	// in the next month, then to the previous business day
+	ModifiedFollowing
+	// Preceding moves the date to the previous business day
+	Preceding
+	// Unadjusted leaves the date unchanged
+	Unadjusted
+)
+
+// Calendar represents the business days of a market with weekends on
+// Saturday and Sunday and a list of holidays
+type Calendar struct {
+	holidays map[time.Time]bool
+}
+
+// New returns a calendar with the given holidays
+func New(holidays ...time.Time) *Calendar {
+	c := &Calendar{holidays: make(map[time.Time]bool)}
+	for _, h := range holidays {
+		c.AddHoliday(h)
+	}
+	return c
+}
+
+// AddHoliday adds a holiday to the calendar
+func (c *Calendar) AddHoliday(date time.Time) {
+	if c.holidays == nil {
+		c.holidays = make(map[time.Time]bool)
+	}
+	c.holidays[day(date)] = true
+}
+
+// IsBusinessDay returns true if the date is neither on a weekend nor a holiday
+// (a nil calendar has no holidays, i.e. only weekends are skipped)
+func (c *Calendar) IsBusinessDay(date time.Time) bool {
+	if wd := date.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return false
+	}
+	if c == nil {
+		return true
+	}
+	return !c.holidays[day(date)]
+}
+
+// Adjust moves the date to a business day according to the convention
+func (c *Calendar) Adjust(date time.Time, convention Convention) time.Time {
+	switch convention {
+	case Following:
+		return c.roll(date, 1)
+	case ModifiedFollowing:
+		if next := c.roll(date, 1); next.Month() == date.Month() {
+			return next
+		}
+		return c.roll(date, -1)
+	case Preceding:
+		return c.roll(date, -1)
+	}
+	return date
+}
+
+// Cashflows returns a copy of the cash flows with the payment dates adjusted to
+// business days; the times to payment are shifted by the actual days moved (ACT/365)
+func (c *Calendar) Cashflows(cfs cashflow.Cashflows, convention Convention) cashflow.Cashflows {
+	adjusted := make(cashflow.Cashflows, len(cfs))
+	for i, cf := range cfs {
+		date := c.Adjust(cf.Date, convention)
+		days := date.Sub(cf.Date).Hours() / 24.0
+		adjusted[i] = cashflow.Cashflow{Date: date, T: cf.T + days/365.0, Amount: cf.Amount}
+	}
+	adjusted.Sort()
+	return adjusted
+}
+
+// roll moves the date by step days until it is a business day
+func (c *Calendar) roll(date time.Time, step int) time.Time {
+	for !c.IsBusinessDay(date) {
+		date = date.AddDate(0, 0, step)
+	}
+	return date
+}
+
+// day truncates the date to midnight UTC of the same calendar day
+func day(date time.Time) time.Time {
+	y, m, d := date.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
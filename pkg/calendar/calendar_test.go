@@ -0,0 +1,67 @@
+package calendar_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/calendar"
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestAdjust(t *testing.T) {
+	// 2021-05-31 is a Monday (holiday), 2021-05-29/30 is a weekend
+	cal := calendar.New(date(2021, 5, 31), date(2021, 12, 31))
+
+	testData := []struct {
+		Date       time.Time
+		Convention calendar.Convention
+		Expected   time.Time
+	}{
+		{date(2021, 5, 28), calendar.Following, date(2021, 5, 28)},
+		{date(2021, 5, 29), calendar.Following, date(2021, 6, 1)},
+		{date(2021, 5, 29), calendar.ModifiedFollowing, date(2021, 5, 28)},
+		{date(2021, 5, 29), calendar.Preceding, date(2021, 5, 28)},
+		{date(2021, 5, 29), calendar.Unadjusted, date(2021, 5, 29)},
+		{date(2021, 12, 31), calendar.Following, date(2022, 1, 3)},
+		{date(2021, 12, 31), calendar.ModifiedFollowing, date(2021, 12, 30)},
+	}
+	for _, test := range testData {
+		if got := cal.Adjust(test.Date, test.Convention); !got.Equal(test.Expected) {
+			t.Errorf("wrong adjusted date for %v; got: %v, expected: %v", test.Date, got, test.Expected)
+		}
+	}
+}
+
+func TestAdjust_Nil(t *testing.T) {
+	// a nil calendar only skips weekends
+	var cal *calendar.Calendar
+	if !cal.IsBusinessDay(date(2021, 5, 31)) || cal.IsBusinessDay(date(2021, 5, 29)) {
+		t.Errorf("nil calendar should only skip weekends")
+	}
+	if got := cal.Adjust(date(2021, 5, 29), calendar.Following); !got.Equal(date(2021, 5, 31)) {
+		t.Errorf("wrong adjusted date for nil calendar; got: %v, expected: %v", got, date(2021, 5, 31))
+	}
+}
+
+func TestCashflows(t *testing.T) {
+	cal := calendar.New()
+	cfs := cashflow.Cashflows{
+		{Date: date(2022, 5, 28), T: 1.0, Amount: 1.0},  // Saturday
+		{Date: date(2022, 5, 27), T: 0.99, Amount: 2.0}, // Friday
+	}
+	adjusted := cal.Cashflows(cfs, calendar.Following)
+	if !adjusted[1].Date.Equal(date(2022, 5, 30)) {
+		t.Errorf("wrong adjusted date; got: %v, expected: %v", adjusted[1].Date, date(2022, 5, 30))
+	}
+	if math.Abs(adjusted[1].T-(1.0+2.0/365.0)) > 1e-12 {
+		t.Errorf("wrong adjusted time; got: %v, expected: %v", adjusted[1].T, 1.0+2.0/365.0)
+	}
+	if !cfs[0].Date.Equal(date(2022, 5, 28)) {
+		t.Errorf("cash flows modified")
+	}
+}
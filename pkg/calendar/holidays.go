@@ -0,0 +1,85 @@
+package calendar
+
+import "time"
+
+// TARGET returns the calendar of the TARGET2 payment system from the year
+// from to the year to: New Year's Day, Good Friday, Easter Monday, Labour Day
+// and Christmas Day and the following day
+func TARGET(from, to int) *Calendar {
+	c := New()
+	for y := from; y <= to; y += 1 {
+		easter := Easter(y)
+		c.AddHoliday(time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC))
+		c.AddHoliday(easter.AddDate(0, 0, -2))
+		c.AddHoliday(easter.AddDate(0, 0, 1))
+		c.AddHoliday(time.Date(y, 5, 1, 0, 0, 0, 0, time.UTC))
+		c.AddHoliday(time.Date(y, 12, 25, 0, 0, 0, 0, time.UTC))
+		c.AddHoliday(time.Date(y, 12, 26, 0, 0, 0, 0, time.UTC))
+	}
+	return c
+}
+
+// US returns the calendar of the US federal holidays from the year from to the
+// year to; holidays on a Saturday are observed on the Friday before and
+// holidays on a Sunday on the Monday after (Juneteenth from 2021)
+func US(from, to int) *Calendar {
+	c := New()
+	for y := from; y <= to; y += 1 {
+		fixed := []time.Time{
+			time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(y, 7, 4, 0, 0, 0, 0, time.UTC),
+			time.Date(y, 11, 11, 0, 0, 0, 0, time.UTC),
+			time.Date(y, 12, 25, 0, 0, 0, 0, time.UTC),
+		}
+		if y >= 2021 {
+			fixed = append(fixed, time.Date(y, 6, 19, 0, 0, 0, 0, time.UTC))
+		}
+		for _, h := range fixed {
+			c.AddHoliday(observed(h))
+		}
+		c.AddHoliday(weekday(y, time.January, time.Monday, 3))    // Martin Luther King Jr. Day
+		c.AddHoliday(weekday(y, time.February, time.Monday, 3))   // Washington's Birthday
+		c.AddHoliday(weekday(y, time.May, time.Monday, -1))       // Memorial Day
+		c.AddHoliday(weekday(y, time.September, time.Monday, 1))  // Labor Day
+		c.AddHoliday(weekday(y, time.October, time.Monday, 2))    // Columbus Day
+		c.AddHoliday(weekday(y, time.November, time.Thursday, 4)) // Thanksgiving Day
+	}
+	return c
+}
+
+// Easter returns the date of Easter Sunday (Gregorian calendar)
+func Easter(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// observed moves a holiday on a Saturday to Friday and on a Sunday to Monday
+func observed(date time.Time) time.Time {
+	switch date.Weekday() {
+	case time.Saturday:
+		return date.AddDate(0, 0, -1)
+	case time.Sunday:
+		return date.AddDate(0, 0, 1)
+	}
+	return date
+}
+
+// weekday returns the n-th weekday of the month (the last one for n = -1)
+func weekday(year int, month time.Month, wd time.Weekday, n int) time.Time {
+	if n < 0 {
+		last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+		return last.AddDate(0, 0, -((int(last.Weekday()) - int(wd) + 7) % 7))
+	}
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return first.AddDate(0, 0, (int(wd)-int(first.Weekday())+7)%7+7*(n-1))
+}
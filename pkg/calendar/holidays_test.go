@@ -0,0 +1,69 @@
+package calendar_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/calendar"
+)
+
+func TestEaster(t *testing.T) {
+	expected := map[int]time.Time{
+		2019: date(2019, 4, 21),
+		2022: date(2022, 4, 17),
+		2024: date(2024, 3, 31),
+		2038: date(2038, 4, 25),
+	}
+	for year, easter := range expected {
+		if got := calendar.Easter(year); !got.Equal(easter) {
+			t.Errorf("wrong Easter Sunday for %d; got: %v, expected: %v", year, got, easter)
+		}
+	}
+}
+
+func TestTARGET(t *testing.T) {
+	cal := calendar.TARGET(2022, 2022)
+	holidays := []time.Time{date(2022, 1, 1), date(2022, 4, 15), date(2022, 4, 18), date(2022, 5, 1), date(2022, 12, 25), date(2022, 12, 26)}
+	if len(cal.Holidays()) != len(holidays) {
+		t.Fatalf("wrong number of holidays; got: %v", cal.Holidays())
+	}
+	for i, h := range cal.Holidays() {
+		if !h.Equal(holidays[i]) {
+			t.Errorf("wrong holiday; got: %v, expected: %v", h, holidays[i])
+		}
+	}
+}
+
+func TestUS(t *testing.T) {
+	cal := calendar.US(2022, 2022)
+	holidays := []time.Time{
+		date(2021, 12, 31), // New Year's Day on a Saturday
+		date(2022, 1, 17),
+		date(2022, 2, 21),
+		date(2022, 5, 30),
+		date(2022, 6, 20), // Juneteenth on a Sunday
+		date(2022, 7, 4),
+		date(2022, 9, 5),
+		date(2022, 10, 10),
+		date(2022, 11, 11),
+		date(2022, 11, 24),
+		date(2022, 12, 26), // Christmas Day on a Sunday
+	}
+	if len(cal.Holidays()) != len(holidays) {
+		t.Fatalf("wrong number of holidays; got: %v", cal.Holidays())
+	}
+	for i, h := range cal.Holidays() {
+		if !h.Equal(holidays[i]) {
+			t.Errorf("wrong holiday; got: %v, expected: %v", h, holidays[i])
+		}
+	}
+
+	// payments in EUR and USD require business days in both calendars
+	union := calendar.Union(calendar.TARGET(2022, 2022), cal)
+	if got := union.Adjust(date(2022, 4, 15), calendar.Following); !got.Equal(date(2022, 4, 19)) {
+		t.Errorf("wrong adjusted date; got: %v, expected: %v", got, date(2022, 4, 19))
+	}
+	if got := union.Adjust(date(2022, 7, 4), calendar.Following); !got.Equal(date(2022, 7, 5)) {
+		t.Errorf("wrong adjusted date; got: %v, expected: %v", got, date(2022, 7, 5))
+	}
+}
@@ -0,0 +1,121 @@
+package calendar
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ErrInvalidHoliday is returned for holiday lists that cannot be parsed
+var ErrInvalidHoliday = errors.New("invalid holiday")
+
+// Holidays returns the holidays of the calendar in chronological order
+// (none for a nil calendar)
+func (c *Calendar) Holidays() []time.Time {
+	if c == nil {
+		return []time.Time{}
+	}
+	holidays := make([]time.Time, 0, len(c.holidays))
+	for h := range c.holidays {
+		holidays = append(holidays, h)
+	}
+	sort.Slice(holidays, func(i, j int) bool { return holidays[i].Before(holidays[j]) })
+	return holidays
+}
+
+// Union returns a calendar with the holidays of all calendars, e.g. for
+// payments that require TARGET and US business days
+func Union(calendars ...*Calendar) *Calendar {
+	u := New()
+	for _, c := range calendars {
+		if c == nil {
+			continue
+		}
+		for h := range c.holidays {
+			u.holidays[h] = true
+		}
+	}
+	return u
+}
+
+// ReadCSV reads the holidays from the first column of a csv file with dates
+// formatted as YYYY-MM-DD; empty lines, comments (#) and a header line (a
+// first line whose first column has no digits, e.g. "date") are skipped
+func ReadCSV(r io.Reader) (*Calendar, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.Comment = '#'
+	c := New()
+	for i := 0; ; i += 1 {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return c, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidHoliday, err)
+		}
+		field := strings.TrimSpace(record[0])
+		if field == "" {
+			continue
+		}
+		if i == 0 && strings.IndexFunc(field, unicode.IsDigit) < 0 {
+			// header line
+			continue
+		}
+		date, err := time.Parse("2006-01-02", field)
+		if err != nil {
+			line, _ := cr.FieldPos(0)
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidHoliday, line, err)
+		}
+		c.AddHoliday(date)
+	}
+}
+
+// ReadJSON reads the holidays from a JSON array of dates formatted as
+// YYYY-MM-DD or from an object with the array in the "holidays" field
+func ReadJSON(r io.Reader) (*Calendar, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var dates []string
+	if err := json.Unmarshal(data, &dates); err != nil {
+		object := struct {
+			Holidays []string `json:"holidays"`
+		}{}
+		if err := json.Unmarshal(data, &object); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidHoliday, err)
+		}
+		dates = object.Holidays
+	}
+	c := New()
+	for _, field := range dates {
+		date, err := time.Parse("2006-01-02", field)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidHoliday, err)
+		}
+		c.AddHoliday(date)
+	}
+	return c, nil
+}
+
+// Load reads the holidays from a csv or JSON file (by the file extension)
+func Load(file string) (*Calendar, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if strings.EqualFold(filepath.Ext(file), ".json") {
+		return ReadJSON(f)
+	}
+	return ReadCSV(f)
+}
@@ -0,0 +1,96 @@
+package calendar_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/calendar"
+)
+
+func TestReadCSV(t *testing.T) {
+	data := "date,name\n2022-12-26,Boxing Day\n\n# comment\n2022-04-15,Good Friday\n"
+	cal, err := calendar.ReadCSV(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	holidays := cal.Holidays()
+	if len(holidays) != 2 || !holidays[0].Equal(date(2022, 4, 15)) || !holidays[1].Equal(date(2022, 12, 26)) {
+		t.Errorf("wrong holidays; got: %v", holidays)
+	}
+	if cal.IsBusinessDay(date(2022, 12, 26)) {
+		t.Error("holiday is a business day")
+	}
+
+	// only a header line without dates is skipped
+	for _, data := range []string{
+		"2022-12-26\n26.12.2022\n",
+		"26.12.2022\n2022-12-26\n",
+		"2022-13-01\n",
+		"date\n2022-12-26\nBoxing Day\n",
+	} {
+		if _, err := calendar.ReadCSV(strings.NewReader(data)); !errors.Is(err, calendar.ErrInvalidHoliday) {
+			t.Errorf("expected ErrInvalidHoliday for %q; got: %v", data, err)
+		}
+	}
+	_, err = calendar.ReadCSV(strings.NewReader("date\n2022-12-26\n\n# comment\n26.12.2022\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 5") {
+		t.Errorf("expected an error for line 5; got: %v", err)
+	}
+}
+
+func TestHolidays_Nil(t *testing.T) {
+	var cal *calendar.Calendar
+	if holidays := cal.Holidays(); len(holidays) != 0 {
+		t.Errorf("nil calendar should not have holidays; got: %v", holidays)
+	}
+	if union := calendar.Union(cal, calendar.New(date(2022, 12, 26))); len(union.Holidays()) != 1 {
+		t.Errorf("wrong union with nil calendar; got: %v", union.Holidays())
+	}
+}
+
+func TestReadJSON(t *testing.T) {
+	for _, data := range []string{`["2022-12-26", "2022-04-15"]`, `{"holidays": ["2022-12-26", "2022-04-15"]}`} {
+		cal, err := calendar.ReadJSON(strings.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cal.Holidays()) != 2 || cal.IsBusinessDay(date(2022, 4, 15)) {
+			t.Errorf("wrong holidays for %s; got: %v", data, cal.Holidays())
+		}
+	}
+	if _, err := calendar.ReadJSON(strings.NewReader(`["2022/12/26"]`)); !errors.Is(err, calendar.ErrInvalidHoliday) {
+		t.Errorf("expected ErrInvalidHoliday; got: %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"holidays.csv":  "2022-12-26\n",
+		"holidays.json": `["2022-12-27"]`,
+	}
+	calendars := []*calendar.Calendar{}
+	for name, content := range files {
+		file := filepath.Join(dir, name)
+		if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		cal, err := calendar.Load(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		calendars = append(calendars, cal)
+	}
+
+	union := calendar.Union(append(calendars, nil)...)
+	if len(union.Holidays()) != 2 || union.IsBusinessDay(date(2022, 12, 26)) || union.IsBusinessDay(date(2022, 12, 27)) {
+		t.Errorf("wrong union of calendars; got: %v", union.Holidays())
+	}
+
+	if _, err := calendar.Load(filepath.Join(dir, "missing.csv")); err == nil {
+		t.Error("expected error for a missing file")
+	}
+}
@@ -0,0 +1,37 @@
+package cashflow
+
+import (
+	"math/big"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// BigPrecision is the mantissa precision in bits for the high-precision calculations
+var BigPrecision uint = 256
+
+// NewBig returns a new big.Float with the high-precision mantissa
+func NewBig(x float64) *big.Float {
+	return new(big.Float).SetPrec(BigPrecision).SetFloat64(x)
+}
+
+// PresentValueBig returns the discounted value of the cash flows using
+// arbitrary-precision arithmetic for the scaling, the rounding, the products
+// and the summation; the amounts and the discount factors of the term
+// structure enter as exact float64 values. The amounts are scaled by
+// nominal/par where par is the notional base of the amounts (e.g. 100 or the
+// ParValue of the bond) and if round is not nil, each cash flow amount is
+// rounded before discounting (e.g. with rounding.ForCurrency("CHF").RoundBig
+// to currency cents).
+func (c Cashflows) PresentValueBig(ts term.Structure, nominal, par float64, round func(*big.Float) *big.Float) *big.Float {
+	pv := NewBig(0.0)
+	for _, cf := range c {
+		amount := NewBig(cf.Amount)
+		amount.Mul(amount, NewBig(nominal))
+		amount.Quo(amount, NewBig(par))
+		if round != nil {
+			amount = round(amount)
+		}
+		pv.Add(pv, amount.Mul(amount, NewBig(ts.Z(cf.T))))
+	}
+	return pv
+}
@@ -0,0 +1,44 @@
+package cashflow
+
+import (
+	"sort"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Cashflow represents a single payment of a security
+type Cashflow struct {
+	// Date is the payment date
+	Date time.Time
+	// T is the time to payment in years from the settlement date
+	T float64
+	// Amount is the payment amount
+	Amount float64
+}
+
+// Cashflows is a list of payments
+type Cashflows []Cashflow
+
+// PresentValue returns the discounted value of the cash flows summed in
+// their order (see Compensated). With a term.Cache the discount factors of
+// dated cash flows are cached by their payment date.
+func (c Cashflows) PresentValue(ts term.Structure) float64 {
+	pv := NewSum()
+	cache, dated := ts.(interface {
+		ZAt(date time.Time, t float64) float64
+	})
+	for _, cf := range c {
+		if dated && !cf.Date.IsZero() {
+			pv.AddProduct(cf.Amount, cache.ZAt(cf.Date, cf.T))
+			continue
+		}
+		pv.AddProduct(cf.Amount, ts.Z(cf.T))
+	}
+	return pv.Value()
+}
+
+// Sort orders the cash flows by payment time
+func (c Cashflows) Sort() {
+	sort.SliceStable(c, func(i, j int) bool { return c[i].T < c[j].T })
+}
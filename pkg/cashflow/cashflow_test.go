@@ -0,0 +1,78 @@
+package cashflow_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/rounding"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestCashflows(t *testing.T) {
+	ts := term.Flat{R: 2.0}
+
+	cfs := cashflow.Cashflows{
+		{T: 2.0, Amount: 102.0},
+		{T: 1.0, Amount: 2.0},
+	}
+	cfs.Sort()
+	if cfs[0].T != 1.0 || cfs[1].T != 2.0 {
+		t.Errorf("cash flows are not sorted; got: %v", cfs)
+	}
+
+	pv := cfs.PresentValue(&ts)
+	expected := 2.0*math.Exp(-0.02) + 102.0*math.Exp(-0.04)
+	if math.Abs(pv-expected) > 1e-12 {
+		t.Errorf("wrong present value; got: %v, expected: %v", pv, expected)
+	}
+}
+
+func TestCashflows_PresentValueBig(t *testing.T) {
+	ts := term.Flat{R: 2.0}
+	cfs := cashflow.Cashflows{
+		{T: 0.5, Amount: 0.333333},
+		{T: 1.0, Amount: 100.333333},
+	}
+
+	// without rounding the high-precision value matches the float64 value
+	pv, _ := cfs.PresentValueBig(&ts, 100.0, 100.0, nil).Float64()
+	if math.Abs(pv-cfs.PresentValue(&ts)) > 1e-12 {
+		t.Errorf("wrong high-precision present value; got: %v, expected: %v", pv, cfs.PresentValue(&ts))
+	}
+
+	// cash flows for a nominal of 1000 rounded to cents: 3.33 and 1003.33
+	pv, _ = cfs.PresentValueBig(&ts, 1000.0, 100.0, rounding.ForCurrency("CHF").RoundBig).Float64()
+	expected := 3.33*ts.Z(0.5) + 1003.33*ts.Z(1.0)
+	if math.Abs(pv-expected) > 1e-10 {
+		t.Errorf("wrong rounded present value; got: %v, expected: %v", pv, expected)
+	}
+}
+
+func TestCashflows_PresentValueBig_Par(t *testing.T) {
+	ts := term.Flat{R: 2.0}
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2026, 5, 15, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+			Basis:      "30E360",
+		},
+		Coupon:     3.0,
+		Redemption: 100.0,
+	}
+	nominal := 1e6
+	expected, _ := b.Cashflows().PresentValueBig(&ts, nominal, b.ParValue(), nil).Float64()
+
+	// the value of the nominal does not depend on the notional base of the amounts
+	for _, par := range []float64{1.0, 1000.0} {
+		b.Par = par
+		pv, _ := b.Cashflows().PresentValueBig(&ts, nominal, b.ParValue(), nil).Float64()
+		if math.Abs(pv-expected) > 1e-6 {
+			t.Errorf("wrong present value for par %v; got: %v, expected: %v", par, pv, expected)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package cashflow_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// book returns n cash flows with rates on a flat curve
+func book(n int) (cashflow.Cashflows, []float64) {
+	cfs := make(cashflow.Cashflows, n)
+	rates := make([]float64, n)
+	for i := range cfs {
+		cfs[i] = cashflow.Cashflow{T: float64(i%360+1) / 12.0, Amount: 1.0 + float64(i%7)}
+		rates[i] = 1.5
+	}
+	return cfs, rates
+}
+
+func TestDiscount(t *testing.T) {
+	ts := term.Flat{R: 1.5}
+	for _, n := range []int{0, 1, 3, 4, 7, 1001} {
+		cfs, rates := book(n)
+		v := cfs.Vector()
+		expected := cfs.PresentValue(&ts)
+
+		if pv, err := cashflow.Discount(v.Amount, rates, v.T); err != nil || math.Abs(pv-expected) > 1e-9 {
+			t.Errorf("n=%d: wrong discounted value; got: %v (%v), expected: %v", n, pv, err, expected)
+		}
+		if pv, err := v.PresentValue(&ts); err != nil || math.Abs(pv-expected) > 1e-9 {
+			t.Errorf("n=%d: wrong present value of vector; got: %v (%v), expected: %v", n, pv, err, expected)
+		}
+	}
+
+	var v cashflow.Vector
+	v.Append(cashflow.Cashflows{{T: 1.0, Amount: 2.0}, {T: 2.0, Amount: 102.0}})
+	expected := 2.0*math.Exp(-0.015) + 102.0*math.Exp(-0.03)
+	if pv, err := v.PresentValue(&ts); err != nil || v.Len() != 2 || math.Abs(pv-expected) > 1e-12 {
+		t.Errorf("wrong present value of appended vector; got: %v (%v), expected: %v", pv, err, expected)
+	}
+
+	// slices of different length
+	if _, err := cashflow.Discount([]float64{1.0, 2.0}, []float64{1.5}, []float64{1.0, 2.0}); !errors.Is(err, cashflow.ErrLength) {
+		t.Errorf("expected ErrLength for rates of different length; got: %v", err)
+	}
+	if _, err := cashflow.Dot([]float64{1.0}, []float64{}); !errors.Is(err, cashflow.ErrLength) {
+		t.Errorf("expected ErrLength for discount factors of different length; got: %v", err)
+	}
+	v.Amount = v.Amount[:1]
+	if _, err := v.PresentValue(&ts); !errors.Is(err, cashflow.ErrLength) {
+		t.Errorf("expected ErrLength for vector of different length; got: %v", err)
+	}
+}
+
+func BenchmarkDiscount_Naive(b *testing.B) {
+	cfs, rates := book(1000000)
+	v := cfs.Vector()
+	b.ResetTimer()
+	for i := 0; i < b.N; i += 1 {
+		pv := 0.0
+		for j := range v.Amount {
+			pv += v.Amount[j] * math.Exp(-rates[j]*0.01*v.T[j])
+		}
+	}
+}
+
+func BenchmarkDiscount_Kernel(b *testing.B) {
+	cfs, rates := book(1000000)
+	v := cfs.Vector()
+	b.ResetTimer()
+	for i := 0; i < b.N; i += 1 {
+		cashflow.Discount(v.Amount, rates, v.T)
+	}
+}
+
+func BenchmarkDiscount_Cashflows(b *testing.B) {
+	cfs, _ := book(1000000)
+	ts := term.Flat{R: 1.5}
+	b.ResetTimer()
+	for i := 0; i < b.N; i += 1 {
+		cfs.PresentValue(&ts)
+	}
+}
@@ -0,0 +1,53 @@
+package cashflow
+
+import "math"
+
+// Compensated enables the compensated (Kahan-Babuska) summation of present
+// values for long cash flow strips (default: false for the plain summation).
+//
+// The present values are summed in the order of the cash flows and every
+// product is rounded to float64 before it is added, so that compilers cannot
+// fuse the multiplication and the addition (FMA) in the summation on some
+// architectures (e.g. arm64, ppc64le, s390x). This fixes the summation order
+// only: the discount factors come from math.Exp and math.Log, which have
+// architecture-specific implementations, and the curve formulas may be fused,
+// so results can differ in the last bits across architectures.
+var Compensated = false
+
+// Sum accumulates float64 values in a fixed order
+type Sum struct {
+	// Compensated enables the compensated summation
+	Compensated bool
+	value       float64
+	c           float64
+}
+
+// NewSum returns an empty sum with the package default summation (see Compensated)
+func NewSum() Sum {
+	return Sum{Compensated: Compensated}
+}
+
+// Add adds x to the sum
+func (s *Sum) Add(x float64) {
+	if !s.Compensated {
+		s.value = float64(s.value + x)
+		return
+	}
+	t := float64(s.value + x)
+	if math.Abs(s.value) >= math.Abs(x) {
+		s.c += float64(s.value-t) + x
+	} else {
+		s.c += float64(x-t) + s.value
+	}
+	s.value = t
+}
+
+// AddProduct adds the product x*y rounded to float64 to the sum
+func (s *Sum) AddProduct(x, y float64) {
+	s.Add(float64(x * y))
+}
+
+// Value returns the sum
+func (s *Sum) Value() float64 {
+	return s.value + s.c
+}
@@ -0,0 +1,55 @@
+package cashflow_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestSum(t *testing.T) {
+	values := []float64{1.0, 1e100, 1.0, -1e100}
+
+	plain := cashflow.Sum{}
+	compensated := cashflow.Sum{Compensated: true}
+	for _, x := range values {
+		plain.Add(x)
+		compensated.Add(x)
+	}
+	if plain.Value() != 0.0 {
+		t.Errorf("wrong plain sum; got: %v, expected: %v", plain.Value(), 0.0)
+	}
+	if compensated.Value() != 2.0 {
+		t.Errorf("wrong compensated sum; got: %v, expected: %v", compensated.Value(), 2.0)
+	}
+
+	// many small amounts
+	compensated = cashflow.Sum{Compensated: true}
+	for i := 0; i < 10000; i++ {
+		compensated.AddProduct(0.1, 0.1)
+	}
+	if math.Abs(compensated.Value()-100.0) > 1e-13 {
+		t.Errorf("wrong compensated sum of products; got: %v, expected: %v", compensated.Value(), 100.0)
+	}
+}
+
+func TestCompensatedPresentValue(t *testing.T) {
+	ts := term.Flat{R: 1.0}
+	cfs := cashflow.Cashflows{}
+	for i := 1; i <= 1200; i++ {
+		cfs = append(cfs, cashflow.Cashflow{T: float64(i) / 12.0, Amount: 0.25})
+	}
+
+	plain := cfs.PresentValue(&ts)
+	cashflow.Compensated = true
+	defer func() { cashflow.Compensated = false }()
+	compensated := cfs.PresentValue(&ts)
+
+	if math.Abs(plain-compensated) > 1e-10 {
+		t.Errorf("wrong compensated present value; got: %v, expected: %v", compensated, plain)
+	}
+	if again := cfs.PresentValue(&ts); again != compensated {
+		t.Errorf("present value is not reproducible; got: %v, expected: %v", again, compensated)
+	}
+}
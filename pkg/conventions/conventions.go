@@ -0,0 +1,288 @@
+package conventions
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/calendar"
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Convention bundles the market conventions of a bond market
+type Convention struct {
+	// Name of the market, e.g. "Swiss Confederation"
+	Name string
+	// Currency of the bonds
+	Currency string
+	// Basis is the day count convention
+	Basis string
+	// Frequency is the number of coupons per year
+	Frequency int
+	// SettlementLag is the number of business days from trade to settlement
+	SettlementLag int
+	// ExDividend is the number of business days before a coupon date from
+	// which the bonds trade ex-dividend (default: 0 for none)
+	ExDividend int
+	// EndOfMonth is the end-of-month rule for coupon dates of bonds maturing
+	// on the last day of a month
+	EndOfMonth bool
+	// Quote is the quoting style in the market
+	Quote fixedincome.QuoteType
+	// Compounding is the number of compounding periods per year of quoted yields
+	Compounding int
+	// Stub is the discounting of the fractional first period for quoted yields
+	Stub term.Stub
+	// SimpleYield quotes the Japanese simple yield on the clean price instead
+	// of a compound yield (see SimpleYield)
+	SimpleYield bool
+	// SimpleFinalPeriod discounts bonds in their final coupon period with
+	// simple interest (money-market yield)
+	SimpleFinalPeriod bool
+}
+
+// Bond is a fixed-coupon bond with cash flows and the accrued year fraction
+type Bond interface {
+	fixedincome.CashflowSecurity
+	DayCountFraction() float64
+}
+
+var (
+	// CH are the conventions of Swiss Confederation bonds
+	CH = Convention{
+		Name:          "Swiss Confederation",
+		Currency:      "CHF",
+		Basis:         "30E360",
+		Frequency:     1,
+		SettlementLag: 2,
+		Quote:         fixedincome.Price,
+		Compounding:   1,
+		Stub:          term.Compound,
+	}
+
+	// DE are the conventions of German federal bonds (Bunds)
+	DE = Convention{
+		Name:          "German Bund",
+		Currency:      "EUR",
+		Basis:         "ACTACT",
+		Frequency:     1,
+		SettlementLag: 2,
+		Quote:         fixedincome.Price,
+		Compounding:   1,
+		Stub:          term.Compound,
+	}
+
+	// US are the conventions of US corporate bonds
+	US = Convention{
+		Name:          "US Corporate",
+		Currency:      "USD",
+		Basis:         "BONDBASIS",
+		Frequency:     2,
+		SettlementLag: 1,
+		EndOfMonth:    true,
+		Quote:         fixedincome.Price,
+		Compounding:   2,
+		Stub:          term.Compound,
+	}
+
+	// XS are the conventions of Eurobonds (corporate bonds in EUR with the
+	// 30/360 ICMA day count, unlike 30/360 US for US corporates)
+	XS = Convention{
+		Name:          "Eurobond",
+		Currency:      "EUR",
+		Basis:         "EUROBOND",
+		Frequency:     1,
+		SettlementLag: 2,
+		Quote:         fixedincome.Price,
+		Compounding:   1,
+		Stub:          term.Compound,
+	}
+
+	// UST are the conventions of US Treasury notes and bonds (street
+	// convention with simple interest in the final coupon period)
+	UST = Convention{
+		Name:              "US Treasury",
+		Currency:          "USD",
+		Basis:             "ACTACT",
+		Frequency:         2,
+		SettlementLag:     1,
+		EndOfMonth:        true,
+		Quote:             fixedincome.Price,
+		Compounding:       2,
+		Stub:              term.Compound,
+		SimpleFinalPeriod: true,
+	}
+
+	// IT are the conventions of Italian government bonds (BTPs) with
+	// semi-annual coupons and no ex-dividend period
+	IT = Convention{
+		Name:          "Italian BTP",
+		Currency:      "EUR",
+		Basis:         "ACTACT",
+		Frequency:     2,
+		SettlementLag: 2,
+		Quote:         fixedincome.Price,
+		Compounding:   1,
+		Stub:          term.Compound,
+	}
+
+	// ES are the conventions of Spanish government bonds (Bonos and
+	// Obligaciones) with annual coupons and no ex-dividend period
+	ES = Convention{
+		Name:          "Spanish Bono",
+		Currency:      "EUR",
+		Basis:         "ACTACT",
+		Frequency:     1,
+		SettlementLag: 2,
+		Quote:         fixedincome.Price,
+		Compounding:   1,
+		Stub:          term.Compound,
+	}
+
+	// JP are the conventions of Japanese government bonds (JGBs) quoted in
+	// simple yield with the accrued interest in ACT/365 (fixed)
+	JP = Convention{
+		Name:          "Japanese Government Bond",
+		Currency:      "JPY",
+		Basis:         "ACT365",
+		Frequency:     2,
+		SettlementLag: 1,
+		Quote:         fixedincome.Price,
+		Compounding:   1,
+		SimpleYield:   true,
+	}
+
+	// GB are the conventions of UK gilts with an ex-dividend period of
+	// seven business days
+	GB = Convention{
+		Name:          "UK Gilt",
+		Currency:      "GBP",
+		Basis:         "ACTACT",
+		Frequency:     2,
+		SettlementLag: 1,
+		ExDividend:    7,
+		Quote:         fixedincome.Price,
+		Compounding:   2,
+		Stub:          term.Compound,
+	}
+)
+
+// markets are the presets by market code
+var markets = map[string]Convention{
+	"CH":  CH,
+	"DE":  DE,
+	"US":  US,
+	"XS":  XS,
+	"UST": UST,
+	"GB":  GB,
+	"IT":  IT,
+	"ES":  ES,
+	"JP":  JP,
+}
+
+// Lookup returns the preset for the market code (e.g. "CH", "UST" or "GB")
+func Lookup(market string) (Convention, error) {
+	c, ok := markets[strings.ToUpper(market)]
+	if !ok {
+		return Convention{}, fmt.Errorf("unknown market %q (available: %s)", market, strings.Join(Markets(), ", "))
+	}
+	return c, nil
+}
+
+// Markets returns the sorted market codes of the presets
+func Markets() []string {
+	codes := make([]string, 0, len(markets))
+	for code := range markets {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// Bond returns a fixed-coupon bond with the conventions of the market
+func (c Convention) Bond(settlement, maturityDate time.Time, coupon float64) *bond.Straight {
+	return &bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: settlement,
+			Maturity:   maturityDate,
+			Frequency:  c.Frequency,
+			Basis:      c.Basis,
+			EndOfMonth: c.EndOfMonth,
+		},
+		Coupon:     coupon,
+		Redemption: 100.0,
+		ExDividend: c.ExDividend,
+	}
+}
+
+// Settlement returns the settlement date for the trade date, i.e. the trade
+// date plus the settlement lag in business days of the calendar (a nil
+// calendar only skips weekends)
+func (c Convention) Settlement(trade time.Time, cal *calendar.Calendar) time.Time {
+	date := trade
+	for i := 0; i < c.SettlementLag; i += 1 {
+		date = cal.Adjust(date.AddDate(0, 0, 1), calendar.Following)
+	}
+	return date
+}
+
+// Yield returns the yield to maturity in percent for the dirty price quoted
+// with the compounding and stub conventions of the market; the cash flows
+// are discounted over the coupon periods and the fraction of the current
+// period remaining until the next coupon
+func (c Convention) Yield(dirty float64, b Bond) (float64, error) {
+	if c.SimpleYield {
+		s, err := straight(b)
+		if err != nil {
+			return 0.0, err
+		}
+		return SimpleYield(dirty-s.Accrued(), s.Coupon, s.Last()), nil
+	}
+	cfs := c.periods(b)
+	return fixedincome.PeriodicYield(dirty, cfs, c.Compounding, c.stub(cfs))
+}
+
+// Price returns the dirty price for the yield to maturity in percent quoted
+// with the conventions of the market
+func (c Convention) Price(y float64, b Bond) float64 {
+	if c.SimpleYield {
+		s, err := straight(b)
+		if err != nil {
+			return math.NaN()
+		}
+		return SimplePrice(y, s.Coupon, s.Last()) + s.Accrued()
+	}
+	cfs := c.periods(b)
+	return cfs.PresentValue(&term.Periodic{Y: y, Frequency: c.Compounding, Stub: c.stub(cfs)})
+}
+
+// periods returns the cash flows with the times in years measured in coupon
+// periods, i.e. (w + j) / frequency for the cash flow j periods after the next
+// coupon date and the fraction w of the current period remaining
+func (c Convention) periods(b Bond) cashflow.Cashflows {
+	n := float64(c.Frequency)
+	if n <= 0.0 {
+		n = 1.0
+	}
+	w := 1.0 - b.DayCountFraction()*n
+	cfs := b.Cashflows()
+	for i := range cfs {
+		j := math.Round(cfs[i].T*n - w)
+		cfs[i].T = (w + j) / n
+	}
+	return cfs
+}
+
+// stub returns the discounting convention for the fractional first period
+func (c Convention) stub(cfs cashflow.Cashflows) term.Stub {
+	if c.SimpleFinalPeriod && len(cfs) == 1 {
+		return term.Simple
+	}
+	return c.Stub
+}
@@ -0,0 +1,320 @@
+package conventions_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/calendar"
+	"github.com/konimarti/fixedincome/pkg/conventions"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// annuity returns the value of n payments of one at the periods 0 to n-1
+// discounted with v per period
+func annuity(v float64, n int) float64 {
+	return (1.0 - math.Pow(v, float64(n))) / (1.0 - v)
+}
+
+func TestSettlement(t *testing.T) {
+	// Friday trade with a holiday on Monday
+	cal := calendar.New(date(2021, 5, 24))
+	trade := date(2021, 5, 21)
+
+	testData := []struct {
+		Convention conventions.Convention
+		Expected   time.Time
+	}{
+		{conventions.CH, date(2021, 5, 26)},
+		{conventions.US, date(2021, 5, 25)},
+	}
+	for _, test := range testData {
+		if got := test.Convention.Settlement(trade, cal); !got.Equal(test.Expected) {
+			t.Errorf("wrong settlement date for %s; got: %v, expected: %v", test.Convention.Name, got, test.Expected)
+		}
+	}
+
+	// without a calendar only the weekend is skipped
+	if got := conventions.CH.Settlement(trade, nil); !got.Equal(date(2021, 5, 25)) {
+		t.Errorf("wrong settlement date without calendar; got: %v, expected: %v", got, date(2021, 5, 25))
+	}
+}
+
+func TestCH(t *testing.T) {
+	// ISIN CH0224396983 (1.25% Confederation 2026-05-28)
+	b := conventions.CH.Bond(date(2021, 4, 1), date(2026, 5, 28), 1.25)
+	if err := b.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if b.Basis != "30E360" || b.Frequency != 1 || b.Redemption != 100.0 {
+		t.Errorf("wrong terms; got: %v", b)
+	}
+	// 303 days of 30E/360 since 2020-05-28
+	if a := b.Accrued(); math.Abs(a-1.25*303.0/360.0) > 1e-9 {
+		t.Errorf("wrong accrued interest; got: %v, expected: %v", a, 1.25*303.0/360.0)
+	}
+
+	// annually compounded yield: at a price of par on a coupon date the yield equals the coupon
+	b.Settlement = date(2021, 5, 28)
+	y, err := conventions.CH.Yield(100.0, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(y-1.25) > 1e-6 {
+		t.Errorf("wrong yield; got: %v, expected: %v", y, 1.25)
+	}
+}
+
+func TestDE_US(t *testing.T) {
+	testData := []struct {
+		Convention conventions.Convention
+		Expected   float64
+	}{
+		{
+			// annual coupons, 320 of 365 days to the next coupon, 10 coupons:
+			// v^w * (2 * (1 - v^10) / (1 - v) + 100 * v^9) with v = 1/1.03, w = 320/365
+			Convention: conventions.DE,
+			Expected:   91.80374279991155,
+		},
+		{
+			// semi-annual coupons, 134 of 180 days (30/360) to the next coupon, 20 coupons:
+			// v^w * ((1 - v^20) / (1 - v) + 100 * v^19) with v = 1/1.015, w = 134/180
+			Convention: conventions.US,
+			Expected:   91.76416772540102,
+		},
+	}
+	for _, test := range testData {
+		c := test.Convention
+		b := c.Bond(date(2021, 4, 1), date(2031, 2, 15), 2.0)
+		if err := b.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		if p := c.Price(3.0, b); math.Abs(p-test.Expected) > 1e-10 {
+			t.Errorf("wrong dirty price for %s; got: %v, expected: %v", c.Name, p, test.Expected)
+		}
+		y, err := c.Yield(test.Expected, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(y-3.0) > 1e-6 {
+			t.Errorf("wrong yield for %s; got: %v, expected: %v", c.Name, y, 3.0)
+		}
+	}
+}
+
+// TestUST checks the Treasury prices against the price formula of 31 CFR
+// Part 356, Appendix B, evaluated in the test for a hypothetical note. The
+// worked examples of Appendix B and a published auction result (price, yield
+// and accrued interest from TreasuryDirect) are not part of this test.
+func TestUST(t *testing.T) {
+	ust := conventions.UST
+	settlement := date(2021, 4, 1)
+
+	// final coupon period from 2021-02-15 to 2021-08-15 (181 days),
+	// 136 days from settlement to maturity: simple interest
+	bill := ust.Bond(settlement, date(2021, 8, 15), 1.5)
+	if err := bill.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if a := bill.Accrued(); math.Abs(a-0.75*45.0/181.0) > 1e-12 {
+		t.Errorf("wrong accrued interest; got: %v, expected: %v", a, 0.75*45.0/181.0)
+	}
+	expected := 100.75 / (1.0 + 136.0/181.0*0.005/2.0)
+	if p := ust.Price(0.5, bill); math.Abs(p-expected) > 1e-10 {
+		t.Errorf("wrong price in final coupon period; got: %v, expected: %v", p, expected)
+	}
+
+	// 20 remaining coupons with the formula of 31 CFR Part 356, Appendix B:
+	// v^(r/s) * (C/2 * (1 - v^20) / (1 - v) + 100 * v^19) with v = 1/(1 + y/2),
+	// r = 136 days to the next coupon and s = 181 days in the period
+	note := ust.Bond(settlement, date(2031, 2, 15), 1.125)
+	v := 1.0 / (1.0 + 0.016/2.0)
+	expected = math.Pow(v, 136.0/181.0) * (1.125/2.0*annuity(v, 20) + 100.0*math.Pow(v, 19.0))
+	if p := ust.Price(1.6, note); math.Abs(p-expected) > 1e-10 {
+		t.Errorf("wrong price; got: %v, expected: %v", p, expected)
+	}
+
+	for _, b := range []conventions.Bond{bill, note} {
+		dirty := ust.Price(1.6, b)
+		y, err := ust.Yield(dirty, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(y-1.6) > 1e-6 {
+			t.Errorf("wrong yield; got: %v, expected: %v", y, 1.6)
+		}
+	}
+}
+
+// TestGB checks the accrued interest and the price of a hypothetical gilt
+// trading ex-dividend against the DMO price formula evaluated in the test. The
+// worked examples of the DMO paper "Formulae for calculating gilt prices from
+// yields", including its ex-dividend case, are not part of this test.
+func TestGB(t *testing.T) {
+	gb := conventions.GB
+	// coupon dates 7 March and 7 September; 2021-03-07 is a Sunday
+	b := gb.Bond(date(2021, 3, 1), date(2031, 3, 7), 4.0)
+	if err := b.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if !b.IsExDividend() {
+		t.Fatalf("expected the gilt to trade ex-dividend")
+	}
+	if a := b.Accrued(); math.Abs(a+2.0*6.0/181.0) > 1e-12 {
+		t.Errorf("wrong ex-dividend accrued interest; got: %v, expected: %v", a, -2.0*6.0/181.0)
+	}
+
+	// price formula of the DMO for an ex-dividend gilt (d1 = 0, d2 = c/2):
+	// v^(r/s) * (d2 * v + c/2 * v^2 * (1 - v^19) / (1 - v) + 100 * v^20)
+	// with v = 1/(1 + y/2), r = 6 days to the next coupon and s = 181 days
+	v := 1.0 / (1.0 + 0.03/2.0)
+	expected := math.Pow(v, 6.0/181.0) * (2.0*v + 2.0*v*v*annuity(v, 19) + 100.0*math.Pow(v, 20.0))
+	if p := gb.Price(3.0, b); math.Abs(p-expected) > 1e-10 {
+		t.Errorf("wrong ex-dividend dirty price; got: %v, expected: %v", p, expected)
+	}
+	y, err := gb.Yield(expected, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(y-3.0) > 1e-6 {
+		t.Errorf("wrong yield; got: %v, expected: %v", y, 3.0)
+	}
+}
+
+func TestSimpleYield(t *testing.T) {
+	testData := []struct {
+		Clean, Coupon, Years float64
+		Expected             float64
+	}{
+		{Clean: 100.5, Coupon: 0.1, Years: 5.0, Expected: 0.0},
+		{Clean: 98.0, Coupon: 1.0, Years: 4.0, Expected: 1.5 / 98.0 * 100.0},
+		{Clean: 100.0, Coupon: 0.8, Years: 10.0, Expected: 0.8},
+	}
+	for _, test := range testData {
+		y := conventions.SimpleYield(test.Clean, test.Coupon, test.Years)
+		if math.Abs(y-test.Expected) > 1e-12 {
+			t.Errorf("wrong simple yield; got: %v, expected: %v", y, test.Expected)
+		}
+		if p := conventions.SimplePrice(y, test.Coupon, test.Years); math.Abs(p-test.Clean) > 1e-10 {
+			t.Errorf("wrong simple price; got: %v, expected: %v", p, test.Clean)
+		}
+	}
+}
+
+func TestJP(t *testing.T) {
+	jp := conventions.JP
+	b := jp.Bond(date(2021, 3, 20), date(2031, 3, 20), 0.1)
+	if err := b.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	// 3652 days to maturity
+	years := 3652.0 / 365.0
+	expected := (0.1 - 0.5/years) / 100.5 * 100.0
+	y, err := jp.Yield(100.5+b.Accrued(), b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(y-expected) > 1e-12 {
+		t.Errorf("wrong simple yield; got: %v, expected: %v", y, expected)
+	}
+	if p := jp.Price(y, b); math.Abs(p-100.5-b.Accrued()) > 1e-10 {
+		t.Errorf("wrong dirty price; got: %v, expected: %v", p, 100.5+b.Accrued())
+	}
+
+	// accrued interest in ACT/365 (fixed): 73 days since the coupon on 20 March
+	b = jp.Bond(date(2021, 6, 1), date(2031, 3, 20), 0.1)
+	if a := b.Accrued(); math.Abs(a-0.1*73.0/365.0) > 1e-12 {
+		t.Errorf("wrong accrued interest; got: %v, expected: %v", a, 0.1*73.0/365.0)
+	}
+}
+
+// TestIT_ES checks BTP and Bono prices of hypothetical bonds against the
+// closed-form price formulas evaluated in the test. Published exchange prices
+// (ISIN, settlement date, clean price and yield from MTS/Borsa Italiana and
+// the Tesoro Publico) are not part of this test.
+func TestIT_ES(t *testing.T) {
+	u, v := math.Pow(1.03, -0.5), 1.0/1.03
+	testData := []struct {
+		Convention conventions.Convention
+		Maturity   time.Time
+		Accrued    float64
+		Expected   float64
+	}{
+		{
+			// BTP with coupons on 1 March and 1 September: 31 of 184 days in the
+			// period accrued, annual yield over half-year periods
+			Convention: conventions.IT,
+			Maturity:   date(2031, 9, 1),
+			Accrued:    1.0 * 31.0 / 184.0,
+			// 21 half-year coupons discounted with u = 1.03^(-1/2) from w = 153/184
+			Expected: math.Pow(u, 153.0/184.0) * (annuity(u, 21) + 100.0*math.Pow(u, 20.0)),
+		},
+		{
+			// Bono with an annual coupon on 30 July: 245 of 365 days accrued
+			Convention: conventions.ES,
+			Maturity:   date(2030, 7, 30),
+			Accrued:    2.0 * 245.0 / 365.0,
+			// 10 annual coupons discounted with v = 1/1.03 from w = 120/365
+			Expected: math.Pow(v, 120.0/365.0) * (2.0*annuity(v, 10) + 100.0*math.Pow(v, 9.0)),
+		},
+	}
+	for _, test := range testData {
+		c := test.Convention
+		b := c.Bond(date(2021, 4, 1), test.Maturity, 2.0)
+		if err := b.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		if a := b.Accrued(); math.Abs(a-test.Accrued) > 1e-12 {
+			t.Errorf("wrong accrued interest for %s; got: %v, expected: %v", c.Name, a, test.Accrued)
+		}
+		// yields are annually compounded
+		if p := c.Price(3.0, b); math.Abs(p-test.Expected) > 1e-10 {
+			t.Errorf("wrong dirty price for %s; got: %v, expected: %v", c.Name, p, test.Expected)
+		}
+		if y, err := c.Yield(test.Expected, b); err != nil || math.Abs(y-3.0) > 1e-6 {
+			t.Errorf("wrong yield for %s; got: %v (%v), expected: %v", c.Name, y, err, 3.0)
+		}
+	}
+}
+
+func TestDayCount(t *testing.T) {
+	settlement := date(2021, 3, 31)
+	testData := []struct {
+		Maturity time.Time
+		Days     map[string]float64
+	}{
+		{
+			// last coupon on 2021-01-15: 30/360 US counts the 31st
+			Maturity: date(2031, 1, 15),
+			Days:     map[string]float64{"US": 76.0, "XS": 75.0, "CH": 75.0},
+		},
+		{
+			// last coupon on 2021-02-28: 30E/360 ISDA moves the end of February to the 30th
+			Maturity: date(2031, 2, 28),
+			Days:     map[string]float64{"US": 33.0, "XS": 32.0, "CH": 30.0},
+		},
+	}
+	for _, test := range testData {
+		for market, days := range test.Days {
+			c, err := conventions.Lookup(market)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c.Frequency = 1
+			b := c.Bond(settlement, test.Maturity, 3.6)
+			if a := b.Accrued(); math.Abs(a-3.6*days/360.0) > 1e-12 {
+				t.Errorf("wrong accrued interest for %s and maturity %v; got: %v, expected: %v", market, test.Maturity, a, 3.6*days/360.0)
+			}
+		}
+	}
+
+	if _, err := conventions.Lookup("XX"); err == nil {
+		t.Errorf("expected error for unknown market")
+	}
+	if c, err := conventions.Lookup("ust"); err != nil || c.Name != conventions.UST.Name {
+		t.Errorf("lookup is not case-insensitive; got: %v (%v)", c.Name, err)
+	}
+}
@@ -0,0 +1,36 @@
+package conventions
+
+import (
+	"fmt"
+
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+)
+
+// SimpleYield returns the Japanese simple yield in percent for the clean
+// price, the annual coupon and the years to maturity, i.e. the coupon plus the
+// pull to par per year relative to the clean price:
+// (C + (100 - P) / T) / P * 100
+func SimpleYield(clean, coupon, years float64) float64 {
+	if clean == 0.0 || years <= 0.0 {
+		return 0.0
+	}
+	return (coupon + (100.0-clean)/years) / clean * 100.0
+}
+
+// SimplePrice returns the clean price for the Japanese simple yield in
+// percent, the annual coupon and the years to maturity
+func SimplePrice(y, coupon, years float64) float64 {
+	if years <= 0.0 {
+		return 100.0
+	}
+	return (coupon + 100.0/years) / (y/100.0 + 1.0/years)
+}
+
+// straight returns the fixed-coupon bond for the simple yield convention
+func straight(b Bond) (*bond.Straight, error) {
+	s, ok := b.(*bond.Straight)
+	if !ok {
+		return nil, fmt.Errorf("simple yield needs a fixed-coupon bond, got %T", b)
+	}
+	return s, nil
+}
@@ -0,0 +1,77 @@
+package credit
+
+import (
+	"fmt"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Basis compares the spreads of a bond with the CDS of the issuer at the
+// maturity of the bond; a negative basis means that the bond is cheap
+// relative to the CDS protection
+type Basis struct {
+	// Maturity is the remaining time to maturity of the bond in years
+	Maturity float64
+	// ZSpread is the static spread in bps of the bond over the swap curve
+	ZSpread float64
+	// AssetSwap is the par asset-swap spread in bps over the swap curve
+	AssetSwap float64
+	// CDS is the interpolated CDS spread in bps at the maturity of the bond
+	CDS float64
+	// ZBasis is the CDS spread less the Z-spread in bps
+	ZBasis float64
+	// AssetSwapBasis is the CDS spread less the asset-swap spread in bps
+	AssetSwapBasis float64
+}
+
+// CDSBasis calculates the basis of the bond for the dirty price against the
+// CDS quotes of the issuer with the swap curve ts
+func CDSBasis(price float64, b *bond.Straight, ts term.Structure, cds *CDS) (Basis, error) {
+	if err := b.Validate(); err != nil {
+		return Basis{}, err
+	}
+	basis := Basis{Maturity: b.Last()}
+	s, err := cds.Spread(basis.Maturity)
+	if err != nil {
+		return basis, err
+	}
+	basis.CDS = s
+
+	z, err := fixedincome.Spread(price, b, ts)
+	if err != nil {
+		return basis, fmt.Errorf("z-spread: %w", err)
+	}
+	basis.ZSpread = z
+
+	asw, err := AssetSwapSpread(price, b, ts)
+	if err != nil {
+		return basis, err
+	}
+	basis.AssetSwap = asw
+
+	basis.ZBasis = basis.CDS - basis.ZSpread
+	basis.AssetSwapBasis = basis.CDS - basis.AssetSwap
+	return basis, nil
+}
+
+// AssetSwapSpread calculates the par asset-swap spread in bps of the bond for
+// the dirty price, i.e. the value of the bond on the swap curve ts less the
+// price divided by the annuity of the floating leg on the coupon schedule
+func AssetSwapSpread(price float64, b *bond.Straight, ts term.Structure) (float64, error) {
+	if err := b.Validate(); err != nil {
+		return 0.0, err
+	}
+	// the present value of a coupon of 1% is the annuity of 1bp times 100
+	c := *b
+	c.CouponRounding = nil
+	c.Coupon = 0.0
+	zero := c.PresentValue(ts)
+	c.Coupon = 1.0
+	unit := c.PresentValue(ts) - zero
+	if unit <= 0.0 {
+		return 0.0, fmt.Errorf("asset-swap spread: annuity %v of the bond is not positive", unit)
+	}
+	return (b.PresentValue(ts) - price) / unit * 100.0, nil
+}
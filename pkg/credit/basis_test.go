@@ -0,0 +1,84 @@
+package credit_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/credit"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestCDSBasis(t *testing.T) {
+	b := bond.Straight{
+		Schedule:   maturity.Schedule{Settlement: settlement, Maturity: settlement.AddDate(5, 0, 0), Frequency: 1, Basis: "30E360"},
+		Coupon:     2.0,
+		Redemption: 100.0,
+	}
+	swap := term.Flat{R: 1.0}
+	price := b.PresentValue(&term.Flat{R: 1.0, Spread: 100.0})
+	cds := credit.CDS{Issuer: "ABC", Quotes: []credit.CDSQuote{{Tenor: 3.0, Spread: 60.0}, {Tenor: 7.0, Spread: 100.0}}}
+
+	basis, err := credit.CDSBasis(price, &b, &swap, &cds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var testData = []struct {
+		Name     string
+		Value    float64
+		Expected float64
+	}{
+		{"maturity", basis.Maturity, 5.0},
+		{"Z-spread", basis.ZSpread, 100.0},
+		{"CDS spread", basis.CDS, 80.0},
+		{"Z-spread basis", basis.ZBasis, -20.0},
+		{"asset-swap basis", basis.AssetSwapBasis, basis.CDS - basis.AssetSwap},
+	}
+	for _, test := range testData {
+		if math.Abs(test.Value-test.Expected) > 1e-4 {
+			t.Errorf("wrong %s; got: %v, expected: %v", test.Name, test.Value, test.Expected)
+		}
+	}
+	// the asset-swap spread of a bond close to par is close to the Z-spread
+	if math.Abs(basis.AssetSwap-basis.ZSpread) > 5.0 {
+		t.Errorf("wrong asset-swap spread; got: %v, expected close to: %v", basis.AssetSwap, basis.ZSpread)
+	}
+}
+
+func TestAssetSwapSpread(t *testing.T) {
+	b := bond.Straight{
+		Schedule:   maturity.Schedule{Settlement: settlement, Maturity: settlement.AddDate(4, 0, 0), Frequency: 2, Basis: "30E360"},
+		Coupon:     3.0,
+		Redemption: 100.0,
+	}
+	swap := term.Flat{R: 1.5}
+	model := b.PresentValue(&swap)
+
+	var testData = []struct {
+		Price    float64
+		Expected float64
+	}{
+		{model, 0.0},
+		// one point of price over the annuity
+		{model - 1.0, 100.0 / annuity(&b, &swap)},
+	}
+	for _, test := range testData {
+		asw, err := credit.AssetSwapSpread(test.Price, &b, &swap)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(asw-test.Expected) > 1e-8 {
+			t.Errorf("wrong asset-swap spread; got: %v, expected: %v", asw, test.Expected)
+		}
+	}
+}
+
+// annuity returns the present value of the coupon periods of the bond
+func annuity(b *bond.Straight, ts term.Structure) float64 {
+	value := 0.0
+	for _, m := range b.M() {
+		value += ts.Z(m) / float64(b.Frequency)
+	}
+	return value
+}
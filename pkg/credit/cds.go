@@ -0,0 +1,68 @@
+package credit
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// CDSQuote is the par spread in bps of a credit default swap with the tenor in years
+type CDSQuote struct {
+	Tenor  float64
+	Spread float64
+}
+
+// CDS is the term structure of the credit default swap quotes of an issuer
+type CDS struct {
+	Issuer string
+	Quotes []CDSQuote
+	// Recovery is the recovery rate of the quotes (default: 0 for 0.4)
+	Recovery float64
+}
+
+// sorted returns the quotes ordered by tenor
+func (c *CDS) sorted() []CDSQuote {
+	quotes := append([]CDSQuote{}, c.Quotes...)
+	sort.SliceStable(quotes, func(i, j int) bool { return quotes[i].Tenor < quotes[j].Tenor })
+	return quotes
+}
+
+// Spread returns the CDS spread in bps at the maturity t in years, linearly
+// interpolated between the quotes and flat beyond the first and last tenor
+func (c *CDS) Spread(t float64) (float64, error) {
+	quotes := c.sorted()
+	if len(quotes) == 0 {
+		return 0.0, fmt.Errorf("%w: no CDS quotes of issuer %q", ErrNoQuotes, c.Issuer)
+	}
+	if t <= quotes[0].Tenor {
+		return quotes[0].Spread, nil
+	}
+	for i := 1; i < len(quotes); i++ {
+		if t <= quotes[i].Tenor {
+			lo, hi := quotes[i-1], quotes[i]
+			w := (t - lo.Tenor) / (hi.Tenor - lo.Tenor)
+			return lo.Spread + w*(hi.Spread-lo.Spread), nil
+		}
+	}
+	return quotes[len(quotes)-1].Spread, nil
+}
+
+// Hazard returns the hazard rates implied by the quotes with the credit
+// triangle, i.e. an average hazard rate of spread / (1 - recovery) up to each tenor
+func (c *CDS) Hazard() (*Hazard, error) {
+	recovery := c.Recovery
+	if recovery == 0.0 {
+		recovery = 0.4
+	}
+	if recovery < 0.0 || recovery >= 1.0 {
+		return nil, fmt.Errorf("recovery rate %v is not valid", recovery)
+	}
+	quotes := c.sorted()
+	tenors := make([]float64, len(quotes))
+	probabilities := make([]float64, len(quotes))
+	for i, q := range quotes {
+		tenors[i] = q.Tenor
+		probabilities[i] = 1.0 - math.Exp(-q.Spread*0.0001/(1.0-recovery)*q.Tenor)
+	}
+	return NewHazard(tenors, probabilities)
+}
@@ -0,0 +1,64 @@
+package credit_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/credit"
+)
+
+func TestCDSSpread(t *testing.T) {
+	cds := credit.CDS{
+		Issuer: "ABC",
+		Quotes: []credit.CDSQuote{{Tenor: 5.0, Spread: 120.0}, {Tenor: 1.0, Spread: 40.0}, {Tenor: 3.0, Spread: 80.0}},
+	}
+	var testData = []struct {
+		T        float64
+		Expected float64
+	}{
+		{0.5, 40.0},
+		{1.0, 40.0},
+		{2.0, 60.0},
+		{4.5, 110.0},
+		{10.0, 120.0},
+	}
+	for _, test := range testData {
+		s, err := cds.Spread(test.T)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(s-test.Expected) > 1e-10 {
+			t.Errorf("wrong CDS spread at %v; got: %v, expected: %v", test.T, s, test.Expected)
+		}
+	}
+
+	if _, err := (&credit.CDS{Issuer: "XYZ"}).Spread(1.0); err == nil {
+		t.Errorf("expected error for missing CDS quotes")
+	}
+}
+
+func TestCDSHazard(t *testing.T) {
+	cds := credit.CDS{Quotes: []credit.CDSQuote{{Tenor: 1.0, Spread: 60.0}, {Tenor: 5.0, Spread: 120.0}}}
+	h, err := cds.Hazard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// credit triangle with a recovery rate of 40%
+	var testData = []struct {
+		T        float64
+		Expected float64
+	}{
+		{1.0, math.Exp(-0.01)},
+		{5.0, math.Exp(-0.1)},
+	}
+	for _, test := range testData {
+		if math.Abs(h.Survival(test.T)-test.Expected) > 1e-12 {
+			t.Errorf("wrong survival probability at %v; got: %v, expected: %v", test.T, h.Survival(test.T), test.Expected)
+		}
+	}
+
+	cds.Recovery = 1.0
+	if _, err := cds.Hazard(); err == nil {
+		t.Errorf("expected error for invalid recovery rate")
+	}
+}
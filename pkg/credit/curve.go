@@ -0,0 +1,75 @@
+package credit
+
+import (
+	"math"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// SpreadCurve is a term structure of the issuer with a spread curve in bps over
+// the base curve shaped like the Nelson-Siegel model, i.e.
+//
+//	s(t) = Level + Slope * (1 - exp(-t/Tau)) / (t/Tau) + Curvature * ((1 - exp(-t/Tau)) / (t/Tau) - exp(-t/Tau))
+type SpreadCurve struct {
+	Base      term.Structure
+	Level     float64
+	Slope     float64
+	Curvature float64
+	// Tau is the decay in years (default: 0 for 2 years)
+	Tau float64
+	// Extra is an additional parallel spread in bps (see SetSpread)
+	Extra float64
+}
+
+// loadings returns the slope and curvature loadings for the maturity t
+func (c *SpreadCurve) loadings(t float64) (float64, float64) {
+	tau := c.Tau
+	if tau <= 0.0 {
+		tau = 2.0
+	}
+	if t <= 0.0 {
+		return 1.0, 0.0
+	}
+	x := t / tau
+	slope := (1.0 - math.Exp(-x)) / x
+	return slope, slope - math.Exp(-x)
+}
+
+// Spread returns the spread in bps of the issuer over the base curve for the maturity t
+func (c *SpreadCurve) Spread(t float64) float64 {
+	slope, curvature := c.loadings(t)
+	return c.Level + c.Slope*slope + c.Curvature*curvature
+}
+
+// Rate returns the continuously compounded spot rate in percent
+func (c *SpreadCurve) Rate(t float64) float64 {
+	return c.Base.Rate(t) + (c.Spread(t)+c.Extra)*0.01
+}
+
+// Z returns the discount factor for the given maturity t
+func (c *SpreadCurve) Z(t float64) float64 {
+	return term.DiscountFactor(c.Base, t, c.Spread(t)+c.Extra)
+}
+
+// SetSpread sets the additional parallel spread in bps
+func (c *SpreadCurve) SetSpread(spread float64) term.Structure {
+	c.Extra = spread
+	return c
+}
+
+// DiscountFactor returns the discount factor for the maturity t with an
+// additional spread in bps
+func (c *SpreadCurve) DiscountFactor(t, spread float64) float64 {
+	return term.DiscountFactor(c, t, spread)
+}
+
+// Shift returns the term structure shifted in parallel by dr in bps
+func (c *SpreadCurve) Shift(dr float64) term.Structure {
+	return term.Shift(c, dr)
+}
+
+// Copy returns a copy of the issuer curve sharing the base curve
+func (c *SpreadCurve) Copy() term.Structure {
+	copied := *c
+	return &copied
+}
@@ -0,0 +1,29 @@
+package credit_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/credit"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestSpreadCurve(t *testing.T) {
+	c := credit.SpreadCurve{Base: &base, Level: 100.0, Slope: -50.0, Curvature: 20.0}
+	if got := c.Spread(0.0); math.Abs(got-50.0) > 1e-12 {
+		t.Errorf("wrong short spread; got: %v, expected: %v", got, 50.0)
+	}
+	if got := c.Spread(1000.0); math.Abs(got-100.0) > 0.2 {
+		t.Errorf("wrong long spread; got: %v, expected: %v", got, 100.0)
+	}
+	if got, expected := c.Rate(5.0), base.Rate(5.0)+c.Spread(5.0)*0.01; math.Abs(got-expected) > 1e-12 {
+		t.Errorf("wrong rate; got: %v, expected: %v", got, expected)
+	}
+	if got, expected := c.Z(5.0), math.Exp(-c.Rate(5.0)/100.0*5.0); math.Abs(got-expected) > 1e-12 {
+		t.Errorf("wrong discount factor; got: %v, expected: %v", got, expected)
+	}
+	shifted := term.WithSpread(&c, 10.0)
+	if got, expected := shifted.Rate(5.0), c.Rate(5.0)+0.1; math.Abs(got-expected) > 1e-12 || c.Extra != 0.0 {
+		t.Errorf("wrong shifted rate; got: %v, expected: %v", got, expected)
+	}
+}
@@ -0,0 +1,137 @@
+package credit
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Hazard is a term structure of default probabilities with piecewise
+// constant hazard rates
+type Hazard struct {
+	// Times are the ascending ends of the periods in years
+	// (default: nil for a constant hazard rate)
+	Times []float64
+	// Rates are the hazard rates in percent per year for the periods;
+	// the last rate applies beyond the last period
+	Rates []float64
+}
+
+// NewHazard returns the hazard rates of the cumulative default probabilities
+// (e.g. 0.02 for 2%) up to the ascending tenors in years
+func NewHazard(tenors, probabilities []float64) (*Hazard, error) {
+	if len(tenors) != len(probabilities) || len(tenors) == 0 {
+		return nil, fmt.Errorf("%w: %d tenors and %d default probabilities", ErrNoQuotes, len(tenors), len(probabilities))
+	}
+	h := &Hazard{Times: make([]float64, len(tenors)), Rates: make([]float64, len(tenors))}
+	last, survival := 0.0, 1.0
+	for i, t := range tenors {
+		s := 1.0 - probabilities[i]
+		if t <= last || s <= 0.0 || s > survival {
+			return nil, fmt.Errorf("default probability %v at %v years is not valid", probabilities[i], t)
+		}
+		h.Times[i] = t
+		h.Rates[i] = -math.Log(s/survival) / (t - last) * 100.0
+		last, survival = t, s
+	}
+	return h, nil
+}
+
+// Survival returns the probability of no default up to t in years
+func (h *Hazard) Survival(t float64) float64 {
+	if len(h.Rates) == 0 {
+		return 1.0
+	}
+	t = math.Max(t, 0.0)
+	integral, start := 0.0, 0.0
+	for i, rate := range h.Rates {
+		end := math.Inf(1)
+		if i < len(h.Times) && i < len(h.Rates)-1 {
+			end = h.Times[i]
+		}
+		if t <= end {
+			integral += rate * (t - start)
+			break
+		}
+		integral += rate * (end - start)
+		start = end
+	}
+	return math.Exp(-integral * 0.01)
+}
+
+// Default returns the cumulative default probability up to t in years
+func (h *Hazard) Default(t float64) float64 {
+	return 1.0 - h.Survival(t)
+}
+
+// ExpectedCashflows returns the promised cash flows weighted with the
+// survival probabilities and the recovery (as fraction of the face value) of
+// the defaults within each period paid at the end of the period
+func (h *Hazard) ExpectedCashflows(cfs cashflow.Cashflows, recovery, face float64) cashflow.Cashflows {
+	sorted := make(cashflow.Cashflows, len(cfs))
+	copy(sorted, cfs)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].T < sorted[j].T })
+	last := 1.0
+	for i := range sorted {
+		s := h.Survival(sorted[i].T)
+		sorted[i].Amount = sorted[i].Amount*s + recovery*face*(last-s)
+		last = s
+	}
+	return sorted
+}
+
+// ExpectedLoss is the decomposition of the credit spread of a bond into the
+// compensation for the expected loss and the risk premium
+type ExpectedLoss struct {
+	// Loss is the present value of the expected loss
+	Loss float64
+	// Yield is the yield to maturity in percent of the promised cash flows
+	Yield float64
+	// Adjusted is the expected-loss-adjusted yield in percent, i.e. the yield
+	// of the expected cash flows
+	Adjusted float64
+	// Spread is the static spread in bps over the term structure
+	Spread float64
+	// LossSpread is the part of the spread in bps that compensates the expected loss
+	LossSpread float64
+	// Premium is the spread in bps beyond the expected loss
+	Premium float64
+}
+
+// Decompose returns the expected loss and the spread decomposition of the bond
+// at the dirty price for the default probabilities and the recovery rate (as
+// fraction of the face value, e.g. 0.4); the face value is the par value of
+// the bond (default: 100)
+func Decompose(price float64, s fixedincome.CashflowSecurity, ts term.Structure, h *Hazard, recovery float64) (ExpectedLoss, error) {
+	if recovery < 0.0 || recovery > 1.0 {
+		return ExpectedLoss{}, fmt.Errorf("recovery rate %v is not valid", recovery)
+	}
+	face := 100.0
+	if p, ok := s.(interface{ ParValue() float64 }); ok {
+		face = p.ParValue()
+	}
+	promised := s.Cashflows()
+	expected := h.ExpectedCashflows(promised, recovery, face)
+
+	e := ExpectedLoss{Loss: promised.PresentValue(ts) - expected.PresentValue(ts)}
+	var err error
+	if e.Yield, err = fixedincome.Irr(price, s); err != nil {
+		return e, fmt.Errorf("yield: %w", err)
+	}
+	if e.Adjusted, err = fixedincome.Irr(price, expected); err != nil {
+		return e, fmt.Errorf("adjusted yield: %w", err)
+	}
+	if e.Spread, err = fixedincome.Spread(price, s, ts); err != nil {
+		return e, fmt.Errorf("spread: %w", err)
+	}
+	// spread of the promised cash flows at the risk-free value of the expected cash flows
+	if e.LossSpread, err = fixedincome.Spread(expected.PresentValue(ts), s, ts); err != nil {
+		return e, fmt.Errorf("expected loss spread: %w", err)
+	}
+	e.Premium = e.Spread - e.LossSpread
+	return e, nil
+}
@@ -0,0 +1,85 @@
+package credit_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/credit"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestHazard(t *testing.T) {
+	tenors := []float64{1.0, 3.0, 5.0}
+	probabilities := []float64{0.01, 0.04, 0.08}
+	h, err := credit.NewHazard(tenors, probabilities)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, tenor := range tenors {
+		if math.Abs(h.Default(tenor)-probabilities[i]) > 1e-12 {
+			t.Errorf("wrong default probability at %v; got: %v, expected: %v", tenor, h.Default(tenor), probabilities[i])
+		}
+	}
+	// constant hazard rate within the periods and beyond the last tenor
+	expected := 0.96 * math.Sqrt(0.92/0.96)
+	if math.Abs(h.Survival(4.0)-expected) > 1e-12 {
+		t.Errorf("wrong survival probability; got: %v, expected: %v", h.Survival(4.0), expected)
+	}
+	expected = 0.92 * math.Sqrt(0.92/0.96)
+	if math.Abs(h.Survival(6.0)-expected) > 1e-12 {
+		t.Errorf("wrong extrapolated survival probability; got: %v, expected: %v", h.Survival(6.0), expected)
+	}
+	flat := credit.Hazard{Rates: []float64{2.0}}
+	if math.Abs(flat.Survival(3.0)-math.Exp(-0.06)) > 1e-12 || flat.Survival(0.0) != 1.0 {
+		t.Errorf("wrong survival probability of constant hazard rate; got: %v, expected: %v", flat.Survival(3.0), math.Exp(-0.06))
+	}
+
+	if _, err := credit.NewHazard([]float64{1.0, 2.0}, []float64{0.05, 0.02}); err == nil {
+		t.Errorf("expected error for decreasing default probabilities")
+	}
+}
+
+func TestDecompose(t *testing.T) {
+	// zero-coupon bond trading 150 bps over the base curve with a hazard
+	// rate of 1% and no recovery
+	zero := bond.Straight{
+		Schedule:   maturity.Schedule{Settlement: settlement, Maturity: settlement.AddDate(5, 0, 0), Frequency: 1, Basis: "30E360"},
+		Redemption: 100.0,
+	}
+	ts := term.Flat{R: 1.0}
+	price := zero.PresentValue(&term.Flat{R: 1.0, Spread: 150.0})
+	h := credit.Hazard{Rates: []float64{1.0}}
+
+	e, err := credit.Decompose(price, &zero, &ts, &h, 0.0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var testData = []struct {
+		Name     string
+		Value    float64
+		Expected float64
+	}{
+		{"spread", e.Spread, 150.0},
+		{"loss spread", e.LossSpread, 100.0},
+		{"premium", e.Premium, 50.0},
+		{"yield", e.Yield, 2.5},
+		{"adjusted yield", e.Adjusted, 1.5},
+		{"loss", e.Loss, 100.0 * math.Exp(-0.05) * (1.0 - math.Exp(-0.05))},
+	}
+	for _, test := range testData {
+		if math.Abs(test.Value-test.Expected) > 1e-4 {
+			t.Errorf("wrong %s; got: %v, expected: %v", test.Name, test.Value, test.Expected)
+		}
+	}
+
+	// recovery lowers the expected loss
+	recovered, err := credit.Decompose(price, &zero, &ts, &h, 0.4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recovered.Loss >= e.Loss || recovered.LossSpread >= e.LossSpread || recovered.Premium <= e.Premium {
+		t.Errorf("wrong decomposition with recovery; got: %v, expected less loss than: %v", recovered, e)
+	}
+}
@@ -0,0 +1,147 @@
+package credit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/term"
+	"gonum.org/v1/gonum/mat"
+)
+
+// ErrNoQuotes is returned when there are not enough quotes to fit a curve
+var ErrNoQuotes = errors.New("not enough quotes")
+
+// Quote is the market quote of a bond
+type Quote struct {
+	// ID identifies the bond in the results (e.g. the ISIN)
+	ID   string
+	Bond fixedincome.CashflowSecurity
+	// Dirty is the quoted dirty price
+	Dirty float64
+}
+
+// maturity returns the time of the last cash flow in years
+func (q Quote) maturity() float64 {
+	t := 0.0
+	for _, cf := range q.Bond.Cashflows() {
+		t = math.Max(t, cf.T)
+	}
+	return t
+}
+
+// Residual is the repricing result of a quoted bond on the fitted curve
+type Residual struct {
+	Quote Quote
+	// Maturity is the time of the last cash flow in years
+	Maturity float64
+	// Spread is the static spread in bps of the quote over the base curve
+	Spread float64
+	// Residual is the static spread in bps of the quote over the issuer curve
+	// (positive if the bond is cheap)
+	Residual float64
+	// Price is the model dirty price on the issuer curve
+	Price float64
+	// Outlier marks bonds with a residual beyond the threshold
+	Outlier bool
+}
+
+// Issuer is the fitted spread curve of an issuer with the repricing results
+type Issuer struct {
+	Curve     *SpreadCurve
+	Residuals []Residual
+}
+
+// Outliers returns the repricing results of the bonds flagged as outliers
+func (i *Issuer) Outliers() []Residual {
+	outliers := []Residual{}
+	for _, r := range i.Residuals {
+		if r.Outlier {
+			outliers = append(outliers, r)
+		}
+	}
+	return outliers
+}
+
+// FitIssuer fits the spread curve of the issuer over the base curve such that the
+// static spreads of the quoted bonds over the issuer curve are minimal in the least
+// squares sense (level only for one bond, level and slope for two bonds) and
+// reprices every bond on the issuer curve.
+// Bonds with an absolute residual above the threshold in bps are flagged as
+// outliers (default: 0 for twice the root mean square of the residuals).
+func FitIssuer(base term.Structure, quotes []Quote, threshold float64) (*Issuer, error) {
+	if len(quotes) == 0 {
+		return nil, fmt.Errorf("%w: no bonds of the issuer", ErrNoQuotes)
+	}
+	curve := &SpreadCurve{Base: base}
+	residuals := make([]Residual, len(quotes))
+	for i, q := range quotes {
+		spread, err := fixedincome.Spread(q.Dirty, q.Bond, base)
+		if err != nil {
+			return nil, fmt.Errorf("spread of %s: %w", q.ID, err)
+		}
+		residuals[i] = Residual{Quote: q, Maturity: q.maturity(), Spread: spread}
+	}
+
+	// least squares in the level, slope and curvature on the static spreads,
+	// refined with the residuals over the issuer curve (the static spread of a
+	// bond averages the spread curve over its cash flows)
+	factors := len(quotes)
+	if factors > 3 {
+		factors = 3
+	}
+	a := mat.NewDense(len(quotes), factors, nil)
+	for i, r := range residuals {
+		slope, curvature := curve.loadings(r.Maturity)
+		row := []float64{1.0, slope, curvature}
+		for j := 0; j < factors; j += 1 {
+			a.Set(i, j, row[j])
+		}
+	}
+	b := mat.NewVecDense(len(quotes), nil)
+	for i, r := range residuals {
+		b.SetVec(i, r.Spread)
+	}
+	for iter := 0; iter < 20; iter += 1 {
+		var x mat.VecDense
+		if err := x.SolveVec(a, b); err != nil {
+			return nil, fmt.Errorf("issuer curve: %w", err)
+		}
+		delta := make([]float64, 3)
+		copy(delta, x.RawVector().Data)
+		curve.Level += delta[0]
+		curve.Slope += delta[1]
+		curve.Curvature += delta[2]
+		if mat.Norm(&x, math.Inf(1)) < 1e-6 {
+			break
+		}
+		for i, r := range residuals {
+			residual, err := fixedincome.Spread(r.Quote.Dirty, r.Quote.Bond, curve)
+			if err != nil {
+				return nil, fmt.Errorf("residual of %s: %w", r.Quote.ID, err)
+			}
+			b.SetVec(i, residual)
+		}
+	}
+
+	// reprice the bonds on the issuer curve
+	sum := 0.0
+	for i, r := range residuals {
+		residual, err := fixedincome.Spread(r.Quote.Dirty, r.Quote.Bond, curve)
+		if err != nil {
+			return nil, fmt.Errorf("residual of %s: %w", r.Quote.ID, err)
+		}
+		residuals[i].Residual = residual
+		residuals[i].Price = r.Quote.Bond.PresentValue(curve)
+		sum += residual * residual
+	}
+	if threshold <= 0.0 {
+		threshold = 2.0 * math.Sqrt(sum/float64(len(residuals)))
+	}
+	for i := range residuals {
+		residuals[i].Outlier = math.Abs(residuals[i].Residual) > threshold
+	}
+
+	return &Issuer{Curve: curve, Residuals: residuals}, nil
+}
@@ -0,0 +1,94 @@
+package credit_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/credit"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+var (
+	settlement = time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	base       = term.NelsonSiegelSvensson{B0: 2.0, B1: -1.0, B2: 1.0, B3: 0.0, T1: 2.0, T2: 2.0}
+)
+
+// stack returns bonds of an issuer with maturities of 1 to n years
+func stack(n int) []*bond.Straight {
+	bonds := make([]*bond.Straight, n)
+	for i := range bonds {
+		bonds[i] = &bond.Straight{
+			Schedule: maturity.Schedule{
+				Settlement: settlement,
+				Maturity:   settlement.AddDate(i+1, 2, 0),
+				Frequency:  1,
+				Basis:      "30E360",
+			},
+			Coupon:     0.5 + 0.25*float64(i),
+			Redemption: 100.0,
+		}
+	}
+	return bonds
+}
+
+func TestFitIssuer(t *testing.T) {
+	// quotes on an issuer curve with one bond 40 bps cheap
+	issuer := credit.SpreadCurve{Base: &base, Level: 120.0, Slope: -70.0, Curvature: 30.0}
+	quotes := []credit.Quote{}
+	for i, b := range stack(8) {
+		spread := 0.0
+		if i == 5 {
+			spread = 40.0
+		}
+		quotes = append(quotes, credit.Quote{ID: string(rune('A' + i)), Bond: b, Dirty: b.PresentValue(term.WithSpread(&issuer, spread))})
+	}
+
+	fit, err := credit.FitIssuer(&base, quotes, 0.0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outliers := fit.Outliers()
+	if len(outliers) != 1 || outliers[0].Quote.ID != "F" {
+		t.Fatalf("wrong outliers; got: %v", outliers)
+	}
+	if outliers[0].Residual < 25.0 {
+		t.Errorf("wrong residual of the outlier; got: %v", outliers[0].Residual)
+	}
+
+	// without the outlier the issuer curve is recovered
+	clean := append(append([]credit.Quote{}, quotes[:5]...), quotes[6:]...)
+	fit, err = credit.FitIssuer(&base, clean, 1.0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range fit.Residuals {
+		if math.Abs(r.Residual) > 0.5 || r.Outlier {
+			t.Errorf("wrong residual for %s; got: %v", r.Quote.ID, r.Residual)
+		}
+		if math.Abs(r.Price-r.Quote.Dirty) > 0.05 {
+			t.Errorf("wrong model price for %s; got: %v, expected: %v", r.Quote.ID, r.Price, r.Quote.Dirty)
+		}
+	}
+	for _, m := range []float64{2.0, 5.0, 8.0} {
+		if got, expected := fit.Curve.Spread(m), issuer.Spread(m); math.Abs(got-expected) > 1.0 {
+			t.Errorf("wrong fitted spread for %v years; got: %v, expected: %v", m, got, expected)
+		}
+	}
+
+	// a single bond gives a flat spread curve
+	fit, err = credit.FitIssuer(&base, quotes[:1], 0.0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(fit.Curve.Level-fit.Residuals[0].Spread) > 1e-9 || fit.Curve.Slope != 0.0 {
+		t.Errorf("wrong curve for a single bond; got: %v", fit.Curve)
+	}
+
+	if _, err := credit.FitIssuer(&base, nil, 0.0); !errors.Is(err, credit.ErrNoQuotes) {
+		t.Errorf("expected error for no quotes; got: %v", err)
+	}
+}
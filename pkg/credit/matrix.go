@@ -0,0 +1,175 @@
+package credit
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Scale is the rating scale from the best to the worst rating
+var Scale = []string{
+	"AAA", "AA+", "AA", "AA-", "A+", "A", "A-",
+	"BBB+", "BBB", "BBB-", "BB+", "BB", "BB-", "B+", "B", "B-",
+	"CCC+", "CCC", "CCC-", "CC", "C", "D",
+}
+
+// notch returns the position of the rating on the scale
+func notch(rating string) (int, error) {
+	rating = strings.ToUpper(strings.TrimSpace(rating))
+	for i, r := range Scale {
+		if r == rating {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown rating %q", rating)
+}
+
+// Matrix holds spreads in bps over the base curve per rating and tenor,
+// e.g. from the evaluated prices of a rating class or a sector
+type Matrix struct {
+	// Tenors are the ascending tenors in years
+	Tenors []float64
+	// Spreads are the spreads in bps per rating for the tenors
+	Spreads map[string][]float64
+}
+
+// Spread returns the spread in bps for the rating and the tenor in years,
+// interpolated linearly in the tenor (flat outside the tenors) and in the notches
+// between the nearest ratings of the matrix (flat outside the ratings)
+func (m *Matrix) Spread(rating string, tenor float64) (float64, error) {
+	n, err := notch(rating)
+	if err != nil {
+		return 0.0, err
+	}
+	lower, upper := -1, -1
+	for r := range m.Spreads {
+		k, err := notch(r)
+		if err != nil {
+			return 0.0, err
+		}
+		if k <= n && (lower < 0 || k > lower) {
+			lower = k
+		}
+		if k >= n && (upper < 0 || k < upper) {
+			upper = k
+		}
+	}
+	switch {
+	case lower < 0 && upper < 0:
+		return 0.0, fmt.Errorf("%w: empty spread matrix", ErrNoQuotes)
+	case lower < 0:
+		lower = upper
+	case upper < 0:
+		upper = lower
+	}
+
+	s1, err := m.tenor(Scale[lower], tenor)
+	if err != nil {
+		return 0.0, err
+	}
+	if lower == upper {
+		return s1, nil
+	}
+	s2, err := m.tenor(Scale[upper], tenor)
+	if err != nil {
+		return 0.0, err
+	}
+	w := float64(n-lower) / float64(upper-lower)
+	return (1.0-w)*s1 + w*s2, nil
+}
+
+// tenor interpolates the spreads of the rating in the tenor
+func (m *Matrix) tenor(rating string, tenor float64) (float64, error) {
+	spreads := m.row(rating)
+	if len(spreads) != len(m.Tenors) || len(spreads) == 0 {
+		return 0.0, fmt.Errorf("%d spreads for %d tenors of rating %s", len(spreads), len(m.Tenors), rating)
+	}
+	i := sort.SearchFloat64s(m.Tenors, tenor)
+	switch {
+	case i == 0:
+		return spreads[0], nil
+	case i == len(m.Tenors):
+		return spreads[i-1], nil
+	}
+	w := (tenor - m.Tenors[i-1]) / (m.Tenors[i] - m.Tenors[i-1])
+	return (1.0-w)*spreads[i-1] + w*spreads[i], nil
+}
+
+// row returns the spreads of the rating (case-insensitive)
+func (m *Matrix) row(rating string) []float64 {
+	for r, spreads := range m.Spreads {
+		if strings.EqualFold(strings.TrimSpace(r), rating) {
+			return spreads
+		}
+	}
+	return nil
+}
+
+// Curve returns the term structure of the rating over the base curve
+func (m *Matrix) Curve(base term.Structure, rating string) (term.Structure, error) {
+	if _, err := m.Spread(rating, 0.0); err != nil {
+		return nil, err
+	}
+	return &matrixCurve{base: base, matrix: m, rating: rating}, nil
+}
+
+// Sectors holds a spread matrix per sector; the matrix of the sector ""
+// applies to the sectors without a matrix
+type Sectors map[string]*Matrix
+
+// Curve returns the term structure of the sector and rating over the base curve
+func (s Sectors) Curve(base term.Structure, sector, rating string) (term.Structure, error) {
+	m, ok := s[sector]
+	if !ok {
+		if m, ok = s[""]; !ok {
+			return nil, fmt.Errorf("%w: no spread matrix for sector %q", ErrNoQuotes, sector)
+		}
+	}
+	return m.Curve(base, rating)
+}
+
+// matrixCurve adds the spreads of a rating in the matrix to the base curve
+type matrixCurve struct {
+	base   term.Structure
+	matrix *Matrix
+	rating string
+	extra  float64
+}
+
+// spread returns the spread in bps of the rating for the maturity t
+// (validated when the curve is created)
+func (c *matrixCurve) spread(t float64) float64 {
+	s, _ := c.matrix.Spread(c.rating, t)
+	return s + c.extra
+}
+
+func (c *matrixCurve) Rate(t float64) float64 {
+	return c.base.Rate(t) + c.spread(t)*0.01
+}
+
+func (c *matrixCurve) Z(t float64) float64 {
+	return term.DiscountFactor(c.base, t, c.spread(t))
+}
+
+func (c *matrixCurve) SetSpread(spread float64) term.Structure {
+	c.extra = spread
+	return c
+}
+
+// DiscountFactor returns the discount factor for the maturity t with an
+// additional spread in bps
+func (c *matrixCurve) DiscountFactor(t, spread float64) float64 {
+	return term.DiscountFactor(c, t, spread)
+}
+
+// Shift returns the term structure shifted in parallel by dr in bps
+func (c *matrixCurve) Shift(dr float64) term.Structure {
+	return term.Shift(c, dr)
+}
+
+func (c *matrixCurve) Copy() term.Structure {
+	copied := *c
+	return &copied
+}
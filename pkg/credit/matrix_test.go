@@ -0,0 +1,81 @@
+package credit_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/credit"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+var matrix = credit.Matrix{
+	Tenors: []float64{1.0, 5.0, 10.0},
+	Spreads: map[string][]float64{
+		"AAA": {10.0, 20.0, 30.0},
+		"AA":  {20.0, 35.0, 50.0},
+		"A":   {40.0, 60.0, 80.0},
+		"BBB": {80.0, 120.0, 150.0},
+	},
+}
+
+func TestMatrix_Spread(t *testing.T) {
+	var tests = []struct {
+		rating   string
+		tenor    float64
+		expected float64
+	}{
+		{"AA", 5.0, 35.0},
+		{"AA", 3.0, 27.5},
+		{"aa", 0.5, 20.0},
+		{"AA", 20.0, 50.0},
+		// one notch from AA to A
+		{"AA-", 5.0, 35.0 + (60.0-35.0)/3.0},
+		{"BBB+", 10.0, 80.0 + (150.0-80.0)/3.0*2.0},
+		// flat beyond the ratings of the matrix
+		{"BB", 5.0, 120.0},
+	}
+	for _, test := range tests {
+		got, err := matrix.Spread(test.rating, test.tenor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(got-test.expected) > 1e-9 {
+			t.Errorf("wrong spread for %s and %v years; got: %v, expected: %v", test.rating, test.tenor, got, test.expected)
+		}
+	}
+	if _, err := matrix.Spread("XYZ", 5.0); err == nil {
+		t.Errorf("expected error for unknown rating")
+	}
+}
+
+func TestSectors_Curve(t *testing.T) {
+	financials := credit.Matrix{Tenors: []float64{5.0}, Spreads: map[string][]float64{"A": {100.0}}}
+	sectors := credit.Sectors{"": &matrix, "financials": &financials}
+
+	curve, err := sectors.Curve(&base, "financials", "A")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := curve.Rate(3.0), base.Rate(3.0)+1.0; math.Abs(got-expected) > 1e-12 {
+		t.Errorf("wrong rate of the sector curve; got: %v, expected: %v", got, expected)
+	}
+
+	// unquoted bond priced on the curve of its rating
+	b := stack(5)[4]
+	curve, err = sectors.Curve(&base, "utilities", "AA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spread, _ := matrix.Spread("AA", b.Last())
+	flat := b.PresentValue(term.WithSpread(&base, spread))
+	if got := b.PresentValue(curve); math.Abs(got-flat) > 0.05 || got >= b.PresentValue(&base) {
+		t.Errorf("wrong price on the rating curve; got: %v, expected about: %v", got, flat)
+	}
+	if got, expected := term.WithSpread(curve, 10.0).Rate(5.0), curve.Rate(5.0)+0.1; math.Abs(got-expected) > 1e-12 {
+		t.Errorf("wrong shifted rate; got: %v, expected: %v", got, expected)
+	}
+
+	if _, err := (credit.Sectors{}).Curve(&base, "utilities", "AA"); err == nil {
+		t.Errorf("expected error for missing sector matrix")
+	}
+}
@@ -0,0 +1,59 @@
+package credit
+
+import (
+	"fmt"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// NewIssue is the pricing of a new bond of an issuer against the secondary
+// curve of the issuer (e.g. the fitted Issuer.Curve)
+type NewIssue struct {
+	// ParCoupon is the coupon in percent for a clean price at par on the curve
+	ParCoupon float64
+	// Price is the clean price of the bond with the proposed coupon on the curve
+	Price float64
+	// Reoffer is the clean reoffer price (default: Price)
+	Reoffer float64
+	// Concession is the static spread in bps of the reoffer price over the
+	// curve (positive if the new issue is cheap to the secondary bonds)
+	Concession float64
+	// DayOne is the profit per unit of the bond at the reoffer price when it
+	// trades at the curve (Price less Reoffer)
+	DayOne float64
+}
+
+// PriceNewIssue prices the bond with the proposed coupon on the secondary
+// curve of the issuer and returns the new-issue concession of the clean
+// reoffer price (0 for pricing at the curve)
+func PriceNewIssue(b *bond.Straight, curve term.Structure, reoffer float64) (NewIssue, error) {
+	if err := b.Validate(); err != nil {
+		return NewIssue{}, err
+	}
+	n := NewIssue{Price: b.PresentValue(curve) - b.Accrued(), Reoffer: reoffer}
+	if n.Reoffer == 0.0 {
+		n.Reoffer = n.Price
+	}
+
+	// the clean price is linear in the coupon
+	c := *b
+	c.CouponRounding = nil
+	c.Coupon = 0.0
+	zero := c.PresentValue(curve) - c.Accrued()
+	c.Coupon = 1.0
+	unit := c.PresentValue(curve) - c.Accrued() - zero
+	if unit <= 0.0 {
+		return n, fmt.Errorf("par coupon: annuity %v of the bond is not positive", unit)
+	}
+	n.ParCoupon = (b.ParValue() - zero) / unit
+
+	spread, err := fixedincome.Spread(n.Reoffer+b.Accrued(), b, curve)
+	if err != nil {
+		return n, fmt.Errorf("concession: %w", err)
+	}
+	n.Concession = spread
+	n.DayOne = n.Price - n.Reoffer
+	return n, nil
+}
@@ -0,0 +1,57 @@
+package credit_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/credit"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestPriceNewIssue(t *testing.T) {
+	curve := credit.SpreadCurve{Base: &base, Level: 80.0, Slope: -30.0}
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: settlement,
+			Issue:      settlement,
+			Maturity:   settlement.AddDate(7, 0, 0),
+			Frequency:  1,
+			Basis:      "30E360",
+		},
+		Coupon:     2.0,
+		Redemption: 100.0,
+	}
+
+	// at the curve
+	n, err := credit.PriceNewIssue(&b, &curve, 0.0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	par := b
+	par.Coupon = n.ParCoupon
+	if p := par.PresentValue(&curve); math.Abs(p-100.0) > 1e-9 {
+		t.Errorf("wrong price at the par coupon; got: %v, expected: %v", p, 100.0)
+	}
+	if math.Abs(n.Price-b.PresentValue(&curve)) > 1e-9 || n.Reoffer != n.Price {
+		t.Errorf("wrong issue price; got: %v, expected: %v", n.Price, b.PresentValue(&curve))
+	}
+	if math.Abs(n.Concession) > 1e-4 || math.Abs(n.DayOne) > 1e-9 {
+		t.Errorf("wrong concession at the curve; got: %v, expected: %v", n.Concession, 0.0)
+	}
+
+	// reoffered 50 cents below the curve
+	n, err = credit.PriceNewIssue(&b, &curve, n.Price-0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := 0.5 / math.Abs(b.Duration(term.WithSpread(&curve, n.Concession))) / n.Reoffer * 10000.0
+	if math.Abs(n.Concession-expected) > 0.2 || math.Abs(n.DayOne-0.5) > 1e-9 {
+		t.Errorf("wrong concession; got: %v (day one %v), expected: %v (day one %v)", n.Concession, n.DayOne, expected, 0.5)
+	}
+	cheap := term.WithSpread(&curve, n.Concession)
+	if p := b.PresentValue(cheap); math.Abs(p-n.Reoffer) > 1e-4 {
+		t.Errorf("wrong reoffer price at the concession; got: %v, expected: %v", p, n.Reoffer)
+	}
+}
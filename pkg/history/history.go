@@ -0,0 +1,204 @@
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// ErrNoCurve is returned if no term structure is available for a date
+var ErrNoCurve = errors.New("no term structure available")
+
+// History provides dated term structures, e.g. for backtests and P&L attribution
+type History interface {
+	// Curve returns the term structure for the date
+	Curve(date time.Time) (term.Structure, error)
+	// Range returns the stored term structures between from and to (inclusive)
+	Range(from, to time.Time) []Entry
+}
+
+// Entry is a term structure as of a date
+type Entry struct {
+	Date  time.Time
+	Curve term.Structure
+}
+
+// Store holds dated term structures in memory ordered by date and is
+// optionally backed by a json file (see Load and Save). Missing days between
+// two stored dates are interpolated linearly in the spot rates.
+type Store struct {
+	entries []Entry
+}
+
+// day truncates the date to midnight UTC
+func day(date time.Time) time.Time {
+	y, m, d := date.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Add stores the term structure for the date and replaces an existing one
+func (s *Store) Add(date time.Time, ts term.Structure) {
+	date = day(date)
+	i := s.search(date)
+	if i < len(s.entries) && s.entries[i].Date.Equal(date) {
+		s.entries[i].Curve = ts
+		return
+	}
+	s.entries = append(s.entries, Entry{})
+	copy(s.entries[i+1:], s.entries[i:])
+	s.entries[i] = Entry{Date: date, Curve: ts}
+}
+
+// Len returns the number of stored term structures
+func (s *Store) Len() int {
+	return len(s.entries)
+}
+
+// Dates returns the dates of the stored term structures in increasing order
+func (s *Store) Dates() []time.Time {
+	dates := make([]time.Time, len(s.entries))
+	for i, e := range s.entries {
+		dates[i] = e.Date
+	}
+	return dates
+}
+
+// Curve returns the term structure stored for the date or interpolates between
+// the previous and the next stored term structures; dates before the first or
+// after the last stored date return an error wrapping ErrNoCurve
+func (s *Store) Curve(date time.Time) (term.Structure, error) {
+	date = day(date)
+	i := s.search(date)
+	if i < len(s.entries) && s.entries[i].Date.Equal(date) {
+		return s.entries[i].Curve, nil
+	}
+	if i == 0 || i == len(s.entries) {
+		return nil, fmt.Errorf("%w: %s is outside the stored dates", ErrNoCurve, date.Format("2006-01-02"))
+	}
+	prev, next := s.entries[i-1], s.entries[i]
+	w := date.Sub(prev.Date).Hours() / next.Date.Sub(prev.Date).Hours()
+	return &Blend{A: prev.Curve, B: next.Curve, W: w}, nil
+}
+
+// Previous returns the latest stored term structure on or before the date
+func (s *Store) Previous(date time.Time) (Entry, error) {
+	date = day(date)
+	i := s.search(date)
+	if i < len(s.entries) && s.entries[i].Date.Equal(date) {
+		return s.entries[i], nil
+	}
+	if i == 0 {
+		return Entry{}, fmt.Errorf("%w: no term structure on or before %s", ErrNoCurve, date.Format("2006-01-02"))
+	}
+	return s.entries[i-1], nil
+}
+
+// Range returns the stored term structures between from and to (inclusive)
+func (s *Store) Range(from, to time.Time) []Entry {
+	i, j := s.search(day(from)), s.search(day(to).AddDate(0, 0, 1))
+	entries := make([]Entry, j-i)
+	copy(entries, s.entries[i:j])
+	return entries
+}
+
+// search returns the index of the first entry on or after the date
+func (s *Store) search(date time.Time) int {
+	return sort.Search(len(s.entries), func(i int) bool { return !s.entries[i].Date.Before(date) })
+}
+
+// record is the json representation of an entry
+type record struct {
+	Date  string          `json:"date"`
+	Curve json.RawMessage `json:"curve"`
+}
+
+// Load reads a store from a json file with a list of dated term structures,
+// e.g. [{"date": "2021-04-01", "curve": {"b0": -0.26, ...}}, ...]
+func Load(file string) (*Store, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading curve history failed: %w", err)
+	}
+	records := []record{}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("parsing curve history failed: %w", err)
+	}
+	s := Store{}
+	for _, r := range records {
+		date, err := time.Parse("2006-01-02", r.Date)
+		if err != nil {
+			return nil, fmt.Errorf("parsing curve history failed: %w", err)
+		}
+		ts, err := term.Parse(r.Curve)
+		if err != nil {
+			return nil, fmt.Errorf("curve of %s: %w", r.Date, err)
+		}
+		s.Add(date, ts)
+	}
+	return &s, nil
+}
+
+// Save writes the store to a json file (see Load)
+func (s *Store) Save(file string) error {
+	records := make([]record, len(s.entries))
+	for i, e := range s.entries {
+		data, err := json.Marshal(e.Curve)
+		if err != nil {
+			return fmt.Errorf("curve of %s: %w", e.Date.Format("2006-01-02"), err)
+		}
+		records[i] = record{Date: e.Date.Format("2006-01-02"), Curve: data}
+	}
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, data, 0644)
+}
+
+// Blend interpolates linearly between the spot rates of two term structures
+// with weight W on B (0 for A, 1 for B)
+type Blend struct {
+	A, B   term.Structure
+	W      float64
+	Spread float64
+}
+
+// SetSpread sets the spread in bps on top of the blended term structure
+func (b *Blend) SetSpread(spread float64) term.Structure {
+	b.Spread = spread
+	return b
+}
+
+// DiscountFactor returns the discount factor for the maturity t with an
+// additional spread in bps
+func (b *Blend) DiscountFactor(t, spread float64) float64 {
+	return term.DiscountFactor(b, t, spread)
+}
+
+// Shift returns the term structure shifted in parallel by dr in bps
+func (b *Blend) Shift(dr float64) term.Structure {
+	return term.Shift(b, dr)
+}
+
+// Copy returns a copy of the blend; the underlying term structures are shared
+// as they are not modified
+func (b *Blend) Copy() term.Structure {
+	c := *b
+	return &c
+}
+
+// Rate returns the continuously compounded spot rate in percent
+func (b *Blend) Rate(t float64) float64 {
+	return (1.0-b.W)*b.A.Rate(t) + b.W*b.B.Rate(t) + b.Spread*0.01
+}
+
+// Z returns the discount factor for the given maturity t
+func (b *Blend) Z(t float64) float64 {
+	return math.Exp(-b.Rate(t) * 0.01 * t)
+}
@@ -0,0 +1,93 @@
+package history_test
+
+import (
+	"errors"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/history"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func date(d int) time.Time {
+	return time.Date(2021, 4, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestStore(t *testing.T) {
+	s := history.Store{}
+	s.Add(date(5), &term.Flat{R: 2.0})
+	s.Add(date(1), &term.Flat{R: 1.0})
+	s.Add(date(8), &term.Flat{R: 5.0})
+	s.Add(date(5), &term.Flat{R: 3.0})
+
+	if s.Len() != 3 {
+		t.Fatalf("wrong number of entries; got: %d, expected: %d", s.Len(), 3)
+	}
+	dates := s.Dates()
+	if !dates[0].Equal(date(1)) || !dates[2].Equal(date(8)) {
+		t.Errorf("dates are not ordered; got: %v", dates)
+	}
+
+	testData := []struct {
+		Date     time.Time
+		Expected float64
+	}{
+		{date(1), 1.0},
+		{date(3), 2.0},
+		{date(5), 3.0},
+		{date(6), 3.0 + 2.0/3.0},
+		{date(8).Add(15 * time.Hour), 5.0},
+	}
+	for _, test := range testData {
+		ts, err := s.Curve(test.Date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(ts.Rate(2.0)-test.Expected) > 1e-12 {
+			t.Errorf("wrong rate for %v; got: %v, expected: %v", test.Date, ts.Rate(2.0), test.Expected)
+		}
+		if math.Abs(ts.Z(2.0)-math.Exp(-test.Expected*0.02)) > 1e-12 {
+			t.Errorf("wrong discount factor for %v; got: %v", test.Date, ts.Z(2.0))
+		}
+	}
+
+	if _, err := s.Curve(date(10)); !errors.Is(err, history.ErrNoCurve) {
+		t.Errorf("expected ErrNoCurve after the last date; got: %v", err)
+	}
+	if e, err := s.Previous(date(7)); err != nil || !e.Date.Equal(date(5)) {
+		t.Errorf("wrong previous entry; got: %v, %v", e.Date, err)
+	}
+	if _, err := s.Previous(time.Date(2021, 3, 31, 0, 0, 0, 0, time.UTC)); !errors.Is(err, history.ErrNoCurve) {
+		t.Errorf("expected ErrNoCurve before the first date; got: %v", err)
+	}
+	if r := s.Range(date(2), date(8)); len(r) != 2 || !r[0].Date.Equal(date(5)) {
+		t.Errorf("wrong range; got: %v", r)
+	}
+}
+
+func TestStore_SaveLoad(t *testing.T) {
+	s := history.Store{}
+	s.Add(date(1), &term.NelsonSiegelSvensson{B0: -0.266372, B1: -0.471343, B2: 5.68789, B3: -5.12324, T1: 5.74881, T2: 4.14426})
+	s.Add(date(2), &term.Flat{R: 1.0})
+
+	file := filepath.Join(t.TempDir(), "history.json")
+	if err := s.Save(file); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := history.Load(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Len() != s.Len() {
+		t.Fatalf("wrong number of entries; got: %d, expected: %d", loaded.Len(), s.Len())
+	}
+	for _, d := range s.Dates() {
+		a, _ := s.Curve(d)
+		b, _ := loaded.Curve(d)
+		if math.Abs(a.Rate(5.0)-b.Rate(5.0)) > 1e-12 {
+			t.Errorf("loaded curve of %v differs; got: %v, expected: %v", d, b.Rate(5.0), a.Rate(5.0))
+		}
+	}
+}
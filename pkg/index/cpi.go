@@ -0,0 +1,58 @@
+package index
+
+import (
+	"math"
+	"time"
+)
+
+// Reference is the reference CPI of inflation-linked bonds with an indexation
+// lag: the reference index for a date d in month m is interpolated daily
+// between the CPI published for the months m - Lag and m - Lag + 1, i.e.
+//
+//	Ref(d) = CPI(m - Lag) + (day(d) - 1) / days(m) * (CPI(m - Lag + 1) - CPI(m - Lag))
+//
+// (e.g. US TIPS, UK index-linked gilts issued after 2005 and OATi/€i)
+type Reference struct {
+	// CPI provides the monthly index values for the first day of a month
+	// (e.g. Fixings of the published CPI with a projection for later months)
+	CPI Projection
+	// Lag is the indexation lag in months (default: 0 for 3 months)
+	Lag int
+	// Digits rounds the reference index and the index ratio to the number of
+	// decimals as in the official figures (default: 0 for no rounding)
+	Digits int
+}
+
+// lag returns the indexation lag in months
+func (r *Reference) lag() int {
+	if r.Lag == 0 {
+		return 3
+	}
+	return r.Lag
+}
+
+// Value returns the reference CPI for the date
+func (r *Reference) Value(date time.Time) float64 {
+	y, m, d := date.Date()
+	first := time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	days := first.AddDate(0, 1, 0).Sub(first).Hours() / 24.0
+
+	lower := r.CPI.Value(first.AddDate(0, -r.lag(), 0))
+	upper := r.CPI.Value(first.AddDate(0, 1-r.lag(), 0))
+	return r.round(lower + float64(d-1)/days*(upper-lower))
+}
+
+// Ratio returns the index ratio of the reference CPI for the date over the base
+// reference CPI (e.g. of the dated date of the bond)
+func (r *Reference) Ratio(date time.Time, base float64) float64 {
+	return r.round(r.Value(date) / base)
+}
+
+// round rounds the value to the number of digits
+func (r *Reference) round(value float64) float64 {
+	if r.Digits <= 0 {
+		return value
+	}
+	scale := math.Pow(10.0, float64(r.Digits))
+	return math.Round(value*scale) / scale
+}
@@ -0,0 +1,51 @@
+package index_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/index"
+)
+
+func month(y int, m time.Month) time.Time {
+	return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func TestReference(t *testing.T) {
+	// CPI-U (NSA) for January to March 1997
+	cpi := index.NewFixings(nil,
+		index.Fixing{Date: month(1997, time.January), Value: 159.1},
+		index.Fixing{Date: month(1997, time.February), Value: 159.6},
+		index.Fixing{Date: month(1997, time.March), Value: 160.0},
+	)
+	ref := index.Reference{CPI: cpi, Digits: 5}
+
+	var tests = []struct {
+		date     time.Time
+		expected float64
+	}{
+		// first day of the month: CPI three months earlier
+		{time.Date(1997, 4, 1, 0, 0, 0, 0, time.UTC), 159.1},
+		// 31 CFR 356, Appendix B: 159.1 + 14/30 * (159.6 - 159.1)
+		{time.Date(1997, 4, 15, 0, 0, 0, 0, time.UTC), 159.33333},
+		{time.Date(1997, 5, 1, 0, 0, 0, 0, time.UTC), 159.6},
+		{time.Date(1997, 5, 31, 0, 0, 0, 0, time.UTC), 159.98710},
+	}
+	for _, test := range tests {
+		if got := ref.Value(test.date); math.Abs(got-test.expected) > 1e-9 {
+			t.Errorf("wrong reference CPI for %v; got: %v, expected: %v", test.date, got, test.expected)
+		}
+	}
+
+	// index ratio over the base of the dated date
+	if got := ref.Ratio(time.Date(1997, 5, 1, 0, 0, 0, 0, time.UTC), 159.1); math.Abs(got-1.00314) > 1e-12 {
+		t.Errorf("wrong index ratio; got: %v, expected: %v", got, 1.00314)
+	}
+
+	// lag of eight months
+	eight := index.Reference{CPI: cpi, Lag: 8}
+	if got := eight.Value(time.Date(1997, 9, 1, 0, 0, 0, 0, time.UTC)); got != 159.1 {
+		t.Errorf("wrong reference CPI with eight months lag; got: %v, expected: %v", got, 159.1)
+	}
+}
@@ -0,0 +1,26 @@
+package index
+
+import (
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Implied projects a price index (e.g. a CPI) with the breakeven inflation of
+// a nominal and real term structure pair
+type Implied struct {
+	Pair *term.Pair
+	// Settlement is the valuation date of the term structures
+	Settlement time.Time
+	// Base is the index value at the settlement date
+	Base float64
+}
+
+// Value returns the projected index value with the times in ACT/365 from the settlement date
+func (i *Implied) Value(date time.Time) float64 {
+	t := years(i.Settlement, date)
+	if t <= 0.0 {
+		return i.Base
+	}
+	return i.Base * i.Pair.Inflation(t)
+}
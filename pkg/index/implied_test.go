@@ -0,0 +1,24 @@
+package index_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/index"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestImplied(t *testing.T) {
+	date := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	pair := term.Pair{Nominal: &term.Flat{R: 3.0}, Real: &term.Flat{R: 1.0}}
+	cpi := index.Implied{Pair: &pair, Settlement: date, Base: 110.0}
+
+	if got := cpi.Value(date.AddDate(0, -1, 0)); got != 110.0 {
+		t.Errorf("wrong index before settlement; got: %v, expected: %v", got, 110.0)
+	}
+	// 365 days with 2% breakeven inflation
+	if got, expected := cpi.Value(date.AddDate(1, 0, 0)), 110.0*math.Exp(0.02); math.Abs(got-expected) > 1e-9 {
+		t.Errorf("wrong projected index; got: %v, expected: %v", got, expected)
+	}
+}
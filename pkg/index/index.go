@@ -0,0 +1,119 @@
+package index
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Projection provides the value of a rate index in percent (e.g. a money market
+// rate or an overnight rate) or a price index (e.g. a CPI) for a fixing date;
+// instruments linked to an index consume the projection instead of the index
+// being hard-wired into the instrument
+type Projection interface {
+	Value(date time.Time) float64
+}
+
+// Func is an adapter to use a function as a Projection, e.g. for a
+// user-supplied projection of a local market index
+type Func func(date time.Time) float64
+
+// Value calls f(date)
+func (f Func) Value(date time.Time) float64 {
+	return f(date)
+}
+
+// Flat projects a constant value
+type Flat float64
+
+// Value returns the constant value
+func (f Flat) Value(date time.Time) float64 {
+	return float64(f)
+}
+
+// Fixing is the published value of an index for a date
+type Fixing struct {
+	Date  time.Time
+	Value float64
+}
+
+// Fixings holds the published values of an index; dates up to the last fixing
+// return the latest fixing at or before the date and later dates return the
+// projection (or the last fixing if there is no projection)
+type Fixings struct {
+	fixings []Fixing
+	// Projection is used for the dates after the last fixing (default: nil for the last fixing)
+	Projection Projection
+}
+
+// NewFixings returns the fixings sorted by date with the projection for later dates
+func NewFixings(projection Projection, fixings ...Fixing) *Fixings {
+	f := &Fixings{Projection: projection}
+	for _, fixing := range fixings {
+		f.Add(fixing.Date, fixing.Value)
+	}
+	return f
+}
+
+// Add stores the value for the date and replaces an existing fixing
+func (f *Fixings) Add(date time.Time, value float64) {
+	i := sort.Search(len(f.fixings), func(i int) bool { return !f.fixings[i].Date.Before(date) })
+	if i < len(f.fixings) && f.fixings[i].Date.Equal(date) {
+		f.fixings[i].Value = value
+		return
+	}
+	f.fixings = append(f.fixings, Fixing{})
+	copy(f.fixings[i+1:], f.fixings[i:])
+	f.fixings[i] = Fixing{Date: date, Value: value}
+}
+
+// Len returns the number of fixings
+func (f *Fixings) Len() int {
+	return len(f.fixings)
+}
+
+// Value returns the fixing for the date or the projection after the last fixing;
+// dates before the first fixing return NaN unless there is a projection
+func (f *Fixings) Value(date time.Time) float64 {
+	n := len(f.fixings)
+	if n > 0 && !date.After(f.fixings[n-1].Date) {
+		i := sort.Search(n, func(i int) bool { return f.fixings[i].Date.After(date) })
+		if i > 0 {
+			return f.fixings[i-1].Value
+		}
+	}
+	if f.Projection != nil {
+		return f.Projection.Value(date)
+	}
+	if n == 0 || date.Before(f.fixings[0].Date) {
+		return math.NaN()
+	}
+	return f.fixings[n-1].Value
+}
+
+// Forward projects a money market rate in percent as the simply compounded
+// forward rate of the term structure for the tenor starting at the fixing date
+type Forward struct {
+	Curve term.Structure
+	// Settlement is the valuation date of the term structure
+	Settlement time.Time
+	// Tenor is the tenor of the rate in months (e.g. 3 for a 3-month rate)
+	Tenor int
+}
+
+// Value returns the forward rate in percent with the times in ACT/365 from the settlement date
+func (f *Forward) Value(date time.Time) float64 {
+	start := years(f.Settlement, date)
+	end := years(f.Settlement, date.AddDate(0, f.Tenor, 0))
+	if end <= start {
+		return math.NaN()
+	}
+	return (f.Curve.Z(math.Max(start, 0.0))/f.Curve.Z(end) - 1.0) / (end - start) * 100.0
+}
+
+// years returns the years between the dates in ACT/365
+func years(from, to time.Time) float64 {
+	return to.Sub(from).Hours() / 24.0 / 365.0
+}
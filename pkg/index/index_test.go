@@ -0,0 +1,66 @@
+package index_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/index"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestFixings(t *testing.T) {
+	date := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	fixings := index.NewFixings(nil,
+		index.Fixing{Date: date.AddDate(0, 3, 0), Value: 1.5},
+		index.Fixing{Date: date, Value: 1.0},
+	)
+	fixings.Add(date.AddDate(0, 6, 0), 2.0)
+	fixings.Add(date.AddDate(0, 6, 0), 2.5)
+
+	var tests = []struct {
+		date     time.Time
+		expected float64
+	}{
+		{date, 1.0},
+		{date.AddDate(0, 1, 0), 1.0},
+		{date.AddDate(0, 3, 0), 1.5},
+		{date.AddDate(0, 6, 0), 2.5},
+		{date.AddDate(1, 0, 0), 2.5},
+	}
+	for _, test := range tests {
+		if got := fixings.Value(test.date); got != test.expected {
+			t.Errorf("wrong fixing for %v; got: %v, expected: %v", test.date, got, test.expected)
+		}
+	}
+	if fixings.Len() != 3 {
+		t.Errorf("wrong number of fixings; got: %v, expected: %v", fixings.Len(), 3)
+	}
+	if got := fixings.Value(date.AddDate(0, -1, 0)); !math.IsNaN(got) {
+		t.Errorf("expected NaN before the first fixing; got: %v", got)
+	}
+
+	// projection after the last fixing
+	fixings.Projection = index.Func(func(d time.Time) float64 { return 3.0 })
+	if got := fixings.Value(date.AddDate(1, 0, 0)); got != 3.0 {
+		t.Errorf("wrong projected value; got: %v, expected: %v", got, 3.0)
+	}
+	if got := fixings.Value(date.AddDate(0, 2, 0)); got != 1.0 {
+		t.Errorf("wrong fixing with projection; got: %v, expected: %v", got, 1.0)
+	}
+}
+
+func TestForward(t *testing.T) {
+	date := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	ts := term.Flat{R: 2.0}
+	f := index.Forward{Curve: &ts, Settlement: date, Tenor: 12}
+
+	// simple rate over one year (365 days) for a flat continuous rate
+	expected := (math.Exp(0.02) - 1.0) * 100.0
+	if got := f.Value(date.AddDate(2, 0, 0)); math.Abs(got-expected) > 1e-9 {
+		t.Errorf("wrong forward rate; got: %v, expected: %v", got, expected)
+	}
+	if got := index.Flat(1.25).Value(date); got != 1.25 {
+		t.Errorf("wrong flat projection; got: %v, expected: %v", got, 1.25)
+	}
+}
@@ -0,0 +1,152 @@
+package bond
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Amortizing represents a mortgage-style bond with level payments of interest
+// and scheduled principal over the remaining periods and optional prepayments
+type Amortizing struct {
+	maturity.Schedule
+	// Coupon is the annual interest rate in percent
+	Coupon float64
+	// Redemption is the outstanding principal (per 100 of original face)
+	Redemption float64
+	// Prepayment is the prepayment model (default: nil for no prepayments)
+	Prepayment Prepayment
+	// Par is the notional base of prices, accrued interest and cash flows
+	// (e.g. 1 for unit notionals; default: 0 for per 100 of par)
+	Par float64
+
+	// Metadata describes the bond for reporting (default: nil for none)
+	Metadata *fixedincome.Metadata
+}
+
+// period is a projected payment of an amortizing bond
+type period struct {
+	date      time.Time
+	t         float64
+	interest  float64
+	principal float64
+}
+
+// ParValue returns the notional base of prices, accrued interest and cash flows
+func (a *Amortizing) ParValue() float64 {
+	return parValue(a.Par)
+}
+
+// Validate checks the schedule and the terms of the bond
+func (a *Amortizing) Validate() error {
+	if err := a.Schedule.Validate(); err != nil {
+		return err
+	}
+	if a.Redemption < 0.0 || math.IsNaN(a.Redemption) || math.IsInf(a.Redemption, 0) {
+		return fmt.Errorf("%w: outstanding principal %v is not valid", ErrInvalidBond, a.Redemption)
+	}
+	if math.IsNaN(a.Coupon) || math.IsInf(a.Coupon, 0) || a.Coupon <= -100.0*float64(a.Compounding()) {
+		return fmt.Errorf("%w: coupon %v is not valid", ErrInvalidBond, a.Coupon)
+	}
+	if a.Par < 0.0 || math.IsNaN(a.Par) || math.IsInf(a.Par, 0) {
+		return fmt.Errorf("%w: par value %v is not valid", ErrInvalidBond, a.Par)
+	}
+	return nil
+}
+
+// projection returns the interest and principal payments of the remaining
+// periods in increasing order; the level payment is recalculated on the
+// outstanding principal after each prepayment
+func (a *Amortizing) projection() []period {
+	maturities := a.M()
+	dates := a.Dates()
+	n := len(maturities)
+	periods := make([]period, n)
+
+	r := a.EffectiveCoupon(a.Coupon) / 100.0
+	balance := a.Redemption
+	for k := 0; k < n; k += 1 {
+		// schedule is generated backwards from the maturity date
+		i := n - 1 - k
+		remaining := float64(n - k)
+
+		interest := balance * r
+		payment := balance / remaining
+		if r != 0.0 {
+			payment = balance * r / (1.0 - math.Pow(1.0+r, -remaining))
+		}
+		scheduled := payment - interest
+		prepaid := 0.0
+		if a.Prepayment != nil {
+			prepaid = a.Prepayment.SMM(k, a.Compounding()) * (balance - scheduled)
+		}
+
+		periods[k] = period{date: dates[i], t: maturities[i], interest: interest, principal: scheduled + prepaid}
+		balance -= scheduled + prepaid
+	}
+	return periods
+}
+
+// Accrued calculates the accrued interest on the outstanding principal
+func (a *Amortizing) Accrued() float64 {
+	return scale(a.Par, a.Coupon*a.DayCountFraction()*a.Redemption/100.0)
+}
+
+// Cashflows returns the projected interest and principal payments including
+// prepayments ordered by payment date
+func (a *Amortizing) Cashflows() cashflow.Cashflows {
+	cfs := cashflow.Cashflows{}
+	for _, p := range a.projection() {
+		cfs = append(cfs, cashflow.Cashflow{Date: p.date, T: p.t, Amount: scale(a.Par, p.interest+p.principal)})
+	}
+	return cfs
+}
+
+// PresentValue returns the "dirty" price of the projected cash flows
+func (a *Amortizing) PresentValue(ts term.Structure) float64 {
+	return a.Cashflows().PresentValue(ts)
+}
+
+// PresentValueAt returns the "dirty" bond price for the given settlement date
+// without modifying the bond
+func (a *Amortizing) PresentValueAt(settlement time.Time, ts term.Structure) float64 {
+	c := *a
+	c.Settlement = settlement
+	return c.PresentValue(ts)
+}
+
+// AccruedAt returns the accrued interest for the given settlement date
+// without modifying the bond
+func (a *Amortizing) AccruedAt(settlement time.Time) float64 {
+	c := *a
+	c.Settlement = settlement
+	return c.Accrued()
+}
+
+// WAL returns the weighted average life in years of the projected principal payments
+func (a *Amortizing) WAL() float64 {
+	periods := a.projection()
+	t := make([]float64, len(periods))
+	principal := make([]float64, len(periods))
+	for i, p := range periods {
+		t[i], principal[i] = p.t, p.principal
+	}
+	return averageLife(t, principal)
+}
+
+// Duration calculates the duration of the projected cash flows
+// dP/P = -D * dr
+func (a *Amortizing) Duration(ts term.Structure) float64 {
+	return duration(a.Cashflows(), ts)
+}
+
+// Convexity calculates the convexity of the projected cash flows
+// dP/P = -D * dr + 1/2 * C * dr^2
+func (a *Amortizing) Convexity(ts term.Structure) float64 {
+	return convexity(a.Cashflows(), ts)
+}
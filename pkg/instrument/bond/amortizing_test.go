@@ -0,0 +1,78 @@
+package bond_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/rate"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestAmortizing(t *testing.T) {
+	testData := []struct {
+		Prepayment bond.Prepayment
+	}{
+		{nil},
+		{bond.CPR(6.0)},
+		{bond.Vector{2.0, 4.0, 6.0, 12.0}},
+	}
+
+	// without prepayments the principal payments grow with the interest rate
+	r := 0.04 / 12.0
+	first := 100.0 * r / (math.Pow(1.0+r, 360.0) - 1.0)
+	expectedWAL := 0.0
+	for k := 1; k <= 360; k += 1 {
+		expectedWAL += float64(k) / 12.0 * first * math.Pow(1.0+r, float64(k-1)) / 100.0
+	}
+
+	// 30 year mortgage with monthly payments at 4%
+	mortgage := bond.Amortizing{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2051, 1, 1, 0, 0, 0, 0, time.UTC),
+			Frequency:  12,
+		},
+		Coupon:     4.0,
+		Redemption: 100.0,
+	}
+	// discounting at the coupon rate values all payments at par
+	ts := term.Flat{R: rate.Continuous(4.0, 12)}
+
+	wal := math.Inf(1)
+	for nr, test := range testData {
+		m := mortgage
+		m.Prepayment = test.Prepayment
+		if err := m.Validate(); err != nil {
+			t.Fatal(err)
+		}
+
+		cfs := m.Cashflows()
+		if len(cfs) != 360 {
+			t.Fatalf("test nr %d: wrong number of payments; got: %d, expected: %d", nr, len(cfs), 360)
+		}
+		if pv := m.PresentValue(&ts); math.Abs(pv-100.0) > 1e-8 {
+			t.Errorf("test nr %d: wrong present value at the coupon rate; got: %v, expected: %v", nr, pv, 100.0)
+		}
+		if test.Prepayment == nil && math.Abs(cfs[0].Amount-cfs[359].Amount) > 1e-10 {
+			t.Errorf("test nr %d: payments are not level; got: %v and %v", nr, cfs[0].Amount, cfs[359].Amount)
+		}
+		if test.Prepayment == nil && math.Abs(m.WAL()-expectedWAL) > 1e-8 {
+			t.Errorf("test nr %d: wrong weighted average life; got: %v, expected: %v", nr, m.WAL(), expectedWAL)
+		}
+		if m.WAL() >= wal {
+			t.Errorf("test nr %d: prepayments do not shorten the weighted average life; got: %v", nr, m.WAL())
+		}
+		wal = m.WAL()
+		if d := m.Duration(&ts); d >= 0.0 || -d > m.WAL() {
+			t.Errorf("test nr %d: wrong duration; got: %v", nr, d)
+		}
+	}
+
+	// single monthly mortality for 6% CPR
+	if smm := bond.CPR(6.0).SMM(0, 12); math.Abs(smm-(1.0-math.Pow(0.94, 1.0/12.0))) > 1e-15 {
+		t.Errorf("wrong single monthly mortality; got: %v", smm)
+	}
+}
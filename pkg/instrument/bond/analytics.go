@@ -0,0 +1,52 @@
+package bond
+
+import (
+	"github.com/konimarti/fixedincome/pkg/ad"
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// sensitivities returns the present value of the cash flows and its first and
+// second derivative for a parallel shift dr of the continuously compounded
+// spot rates, computed in a single pass with algorithmic differentiation
+func sensitivities(cfs cashflow.Cashflows, ts term.Structure) ad.Second {
+	dr := ad.SecondVariable(0.0)
+	pv := ad.Second{}
+	for _, cf := range cfs {
+		// Z(t) * exp(-t * dr)
+		z := ad.ScaleSecond(ad.ExpSecond(ad.ScaleSecond(dr, -cf.T)), cf.Amount*ts.Z(cf.T))
+		pv = ad.AddSecond(pv, z)
+	}
+	return pv
+}
+
+// duration returns the duration of the cash flows (dP/P = -D * dr)
+func duration(cfs cashflow.Cashflows, ts term.Structure) float64 {
+	pv := sensitivities(cfs, ts)
+	if pv.Value == 0.0 {
+		return 0.0
+	}
+	return pv.D1 / pv.Value
+}
+
+// convexity returns the convexity of the cash flows (dP/P = -D * dr + 1/2 * C * dr^2)
+func convexity(cfs cashflow.Cashflows, ts term.Structure) float64 {
+	pv := sensitivities(cfs, ts)
+	if pv.Value == 0.0 {
+		return 0.0
+	}
+	return pv.D2 / pv.Value
+}
+
+// averageLife returns the principal weighted average time in years
+func averageLife(t, principal []float64) float64 {
+	life, total := 0.0, 0.0
+	for i := range t {
+		life += t[i] * principal[i]
+		total += principal[i]
+	}
+	if total == 0.0 {
+		return 0.0
+	}
+	return life / total
+}
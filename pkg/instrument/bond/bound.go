@@ -0,0 +1,79 @@
+package bond
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/konimarti/fixedincome/pkg/instrument/option"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Bound is a cap or a floor on the coupon rate of a floating-rate bond
+type Bound struct {
+	// Rate is the strike rate in percent
+	Rate float64
+	// Vola is the lognormal volatility of the forward rates
+	// (or the normal volatility in percent, see Normal)
+	Vola float64
+	// Normal selects the Bachelier model with normal volatilities
+	Normal bool
+}
+
+func (b *Bound) validate() error {
+	if b == nil {
+		return nil
+	}
+	if math.IsNaN(b.Rate) || math.IsInf(b.Rate, 0) {
+		return fmt.Errorf("%w: rate %v of cap or floor is not valid", ErrInvalidBond, b.Rate)
+	}
+	if b.Vola < 0.0 || math.IsNaN(b.Vola) || math.IsInf(b.Vola, 0) {
+		return fmt.Errorf("%w: volatility %v of cap or floor is not valid", ErrInvalidBond, b.Vola)
+	}
+	return nil
+}
+
+// value returns the value per 100 notional of the caplets (or floorlets) on the
+// coupons fixed at the reset dates after the next coupon of the schedule
+func (b *Bound) value(optionType int, s *maturity.Schedule, ts term.Structure) float64 {
+	if b == nil {
+		return 0.0
+	}
+	resets := resets(s)
+	if len(resets) == 0 {
+		return 0.0
+	}
+	c := option.Cap{
+		Type:     optionType,
+		Resets:   resets,
+		Maturity: s.Last(),
+		K:        b.Rate,
+		Vola:     b.Vola,
+		Normal:   b.Normal,
+	}
+	return c.PresentValue(ts)
+}
+
+// option returns the undiscounted value in percent of a caplet (or floorlet)
+// on the rate in percent fixed at the reset time in years
+func (b *Bound) option(optionType int, rate, reset float64) float64 {
+	if b == nil {
+		return 0.0
+	}
+	if b.Normal {
+		return option.Bachelier(optionType, rate, b.Rate, reset, b.Vola)
+	}
+	return option.Black76(optionType, rate, b.Rate, reset, b.Vola)
+}
+
+// resets returns the ascending reset times in years of the coupons that are not
+// fixed yet, i.e. all coupon dates except the maturity
+func resets(s *maturity.Schedule) []float64 {
+	m := s.M()
+	sort.Float64s(m)
+	if len(m) < 2 {
+		return nil
+	}
+	return m[:len(m)-1]
+}
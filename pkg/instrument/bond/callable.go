@@ -0,0 +1,278 @@
+package bond
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/lattice"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Call is a call date of a callable bond with the clean call price per 100
+// of face
+type Call struct {
+	Date  time.Time
+	Price float64
+}
+
+// MakeWhole is a make-whole call at the greater of the floor and the present
+// value of the remaining payments (exclusive of accrued interest) discounted
+// at the treasury curve plus the make-whole spread
+type MakeWhole struct {
+	// Start and End of the make-whole period (default: zero for the
+	// settlement and the maturity date)
+	Start time.Time
+	End   time.Time
+	// Spread is the make-whole spread in bps over the treasury curve
+	Spread float64
+	// Floor is the minimum clean call price per 100 of face
+	// (default: 0 for the redemption value)
+	Floor float64
+}
+
+// EventCall is a call on a special event, e.g. a tax call after a change of
+// the tax law or a clean-up call when most of the bond has been redeemed
+type EventCall struct {
+	// Name identifies the event in scenarios, e.g. "tax" or "clean-up"
+	Name string
+	// Date is the assumed call date if the event occurs
+	Date time.Time
+	// Price is the clean call price per 100 of face
+	Price float64
+}
+
+// Callable is a straight bond with a call schedule, an optional make-whole
+// call and event calls that are included in the yields if their event is
+// assumed; the call schedule can be priced on a lattice (see LatticeValue)
+type Callable struct {
+	Bond      *Straight
+	Calls     []Call
+	MakeWhole *MakeWhole
+	Events    []EventCall
+}
+
+// Yield is the yield of a bond to a redemption date
+type Yield struct {
+	// Event is the redemption event, e.g. "maturity", "call" or "make-whole"
+	Event string
+	Date  time.Time
+	// Price is the clean redemption price per 100 of face
+	Price float64
+	// Yield is the continuously compounded yield in percent
+	Yield float64
+}
+
+// MakeWholePrice returns the clean make-whole call price per 100 of face on
+// the date with the treasury curve as of that date
+func (c *Callable) MakeWholePrice(date time.Time, treasury term.Structure) (float64, error) {
+	if c.MakeWhole == nil {
+		return 0.0, fmt.Errorf("%w: no make-whole call", ErrInvalidBond)
+	}
+	if !date.Before(c.Bond.Maturity) {
+		return 0.0, fmt.Errorf("%w: make-whole call on %s not before maturity %s", ErrInvalidBond,
+			date.Format("2006-01-02"), c.Bond.Maturity.Format("2006-01-02"))
+	}
+	b := *c.Bond
+	b.Settlement, b.Par, b.Factor = date, 0.0, 0.0
+	pv := b.PresentValue(term.WithSpread(treasury, c.MakeWhole.Spread)) - b.Accrued()
+	floor := c.MakeWhole.Floor
+	if floor == 0.0 {
+		floor = b.Redemption
+	}
+	return math.Max(pv, floor), nil
+}
+
+// LatticeValue returns the dirty price of the bond with the call schedule on
+// the Black-Derman-Toy tree calibrated as of the settlement date; the issuer
+// calls the bond at a call date if the value at the node exceeds the call
+// price plus the accrued interest. The make-whole and event calls are not
+// valued. For negative rates, calibrate the tree with lattice.NewShiftedBDT.
+func (c *Callable) LatticeValue(tree *lattice.BDT) (float64, error) {
+	b := c.Bond
+	if err := b.Validate(); err != nil {
+		return 0.0, err
+	}
+	if b.Flat {
+		return 0.0, fmt.Errorf("%w: calls of a bond trading flat cannot be valued on a lattice", ErrInvalidBond)
+	}
+	horizon := tree.Dt * float64(tree.Steps())
+	if _, last := b.redemption(); last > horizon+0.5*tree.Dt {
+		return 0.0, fmt.Errorf("%w: maturity in %v years beyond the lattice of %v years", ErrInvalidBond, last, horizon)
+	}
+	dc, err := b.DayCounter()
+	if err != nil {
+		return 0.0, err
+	}
+	calls := map[int]float64{}
+	for _, call := range c.Calls {
+		if !call.Date.After(b.Settlement) || !call.Date.Before(b.Maturity) {
+			continue
+		}
+		i := int(math.Round(maturity.YearFraction(dc, b.Settlement, call.Date) / tree.Dt))
+		calls[i] = b.outstanding(call.Price) + b.AccruedAt(call.Date)
+	}
+	exercise := func(i, j int, value float64) float64 {
+		if price, ok := calls[i]; ok {
+			return math.Min(value, price)
+		}
+		return value
+	}
+	return tree.Price(b.Cashflows(), exercise), nil
+}
+
+// Yields returns the yields of the dirty price to the call dates, to the
+// coupon dates of the make-whole period (with the make-whole price on the
+// treasury curve), to the event calls of the given events and to the
+// maturity in chronological order
+func (c *Callable) Yields(price float64, treasury term.Structure, events ...string) ([]Yield, error) {
+	b := c.Bond
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	redemptions := []Yield{}
+	for _, call := range c.Calls {
+		redemptions = append(redemptions, Yield{Event: "call", Date: call.Date, Price: call.Price})
+	}
+	for _, name := range events {
+		found := false
+		for _, e := range c.Events {
+			if e.Name == name {
+				redemptions = append(redemptions, Yield{Event: e.Name, Date: e.Date, Price: e.Price})
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: unknown call event %q", ErrInvalidBond, name)
+		}
+	}
+	if mw := c.MakeWhole; mw != nil {
+		end := mw.End
+		if end.IsZero() {
+			end = b.Maturity
+		}
+		for _, date := range b.Dates() {
+			if date.Before(mw.Start) || date.After(end) || !date.Before(b.Maturity) {
+				continue
+			}
+			p, err := c.MakeWholePrice(date, treasury)
+			if err != nil {
+				return nil, err
+			}
+			redemptions = append(redemptions, Yield{Event: "make-whole", Date: date, Price: p})
+		}
+	}
+
+	yields := []Yield{}
+	for _, r := range redemptions {
+		if !r.Date.After(b.Settlement) || !r.Date.Before(b.Maturity) {
+			continue
+		}
+		y, err := fixedincome.Irr(price, c.redeemed(r.Date, r.Price))
+		if err != nil {
+			return nil, fmt.Errorf("yield to %s on %s: %w", r.Event, r.Date.Format("2006-01-02"), err)
+		}
+		r.Yield = y
+		yields = append(yields, r)
+	}
+	ytm, err := fixedincome.Irr(price, b)
+	if err != nil {
+		return nil, fmt.Errorf("yield to maturity: %w", err)
+	}
+	yields = append(yields, Yield{Event: "maturity", Date: b.Maturity, Price: b.Redemption, Yield: ytm})
+	sort.SliceStable(yields, func(i, j int) bool { return yields[i].Date.Before(yields[j].Date) })
+	return yields, nil
+}
+
+// YieldToWorst returns the lowest yield of the dirty price to the call
+// dates, the make-whole period, the event calls of the given events and the
+// maturity
+func (c *Callable) YieldToWorst(price float64, treasury term.Structure, events ...string) (Yield, error) {
+	yields, err := c.Yields(price, treasury, events...)
+	if err != nil {
+		return Yield{}, err
+	}
+	return worst(yields), nil
+}
+
+// Scenario is the yield to worst under the assumed call events
+type Scenario struct {
+	Events []string
+	Worst  Yield
+}
+
+// Scenarios returns the yields to worst without events, with each event
+// alone and with all events
+func (c *Callable) Scenarios(price float64, treasury term.Structure) ([]Scenario, error) {
+	toggles := [][]string{{}}
+	all := []string{}
+	for _, e := range c.Events {
+		toggles = append(toggles, []string{e.Name})
+		all = append(all, e.Name)
+	}
+	if len(c.Events) > 1 {
+		toggles = append(toggles, all)
+	}
+	scenarios := make([]Scenario, len(toggles))
+	for i, events := range toggles {
+		w, err := c.YieldToWorst(price, treasury, events...)
+		if err != nil {
+			return nil, err
+		}
+		scenarios[i] = Scenario{Events: events, Worst: w}
+	}
+	return scenarios, nil
+}
+
+// Range returns the best and the worst case of the yields to worst of the scenarios
+func (c *Callable) Range(price float64, treasury term.Structure) (Scenario, Scenario, error) {
+	scenarios, err := c.Scenarios(price, treasury)
+	if err != nil {
+		return Scenario{}, Scenario{}, err
+	}
+	best, worst := scenarios[0], scenarios[0]
+	for _, s := range scenarios[1:] {
+		if s.Worst.Yield > best.Worst.Yield {
+			best = s
+		}
+		if s.Worst.Yield < worst.Worst.Yield {
+			worst = s
+		}
+	}
+	return best, worst, nil
+}
+
+// worst returns the lowest yield
+func worst(yields []Yield) Yield {
+	w := yields[0]
+	for _, y := range yields[1:] {
+		if y.Yield < w.Yield {
+			w = y
+		}
+	}
+	return w
+}
+
+// redeemed returns the cash flows of the bond redeemed on the date at the
+// clean price per 100 of face plus the accrued interest
+func (c *Callable) redeemed(date time.Time, price float64) cashflow.Cashflows {
+	b := c.Bond
+	cfs := cashflow.Cashflows{}
+	for _, cf := range b.Cashflows() {
+		if !cf.Date.After(date) {
+			cfs = append(cfs, cf)
+		}
+	}
+	dc, err := b.DayCounter()
+	if err != nil {
+		return cfs
+	}
+	amount := b.outstanding(price) + b.AccruedAt(date)
+	cfs = append(cfs, cashflow.Cashflow{Date: date, T: maturity.YearFraction(dc, b.Settlement, date), Amount: amount})
+	cfs.Sort()
+	return cfs
+}
@@ -0,0 +1,267 @@
+package bond_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/lattice"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func callable() *bond.Callable {
+	return &bond.Callable{
+		Bond: &bond.Straight{
+			Schedule: maturity.Schedule{
+				Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+				Maturity:   time.Date(2028, 6, 15, 0, 0, 0, 0, time.UTC),
+				Frequency:  1,
+				Basis:      "30E360",
+			},
+			Coupon:     4.0,
+			Redemption: 100.0,
+		},
+		Calls: []bond.Call{
+			{Date: time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC), Price: 101.0},
+			{Date: time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC), Price: 100.0},
+		},
+	}
+}
+
+func TestCallable_YieldToWorst(t *testing.T) {
+	c := callable()
+	treasury := term.Flat{R: 1.0}
+
+	var testData = []struct {
+		Clean    float64
+		Event    string
+		Expected time.Time
+	}{
+		// a premium bond is called at the first call date (the call premium
+		// is smaller than the coupon pickup to the par call)
+		{108.0, "call", time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)},
+		// a discount bond is not called
+		{95.0, "maturity", c.Bond.Maturity},
+	}
+	for _, test := range testData {
+		price := test.Clean + c.Bond.Accrued()
+		yields, err := c.Yields(price, &treasury)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(yields) != 3 {
+			t.Fatalf("wrong number of yields; got: %v, expected: %v", len(yields), 3)
+		}
+		ytw, err := c.YieldToWorst(price, &treasury)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ytw.Event != test.Event || !ytw.Date.Equal(test.Expected) {
+			t.Errorf("wrong yield to worst for %v; got: %v on %v, expected: %v on %v", test.Clean, ytw.Event, ytw.Date, test.Event, test.Expected)
+		}
+		for _, y := range yields {
+			if y.Yield < ytw.Yield {
+				t.Errorf("wrong yield to worst for %v; got: %v, expected below: %v", test.Clean, ytw.Yield, y.Yield)
+			}
+		}
+		ytm, _ := fixedincome.Irr(price, c.Bond)
+		if last := yields[len(yields)-1]; last.Event != "maturity" || math.Abs(last.Yield-ytm) > 1e-9 {
+			t.Errorf("wrong yield to maturity; got: %v, expected: %v", last.Yield, ytm)
+		}
+	}
+
+	// the yield to the first call reprices to the call price
+	price := 108.0 + c.Bond.Accrued()
+	yields, _ := c.Yields(price, &treasury)
+	y := yields[0].Yield * 0.01
+	dc, _ := maturity.Lookup("30E360")
+	value := 0.0
+	for k, date := range []time.Time{
+		time.Date(2021, 6, 15, 0, 0, 0, 0, time.UTC), time.Date(2022, 6, 15, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC), time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC),
+	} {
+		amount := 4.0
+		if k == 4 {
+			amount += 101.0
+		}
+		value += amount * math.Exp(-y*maturity.YearFraction(dc, c.Bond.Settlement, date))
+	}
+	if math.Abs(value-price) > 1e-4 {
+		t.Errorf("wrong yield to call; got: %v, expected: %v", value, price)
+	}
+}
+
+func TestCallable_MakeWhole(t *testing.T) {
+	c := callable()
+	c.Calls = nil
+	c.MakeWhole = &bond.MakeWhole{
+		Start:  time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:    time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC),
+		Spread: 25.0,
+	}
+	date := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	// low treasury rates: present value at treasury plus 25 bps
+	treasury := term.Flat{R: 1.0}
+	p, err := c.MakeWholePrice(date, &treasury)
+	if err != nil {
+		t.Fatal(err)
+	}
+	remaining := *c.Bond
+	remaining.Settlement = date
+	expected := remaining.PresentValue(&term.Flat{R: 1.0, Spread: 25.0}) - remaining.Accrued()
+	if math.Abs(p-expected) > 1e-9 || p <= 100.0 {
+		t.Errorf("wrong make-whole price; got: %v, expected: %v", p, expected)
+	}
+
+	// high treasury rates: floored at the redemption value
+	if p, _ := c.MakeWholePrice(date, &term.Flat{R: 8.0}); p != 100.0 {
+		t.Errorf("wrong floored make-whole price; got: %v, expected: %v", p, 100.0)
+	}
+
+	// make-whole calls on the coupon dates up to the end of the period
+	yields, err := c.Yields(112.0+c.Bond.Accrued(), &treasury)
+	if err != nil {
+		t.Fatal(err)
+	}
+	makeWhole := 0
+	for _, y := range yields {
+		if y.Event == "make-whole" {
+			makeWhole += 1
+			if y.Date.Before(c.MakeWhole.Start) || y.Date.After(c.MakeWhole.End) || y.Price < 100.0 {
+				t.Errorf("wrong make-whole call; got: %v at %v", y.Date, y.Price)
+			}
+		}
+	}
+	// coupon dates from 2023 to 2026
+	if makeWhole != 4 {
+		t.Errorf("wrong number of make-whole calls; got: %v, expected: %v", makeWhole, 4)
+	}
+
+	c.MakeWhole = nil
+	if _, err := c.MakeWholePrice(date, &treasury); !errors.Is(err, bond.ErrInvalidBond) {
+		t.Errorf("wrong error without make-whole call; got: %v, expected: %v", err, bond.ErrInvalidBond)
+	}
+}
+
+func TestCallable_Events(t *testing.T) {
+	c := callable()
+	c.Events = []bond.EventCall{
+		{Name: "tax", Date: time.Date(2022, 6, 15, 0, 0, 0, 0, time.UTC), Price: 100.0},
+		{Name: "clean-up", Date: time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC), Price: 100.0},
+	}
+	treasury := term.Flat{R: 1.0}
+	price := 108.0 + c.Bond.Accrued()
+
+	var testData = []struct {
+		Events   []string
+		Event    string
+		Expected time.Time
+	}{
+		{nil, "call", time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)},
+		{[]string{"clean-up"}, "clean-up", time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)},
+		{[]string{"tax", "clean-up"}, "tax", time.Date(2022, 6, 15, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range testData {
+		ytw, err := c.YieldToWorst(price, &treasury, test.Events...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ytw.Event != test.Event || !ytw.Date.Equal(test.Expected) {
+			t.Errorf("wrong yield to worst for %v; got: %v on %v, expected: %v on %v", test.Events, ytw.Event, ytw.Date, test.Event, test.Expected)
+		}
+	}
+
+	scenarios, err := c.Scenarios(price, &treasury)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// no events, each event alone and all events
+	if len(scenarios) != 4 {
+		t.Fatalf("wrong number of scenarios; got: %v, expected: %v", len(scenarios), 4)
+	}
+	best, worst, err := c.Range(price, &treasury)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(best.Events) != 0 || best.Worst.Event != "call" {
+		t.Errorf("wrong best case; got: %v, expected: %v", best.Events, "no events")
+	}
+	if worst.Worst.Event != "tax" || worst.Worst.Yield >= best.Worst.Yield {
+		t.Errorf("wrong worst case; got: %v (%v), expected: %v", worst.Events, worst.Worst.Yield, "tax")
+	}
+
+	if _, err := c.Yields(price, &treasury, "regulatory"); !errors.Is(err, bond.ErrInvalidBond) {
+		t.Errorf("wrong error for unknown event; got: %v, expected: %v", err, bond.ErrInvalidBond)
+	}
+}
+
+func TestCallable_LatticeValue(t *testing.T) {
+	// settlement on a coupon date, so the cash flows fall on the quarterly steps
+	c := callable()
+	c.Bond.Settlement = time.Date(2021, 6, 15, 0, 0, 0, 0, time.UTC)
+	ts := term.Flat{R: 3.0}
+	tree, err := lattice.NewBDT(&ts, lattice.FlatVol(0.2), 7.0, 28)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// without calls the tree reproduces the straight bond
+	straight := *c
+	straight.Calls = nil
+	pv, err := straight.LatticeValue(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(pv-c.Bond.PresentValue(&ts)) > 1e-6 {
+		t.Errorf("wrong straight bond price on the lattice; got: %v, expected: %v", pv, c.Bond.PresentValue(&ts))
+	}
+
+	// the holder is short the calls
+	value, err := c.LatticeValue(tree)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value >= pv || value < pv-10.0 {
+		t.Errorf("wrong callable bond price; got: %v, straight: %v", value, pv)
+	}
+
+	// calls far out of the money are worthless
+	c.Calls = []bond.Call{{Date: time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC), Price: 200.0}}
+	if value, err := c.LatticeValue(tree); err != nil || math.Abs(value-pv) > 1e-9 {
+		t.Errorf("wrong value with out-of-the-money call; got: %v (%v), expected: %v", value, err, pv)
+	}
+
+	// the lattice must cover the maturity
+	short, err := lattice.NewBDT(&ts, lattice.FlatVol(0.2), 5.0, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.LatticeValue(short); !errors.Is(err, bond.ErrInvalidBond) {
+		t.Errorf("expected ErrInvalidBond for a short lattice; got: %v", err)
+	}
+	// negative CHF rates on the shifted lognormal tree
+	c = callable()
+	c.Bond.Settlement = time.Date(2021, 6, 15, 0, 0, 0, 0, time.UTC)
+	chf := term.NelsonSiegelSvensson{B0: -0.266372, B1: -0.471343, B2: 5.68789, B3: -5.12324, T1: 5.74881, T2: 4.14426}
+	if _, err := lattice.NewBDT(&chf, lattice.FlatVol(0.2), 7.0, 28); !errors.Is(err, lattice.ErrCalibration) {
+		t.Errorf("expected ErrCalibration for negative rates without a shift; got: %v", err)
+	}
+	shifted, err := lattice.NewShiftedBDT(&chf, lattice.FlatVol(0.2), 7.0, 28, 2.0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	straight = *c
+	straight.Calls = nil
+	if pv, err = straight.LatticeValue(shifted); err != nil || math.Abs(pv-c.Bond.PresentValue(&chf)) > 1e-6 {
+		t.Errorf("wrong straight bond price on the shifted lattice; got: %v (%v), expected: %v", pv, err, c.Bond.PresentValue(&chf))
+	}
+	if value, err := c.LatticeValue(shifted); err != nil || value >= pv {
+		t.Errorf("wrong callable bond price on the shifted lattice; got: %v (%v), straight: %v", value, err, pv)
+	}
+}
@@ -0,0 +1,94 @@
+package bond_test
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/calendar"
+	"github.com/konimarti/fixedincome/pkg/index"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/rounding"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// TestConcurrentReads checks (with go test -race) that one instrument can be
+// priced from several goroutines
+func TestConcurrentReads(t *testing.T) {
+	ts := term.NelsonSiegelSvensson{B0: 4.0, B1: -2.0, B2: 1.0, B3: 0.0, T1: 2.0, T2: 2.0}
+	settlement := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	straight := &bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: settlement,
+			Maturity:   time.Date(2031, 3, 7, 0, 0, 0, 0, time.UTC),
+			Issue:      time.Date(2020, 11, 20, 0, 0, 0, 0, time.UTC),
+			Frequency:  2,
+			Basis:      "ACTACT",
+		},
+		Coupon:           1.125,
+		Redemption:       100.0,
+		ExDividend:       7,
+		Calendar:         calendar.New(time.Date(2021, 3, 5, 0, 0, 0, 0, time.UTC)),
+		MaturityAdjusted: true,
+		CouponRounding:   &rounding.Coupon{Face: 1000.0, Policy: rounding.Policy{Decimals: 2, Mode: rounding.HalfUp}},
+	}
+	floating := &bond.Floating{
+		Schedule: maturity.Schedule{
+			Settlement: settlement,
+			Maturity:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			Frequency:  4,
+			Basis:      "ACT360",
+		},
+		Rate:       2.5,
+		Redemption: 100.0,
+		Cap:        &bond.Bound{Rate: 3.0, Vola: 0.2},
+		Index:      &index.Forward{Curve: &ts, Settlement: settlement, Tenor: 3},
+	}
+
+	securities := []interface {
+		fixedincome.TermSecurity
+		Accrued() float64
+	}{straight, floating}
+	for _, s := range securities {
+		// reference values
+		pv := s.PresentValue(&ts)
+		accrued := s.Accrued()
+		duration := s.Duration(&ts)
+		yield, err := fixedincome.Irr(pv, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var wg sync.WaitGroup
+		errs := make(chan string, 8)
+		for g := 0; g < 8; g += 1 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if s.PresentValue(&ts) != pv || s.Accrued() != accrued || s.Duration(&ts) != duration {
+					errs <- "shared instrument gives different values"
+					return
+				}
+				if y, err := fixedincome.Irr(pv, s); err != nil || math.Abs(y-yield) > 1e-10 {
+					errs <- "shared instrument gives a different yield"
+					return
+				}
+				if later := straight.PresentValueAt(settlement.AddDate(0, 1, 0), &ts); math.IsNaN(later) {
+					errs <- "no value at a later settlement date"
+				}
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for e := range errs {
+			t.Errorf("%T: %s", s, e)
+			break
+		}
+	}
+	if !straight.Settlement.Equal(settlement) {
+		t.Errorf("shared bond was modified; got settlement: %v", straight.Settlement)
+	}
+}
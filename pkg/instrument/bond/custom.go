@@ -0,0 +1,169 @@
+package bond
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Payment is a contractual payment of a custom bond per 100 of face
+type Payment struct {
+	Date      time.Time
+	Interest  float64
+	Principal float64
+}
+
+// Custom represents a bond with explicit (irregular) payments for structures
+// that are not modelled otherwise. The interest accrues linearly (with the
+// day count convention of the basis) between the payment dates.
+type Custom struct {
+	// Settlement is the date of valuation (or settlement)
+	Settlement time.Time
+	// Issue is the date from which interest accrues until the first payment
+	// (default: zero for no accrued interest in the first period)
+	Issue time.Time
+	// Payments are the payments from the issue to the maturity
+	Payments []Payment
+	// Basis is the day count convention (default: "" for 30E/360 ISDA)
+	Basis string
+	// Par is the notional base of prices, accrued interest and cash flows
+	// (e.g. 1 for unit notionals; default: 0 for per 100 of par)
+	Par float64
+	// Metadata describes the bond for reporting (default: nil for none)
+	Metadata *fixedincome.Metadata
+}
+
+// ParValue returns the notional base of prices, accrued interest and cash flows
+func (c *Custom) ParValue() float64 {
+	return parValue(c.Par)
+}
+
+// Validate checks the payments and the day count convention
+func (c *Custom) Validate() error {
+	if _, err := maturity.Lookup(c.Basis); err != nil {
+		return err
+	}
+	if c.Par < 0.0 || math.IsNaN(c.Par) || math.IsInf(c.Par, 0) {
+		return fmt.Errorf("%w: par value %v is not valid", ErrInvalidBond, c.Par)
+	}
+	outstanding := false
+	for _, p := range c.Payments {
+		if p.Date.IsZero() {
+			return fmt.Errorf("%w: payment date is missing", ErrInvalidBond)
+		}
+		for _, amount := range []float64{p.Interest, p.Principal} {
+			if math.IsNaN(amount) || math.IsInf(amount, 0) {
+				return fmt.Errorf("%w: payment %v on %s is not valid", ErrInvalidBond, amount, p.Date.Format("2006-01-02"))
+			}
+		}
+		outstanding = outstanding || p.Date.After(c.Settlement)
+	}
+	if !outstanding {
+		return fmt.Errorf("%w: no payments after settlement date %s", ErrInvalidBond, c.Settlement.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// payments returns the payments ordered by date
+func (c *Custom) payments() []Payment {
+	payments := append([]Payment(nil), c.Payments...)
+	sort.SliceStable(payments, func(i, j int) bool { return payments[i].Date.Before(payments[j].Date) })
+	return payments
+}
+
+// Cashflows returns the outstanding payments ordered by payment date
+func (c *Custom) Cashflows() cashflow.Cashflows {
+	cfs := cashflow.Cashflows{}
+	dc, err := maturity.Lookup(c.Basis)
+	if err != nil {
+		return cfs
+	}
+	for _, p := range c.payments() {
+		if !p.Date.After(c.Settlement) {
+			continue
+		}
+		t := maturity.YearFraction(dc, c.Settlement, p.Date)
+		cfs = append(cfs, cashflow.Cashflow{Date: p.Date, T: t, Amount: scale(c.Par, p.Interest+p.Principal)})
+	}
+	return cfs
+}
+
+// Accrued calculates the accrued interest of the next interest payment
+// since the previous payment date (or the issue date)
+func (c *Custom) Accrued() float64 {
+	dc, err := maturity.Lookup(c.Basis)
+	if err != nil {
+		return 0.0
+	}
+	previous := c.Issue
+	for _, p := range c.payments() {
+		if !p.Date.After(c.Settlement) {
+			previous = p.Date
+			continue
+		}
+		if previous.IsZero() || p.Interest == 0.0 {
+			return 0.0
+		}
+		period := dc.Days(previous, p.Date)
+		if period <= 0.0 {
+			return 0.0
+		}
+		return scale(c.Par, p.Interest*dc.Days(previous, c.Settlement)/period)
+	}
+	return 0.0
+}
+
+// PresentValue returns the "dirty" bond price
+func (c *Custom) PresentValue(ts term.Structure) float64 {
+	return c.Cashflows().PresentValue(ts)
+}
+
+// PresentValueAt returns the "dirty" bond price for the given settlement date
+// without modifying the bond
+func (c *Custom) PresentValueAt(settlement time.Time, ts term.Structure) float64 {
+	b := *c
+	b.Settlement = settlement
+	return b.PresentValue(ts)
+}
+
+// AccruedAt returns the accrued interest for the given settlement date
+// without modifying the bond
+func (c *Custom) AccruedAt(settlement time.Time) float64 {
+	b := *c
+	b.Settlement = settlement
+	return b.Accrued()
+}
+
+// WAL returns the weighted average life in years of the outstanding principal payments
+func (c *Custom) WAL() float64 {
+	dc, err := maturity.Lookup(c.Basis)
+	if err != nil {
+		return 0.0
+	}
+	t, principal := []float64{}, []float64{}
+	for _, p := range c.payments() {
+		if p.Date.After(c.Settlement) {
+			t = append(t, maturity.YearFraction(dc, c.Settlement, p.Date))
+			principal = append(principal, p.Principal)
+		}
+	}
+	return averageLife(t, principal)
+}
+
+// Duration calculates the duration of the bond
+// dP/P = -D * dr
+func (c *Custom) Duration(ts term.Structure) float64 {
+	return duration(c.Cashflows(), ts)
+}
+
+// Convexity calculates the convexity of the bond
+// dP/P = -D * dr + 1/2 * C * dr^2
+func (c *Custom) Convexity(ts term.Structure) float64 {
+	return convexity(c.Cashflows(), ts)
+}
@@ -0,0 +1,90 @@
+package bond_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestCustom(t *testing.T) {
+	settlement := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	straight := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: settlement,
+			Maturity:   time.Date(2024, 5, 28, 0, 0, 0, 0, time.UTC),
+			Issue:      time.Date(2020, 5, 28, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+			Basis:      "30E360",
+		},
+		Coupon:     2.0,
+		Redemption: 100.0,
+	}
+
+	// the straight bond with explicit payments
+	custom := bond.Custom{
+		Settlement: settlement,
+		Issue:      straight.Issue,
+		Basis:      "30E360",
+	}
+	for y := 2024; y >= 2021; y -= 1 {
+		p := bond.Payment{Date: time.Date(y, 5, 28, 0, 0, 0, 0, time.UTC), Interest: 2.0}
+		if y == 2024 {
+			p.Principal = 100.0
+		}
+		custom.Payments = append(custom.Payments, p)
+	}
+	if err := custom.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	ts := term.NelsonSiegelSvensson{B0: -0.266372, B1: -0.471343, B2: 5.68789, B3: -5.12324, T1: 5.74881, T2: 4.14426}
+	var testData = []struct {
+		Name     string
+		Value    float64
+		Expected float64
+	}{
+		{"present value", custom.PresentValue(&ts), straight.PresentValue(&ts)},
+		{"accrued interest", custom.Accrued(), straight.Accrued()},
+		{"duration", custom.Duration(&ts), straight.Duration(&ts)},
+		{"convexity", custom.Convexity(&ts), straight.Convexity(&ts)},
+		{"weighted average life", custom.WAL(), straight.WAL()},
+	}
+	for _, test := range testData {
+		if math.Abs(test.Value-test.Expected) > 1e-10 {
+			t.Errorf("wrong %s; got: %v, expected: %v", test.Name, test.Value, test.Expected)
+		}
+	}
+
+	dirty := 103.0
+	irr, err := fixedincome.Irr(dirty, &custom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, _ := fixedincome.Irr(dirty, &straight)
+	if math.Abs(irr-expected) > 1e-6 {
+		t.Errorf("wrong yield; got: %v, expected: %v", irr, expected)
+	}
+	spread, err := fixedincome.Spread(dirty, &custom, &ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected, _ := fixedincome.Spread(dirty, &straight, &ts); math.Abs(spread-expected) > 1e-4 {
+		t.Errorf("wrong spread; got: %v, expected: %v", spread, expected)
+	}
+
+	// the payment on the settlement date is not outstanding
+	if cfs := custom.AccruedAt(time.Date(2021, 5, 28, 0, 0, 0, 0, time.UTC)); cfs != 0.0 {
+		t.Errorf("wrong accrued interest on the payment date; got: %v, expected: %v", cfs, 0.0)
+	}
+
+	custom.Settlement = time.Date(2024, 5, 28, 0, 0, 0, 0, time.UTC)
+	if err := custom.Validate(); !errors.Is(err, bond.ErrInvalidBond) {
+		t.Errorf("expected ErrInvalidBond without outstanding payments; got: %v", err)
+	}
+}
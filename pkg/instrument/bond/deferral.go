@@ -0,0 +1,83 @@
+package bond
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+)
+
+// Deferral is an assumed deferral of the coupons of a hybrid bond for a
+// scenario, e.g. an optional deferral by the issuer or a mandatory deferral
+// after the breach of a capital ratio. Both result in the same cash flows;
+// the terms of the bond for the deferral decide whether the coupons are
+// Cumulative and Compounding.
+type Deferral struct {
+	// Start and End of the deferral; the coupons on the dates from Start up
+	// to and including End are not paid
+	Start time.Time
+	End   time.Time
+	// Cumulative pays the deferred coupons (arrears) with the first coupon
+	// after the deferral or at maturity; non-cumulative coupons are lost
+	Cumulative bool
+	// Compounding accrues interest at the coupon rate on the arrears of
+	// cumulative deferrals
+	Compounding bool
+}
+
+// DeferredCashflows returns the cash flows of the bond under the coupon deferral
+func (b *Straight) DeferredCashflows(d Deferral) (cashflow.Cashflows, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	if b.Flat {
+		return nil, fmt.Errorf("%w: coupons of a bond trading flat cannot be deferred", ErrInvalidBond)
+	}
+	if d.End.Before(d.Start) {
+		return nil, fmt.Errorf("%w: deferral end %s before start %s", ErrInvalidBond, d.End.Format("2006-01-02"), d.Start.Format("2006-01-02"))
+	}
+	coupon := b.outstanding(b.periodCoupon())
+	growth := 1.0 + b.periodCoupon()/100.0
+
+	cfs := b.Cashflows()
+	arrears := 0.0
+	for i := range cfs {
+		cf := &cfs[i]
+		deferred := !cf.Date.Before(d.Start) && !cf.Date.After(d.End)
+		if d.Compounding {
+			arrears *= growth
+		}
+		switch {
+		case deferred && i == 0 && b.IsExDividend():
+			// the next coupon is paid to the seller
+		case deferred:
+			amount := coupon
+			if i == 0 {
+				amount = b.outstanding(b.nextPeriodCoupon())
+			}
+			cf.Amount -= amount
+			if d.Cumulative {
+				arrears += amount
+			}
+		case arrears > 0.0:
+			cf.Amount += arrears
+			arrears = 0.0
+		}
+	}
+	// arrears of a deferral up to maturity are paid with the redemption
+	if n := len(cfs); n > 0 && arrears > 0.0 {
+		cfs[n-1].Amount += arrears
+	}
+	return cfs, nil
+}
+
+// DeferredYield returns the yield of the dirty price in percent under the
+// coupon deferral
+func (b *Straight) DeferredYield(price float64, d Deferral) (float64, error) {
+	cfs, err := b.DeferredCashflows(d)
+	if err != nil {
+		return 0.0, err
+	}
+	return fixedincome.Irr(price, cfs)
+}
@@ -0,0 +1,75 @@
+package bond_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+)
+
+func TestStraight_DeferredCashflows(t *testing.T) {
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+			Basis:      "30E360",
+		},
+		Coupon:     5.0,
+		Redemption: 100.0,
+	}
+	// coupons of 2022 and 2023 deferred
+	d := bond.Deferral{Start: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)}
+	cumulative, compounding := d, d
+	cumulative.Cumulative = true
+	compounding.Cumulative, compounding.Compounding = true, true
+	// deferred up to maturity
+	maturing := bond.Deferral{Start: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), End: b.Maturity, Cumulative: true}
+
+	var testData = []struct {
+		Name     string
+		Deferral bond.Deferral
+		Expected []float64
+	}{
+		{"non-cumulative", d, []float64{5.0, 0.0, 0.0, 5.0, 5.0, 105.0}},
+		{"cumulative", cumulative, []float64{5.0, 0.0, 0.0, 15.0, 5.0, 105.0}},
+		{"compounding", compounding, []float64{5.0, 0.0, 0.0, 5.0 + 5.0*1.05*1.05 + 5.0*1.05, 5.0, 105.0}},
+		{"to maturity", maturing, []float64{5.0, 5.0, 5.0, 5.0, 0.0, 110.0}},
+		{"none", bond.Deferral{}, []float64{5.0, 5.0, 5.0, 5.0, 5.0, 105.0}},
+	}
+	for _, test := range testData {
+		cfs, err := b.DeferredCashflows(test.Deferral)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cfs) != len(test.Expected) {
+			t.Fatalf("wrong number of cash flows for %s; got: %v, expected: %v", test.Name, len(cfs), len(test.Expected))
+		}
+		for i, cf := range cfs {
+			if math.Abs(cf.Amount-test.Expected[i]) > 1e-9 {
+				t.Errorf("wrong %s cash flow on %v; got: %v, expected: %v", test.Name, cf.Date, cf.Amount, test.Expected[i])
+			}
+		}
+	}
+
+	// yields under the deferral assumptions
+	price := 102.0 + b.Accrued()
+	ytm, _ := fixedincome.Irr(price, &b)
+	lost, err := b.DeferredYield(price, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	arrears, _ := b.DeferredYield(price, cumulative)
+	compounded, _ := b.DeferredYield(price, compounding)
+	if !(lost < arrears && arrears < ytm && ytm < compounded) {
+		t.Errorf("wrong deferral yields; got: %v (non-cumulative), %v (cumulative), %v (compounding), expected around: %v", lost, arrears, compounded, ytm)
+	}
+
+	if _, err := b.DeferredCashflows(bond.Deferral{Start: b.Maturity, End: b.Settlement}); !errors.Is(err, bond.ErrInvalidBond) {
+		t.Errorf("wrong error for invalid deferral; got: %v, expected: %v", err, bond.ErrInvalidBond)
+	}
+}
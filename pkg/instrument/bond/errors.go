@@ -0,0 +1,6 @@
+package bond
+
+import "errors"
+
+// ErrInvalidBond is returned for bonds with invalid terms (e.g. negative redemption value)
+var ErrInvalidBond = errors.New("invalid bond")
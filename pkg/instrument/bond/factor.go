@@ -0,0 +1,37 @@
+package bond
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+)
+
+// Redeem records the partial redemption (e.g. a partial call or a pool
+// paydown) of the amount per 100 of original face on the date and reduces the
+// factor of the bond; it returns the redemption payment at the redemption
+// value with the time T in years from the settlement date
+func (b *Straight) Redeem(date time.Time, amount float64) (cashflow.Cashflow, error) {
+	factor := b.OutstandingFactor()
+	// the full redemption of the outstanding face is a call of the bond
+	if amount <= 0.0 || math.IsNaN(amount) || amount >= 100.0*factor {
+		return cashflow.Cashflow{}, fmt.Errorf("%w: partial redemption of %v with %v outstanding", ErrInvalidBond, amount, 100.0*factor)
+	}
+	if !date.Before(b.Maturity) {
+		return cashflow.Cashflow{}, fmt.Errorf("%w: partial redemption on %s not before maturity %s", ErrInvalidBond,
+			date.Format("2006-01-02"), b.Maturity.Format("2006-01-02"))
+	}
+	dc, err := b.DayCounter()
+	if err != nil {
+		return cashflow.Cashflow{}, err
+	}
+
+	t := maturity.YearFraction(dc, b.Settlement, date)
+	if date.Before(b.Settlement) {
+		t = -maturity.YearFraction(dc, date, b.Settlement)
+	}
+	b.Factor = factor - amount/100.0
+	return cashflow.Cashflow{Date: date, T: t, Amount: scale(b.Par, amount*b.Redemption/100.0)}, nil
+}
@@ -0,0 +1,100 @@
+package bond_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/rounding"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestStraight_Factor(t *testing.T) {
+	full := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2026, 5, 15, 0, 0, 0, 0, time.UTC),
+			Frequency:  2,
+			Basis:      "30E360",
+		},
+		Coupon:     3.0,
+		Redemption: 100.0,
+		Par:        1000.0,
+	}
+	factored := full
+	factored.Factor = 0.6
+	ts := term.Flat{R: 1.5}
+
+	var testData = []struct {
+		Name     string
+		Value    float64
+		Expected float64
+	}{
+		{"present value", factored.PresentValue(&ts), 0.6 * full.PresentValue(&ts)},
+		{"accrued", factored.Accrued(), 0.6 * full.Accrued()},
+		{"duration", factored.Duration(&ts), full.Duration(&ts)},
+		{"convexity", factored.Convexity(&ts), full.Convexity(&ts)},
+	}
+	for _, test := range testData {
+		if math.Abs(test.Value-test.Expected) > 1e-9 {
+			t.Errorf("wrong %s with factor; got: %v, expected: %v", test.Name, test.Value, test.Expected)
+		}
+	}
+	// the accrued amount on the outstanding nominal
+	if a, e := factored.AccruedBig(1e6, rounding.ForCurrency("CHF").RoundBig).Text('f', 2), full.AccruedBig(6e5, rounding.ForCurrency("CHF").RoundBig).Text('f', 2); a != e {
+		t.Errorf("wrong accrued amount with factor; got: %s, expected: %s", a, e)
+	}
+	cfs, expected := factored.Cashflows(), full.Cashflows()
+	for i := range cfs {
+		if math.Abs(cfs[i].Amount-0.6*expected[i].Amount) > 1e-9 {
+			t.Errorf("wrong cash flow at %v; got: %v, expected: %v", cfs[i].Date, cfs[i].Amount, 0.6*expected[i].Amount)
+		}
+	}
+
+	// a partial call of 20 of the original face
+	cf, err := factored.Redeem(time.Date(2021, 5, 15, 0, 0, 0, 0, time.UTC), 20.0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(factored.OutstandingFactor()-0.4) > 1e-12 || cf.Amount != 200.0 {
+		t.Errorf("wrong partial redemption; got: %v (factor %v), expected: %v (factor %v)", cf.Amount, factored.OutstandingFactor(), 200.0, 0.4)
+	}
+	if math.Abs(factored.PresentValue(&ts)-0.4*full.PresentValue(&ts)) > 1e-9 {
+		t.Errorf("wrong present value after partial redemption; got: %v, expected: %v", factored.PresentValue(&ts), 0.4*full.PresentValue(&ts))
+	}
+	if full.OutstandingFactor() != 1.0 {
+		t.Errorf("wrong default factor; got: %v, expected: %v", full.OutstandingFactor(), 1.0)
+	}
+}
+
+func TestStraight_FactorErrors(t *testing.T) {
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2026, 5, 15, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+		},
+		Redemption: 100.0,
+		Factor:     0.5,
+	}
+	var testData = []struct {
+		Date   time.Time
+		Amount float64
+	}{
+		{b.Settlement, 50.0},
+		{b.Settlement, -1.0},
+		{b.Maturity, 10.0},
+	}
+	for _, test := range testData {
+		if _, err := b.Redeem(test.Date, test.Amount); !errors.Is(err, bond.ErrInvalidBond) {
+			t.Errorf("wrong error for %v; got: %v, expected: %v", test.Amount, err, bond.ErrInvalidBond)
+		}
+	}
+	b.Factor = 1.5
+	if err := b.Validate(); !errors.Is(err, bond.ErrInvalidBond) {
+		t.Errorf("wrong error for factor %v; got: %v, expected: %v", b.Factor, err, bond.ErrInvalidBond)
+	}
+}
@@ -0,0 +1,35 @@
+package bond
+
+import (
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+)
+
+// Recovery is an expected recovery payment of a bond that trades flat (e.g. after a default)
+type Recovery struct {
+	// Date is the expected payment date
+	Date time.Time
+	// Amount is the expected payment per 100 of face
+	Amount float64
+}
+
+// recoveries returns the outstanding expected recovery payments ordered by payment date
+func (b *Straight) recoveries() cashflow.Cashflows {
+	cfs := cashflow.Cashflows{}
+	dc, err := b.DayCounter()
+	if err != nil {
+		return cfs
+	}
+	quote := b.Settlement
+	for _, r := range b.Recovery {
+		if !r.Date.After(quote) {
+			continue
+		}
+		t := maturity.YearFraction(dc, quote, r.Date)
+		cfs = append(cfs, cashflow.Cashflow{Date: r.Date, T: t, Amount: b.outstanding(r.Amount)})
+	}
+	cfs.Sort()
+	return cfs
+}
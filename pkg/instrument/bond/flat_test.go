@@ -0,0 +1,73 @@
+package bond_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/rounding"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestStraight_Flat(t *testing.T) {
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2026, 5, 28, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+		},
+		Coupon:     5.0,
+		Redemption: 100.0,
+		Flat:       true,
+		Recovery: []bond.Recovery{
+			{Date: time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC), Amount: 10.0}, // already paid
+			{Date: time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC), Amount: 15.0},
+			{Date: time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC), Amount: 25.0},
+		},
+	}
+	if err := b.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	ts := term.Flat{R: 10.0}
+
+	if a := b.Accrued(); a != 0.0 {
+		t.Errorf("bond trading flat has accrued interest; got: %v", a)
+	}
+	if a, _ := b.AccruedBig(1e6, rounding.ForCurrency("CHF").RoundBig).Float64(); a != 0.0 {
+		t.Errorf("bond trading flat has accrued interest; got: %v", a)
+	}
+
+	cfs := b.Cashflows()
+	if len(cfs) != 2 || cfs[0].Amount != 25.0 || cfs[1].Amount != 15.0 {
+		t.Fatalf("wrong recovery cash flows; got: %v", cfs)
+	}
+
+	expected := 25.0*math.Exp(-0.1) + 15.0*math.Exp(-0.2)
+	if pv := b.PresentValue(&ts); math.Abs(pv-expected) > 1e-10 {
+		t.Errorf("wrong present value; got: %v, expected: %v", pv, expected)
+	}
+	if wal := b.WAL(); math.Abs(wal-(25.0+2.0*15.0)/40.0) > 1e-12 {
+		t.Errorf("wrong weighted average life; got: %v, expected: %v", wal, (25.0+2.0*15.0)/40.0)
+	}
+	if d := b.Duration(&ts); d >= -1.0 || d <= -2.0 {
+		t.Errorf("wrong duration; got: %v", d)
+	}
+
+	// recovery yield
+	irr, err := fixedincome.Irr(expected, &b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(irr-10.0) > 1e-4 {
+		t.Errorf("wrong recovery yield; got: %v, expected: %v", irr, 10.0)
+	}
+
+	// same bond without the flag
+	b.Flat = false
+	if a := b.Accrued(); a == 0.0 {
+		t.Errorf("expected accrued interest for a performing bond")
+	}
+}
@@ -1,6 +1,14 @@
 package bond
 
 import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/index"
+	"github.com/konimarti/fixedincome/pkg/instrument/option"
 	"github.com/konimarti/fixedincome/pkg/maturity"
 	"github.com/konimarti/fixedincome/pkg/term"
 )
@@ -12,47 +20,208 @@ type Floating struct {
 	// which is known today
 	Rate       float64
 	Redemption float64
+	// Par is the notional base of prices, accrued interest and cash flows
+	// (e.g. 1 for unit notionals; default: 0 for per 100 of par)
+	Par float64
+	// Cap and Floor are the optional bounds on the coupon rate
+	// (default: nil for no cap or floor)
+	Cap   *Bound
+	Floor *Bound
+	// Index projects the index rate in percent for the later coupons fixed at the
+	// start of each period (default: nil for a bond resetting to par); the cap
+	// and the floor are valued as options on the projected rates
+	Index index.Projection
+	// Margin is the spread in percent over the index rate of the projected coupons
+	Margin float64
+	// Metadata describes the bond for reporting (default: nil for none)
+	Metadata *fixedincome.Metadata
+}
+
+// ParValue returns the notional base of prices, accrued interest and cash flows
+func (f *Floating) ParValue() float64 {
+	return parValue(f.Par)
+}
+
+// Validate checks the schedule and the terms of the bond
+func (f *Floating) Validate() error {
+	if err := f.Schedule.Validate(); err != nil {
+		return err
+	}
+	if f.Redemption < 0.0 || math.IsNaN(f.Redemption) {
+		return fmt.Errorf("%w: redemption value %v is not valid", ErrInvalidBond, f.Redemption)
+	}
+	if f.Par < 0.0 || math.IsNaN(f.Par) || math.IsInf(f.Par, 0) {
+		return fmt.Errorf("%w: par value %v is not valid", ErrInvalidBond, f.Par)
+	}
+	if math.IsNaN(f.Rate) || math.IsInf(f.Rate, 0) {
+		return fmt.Errorf("%w: rate %v is not valid", ErrInvalidBond, f.Rate)
+	}
+	for _, b := range []*Bound{f.Cap, f.Floor} {
+		if err := b.validate(); err != nil {
+			return err
+		}
+	}
+	if f.Cap != nil && f.Floor != nil && f.Cap.Rate < f.Floor.Rate {
+		return fmt.Errorf("%w: cap %v is below floor %v", ErrInvalidBond, f.Cap.Rate, f.Floor.Rate)
+	}
+	return nil
+}
+
+// Coupon returns the current coupon rate in percent within the cap and the floor
+func (f *Floating) Coupon() float64 {
+	return f.bound(f.Rate)
+}
+
+// bound returns the rate in percent within the cap and the floor
+func (f *Floating) bound(rate float64) float64 {
+	if f.Cap != nil {
+		rate = math.Min(rate, f.Cap.Rate)
+	}
+	if f.Floor != nil {
+		rate = math.Max(rate, f.Floor.Rate)
+	}
+	return rate
 }
 
 // Accrued calculated the accrued interest
 func (f *Floating) Accrued() float64 {
-	return f.Rate * f.Schedule.DayCountFraction()
+	return scale(f.Par, f.Coupon()*f.Schedule.DayCountFraction())
 }
 
 // PresentValue returns the "dirty" bond prices (for the "clean" price just subtract the accrued interest)
 func (f *Floating) PresentValue(ts term.Structure) float64 {
 	pv := 0.0
+	if len(f.M()) == 0 {
+		return pv
+	}
+	if f.Index != nil {
+		return f.projectedValue(ts)
+	}
 
 	// discount face value at next reset date
-	effRate := f.EffectiveCoupon(f.Rate)
+	effRate := f.EffectiveCoupon(f.Coupon())
 	pv += (f.Redemption + effRate) * ts.Z(f.Next())
 
-	return pv
+	// the bond holder is short the caplets and long the floorlets on the
+	// coupons fixed at the later reset dates
+	pv += f.Redemption / 100.0 * (f.Floor.value(option.Floorlet, &f.Schedule, ts) - f.Cap.value(option.Caplet, &f.Schedule, ts))
+
+	return scale(f.Par, pv)
 }
 
-// Duration calculates the duration of the floating-rate bond
-// dP/P = -D * dr
-func (f *Floating) Duration(ts term.Structure) float64 {
-	p := f.PresentValue(ts)
-	if p == 0.0 {
+// projectedValue returns the present value of the coupons projected from the
+// index with the caplets and floorlets on the projected rates (the holder is
+// short the caplets and long the floorlets, see Bound)
+func (f *Floating) projectedValue(ts term.Structure) float64 {
+	m, dates := f.M(), f.Dates()
+	if len(dates) != len(m) {
 		return 0.0
 	}
+	pv := cashflow.NewSum()
+	for i := range m {
+		amount := f.EffectiveCoupon(f.Coupon())
+		if i+1 < len(m) {
+			rate := f.Index.Value(dates[i+1]) + f.Margin
+			reset := m[i+1]
+			rate += f.Floor.option(option.Floorlet, rate, reset) - f.Cap.option(option.Caplet, rate, reset)
+			amount = f.EffectiveCoupon(rate)
+		}
+		if i == 0 {
+			amount += f.Redemption
+		}
+		pv.AddProduct(amount, ts.Z(m[i]))
+	}
+	return scale(f.Par, pv.Value())
+}
 
-	// discount redemption value
-	duration := f.Next() * (f.Redemption + f.EffectiveCoupon(f.Rate)) * ts.Z(f.Next())
+// projected returns the cash flows with the later coupons projected from the
+// index (the caps and floors are applied to the projected rates, i.e. the
+// cash flows hold the intrinsic values of the caplets and floorlets only)
+func (f *Floating) projected() cashflow.Cashflows {
+	m, dates := f.M(), f.Dates()
+	if len(dates) != len(m) {
+		return cashflow.Cashflows{}
+	}
+	// maturities and dates are in descending order, i.e. the coupon paid at m[i]
+	// is fixed at the payment date of the previous coupon dates[i+1]
+	cfs := cashflow.Cashflows{}
+	for i := range m {
+		rate := f.Rate
+		if i+1 < len(m) {
+			rate = f.Index.Value(dates[i+1]) + f.Margin
+		}
+		amount := f.EffectiveCoupon(f.bound(rate))
+		if i == 0 {
+			amount += f.Redemption
+		}
+		cfs = append(cfs, cashflow.Cashflow{Date: dates[i], T: m[i], Amount: scale(f.Par, amount)})
+	}
+	cfs.Sort()
+	return cfs
+}
 
-	return -duration / p
+// PresentValueAt returns the "dirty" bond price for the given settlement date
+// without modifying the bond
+func (f *Floating) PresentValueAt(settlement time.Time, ts term.Structure) float64 {
+	c := *f
+	c.Settlement = settlement
+	return c.PresentValue(ts)
 }
 
-// Convexity calculates the modified duration of the bond
-// dP/P = -D * dr + 1/2 * C * dr^2
-func (f *Floating) Convexity(ts term.Structure) float64 {
-	p := f.PresentValue(ts)
-	if p == 0.0 {
-		return 0.0
+// AccruedAt returns the accrued interest for the given settlement date
+// without modifying the bond
+func (f *Floating) AccruedAt(settlement time.Time) float64 {
+	c := *f
+	c.Settlement = settlement
+	return c.Accrued()
+}
+
+// AsOf returns a copy of the floating-rate bond for the valuation date
+func (f *Floating) AsOf(date time.Time) (*Floating, error) {
+	schedule, err := f.Schedule.AsOf(date)
+	if err != nil {
+		return nil, err
+	}
+	c := *f
+	c.Schedule = schedule
+	return &c, nil
+}
+
+// Cashflows returns the payment at the next reset date (face value plus the
+// known coupon), which is equivalent to the floating-rate bond's cash flows,
+// or the coupons projected from the index plus the margin and the redemption
+// if the bond has an index
+func (f *Floating) Cashflows() cashflow.Cashflows {
+	if f.Index != nil {
+		return f.projected()
 	}
+	dates := f.Dates()
+	if len(dates) == 0 {
+		return cashflow.Cashflows{}
+	}
+	return cashflow.Cashflows{
+		{
+			Date:   dates[len(dates)-1],
+			T:      f.Next(),
+			Amount: scale(f.Par, f.Redemption+f.EffectiveCoupon(f.Coupon())),
+		},
+	}
+}
 
-	convex := f.Next() * f.Next() * (f.Redemption + f.EffectiveCoupon(f.Rate)) * ts.Z(f.Next())
+// WAL returns the weighted average life in years, i.e. the years to maturity
+// as the principal is repaid at maturity
+func (f *Floating) WAL() float64 {
+	return f.Last()
+}
+
+// Duration calculates the duration of the floating-rate bond
+// dP/P = -D * dr
+func (f *Floating) Duration(ts term.Structure) float64 {
+	return duration(f.Cashflows(), ts)
+}
 
-	return convex / p
+// Convexity calculates the modified duration of the bond
+// dP/P = -D * dr + 1/2 * C * dr^2
+func (f *Floating) Convexity(ts term.Structure) float64 {
+	return convexity(f.Cashflows(), ts)
 }
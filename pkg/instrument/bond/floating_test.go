@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/konimarti/fixedincome/pkg/index"
 	"github.com/konimarti/fixedincome/pkg/instrument/bond"
 	"github.com/konimarti/fixedincome/pkg/maturity"
 	"github.com/konimarti/fixedincome/pkg/rate"
@@ -104,3 +105,143 @@ func TestFloating_DurationConvexity(t *testing.T) {
 		}
 	}
 }
+
+func TestFloating_Validate(t *testing.T) {
+	if err := floatingBond.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	invalid := floatingBond
+	invalid.Redemption = -1.0
+	if err := invalid.Validate(); err == nil {
+		t.Errorf("negative redemption should not be valid")
+	}
+}
+
+func TestFloating_CapFloor(t *testing.T) {
+	ts := term.NelsonSiegelSvensson{B0: 4.0, B1: -2.0, B2: 1.0, B3: 0.0, T1: 2.0, T2: 2.0}
+	plain := bond.Floating{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			Frequency:  4,
+			Basis:      "ACT360",
+		},
+		Rate:       2.5,
+		Redemption: 100.0,
+	}
+
+	// a cap far out of the money and a floor far in the money are worthless / intrinsic
+	capped := plain
+	capped.Cap = &bond.Bound{Rate: 50.0, Vola: 0.2}
+	if got, expected := capped.PresentValue(&ts), plain.PresentValue(&ts); math.Abs(got-expected) > 1e-8 {
+		t.Errorf("wrong value with out-of-the-money cap; got: %v, expected: %v", got, expected)
+	}
+
+	// the holder is short the cap and long the floor
+	capped.Cap = &bond.Bound{Rate: 3.0, Vola: 0.2}
+	floored := plain
+	floored.Floor = &bond.Bound{Rate: 2.0, Vola: 0.2}
+	if capped.PresentValue(&ts) >= plain.PresentValue(&ts) {
+		t.Errorf("capped floater not below plain floater; got: %v, plain: %v", capped.PresentValue(&ts), plain.PresentValue(&ts))
+	}
+	if floored.PresentValue(&ts) <= plain.PresentValue(&ts) {
+		t.Errorf("floored floater not above plain floater; got: %v, plain: %v", floored.PresentValue(&ts), plain.PresentValue(&ts))
+	}
+
+	// current coupon within the bounds
+	collared := plain
+	collared.Cap = &bond.Bound{Rate: 2.25, Vola: 0.005, Normal: true}
+	collared.Floor = &bond.Bound{Rate: 1.0, Vola: 0.005, Normal: true}
+	if collared.Coupon() != 2.25 {
+		t.Errorf("wrong coupon; got: %v, expected: %v", collared.Coupon(), 2.25)
+	}
+	if err := collared.Validate(); err != nil {
+		t.Error(err)
+	}
+	collared.Floor.Rate = 3.0
+	if err := collared.Validate(); err == nil {
+		t.Errorf("expected error for floor above cap")
+	}
+}
+
+func TestFloating_Index(t *testing.T) {
+	settlement := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	ts := term.Flat{R: 2.0}
+	f := bond.Floating{
+		Schedule: maturity.Schedule{
+			Settlement: settlement,
+			Maturity:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+			Basis:      "30E360",
+		},
+		Rate:       (math.Exp(0.02) - 1.0) * 100.0,
+		Redemption: 100.0,
+	}
+
+	// projecting the forward rates of the discount curve prices at par
+	f.Index = &index.Forward{Curve: &ts, Settlement: settlement, Tenor: 12}
+	if got := f.PresentValue(&ts); math.Abs(got-100.0) > 0.01 {
+		t.Errorf("wrong value with forward projection; got: %v, expected: %v", got, 100.0)
+	}
+
+	// a flat index of 1% with a margin of 0.5% projects coupons of 1.5 after
+	// the known first coupon: P = sum(c_t * exp(-0.02 t)), D = -sum(t * c_t *
+	// exp(-0.02 t)) / P and C = sum(t^2 * c_t * exp(-0.02 t)) / P
+	f.Index = index.Flat(1.0)
+	f.Margin = 0.5
+	if cfs := f.Cashflows(); len(cfs) != 5 || math.Abs(cfs[1].Amount-1.5) > 1e-12 || math.Abs(cfs[4].Amount-101.5) > 1e-12 {
+		t.Errorf("wrong projected cash flows; got: %v", cfs)
+	}
+	if got := f.PresentValue(&ts); math.Abs(got-98.05963607865917) > 1e-9 {
+		t.Errorf("wrong value with flat projection; got: %v, expected: %v", got, 98.05963607865917)
+	}
+	if got := f.Duration(&ts); math.Abs(got+4.83220362697841) > 1e-9 {
+		t.Errorf("wrong duration with flat projection; got: %v, expected: %v", got, -4.83220362697841)
+	}
+	if got := f.Convexity(&ts); math.Abs(got-23.849144506422586) > 1e-9 {
+		t.Errorf("wrong convexity with flat projection; got: %v, expected: %v", got, 23.849144506422586)
+	}
+
+	// with a floor the projection equals a fixed-coupon bond with the known first coupon
+	f.Floor = &bond.Bound{Rate: 1.75}
+	expected := f.EffectiveCoupon(f.Rate) * ts.Z(f.Next())
+	for _, m := range f.M()[:4] {
+		expected += 1.75 * ts.Z(m)
+	}
+	expected += 100.0 * ts.Z(f.Last())
+	if got := f.PresentValue(&ts); math.Abs(got-expected) > 1e-9 {
+		t.Errorf("wrong value with flat projection; got: %v, expected: %v", got, expected)
+	}
+}
+
+func TestFloating_IndexCapFloor(t *testing.T) {
+	settlement := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	ts := term.NelsonSiegelSvensson{B0: 4.0, B1: -2.0, B2: 1.0, B3: 0.0, T1: 2.0, T2: 2.0}
+	plain := bond.Floating{
+		Schedule: maturity.Schedule{
+			Settlement: settlement,
+			Maturity:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			Frequency:  4,
+			Basis:      "ACT365",
+		},
+		Rate:       2.5,
+		Redemption: 100.0,
+	}
+	capped := plain
+	capped.Cap = &bond.Bound{Rate: 3.0, Vola: 0.2}
+
+	// the caplets on the projected forwards keep their time value
+	indexed, cappedIndexed := plain, capped
+	indexed.Index = &index.Forward{Curve: &ts, Settlement: settlement, Tenor: 3}
+	cappedIndexed.Index = indexed.Index
+	if got, intrinsic := cappedIndexed.PresentValue(&ts), cappedIndexed.Cashflows().PresentValue(&ts); got >= intrinsic {
+		t.Errorf("no time value of the caplets; got: %v, intrinsic: %v", got, intrinsic)
+	}
+
+	// the same cap value with and without the index (up to the accrual
+	// periods of the caplets, quarters with and days/365 without the index)
+	cap := plain.PresentValue(&ts) - capped.PresentValue(&ts)
+	if got := indexed.PresentValue(&ts) - cappedIndexed.PresentValue(&ts); math.Abs(got-cap) > 0.02*cap {
+		t.Errorf("wrong cap value with index; got: %v, expected: %v", got, cap)
+	}
+}
@@ -0,0 +1,59 @@
+package bond
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Forward is the price of a bond for settlement on a forward date
+type Forward struct {
+	Settlement time.Time
+	Dirty      float64
+	// Accrued is the accrued interest at the forward settlement date
+	Accrued float64
+	Clean   float64
+	// Drop is the clean spot price less the clean forward price, i.e. the
+	// carry of the bond to the forward settlement date (zero for when-issued bonds)
+	Drop float64
+}
+
+// ForwardPrice returns the price of the bond for settlement on the forward date
+// after the settlement date of the bond, e.g. when-issued trading before the
+// issue date or forward settlement beyond the standard lag. The cash flows
+// after the forward settlement date are discounted with the term structure as
+// of the settlement date and compounded to the forward settlement date.
+func (b *Straight) ForwardPrice(settlement time.Time, ts term.Structure) (Forward, error) {
+	if err := b.Validate(); err != nil {
+		return Forward{}, err
+	}
+	if settlement.Before(b.Settlement) || !settlement.Before(b.Maturity) {
+		return Forward{}, fmt.Errorf("%w: forward settlement %s not between settlement %s and maturity %s", ErrInvalidBond,
+			settlement.Format("2006-01-02"), b.Settlement.Format("2006-01-02"), b.Maturity.Format("2006-01-02"))
+	}
+	if !b.Issue.IsZero() && settlement.Before(b.Issue) {
+		return Forward{}, fmt.Errorf("%w: forward settlement %s before issue date %s", ErrInvalidBond,
+			settlement.Format("2006-01-02"), b.Issue.Format("2006-01-02"))
+	}
+	dc, err := b.DayCounter()
+	if err != nil {
+		return Forward{}, err
+	}
+
+	c := *b
+	c.Settlement = settlement
+	value := 0.0
+	for _, cf := range c.Cashflows() {
+		value += cf.Amount * ts.Z(maturity.YearFraction(dc, b.Settlement, cf.Date))
+	}
+
+	f := Forward{Settlement: settlement, Accrued: c.Accrued()}
+	f.Dirty = value / ts.Z(maturity.YearFraction(dc, b.Settlement, settlement))
+	f.Clean = f.Dirty - f.Accrued
+	if b.Issue.IsZero() || !b.Settlement.Before(b.Issue) {
+		f.Drop = b.PresentValue(ts) - b.Accrued() - f.Clean
+	}
+	return f, nil
+}
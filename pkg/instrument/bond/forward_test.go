@@ -0,0 +1,106 @@
+package bond_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestStraight_ForwardPrice(t *testing.T) {
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 15, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2026, 5, 15, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+			Basis:      "30E360",
+		},
+		Coupon:     2.0,
+		Redemption: 100.0,
+	}
+	ts := term.Flat{R: 1.0}
+
+	// spot settlement
+	spot, err := b.ForwardPrice(b.Settlement, &ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(spot.Dirty-b.PresentValue(&ts)) > 1e-9 || spot.Accrued != b.Accrued() || math.Abs(spot.Drop) > 1e-9 {
+		t.Errorf("wrong spot price; got: %v, expected: %v", spot, b.PresentValue(&ts))
+	}
+
+	// 20 days forward without a coupon payment
+	forward := time.Date(2021, 5, 5, 0, 0, 0, 0, time.UTC)
+	f, err := b.ForwardPrice(forward, &ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var testData = []struct {
+		Name     string
+		Value    float64
+		Expected float64
+	}{
+		{"dirty", f.Dirty, b.PresentValue(&ts) * math.Exp(0.01*20.0/360.0)},
+		{"accrued", f.Accrued, b.AccruedAt(forward)},
+		{"clean", f.Clean, f.Dirty - b.AccruedAt(forward)},
+		{"drop", f.Drop, b.PresentValue(&ts) - b.Accrued() - f.Clean},
+		// on a flat curve the forward price is the spot price at the forward date
+		{"flat", f.Dirty, b.PresentValueAt(forward, &ts)},
+	}
+	for _, test := range testData {
+		if math.Abs(test.Value-test.Expected) > 1e-9 {
+			t.Errorf("wrong %s forward price; got: %v, expected: %v", test.Name, test.Value, test.Expected)
+		}
+	}
+
+	// the coupon paid before the forward settlement is carried by the seller
+	forward = time.Date(2021, 7, 15, 0, 0, 0, 0, time.UTC)
+	f, _ = b.ForwardPrice(forward, &ts)
+	expected := (b.PresentValue(&ts) - 2.0*ts.Z(30.0/360.0)) / ts.Z(0.25)
+	if math.Abs(f.Dirty-expected) > 1e-9 {
+		t.Errorf("wrong forward price after coupon; got: %v, expected: %v", f.Dirty, expected)
+	}
+}
+
+func TestStraight_ForwardPriceWhenIssued(t *testing.T) {
+	issue := time.Date(2021, 5, 15, 0, 0, 0, 0, time.UTC)
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 15, 0, 0, 0, 0, time.UTC),
+			Issue:      issue,
+			Maturity:   time.Date(2031, 5, 15, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+			Basis:      "30E360",
+		},
+		Coupon:     1.0,
+		Redemption: 100.0,
+	}
+	ts := term.NelsonSiegelSvensson{-0.43381, -0.31901, 0.01, 0.01, 2.24036, 0.0, 0.0}
+	f, err := b.ForwardPrice(issue, &ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Accrued != 0.0 || f.Drop != 0.0 || f.Clean != f.Dirty {
+		t.Errorf("wrong when-issued accrued interest; got: %v, expected: %v", f.Accrued, 0.0)
+	}
+	expected := 0.0
+	for _, cf := range b.Cashflows() {
+		// the coupon on the issue date is not paid
+		if cf.Date.After(issue) {
+			expected += cf.Amount * ts.Z(cf.T)
+		}
+	}
+	if expected /= ts.Z(1.0 / 12.0); math.Abs(f.Dirty-expected) > 1e-9 {
+		t.Errorf("wrong when-issued price; got: %v, expected: %v", f.Dirty, expected)
+	}
+
+	for _, date := range []time.Time{b.Settlement.AddDate(0, 0, -1), issue.AddDate(0, 0, -1), b.Maturity} {
+		if _, err := b.ForwardPrice(date, &ts); !errors.Is(err, bond.ErrInvalidBond) {
+			t.Errorf("wrong error for %v; got: %v, expected: %v", date, err, bond.ErrInvalidBond)
+		}
+	}
+}
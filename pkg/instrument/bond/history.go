@@ -0,0 +1,58 @@
+package bond
+
+import (
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+)
+
+// CouponHistory returns the coupons paid from the issue date up to and
+// including the settlement date in chronological order; the times T are the
+// negative year fractions before the settlement date. A coupon on the
+// settlement date is paid to the seller and is part of the history. The
+// coupons are paid on the original face (see Factor); a first coupon from an
+// issue date off the coupon schedule is pro-rated by the day count of its period.
+func (b *Straight) CouponHistory() (cashflow.Cashflows, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	dates, err := b.PastDates()
+	if err != nil {
+		return nil, err
+	}
+	dc, err := b.DayCounter()
+	if err != nil {
+		return nil, err
+	}
+	cfs := make(cashflow.Cashflows, 0, len(dates))
+	amount := scale(b.Par, b.periodCoupon())
+	for i, date := range dates {
+		cf := cashflow.Cashflow{Date: date, T: -maturity.YearFraction(dc, date, b.Settlement), Amount: amount}
+		if i == 0 {
+			fraction, err := b.firstPeriod(dc)
+			if err != nil {
+				return nil, err
+			}
+			cf.Amount *= fraction
+		}
+		cfs = append(cfs, cf)
+	}
+	return cfs, nil
+}
+
+// firstPeriod returns the fraction of a regular coupon period from the issue
+// date to the first coupon date (1 if the bond is issued on a coupon date)
+func (b *Straight) firstPeriod(dc maturity.DayCounter) (float64, error) {
+	// roll back to the coupon date on or before the issue date
+	k := 0
+	for {
+		date, err := b.CouponDate(k + 1)
+		if err != nil {
+			return 0.0, err
+		}
+		if !date.After(b.Issue) {
+			first, _ := b.CouponDate(k)
+			return dc.Days(b.Issue, first) / dc.Days(date, first), nil
+		}
+		k += 1
+	}
+}
@@ -0,0 +1,68 @@
+package bond_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+)
+
+func TestStraight_CouponHistory(t *testing.T) {
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2022, 5, 25, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2026, 11, 25, 0, 0, 0, 0, time.UTC),
+			Issue:      time.Date(2020, 2, 10, 0, 0, 0, 0, time.UTC),
+			Frequency:  2,
+			Basis:      "30E360",
+		},
+		Coupon:     3.0,
+		Redemption: 100.0,
+		Par:        1000.0,
+	}
+	cfs, err := b.CouponHistory()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the coupon on the settlement date belongs to the history
+	expected := []time.Time{
+		time.Date(2020, 5, 25, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 11, 25, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, 5, 25, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, 11, 25, 0, 0, 0, 0, time.UTC),
+		time.Date(2022, 5, 25, 0, 0, 0, 0, time.UTC),
+	}
+	if len(cfs) != len(expected) {
+		t.Fatalf("wrong number of coupons; got: %d, expected: %d", len(cfs), len(expected))
+	}
+	// the first coupon accrues from the issue date over 105 of 180 days (30E/360)
+	amounts := []float64{15.0 * 105.0 / 180.0, 15.0, 15.0, 15.0, 15.0}
+	for i, cf := range cfs {
+		if !cf.Date.Equal(expected[i]) || math.Abs(cf.Amount-amounts[i]) > 1e-12 {
+			t.Errorf("wrong coupon; got: %v, expected: %v on %v", cf, amounts[i], expected[i])
+		}
+	}
+	if math.Abs(cfs[0].T+2.0) > 1e-12 || cfs[4].T != 0.0 {
+		t.Errorf("wrong times of the coupons; got: %v, %v, expected: %v, %v", cfs[0].T, cfs[4].T, -2.0, 0.0)
+	}
+
+	// the past and the outstanding coupons make up the schedule
+	if len(b.Cashflows())+len(cfs) != 14 {
+		t.Errorf("wrong number of coupons; got: %d, expected: %d", len(b.Cashflows())+len(cfs), 14)
+	}
+
+	// a full first coupon if the bond is issued on a coupon date
+	b.Issue = time.Date(2019, 11, 25, 0, 0, 0, 0, time.UTC)
+	if cfs, err := b.CouponHistory(); err != nil || len(cfs) != 5 || cfs[0].Amount != 15.0 {
+		t.Errorf("wrong first coupon with issue on a coupon date; got: %v (%v)", cfs, err)
+	}
+
+	b.Issue = time.Time{}
+	if _, err := b.CouponHistory(); !errors.Is(err, maturity.ErrInvalidSchedule) {
+		t.Errorf("expected ErrInvalidSchedule without issue date; got: %v", err)
+	}
+}
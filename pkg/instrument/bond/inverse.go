@@ -0,0 +1,118 @@
+package bond
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/option"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Inverse represents an inverse (reverse) floater paying the coupon
+// max(Fixed - Leverage * index, 0) in percent, i.e. a fixed-coupon bond that is
+// short Leverage floating-rate notes and long Leverage caps at Fixed / Leverage
+type Inverse struct {
+	maturity.Schedule
+	// Fixed is the fixed rate in percent
+	Fixed float64
+	// Leverage is the multiplier of the index (default: 0 for 1)
+	Leverage float64
+	// Rate is the current index rate in percent for the next coupon payment
+	// which is known today
+	Rate       float64
+	Redemption float64
+	// Vola is the lognormal volatility of the forward index rates
+	// (or the normal volatility in percent, see Normal)
+	Vola float64
+	// Normal selects the Bachelier model with normal volatilities
+	Normal bool
+	// Par is the notional base of prices, accrued interest and cash flows
+	// (e.g. 1 for unit notionals; default: 0 for per 100 of par)
+	Par float64
+	// Metadata describes the bond for reporting (default: nil for none)
+	Metadata *fixedincome.Metadata
+}
+
+// ParValue returns the notional base of prices, accrued interest and cash flows
+func (f *Inverse) ParValue() float64 {
+	return parValue(f.Par)
+}
+
+// Validate checks the schedule and the terms of the bond
+func (f *Inverse) Validate() error {
+	if err := f.Schedule.Validate(); err != nil {
+		return err
+	}
+	if f.Redemption < 0.0 || math.IsNaN(f.Redemption) {
+		return fmt.Errorf("%w: redemption value %v is not valid", ErrInvalidBond, f.Redemption)
+	}
+	if f.Par < 0.0 || math.IsNaN(f.Par) || math.IsInf(f.Par, 0) {
+		return fmt.Errorf("%w: par value %v is not valid", ErrInvalidBond, f.Par)
+	}
+	if f.Leverage < 0.0 || math.IsNaN(f.Leverage) || math.IsInf(f.Leverage, 0) {
+		return fmt.Errorf("%w: leverage %v is not valid", ErrInvalidBond, f.Leverage)
+	}
+	for _, v := range []float64{f.Fixed, f.Rate, f.Vola} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("%w: rate or volatility %v is not valid", ErrInvalidBond, v)
+		}
+	}
+	return nil
+}
+
+// leverage returns the multiplier of the index
+func (f *Inverse) leverage() float64 {
+	if f.Leverage == 0.0 {
+		return 1.0
+	}
+	return f.Leverage
+}
+
+// Coupon returns the current coupon rate in percent
+func (f *Inverse) Coupon() float64 {
+	return math.Max(f.Fixed-f.leverage()*f.Rate, 0.0)
+}
+
+// Accrued calculates the accrued interest of the current coupon
+func (f *Inverse) Accrued() float64 {
+	return scale(f.Par, f.Coupon()*f.Schedule.DayCountFraction())
+}
+
+// PresentValue returns the "dirty" bond price (for the "clean" price just subtract the accrued interest)
+func (f *Inverse) PresentValue(ts term.Structure) float64 {
+	resets := resets(&f.Schedule)
+	if len(f.M()) == 0 {
+		return 0.0
+	}
+
+	// known coupon and redemption
+	pv := f.EffectiveCoupon(f.Coupon())*ts.Z(f.Next()) + f.Redemption*ts.Z(f.Last())
+
+	// coupons fixed at the later reset dates, i.e. the fixed coupons minus the
+	// floating leg plus the caps on the index at Fixed / Leverage (per 100 notional)
+	l := f.leverage()
+	if len(resets) > 0 {
+		fixed := 0.0
+		for i := range resets {
+			end := f.Last()
+			if i+1 < len(resets) {
+				end = resets[i+1]
+			}
+			fixed += f.EffectiveCoupon(f.Fixed) * ts.Z(end)
+		}
+		floating := 100.0 * (ts.Z(resets[0]) - ts.Z(f.Last()))
+		c := option.Cap{
+			Type:     option.Caplet,
+			Resets:   resets,
+			Maturity: f.Last(),
+			K:        f.Fixed / l,
+			Vola:     f.Vola,
+			Normal:   f.Normal,
+		}
+		pv += f.Redemption / 100.0 * (fixed - l*floating + l*c.PresentValue(ts))
+	}
+
+	return scale(f.Par, pv)
+}
@@ -0,0 +1,51 @@
+package bond_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestInverse(t *testing.T) {
+	ts := term.Flat{R: 3.0}
+	schedule := maturity.Schedule{
+		Settlement: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		Maturity:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Frequency:  1,
+		Basis:      "30E360",
+	}
+
+	// without optionality (zero volatility and far in the money): fixed bond
+	// minus floating-rate note plus zero bond (see example/leveraged_inverse_floater)
+	inverse := bond.Inverse{
+		Schedule:   schedule,
+		Fixed:      15.0,
+		Rate:       3.0,
+		Redemption: 100.0,
+	}
+	fixed := bond.Straight{Schedule: schedule, Coupon: 15.0, Redemption: 100.0}
+	zero := bond.Straight{Schedule: schedule, Coupon: 0.0, Redemption: 100.0}
+	floating := bond.Floating{Schedule: schedule, Rate: 3.0, Redemption: 100.0}
+	expected := fixed.PresentValue(&ts) - floating.PresentValue(&ts) + zero.PresentValue(&ts)
+	if got := inverse.PresentValue(&ts); math.Abs(got-expected) > 1e-2 {
+		t.Errorf("wrong inverse floater value; got: %v, expected: %v", got, expected)
+	}
+
+	// the floor at zero adds value with volatility and leverage
+	inverse.Fixed, inverse.Leverage, inverse.Vola = 8.0, 2.0, 0.3
+	noVola := inverse
+	noVola.Vola = 0.0
+	if inverse.PresentValue(&ts) <= noVola.PresentValue(&ts) {
+		t.Errorf("inverse floater value without optionality; got: %v, without volatility: %v", inverse.PresentValue(&ts), noVola.PresentValue(&ts))
+	}
+	if got := inverse.Coupon(); got != 2.0 {
+		t.Errorf("wrong coupon; got: %v, expected: %v", got, 2.0)
+	}
+	if err := inverse.Validate(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,81 @@
+package bond
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/index"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Linker represents an inflation-linked bond paying the real coupons and redemption
+// of the straight bond scaled by the index ratio of the reference CPI;
+// PresentValue and Accrued of the embedded straight bond return the real
+// price and the real accrued interest for a real term structure
+type Linker struct {
+	Straight
+	// Reference provides the reference CPI with the indexation lag
+	Reference index.Reference
+	// Base is the base reference CPI (e.g. for the dated date of the bond)
+	Base float64
+	// Floor protects the redemption against deflation, i.e. the index ratio
+	// applied to the redemption is at least 1
+	Floor bool
+}
+
+// Validate checks the terms of the straight bond and the reference CPI
+func (l *Linker) Validate() error {
+	if err := l.Straight.Validate(); err != nil {
+		return err
+	}
+	if l.Reference.CPI == nil {
+		return fmt.Errorf("%w: no reference CPI", ErrInvalidBond)
+	}
+	if l.Base <= 0.0 || math.IsNaN(l.Base) || math.IsInf(l.Base, 0) {
+		return fmt.Errorf("%w: base reference CPI %v is not valid", ErrInvalidBond, l.Base)
+	}
+	return nil
+}
+
+// IndexRatio returns the index ratio for the settlement date
+func (l *Linker) IndexRatio() float64 {
+	return l.Reference.Ratio(l.Settlement, l.Base)
+}
+
+// Invoice returns the nominal settlement price for the quoted real clean price,
+// i.e. the real clean price plus the real accrued interest times the index ratio
+func (l *Linker) Invoice(clean float64) float64 {
+	return (clean + l.Accrued()) * l.IndexRatio()
+}
+
+// NominalCashflows returns the outstanding payments scaled by the index ratios
+// of the payment dates (with the projection of the CPI for the future months)
+func (l *Linker) NominalCashflows() cashflow.Cashflows {
+	cfs := l.Straight.Cashflows()
+	if l.Flat {
+		return cfs
+	}
+	for i := range cfs {
+		ratio := l.Reference.Ratio(cfs[i].Date, l.Base)
+		if i < len(cfs)-1 {
+			cfs[i].Amount *= ratio
+			continue
+		}
+		// the redemption is paid with the last coupon
+		redemption := l.outstanding(l.Redemption)
+		coupon := cfs[i].Amount - redemption
+		if l.Floor {
+			cfs[i].Amount = coupon*ratio + redemption*math.Max(ratio, 1.0)
+		} else {
+			cfs[i].Amount = (coupon + redemption) * ratio
+		}
+	}
+	return cfs
+}
+
+// NominalValue returns the present value of the nominal cash flows discounted
+// with the nominal term structure
+func (l *Linker) NominalValue(ts term.Structure) float64 {
+	return l.NominalCashflows().PresentValue(ts)
+}
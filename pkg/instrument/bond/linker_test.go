@@ -0,0 +1,83 @@
+package bond_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/index"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestLinker(t *testing.T) {
+	first := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	// published CPI of 100 in January 2020 rising by 0.25 per month
+	cpi := index.NewFixings(nil)
+	for m := 0; m < 12; m += 1 {
+		cpi.Add(first.AddDate(0, m, 0), 100.0+0.25*float64(m))
+	}
+
+	linker := bond.Linker{
+		Straight: bond.Straight{
+			Schedule: maturity.Schedule{
+				Settlement: time.Date(2020, 10, 16, 0, 0, 0, 0, time.UTC),
+				Maturity:   time.Date(2023, 4, 15, 0, 0, 0, 0, time.UTC),
+				Frequency:  2,
+				Basis:      "ACTACT",
+			},
+			Coupon:     0.5,
+			Redemption: 100.0,
+		},
+		Reference: index.Reference{CPI: cpi, Digits: 5},
+		Base:      100.0,
+	}
+	if err := linker.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	// reference CPI on October 16: CPI of July (101.5) and August (101.75)
+	expected := math.Round((101.5+15.0/31.0*0.25)*1e5) / 1e7
+	if got := linker.IndexRatio(); math.Abs(got-math.Round(expected*1e5)/1e5) > 1e-12 {
+		t.Errorf("wrong index ratio; got: %v, expected: %v", got, expected)
+	}
+	if got, expected := linker.Invoice(99.0), (99.0+linker.Accrued())*linker.IndexRatio(); math.Abs(got-expected) > 1e-12 {
+		t.Errorf("wrong invoice price; got: %v, expected: %v", got, expected)
+	}
+
+	// projection of 2% annual inflation after the last published CPI
+	last := 100.0 + 0.25*11.0
+	cpi.Projection = index.Func(func(d time.Time) float64 {
+		return last * math.Pow(1.02, d.Sub(first.AddDate(0, 11, 0)).Hours()/24.0/365.0)
+	})
+	cfs := linker.NominalCashflows()
+	real := linker.Cashflows()
+	for i, cf := range cfs {
+		ratio := linker.Reference.Ratio(cf.Date, linker.Base)
+		if math.Abs(cf.Amount-real[i].Amount*ratio) > 1e-12 {
+			t.Errorf("wrong nominal cash flow at %v; got: %v, expected: %v", cf.Date, cf.Amount, real[i].Amount*ratio)
+		}
+	}
+	ts := term.Flat{R: 2.0}
+	if got, expected := linker.NominalValue(&ts), cfs.PresentValue(&ts); math.Abs(got-expected) > 1e-12 {
+		t.Errorf("wrong nominal value; got: %v, expected: %v", got, expected)
+	}
+
+	// the deflation floor applies to the redemption only
+	cpi.Projection = index.Flat(90.0)
+	linker.Floor = true
+	cfs = linker.NominalCashflows()
+	final := cfs[len(cfs)-1]
+	if expected := 0.25*0.9 + 100.0; math.Abs(final.Amount-expected) > 1e-9 {
+		t.Errorf("wrong floored redemption; got: %v, expected: %v", final.Amount, expected)
+	}
+
+	// a factor of 0.6 scales the floored coupon and redemption alike
+	linker.Factor = 0.6
+	cfs = linker.NominalCashflows()
+	final = cfs[len(cfs)-1]
+	if expected := 0.6 * (0.25*0.9 + 100.0); math.Abs(final.Amount-expected) > 1e-9 {
+		t.Errorf("wrong floored redemption with factor; got: %v, expected: %v", final.Amount, expected)
+	}
+}
@@ -0,0 +1,33 @@
+package bond
+
+import "github.com/konimarti/fixedincome"
+
+// Describe returns the metadata of the bond
+func (b *Straight) Describe() *fixedincome.Metadata {
+	return b.Metadata
+}
+
+// Describe returns the metadata of the bond
+func (f *Floating) Describe() *fixedincome.Metadata {
+	return f.Metadata
+}
+
+// Describe returns the metadata of the bond
+func (f *Inverse) Describe() *fixedincome.Metadata {
+	return f.Metadata
+}
+
+// Describe returns the metadata of the bond
+func (s *Sinking) Describe() *fixedincome.Metadata {
+	return s.Metadata
+}
+
+// Describe returns the metadata of the bond
+func (a *Amortizing) Describe() *fixedincome.Metadata {
+	return a.Metadata
+}
+
+// Describe returns the metadata of the bond
+func (c *Custom) Describe() *fixedincome.Metadata {
+	return c.Metadata
+}
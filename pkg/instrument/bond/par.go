@@ -0,0 +1,17 @@
+package bond
+
+// parValue returns the notional base for par (100 if not set)
+func parValue(par float64) float64 {
+	if par == 0.0 {
+		return 100.0
+	}
+	return par
+}
+
+// scale converts a value per 100 of par to the notional base par
+func scale(par, x float64) float64 {
+	if par == 0.0 {
+		return x
+	}
+	return x * par / 100.0
+}
@@ -0,0 +1,43 @@
+package bond
+
+import "math"
+
+// Prepayment is a prepayment model for amortizing bonds
+type Prepayment interface {
+	// SMM returns the single monthly mortality, i.e. the fraction of the
+	// outstanding principal (after scheduled amortization) prepaid in the
+	// period with the given index (starting at 0 for the next payment) for the
+	// compounding frequency n per year
+	SMM(period, n int) float64
+}
+
+// CPR is a constant conditional prepayment rate in percent per year
+type CPR float64
+
+// SMM converts the annual prepayment rate to the rate per period
+func (c CPR) SMM(period, n int) float64 {
+	return smm(float64(c), n)
+}
+
+// Vector is a vector of conditional prepayment rates in percent per year for the
+// upcoming periods; the last rate applies to all remaining periods
+type Vector []float64
+
+// SMM converts the annual prepayment rate of the period to the rate per period
+func (v Vector) SMM(period, n int) float64 {
+	if len(v) == 0 {
+		return 0.0
+	}
+	if period >= len(v) {
+		period = len(v) - 1
+	}
+	return smm(v[period], n)
+}
+
+// smm converts an annual prepayment rate in percent to the rate per period
+func smm(cpr float64, n int) float64 {
+	if n <= 0 {
+		n = 1
+	}
+	return 1.0 - math.Pow(1.0-cpr/100.0, 1.0/float64(n))
+}
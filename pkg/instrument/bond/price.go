@@ -0,0 +1,30 @@
+package bond
+
+import "time"
+
+// Accruer is a bond with accrued interest at a given settlement date
+// (e.g. Straight and Floating)
+type Accruer interface {
+	AccruedAt(settlement time.Time) float64
+}
+
+// CleanToDirty returns the dirty price for the clean price at the settlement date
+func CleanToDirty(b Accruer, settlement time.Time, clean float64) float64 {
+	return clean + b.AccruedAt(settlement)
+}
+
+// DirtyToClean returns the clean price for the dirty price at the settlement date
+func DirtyToClean(b Accruer, settlement time.Time, dirty float64) float64 {
+	return dirty - b.AccruedAt(settlement)
+}
+
+// Invoice returns the invoice amount (settlement amount before fees) for the
+// face value at the clean price; prices are quoted per 100 of par unless the
+// bond has a different notional base (see Straight.Par)
+func Invoice(b Accruer, settlement time.Time, clean, face float64) float64 {
+	par := 100.0
+	if p, ok := b.(interface{ ParValue() float64 }); ok {
+		par = p.ParValue()
+	}
+	return face * CleanToDirty(b, settlement, clean) / par
+}
@@ -0,0 +1,64 @@
+package bond_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+)
+
+func TestCleanDirty(t *testing.T) {
+	// ISIN CH0224396983
+	straight := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2026, 5, 28, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+		},
+		Coupon:     1.25,
+		Redemption: 100.0,
+	}
+
+	testData := []struct {
+		Settlement time.Time
+		Clean      float64
+		Accrued    float64
+	}{
+		{
+			Settlement: time.Date(2021, 4, 17, 0, 0, 0, 0, time.UTC),
+			Clean:      109.70,
+			Accrued:    1.25 * 319.0 / 360.0,
+		},
+		{
+			Settlement: time.Date(2021, 5, 28, 0, 0, 0, 0, time.UTC),
+			Clean:      109.70,
+			Accrued:    0.0,
+		},
+		{
+			Settlement: time.Date(2021, 6, 28, 0, 0, 0, 0, time.UTC),
+			Clean:      109.70,
+			Accrued:    1.25 * 30.0 / 360.0,
+		},
+	}
+
+	for nr, test := range testData {
+		dirty := bond.CleanToDirty(&straight, test.Settlement, test.Clean)
+		if math.Abs(dirty-test.Clean-test.Accrued) > 1e-10 {
+			t.Errorf("test nr %d: wrong dirty price; got: %v, expected: %v", nr, dirty, test.Clean+test.Accrued)
+		}
+		if clean := bond.DirtyToClean(&straight, test.Settlement, dirty); math.Abs(clean-test.Clean) > 1e-10 {
+			t.Errorf("test nr %d: wrong clean price; got: %v, expected: %v", nr, clean, test.Clean)
+		}
+		invoice := bond.Invoice(&straight, test.Settlement, test.Clean, 50000.0)
+		if expected := 500.0 * (test.Clean + test.Accrued); math.Abs(invoice-expected) > 1e-8 {
+			t.Errorf("test nr %d: wrong invoice amount; got: %v, expected: %v", nr, invoice, expected)
+		}
+	}
+
+	// the settlement date of the bond is not modified
+	if !straight.Settlement.Equal(time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("settlement date of bond was modified; got: %v", straight.Settlement)
+	}
+}
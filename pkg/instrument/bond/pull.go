@@ -0,0 +1,42 @@
+package bond
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// PricePoint is the projected clean price of a bond at a date
+type PricePoint struct {
+	Date  time.Time
+	Price float64
+}
+
+// PullToPar projects the clean price of the bond from the settlement date to the
+// maturity in steps of the given number of months with the term structure and
+// the static spread in bps unchanged (i.e. the spot rates stay the same for the
+// same time to maturity). The price converges to the redemption value at maturity.
+func (b *Straight) PullToPar(ts term.Structure, spread float64, months int) ([]PricePoint, error) {
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	if months <= 0 {
+		return nil, fmt.Errorf("%w: step of %d months is not valid", ErrInvalidBond, months)
+	}
+	if spread != 0.0 {
+		ts = term.WithSpread(ts, spread)
+	}
+
+	points := []PricePoint{}
+	for k := 0; ; k += 1 {
+		date := b.Settlement.AddDate(0, k*months, 0)
+		if !date.Before(b.Maturity) {
+			break
+		}
+		clean := b.PresentValueAt(date, ts) - b.AccruedAt(date)
+		points = append(points, PricePoint{Date: date, Price: clean})
+	}
+	points = append(points, PricePoint{Date: b.Maturity, Price: b.outstanding(b.Redemption)})
+	return points, nil
+}
@@ -0,0 +1,58 @@
+package bond_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestStraight_PullToPar(t *testing.T) {
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2024, 5, 28, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+			Basis:      "30E360",
+		},
+		Coupon:     5.0,
+		Redemption: 100.0,
+	}
+	ts := term.Flat{R: 1.0}
+
+	points, err := b.PullToPar(&ts, 100.0, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 38 months in steps of 3 months and the maturity
+	if len(points) != 14 {
+		t.Fatalf("wrong number of points; got: %d, expected: %d", len(points), 14)
+	}
+
+	// the first point is the clean price with the spread
+	expected := b.PresentValue(term.WithSpread(&ts, 100.0)) - b.Accrued()
+	if math.Abs(points[0].Price-expected) > 1e-9 || !points[0].Date.Equal(b.Settlement) {
+		t.Errorf("wrong first point; got: %v, expected: %v", points[0], expected)
+	}
+
+	// a premium bond is pulled down to par
+	for i := 1; i < len(points); i += 1 {
+		if points[i].Price >= points[i-1].Price {
+			t.Errorf("price does not decrease at %v; got: %v, previous: %v", points[i].Date, points[i].Price, points[i-1].Price)
+		}
+	}
+	last := points[len(points)-1]
+	if !last.Date.Equal(b.Maturity) || last.Price != 100.0 {
+		t.Errorf("wrong price at maturity; got: %v, expected: %v", last, 100.0)
+	}
+	if points[len(points)-2].Price < 100.0 {
+		t.Errorf("price below par before maturity; got: %v", points[len(points)-2])
+	}
+
+	if _, err := b.PullToPar(&ts, 0.0, 0); err == nil {
+		t.Error("expected error for a step of 0 months")
+	}
+}
@@ -0,0 +1,273 @@
+package bond
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Sink is a mandatory partial redemption at par of a sinking-fund bond
+type Sink struct {
+	// Date is the sink date (a coupon date)
+	Date time.Time
+	// Amount is the redeemed principal per 100 of original face
+	Amount float64
+}
+
+// Sinking represents a fixed-coupon bond with a sinking fund, i.e. with partial
+// redemptions at the sink dates and the remaining principal at maturity.
+// Coupons are paid on the outstanding principal.
+type Sinking struct {
+	maturity.Schedule
+	Coupon float64
+	// Redemption is the original face value (per 100)
+	Redemption float64
+	// Sinks are the mandatory redemptions
+	Sinks []Sink
+	// Redeemed is the date at which the outstanding principal is redeemed
+	// with the interest accrued since the last coupon date (default: zero
+	// for redemption at maturity; see RedeemedAt)
+	Redeemed time.Time
+	// Par is the notional base of prices, accrued interest and cash flows
+	// (e.g. 1 for unit notionals; default: 0 for per 100 of par)
+	Par float64
+
+	// Metadata describes the bond for reporting (default: nil for none)
+	Metadata *fixedincome.Metadata
+}
+
+// ParValue returns the notional base of prices, accrued interest and cash flows
+func (s *Sinking) ParValue() float64 {
+	return parValue(s.Par)
+}
+
+// Validate checks the schedule, the terms and that the sinks fall on coupon dates
+func (s *Sinking) Validate() error {
+	if err := s.Schedule.Validate(); err != nil {
+		return err
+	}
+	if s.Redemption < 0.0 || math.IsNaN(s.Redemption) || math.IsInf(s.Redemption, 0) {
+		return fmt.Errorf("%w: redemption value %v is not valid", ErrInvalidBond, s.Redemption)
+	}
+	if math.IsNaN(s.Coupon) || math.IsInf(s.Coupon, 0) {
+		return fmt.Errorf("%w: coupon %v is not valid", ErrInvalidBond, s.Coupon)
+	}
+	if s.Par < 0.0 || math.IsNaN(s.Par) || math.IsInf(s.Par, 0) {
+		return fmt.Errorf("%w: par value %v is not valid", ErrInvalidBond, s.Par)
+	}
+
+	// sinks on coupon dates (including past coupon dates)
+	c := s.Schedule
+	for _, sink := range s.Sinks {
+		if !sink.Date.After(c.Settlement) {
+			c.Settlement = sink.Date.AddDate(0, 0, -1)
+		}
+	}
+	coupons := map[int64]bool{}
+	for _, date := range c.Dates() {
+		coupons[date.Unix()] = true
+	}
+	total := 0.0
+	for _, sink := range s.Sinks {
+		if sink.Amount < 0.0 || math.IsNaN(sink.Amount) {
+			return fmt.Errorf("%w: sink amount %v is not valid", ErrInvalidBond, sink.Amount)
+		}
+		if !coupons[sink.Date.Unix()] {
+			return fmt.Errorf("%w: sink date %s is not a coupon date", ErrInvalidBond, sink.Date.Format("2006-01-02"))
+		}
+		total += sink.Amount
+	}
+	if total > s.Redemption {
+		return fmt.Errorf("%w: sinks of %v exceed the face value %v", ErrInvalidBond, total, s.Redemption)
+	}
+	return nil
+}
+
+// Outstanding returns the outstanding principal (per 100 of original face) at the settlement date
+func (s *Sinking) Outstanding() float64 {
+	outstanding := s.Redemption
+	for _, sink := range s.Sinks {
+		if !sink.Date.After(s.Settlement) {
+			outstanding -= sink.Amount
+		}
+	}
+	return outstanding
+}
+
+// payment is a remaining payment of coupon and principal per 100 of original face
+type payment struct {
+	date      time.Time
+	t         float64
+	coupon    float64
+	principal float64
+}
+
+// payments returns the remaining coupon and principal payments ordered by
+// payment date. The coupons are paid on the outstanding principal; if the
+// bond is redeemed before maturity, the final payment at the redemption date
+// includes the coupon accrued since the last coupon date.
+func (s *Sinking) payments() []payment {
+	dates := s.Dates()
+	maturities := s.M()
+	effCoupon := s.EffectiveCoupon(s.Coupon) / 100.0
+	early := !s.Redeemed.IsZero() && s.Redeemed.After(s.Settlement) && s.Redeemed.Before(s.Maturity)
+
+	payments := []payment{}
+	outstanding := s.Outstanding()
+	for i := len(dates) - 1; i >= 0; i -= 1 {
+		if early && !dates[i].Before(s.Redeemed) {
+			// final stub period from the previous coupon date to the redemption date
+			previous := s.PreviousCoupon()
+			if i+1 < len(dates) {
+				previous = dates[i+1]
+			}
+			dc, err := s.DayCounter()
+			if err != nil {
+				return []payment{}
+			}
+			payments = append(payments, payment{
+				date:      s.Redeemed,
+				t:         maturity.YearFraction(dc, s.Settlement, s.Redeemed),
+				coupon:    effCoupon * outstanding * dc.Fraction(previous, s.Redeemed, dates[i]),
+				principal: outstanding,
+			})
+			break
+		}
+
+		p := payment{date: dates[i], t: maturities[i], coupon: effCoupon * outstanding}
+		if i == 0 {
+			// remaining principal at maturity
+			p.principal = outstanding
+		} else {
+			for _, sink := range s.Sinks {
+				if sink.Date.Equal(dates[i]) {
+					p.principal += sink.Amount
+				}
+			}
+		}
+		payments = append(payments, p)
+		outstanding -= p.principal
+	}
+	return payments
+}
+
+// Accrued calculates the accrued interest on the outstanding principal
+func (s *Sinking) Accrued() float64 {
+	return scale(s.Par, s.Coupon*s.DayCountFraction()*s.Outstanding()/100.0)
+}
+
+// Cashflows returns the coupons on the outstanding principal and the
+// redemptions ordered by payment date
+func (s *Sinking) Cashflows() cashflow.Cashflows {
+	cfs := cashflow.Cashflows{}
+	for _, p := range s.payments() {
+		cfs = append(cfs, cashflow.Cashflow{Date: p.date, T: p.t, Amount: scale(s.Par, p.coupon+p.principal)})
+	}
+	return cfs
+}
+
+// PresentValue returns the "dirty" bond price
+func (s *Sinking) PresentValue(ts term.Structure) float64 {
+	return s.Cashflows().PresentValue(ts)
+}
+
+// PresentValueAt returns the "dirty" bond price for the given settlement date
+// without modifying the bond
+func (s *Sinking) PresentValueAt(settlement time.Time, ts term.Structure) float64 {
+	c := *s
+	c.Settlement = settlement
+	return c.PresentValue(ts)
+}
+
+// AccruedAt returns the accrued interest for the given settlement date
+// without modifying the bond
+func (s *Sinking) AccruedAt(settlement time.Time) float64 {
+	c := *s
+	c.Settlement = settlement
+	return c.Accrued()
+}
+
+// WAL returns the weighted average life in years of the outstanding principal payments
+func (s *Sinking) WAL() float64 {
+	payments := s.payments()
+	t, principal := make([]float64, len(payments)), make([]float64, len(payments))
+	for i, p := range payments {
+		t[i], principal[i] = p.t, p.principal
+	}
+	return averageLife(t, principal)
+}
+
+// Duration calculates the duration of the bond
+// dP/P = -D * dr
+func (s *Sinking) Duration(ts term.Structure) float64 {
+	return duration(s.Cashflows(), ts)
+}
+
+// Convexity calculates the convexity of the bond
+// dP/P = -D * dr + 1/2 * C * dr^2
+func (s *Sinking) Convexity(ts term.Structure) float64 {
+	return convexity(s.Cashflows(), ts)
+}
+
+// AverageLifeDate returns the date at the weighted average life from the settlement date
+func (s *Sinking) AverageLifeDate() time.Time {
+	years, frac := math.Modf(s.WAL())
+	return s.Settlement.AddDate(int(years), 0, int(math.Round(frac*365.0)))
+}
+
+// RedeemedAt returns a copy of the bond where the outstanding principal is
+// fully redeemed at the given date and the later sinks are dropped; the coupon
+// dates are kept and the coupon of the final (broken) period is pro-rated
+func (s *Sinking) RedeemedAt(date time.Time) *Sinking {
+	c := *s
+	c.Redeemed = date
+	c.Sinks = []Sink{}
+	for _, sink := range s.Sinks {
+		if sink.Date.Before(date) {
+			c.Sinks = append(c.Sinks, sink)
+		}
+	}
+	return &c
+}
+
+// YieldToAverageLife returns the yield for the dirty price when the
+// outstanding principal is redeemed at the average life date (market
+// convention for sinking-fund bonds)
+func (s *Sinking) YieldToAverageLife(dirty float64) (float64, error) {
+	return fixedincome.Irr(dirty, s.RedeemedAt(s.AverageLifeDate()))
+}
+
+// SinkYield is the yield to a sink date
+type SinkYield struct {
+	Date  time.Time
+	Yield float64
+}
+
+// YieldsToSinks returns the yields for the dirty price when the outstanding
+// principal is redeemed at each of the remaining sink dates and at maturity
+func (s *Sinking) YieldsToSinks(dirty float64) ([]SinkYield, error) {
+	dates := []time.Time{}
+	for _, sink := range s.Sinks {
+		if sink.Date.After(s.Settlement) && sink.Date.Before(s.Maturity) {
+			dates = append(dates, sink.Date)
+		}
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	dates = append(dates, s.Maturity)
+
+	yields := make([]SinkYield, len(dates))
+	for i, date := range dates {
+		y, err := fixedincome.Irr(dirty, s.RedeemedAt(date))
+		if err != nil {
+			return nil, fmt.Errorf("yield to %s: %w", date.Format("2006-01-02"), err)
+		}
+		yields[i] = SinkYield{Date: date, Yield: y}
+	}
+	return yields, nil
+}
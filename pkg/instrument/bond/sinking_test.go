@@ -0,0 +1,186 @@
+package bond_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func sinker() bond.Sinking {
+	// 10 year bond sinking 10% p.a. in years 6 to 9
+	sinks := []bond.Sink{}
+	for y := 2027; y <= 2030; y += 1 {
+		sinks = append(sinks, bond.Sink{Date: time.Date(y, 6, 1, 0, 0, 0, 0, time.UTC), Amount: 10.0})
+	}
+	return bond.Sinking{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2031, 6, 1, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+		},
+		Coupon:     3.0,
+		Redemption: 100.0,
+		Sinks:      sinks,
+	}
+}
+
+func TestSinking(t *testing.T) {
+	s := sinker()
+	if err := s.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	ts := term.Flat{R: 2.0}
+
+	// WAL: 4 sinks of 10% at 6 to 9 years and 60% at 10 years
+	if wal := s.WAL(); math.Abs(wal-9.0) > 1e-12 {
+		t.Errorf("wrong weighted average life; got: %v, expected: %v", wal, 9.0)
+	}
+
+	expected := 0.0
+	outstanding := 100.0
+	for y := 1; y <= 10; y += 1 {
+		amount := 0.03 * outstanding
+		if y >= 6 && y <= 9 {
+			amount += 10.0
+			outstanding -= 10.0
+		}
+		if y == 10 {
+			amount += outstanding
+		}
+		expected += amount * math.Exp(-0.02*float64(y))
+	}
+	if pv := s.PresentValue(&ts); math.Abs(pv-expected) > 1e-10 {
+		t.Errorf("wrong present value; got: %v, expected: %v", pv, expected)
+	}
+	if d := s.Duration(&ts); -d >= s.WAL() || d >= 0.0 {
+		t.Errorf("wrong duration; got: %v", d)
+	}
+
+	// after the second sink
+	later := s
+	later.Settlement = time.Date(2028, 12, 1, 0, 0, 0, 0, time.UTC)
+	if o := later.Outstanding(); o != 80.0 {
+		t.Errorf("wrong outstanding principal; got: %v, expected: %v", o, 80.0)
+	}
+	if a := later.Accrued(); math.Abs(a-3.0*0.5*0.8) > 1e-12 {
+		t.Errorf("wrong accrued interest; got: %v, expected: %v", a, 3.0*0.5*0.8)
+	}
+	if wal := later.WAL(); math.Abs(wal-(0.5*10.0+1.5*10.0+2.5*60.0)/80.0) > 1e-12 {
+		t.Errorf("wrong weighted average life; got: %v", wal)
+	}
+
+	// sink date off the coupon schedule
+	invalid := sinker()
+	invalid.Sinks = append(invalid.Sinks, bond.Sink{Date: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), Amount: 5.0})
+	if err := invalid.Validate(); !errors.Is(err, bond.ErrInvalidBond) {
+		t.Errorf("expected ErrInvalidBond for sink off the coupon dates; got: %v", err)
+	}
+}
+
+func TestStraight_WAL(t *testing.T) {
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2031, 6, 1, 0, 0, 0, 0, time.UTC),
+			Frequency:  2,
+		},
+		Coupon:     3.0,
+		Redemption: 100.0,
+	}
+	if wal := b.WAL(); math.Abs(wal-10.0) > 1e-12 {
+		t.Errorf("wrong weighted average life; got: %v, expected: %v", wal, 10.0)
+	}
+}
+
+func TestSinking_Yields(t *testing.T) {
+	s := sinker()
+	ts := term.Flat{R: 2.0}
+	dirty := s.PresentValue(&ts)
+
+	if d := s.AverageLifeDate(); !d.Equal(time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("wrong average life date; got: %v", d)
+	}
+
+	// at par, all yields equal the coupon (continuously compounded)
+	par := s.Outstanding()
+	expected := 100.0 * math.Log(1.03)
+	ytal, err := s.YieldToAverageLife(par)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(ytal-expected) > 1e-4 {
+		t.Errorf("wrong yield to average life; got: %v, expected: %v", ytal, expected)
+	}
+
+	yields, err := s.YieldsToSinks(dirty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(yields) != 5 {
+		t.Fatalf("wrong number of sink yields; got: %v, expected: %v", len(yields), 5)
+	}
+	if !yields[4].Date.Equal(s.Maturity) {
+		t.Errorf("last yield is not to maturity; got: %v", yields[4].Date)
+	}
+	// the yield to maturity recovers the flat rate
+	if math.Abs(yields[4].Yield-2.0) > 1e-4 {
+		t.Errorf("wrong yield to maturity; got: %v, expected: %v", yields[4].Yield, 2.0)
+	}
+	for i := 1; i < len(yields); i += 1 {
+		if !yields[i].Date.After(yields[i-1].Date) {
+			t.Errorf("sink yields not ordered by date")
+		}
+	}
+}
+
+func TestSinking_RedeemedAt(t *testing.T) {
+	s := sinker()
+
+	// redemption in the middle of the coupon period from 2029-06-01 to 2030-06-01
+	redeemed := s.RedeemedAt(time.Date(2029, 12, 1, 0, 0, 0, 0, time.UTC))
+	cfs := redeemed.Cashflows()
+	if len(cfs) != 9 {
+		t.Fatalf("wrong number of cash flows; got: %d, expected: %d", len(cfs), 9)
+	}
+
+	// the coupon dates of the schedule are kept
+	for i, cf := range cfs[:8] {
+		if date := time.Date(2022+i, 6, 1, 0, 0, 0, 0, time.UTC); !cf.Date.Equal(date) {
+			t.Errorf("wrong coupon date nr %d; got: %v, expected: %v", i, cf.Date, date)
+		}
+	}
+	if amount := cfs[7].Amount; math.Abs(amount-(0.03*80.0+10.0)) > 1e-12 {
+		t.Errorf("wrong payment at the last sink; got: %v, expected: %v", amount, 0.03*80.0+10.0)
+	}
+
+	// final stub: 70 outstanding with half a coupon period of interest
+	last := cfs[8]
+	if !last.Date.Equal(time.Date(2029, 12, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("wrong redemption date; got: %v", last.Date)
+	}
+	if math.Abs(last.T-8.5) > 1e-12 {
+		t.Errorf("wrong time to redemption; got: %v, expected: %v", last.T, 8.5)
+	}
+	if math.Abs(last.Amount-(70.0+70.0*0.03*0.5)) > 1e-12 {
+		t.Errorf("wrong final payment; got: %v, expected: %v", last.Amount, 70.0+70.0*0.03*0.5)
+	}
+	if wal := redeemed.WAL(); math.Abs(wal-(6.0*10.0+7.0*10.0+8.0*10.0+8.5*70.0)/100.0) > 1e-12 {
+		t.Errorf("wrong weighted average life; got: %v", wal)
+	}
+
+	// the accrued interest follows the coupon schedule
+	if a, e := redeemed.Accrued(), s.Accrued(); a != e {
+		t.Errorf("wrong accrued interest; got: %v, expected: %v", a, e)
+	}
+
+	// redemption at maturity leaves the bond unchanged
+	ts := term.Flat{R: 2.0}
+	if pv := s.RedeemedAt(s.Maturity).PresentValue(&ts); math.Abs(pv-s.PresentValue(&ts)) > 1e-12 {
+		t.Errorf("wrong value for redemption at maturity; got: %v, expected: %v", pv, s.PresentValue(&ts))
+	}
+}
@@ -1,7 +1,23 @@
+// Package bond values bonds off a term structure. The bonds only read their
+// terms when they are valued, so one bond can be priced from several
+// goroutines, e.g. with a shared term structure (see term.Structure). Redeem
+// modifies the bond and must not be called while it is shared; value a
+// shared bond at other settlement dates with the methods PresentValueAt,
+// AccruedAt and AsOf, which work on copies, instead of setting Settlement.
 package bond
 
 import (
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/calendar"
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/logging"
 	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/rounding"
 	"github.com/konimarti/fixedincome/pkg/term"
 )
 
@@ -10,70 +26,329 @@ type Straight struct {
 	maturity.Schedule
 	Coupon     float64
 	Redemption float64
+	// Par is the notional base of prices, accrued interest and cash flows
+	// (e.g. 1 for unit notionals; default: 0 for per 100 of par)
+	Par float64
+	// Flat marks a bond that trades flat (e.g. defaulted debt) without
+	// accrued interest; it is valued off the expected Recovery payments
+	Flat     bool
+	Recovery []Recovery
+	// ExDividend is the number of business days before a coupon date from
+	// which the bond trades ex-dividend, i.e. the buyer does not receive the
+	// next coupon (default: 0 for no ex-dividend period)
+	ExDividend int
+	// Calendar provides the business days for the ex-dividend date and the
+	// adjusted maturity date (default: nil for weekends only)
+	Calendar *calendar.Calendar
+	// MaturityAdjusted pays the final coupon and the redemption on the
+	// maturity date rolled to a business day with the MaturityConvention;
+	// the coupon schedule and the accrued interest keep the nominal
+	// maturity date (default: false for payment on the maturity date)
+	MaturityAdjusted   bool
+	MaturityConvention calendar.Convention
+	// CouponRounding rounds the coupon per period on a unit face value to
+	// match the published coupon amounts (default: nil for no rounding)
+	CouponRounding *rounding.Coupon
+	// Factor is the outstanding fraction of the original face after partial
+	// redemptions (e.g. a pool factor or 0.6 after a partial call of 40%);
+	// prices, accrued interest and cash flows are per original face
+	// (default: 0 for the full face outstanding)
+	Factor float64
+	// Metadata describes the bond for reporting (default: nil for none)
+	Metadata *fixedincome.Metadata
+}
+
+// ParValue returns the notional base of prices, accrued interest and cash flows
+func (b *Straight) ParValue() float64 {
+	return parValue(b.Par)
+}
+
+// RedemptionValue returns the redemption amount in the notional base of the
+// prices (i.e. the redemption per 100 of par on the outstanding face)
+func (b *Straight) RedemptionValue() float64 {
+	return b.outstanding(b.Redemption)
+}
+
+// OutstandingFactor returns the outstanding fraction of the original face
+func (b *Straight) OutstandingFactor() float64 {
+	if b.Factor == 0.0 {
+		return 1.0
+	}
+	return b.Factor
+}
+
+// outstanding converts a value per 100 of original face to the notional base
+// par on the outstanding face
+func (b *Straight) outstanding(x float64) float64 {
+	return scale(b.Par, x) * b.OutstandingFactor()
 }
 
-// Accrued calculated the accrued interest
+// Validate checks the schedule and the terms of the bond
+func (b *Straight) Validate() error {
+	if err := b.Schedule.Validate(); err != nil {
+		return err
+	}
+	if b.Redemption < 0.0 || math.IsNaN(b.Redemption) {
+		return fmt.Errorf("%w: redemption value %v is not valid", ErrInvalidBond, b.Redemption)
+	}
+	if b.Par < 0.0 || math.IsNaN(b.Par) || math.IsInf(b.Par, 0) {
+		return fmt.Errorf("%w: par value %v is not valid", ErrInvalidBond, b.Par)
+	}
+	if math.IsNaN(b.Coupon) || math.IsInf(b.Coupon, 0) {
+		return fmt.Errorf("%w: coupon %v is not valid", ErrInvalidBond, b.Coupon)
+	}
+	if b.Factor < 0.0 || b.Factor > 1.0 || math.IsNaN(b.Factor) {
+		return fmt.Errorf("%w: factor %v is not valid", ErrInvalidBond, b.Factor)
+	}
+	if b.ExDividend < 0 {
+		return fmt.Errorf("%w: ex-dividend period %d is not valid", ErrInvalidBond, b.ExDividend)
+	}
+	for _, r := range b.Recovery {
+		if r.Amount < 0.0 || math.IsNaN(r.Amount) || math.IsInf(r.Amount, 0) {
+			return fmt.Errorf("%w: recovery amount %v is not valid", ErrInvalidBond, r.Amount)
+		}
+	}
+	return nil
+}
+
+// Accrued calculated the accrued interest (zero if the bond trades flat and
+// negative if the bond trades ex-dividend)
 func (b *Straight) Accrued() float64 {
-	return b.Coupon * b.DayCountFraction()
+	if b.Flat {
+		return 0.0
+	}
+	accrued := b.Coupon * b.DayCountFraction()
+	if b.IsExDividend() {
+		accrued -= b.nextPeriodCoupon()
+	}
+	return b.outstanding(accrued)
+}
+
+// AccruedBig returns the accrued interest amount for the given nominal with
+// arbitrary-precision arithmetic on the exact float64 coupon, day-count
+// fraction and factor; if round is not nil, the amount is rounded
+// (e.g. with rounding.ForCurrency("CHF").RoundBig to currency cents)
+func (b *Straight) AccruedBig(nominal float64, round func(*big.Float) *big.Float) *big.Float {
+	if b.Flat {
+		return cashflow.NewBig(0.0)
+	}
+	accrued := cashflow.NewBig(b.Coupon)
+	accrued.Mul(accrued, cashflow.NewBig(b.DayCountFraction()))
+	if b.IsExDividend() {
+		// the same (rounded) coupon as the ex-dividend branch of Accrued
+		accrued.Sub(accrued, cashflow.NewBig(b.nextPeriodCoupon()))
+	}
+	accrued.Mul(accrued, cashflow.NewBig(nominal))
+	accrued.Mul(accrued, cashflow.NewBig(b.OutstandingFactor()))
+	accrued.Quo(accrued, cashflow.NewBig(100.0))
+	if round != nil {
+		accrued = round(accrued)
+	}
+	return accrued
 }
 
 // PresentValue returns the "dirty" bond prices
 // (for the "clean" price just subtract the accrued interest)
 func (b *Straight) PresentValue(ts term.Structure) float64 {
-	dcf := 0.0
+	if b.Flat {
+		return b.recoveries().PresentValue(ts)
+	}
+
+	// sum in a fixed order: coupon payments by maturity, then redemption
+	dcf := cashflow.NewSum()
 
 	// discount coupon payments
-	effCoupon := b.EffectiveCoupon(b.Coupon)
-	for _, m := range b.M() {
-		dcf += effCoupon * ts.Z(m)
+	effCoupon := b.periodCoupon()
+	next := len(b.M()) - 1
+	for i, m := range b.coupons() {
+		coupon := effCoupon
+		if i == next {
+			coupon = b.nextPeriodCoupon()
+		}
+		dcf.AddProduct(coupon, ts.Z(m))
 	}
 
-	// discount redemption value
-	dcf += b.Redemption * ts.Z(b.Last())
+	// discount redemption value (if bond has not matured yet)
+	if _, last := b.redemption(); last > 0.0 {
+		dcf.AddProduct(b.Redemption, ts.Z(last))
+	}
 
-	return dcf
+	return b.outstanding(dcf.Value())
 }
 
-// Duration calculates the duration of the bond
-// dP/P = -D * dr
-func (b *Straight) Duration(ts term.Structure) float64 {
-	duration := 0.0
+// PresentValueAt returns the "dirty" bond price for the given settlement date
+// without modifying the bond
+func (b *Straight) PresentValueAt(settlement time.Time, ts term.Structure) float64 {
+	c := *b
+	c.Settlement = settlement
+	return c.PresentValue(ts)
+}
 
-	p := b.PresentValue(ts)
-	if p == 0.0 {
-		return 0.0
+// AccruedAt returns the accrued interest for the given settlement date
+// without modifying the bond
+func (b *Straight) AccruedAt(settlement time.Time) float64 {
+	c := *b
+	c.Settlement = settlement
+	return c.Accrued()
+}
+
+// AsOf returns a copy of the bond for the valuation date with the remaining
+// cash flows and accrued interest as of that date
+func (b *Straight) AsOf(date time.Time) (*Straight, error) {
+	schedule, err := b.Schedule.AsOf(date)
+	if err != nil {
+		return nil, err
 	}
+	c := *b
+	c.Schedule = schedule
+	return &c, nil
+}
 
-	// discount coupon payments
-	effCoupon := b.EffectiveCoupon(b.Coupon)
-	for _, m := range b.M() {
-		duration += m * effCoupon * ts.Z(m)
+// Cashflows returns the outstanding coupon and redemption payments
+// (or the expected recovery payments if the bond trades flat)
+// ordered by payment date
+func (b *Straight) Cashflows() cashflow.Cashflows {
+	if b.Flat {
+		return b.recoveries()
 	}
 
-	// discount redemption value
-	duration += b.Last() * b.Redemption * ts.Z(b.Last())
+	cfs := cashflow.Cashflows{}
 
-	return -duration / p
+	effCoupon := b.periodCoupon()
+	maturities := b.M()
+	dates := b.Dates()
+	for i, date := range dates {
+		amount := effCoupon
+		if i == len(dates)-1 {
+			amount = b.nextPeriodCoupon()
+			if b.IsExDividend() {
+				// next coupon is paid to the seller
+				amount = 0.0
+			}
+		}
+		t := maturities[i]
+		if i == 0 {
+			// cash flows are generated backwards from the maturity date
+			amount += b.Redemption
+			date, t = b.redemption()
+		}
+		cfs = append(cfs, cashflow.Cashflow{Date: date, T: t, Amount: b.outstanding(amount)})
+	}
+	cfs.Sort()
+
+	if logging.Debug() {
+		for _, cf := range cfs {
+			logging.Logger().Debug("cashflow", "maturity", b.Maturity.Format("2006-01-02"), "date", cf.Date.Format("2006-01-02"), "t", cf.T, "amount", cf.Amount)
+		}
+	}
+	return cfs
+}
+
+// WAL returns the weighted average life in years, i.e. the years to maturity
+// as the principal is repaid at maturity (or the average time of the expected
+// recovery payments if the bond trades flat)
+func (b *Straight) WAL() float64 {
+	if b.Flat {
+		cfs := b.recoveries()
+		t, amounts := make([]float64, len(cfs)), make([]float64, len(cfs))
+		for i, cf := range cfs {
+			t[i], amounts[i] = cf.T, cf.Amount
+		}
+		return averageLife(t, amounts)
+	}
+	_, last := b.redemption()
+	return last
+}
+
+// Duration calculates the duration of the bond
+// dP/P = -D * dr
+func (b *Straight) Duration(ts term.Structure) float64 {
+	return duration(b.Cashflows(), ts)
 }
 
 // Convexity calculates the modified duration of the bond
 // dP/P = -D * dr + 1/2 * C * dr^2
 func (b *Straight) Convexity(ts term.Structure) float64 {
-	convex := 0.0
+	return convexity(b.Cashflows(), ts)
+}
 
-	p := b.PresentValue(ts)
-	if p == 0.0 {
-		return 0.0
+// IsExDividend returns true if the settlement date is in the ex-dividend
+// period before the next coupon date
+func (b *Straight) IsExDividend() bool {
+	if b.ExDividend <= 0 {
+		return false
 	}
+	ex := b.NextCoupon()
+	if ex.IsZero() {
+		return false
+	}
+	cal := b.Calendar
+	if cal == nil {
+		cal = calendar.New()
+	}
+	for i := 0; i < b.ExDividend; i += 1 {
+		ex = cal.Adjust(ex.AddDate(0, 0, -1), calendar.Preceding)
+	}
+	return !b.Settlement.Before(ex)
+}
 
-	// discount coupon payments
-	effCoupon := b.EffectiveCoupon(b.Coupon)
-	for _, m := range b.M() {
-		convex += m * m * effCoupon * ts.Z(m)
+// redemption returns the payment date and the maturity in years of the
+// redemption (see MaturityAdjusted)
+func (b *Straight) redemption() (time.Time, float64) {
+	last := b.Last()
+	if !b.MaturityAdjusted || last <= 0.0 {
+		return b.Maturity, last
 	}
+	dc, err := b.DayCounter()
+	if err != nil {
+		return b.Maturity, last
+	}
+	cal := b.Calendar
+	if cal == nil {
+		cal = calendar.New()
+	}
+	date := cal.Adjust(b.Maturity, b.MaturityConvention)
+	return date, maturity.YearFraction(dc, b.Settlement, date)
+}
 
-	// discount redemption value
-	convex += b.Last() * b.Last() * b.Redemption * ts.Z(b.Last())
+// periodCoupon returns the coupon paid per period per 100 of par
+func (b *Straight) periodCoupon() float64 {
+	coupon := b.EffectiveCoupon(b.Coupon)
+	if b.CouponRounding != nil {
+		return b.CouponRounding.PerPeriod(coupon)
+	}
+	return coupon
+}
+
+// nextPeriodCoupon returns the next coupon per 100 of par, which is pro-rated
+// in the first coupon period if the issue date is off the coupon schedule
+// (see firstPeriod)
+func (b *Straight) nextPeriodCoupon() float64 {
+	coupon := b.periodCoupon()
+	if b.Issue.IsZero() || !b.Issue.After(b.PreviousCoupon()) {
+		return coupon
+	}
+	dc, err := b.DayCounter()
+	if err != nil {
+		return coupon
+	}
+	fraction, err := b.firstPeriod(dc)
+	if err != nil {
+		return coupon
+	}
+	return coupon * fraction
+}
 
-	return convex / p
+// coupons returns the maturities of the coupons paid to the holder
+// (without the next coupon if the bond trades ex-dividend)
+func (b *Straight) coupons() []float64 {
+	m := b.M()
+	if len(m) > 0 && b.MaturityAdjusted {
+		// the final coupon is paid with the redemption
+		_, m[0] = b.redemption()
+	}
+	if len(m) > 0 && b.IsExDividend() {
+		return m[:len(m)-1]
+	}
+	return m
 }
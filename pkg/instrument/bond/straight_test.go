@@ -1,12 +1,15 @@
 package bond_test
 
 import (
+	"errors"
 	"math"
 	"testing"
 	"time"
 
+	"github.com/konimarti/fixedincome/pkg/calendar"
 	"github.com/konimarti/fixedincome/pkg/instrument/bond"
 	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/rounding"
 	"github.com/konimarti/fixedincome/pkg/term"
 )
 
@@ -143,3 +146,406 @@ func TestStraight_DurationConvexity(t *testing.T) {
 		}
 	}
 }
+
+func TestStraight_Cashflows(t *testing.T) {
+	bond := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2026, 5, 28, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+		},
+		Coupon:     1.25,
+		Redemption: 100.0,
+	}
+	ts := term.Flat{R: 1.0}
+
+	cfs := bond.Cashflows()
+	if len(cfs) != 6 {
+		t.Fatalf("wrong number of cash flows; got: %d, expected: %d", len(cfs), 6)
+	}
+	if !cfs[0].Date.Equal(time.Date(2021, 5, 28, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("wrong first payment date; got: %v", cfs[0].Date)
+	}
+	if cfs[5].Amount != 101.25 {
+		t.Errorf("wrong last payment; got: %v, expected: %v", cfs[5].Amount, 101.25)
+	}
+	if math.Abs(cfs.PresentValue(&ts)-bond.PresentValue(&ts)) > 1e-9 {
+		t.Errorf("present value of cash flows does not match; got: %v, expected: %v", cfs.PresentValue(&ts), bond.PresentValue(&ts))
+	}
+}
+
+func TestStraight_AsOf(t *testing.T) {
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Maturity:  time.Date(2026, 5, 28, 0, 0, 0, 0, time.UTC),
+			Frequency: 1,
+			Issue:     time.Date(2016, 5, 28, 0, 0, 0, 0, time.UTC),
+		},
+		Coupon:     1.25,
+		Redemption: 100.0,
+	}
+	ts := term.Flat{R: 1.0}
+
+	asOf, err := b.AsOf(time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref := b
+	ref.Settlement = time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	if math.Abs(asOf.PresentValue(&ts)-ref.PresentValue(&ts)) > 1e-12 {
+		t.Errorf("wrong as-of value; got: %v, expected: %v", asOf.PresentValue(&ts), ref.PresentValue(&ts))
+	}
+
+	if _, err := b.AsOf(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Errorf("valuation after maturity should fail")
+	}
+
+	// matured bonds have no value
+	ref.Settlement = time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	if v := ref.PresentValue(&ts); v != 0.0 {
+		t.Errorf("matured bond should have zero value; got: %v", v)
+	}
+}
+
+func TestStraight_AccruedBig(t *testing.T) {
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 17, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2026, 5, 28, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+		},
+		Coupon:     1.25,
+		Redemption: 100.0,
+	}
+
+	// 319 days (30E/360) of accrued interest on a nominal of 1'000'000
+	accrued := b.AccruedBig(1e6, rounding.ForCurrency("CHF").RoundBig).Text('f', 2)
+	if accrued != "11076.39" {
+		t.Errorf("wrong accrued amount; got: %s, expected: %s", accrued, "11076.39")
+	}
+}
+
+func TestStraight_Validate(t *testing.T) {
+	valid := maturity.Schedule{
+		Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+		Maturity:   time.Date(2026, 5, 28, 0, 0, 0, 0, time.UTC),
+		Frequency:  2,
+		Basis:      "ACT360",
+	}
+
+	testData := []struct {
+		Bond     bond.Straight
+		Expected error
+	}{
+		{
+			Bond:     bond.Straight{Schedule: valid, Coupon: 1.25, Redemption: 100.0},
+			Expected: nil,
+		},
+		{
+			Bond: bond.Straight{Schedule: maturity.Schedule{
+				Settlement: valid.Maturity,
+				Maturity:   valid.Settlement,
+			}, Coupon: 1.25, Redemption: 100.0},
+			Expected: maturity.ErrInvalidSchedule,
+		},
+		{
+			Bond: bond.Straight{Schedule: maturity.Schedule{
+				Settlement: valid.Settlement,
+				Maturity:   valid.Maturity,
+				Frequency:  -1,
+			}, Coupon: 1.25, Redemption: 100.0},
+			Expected: maturity.ErrInvalidSchedule,
+		},
+		{
+			Bond: bond.Straight{Schedule: maturity.Schedule{
+				Settlement: valid.Settlement,
+				Maturity:   valid.Maturity,
+				Frequency:  5,
+			}, Coupon: 1.25, Redemption: 100.0},
+			Expected: maturity.ErrInvalidSchedule,
+		},
+		{
+			Bond: bond.Straight{Schedule: maturity.Schedule{
+				Settlement: valid.Settlement,
+				Maturity:   valid.Maturity,
+				Basis:      "ACT/364",
+			}, Coupon: 1.25, Redemption: 100.0},
+			Expected: maturity.ErrInvalidSchedule,
+		},
+		{
+			Bond:     bond.Straight{Schedule: valid, Coupon: 1.25, Redemption: -100.0},
+			Expected: bond.ErrInvalidBond,
+		},
+		{
+			Bond:     bond.Straight{Schedule: valid, Coupon: math.NaN(), Redemption: 100.0},
+			Expected: bond.ErrInvalidBond,
+		},
+	}
+
+	for nr, test := range testData {
+		err := test.Bond.Validate()
+		if test.Expected == nil && err != nil {
+			t.Errorf("test nr %d, unexpected error: %v", nr, err)
+		}
+		if test.Expected != nil && !errors.Is(err, test.Expected) {
+			t.Errorf("test nr %d, got error: %v, expected: %v", nr, err, test.Expected)
+		}
+	}
+}
+
+func BenchmarkStraight_PresentValue(b *testing.B) {
+	bond := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2046, 5, 28, 0, 0, 0, 0, time.UTC),
+			Frequency:  2,
+			Basis:      "30E360",
+		},
+		Coupon:     1.25,
+		Redemption: 100.0,
+	}
+	ts := term.NelsonSiegelSvensson{B0: -0.266372, B1: -0.471343, B2: 5.68789, B3: -5.12324, T1: 5.74881, T2: 4.14426}
+	b.ResetTimer()
+	for i := 0; i < b.N; i += 1 {
+		bond.PresentValue(&ts)
+	}
+}
+
+func BenchmarkStraight_Duration(b *testing.B) {
+	bond := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2046, 5, 28, 0, 0, 0, 0, time.UTC),
+			Frequency:  2,
+			Basis:      "30E360",
+		},
+		Coupon:     1.25,
+		Redemption: 100.0,
+	}
+	ts := term.NelsonSiegelSvensson{B0: -0.266372, B1: -0.471343, B2: 5.68789, B3: -5.12324, T1: 5.74881, T2: 4.14426}
+	b.ResetTimer()
+	for i := 0; i < b.N; i += 1 {
+		bond.Duration(&ts)
+	}
+}
+
+func TestStraight_Par(t *testing.T) {
+	ts := term.NelsonSiegelSvensson{B0: -0.266372, B1: -0.471343, B2: 5.68789, B3: -5.12324, T1: 5.74881, T2: 4.14426}
+	settlement := time.Date(2021, 4, 17, 0, 0, 0, 0, time.UTC)
+	ref := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: settlement,
+			Maturity:   time.Date(2026, 5, 28, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+		},
+		Coupon:     1.25,
+		Redemption: 100.0,
+	}
+
+	for _, par := range []float64{1.0, 100.0, 1000.0, 10000.0} {
+		b := ref
+		b.Par = par
+		factor := par / 100.0
+		if math.Abs(b.PresentValue(&ts)-factor*ref.PresentValue(&ts)) > 1e-9 {
+			t.Errorf("par %v: wrong present value; got: %v, expected: %v", par, b.PresentValue(&ts), factor*ref.PresentValue(&ts))
+		}
+		if math.Abs(b.Accrued()-factor*ref.Accrued()) > 1e-9 {
+			t.Errorf("par %v: wrong accrued interest; got: %v, expected: %v", par, b.Accrued(), factor*ref.Accrued())
+		}
+		if math.Abs(b.Duration(&ts)-ref.Duration(&ts)) > 1e-12 {
+			t.Errorf("par %v: duration depends on par; got: %v, expected: %v", par, b.Duration(&ts), ref.Duration(&ts))
+		}
+		if math.Abs(b.Cashflows().PresentValue(&ts)-b.PresentValue(&ts)) > 1e-9 {
+			t.Errorf("par %v: cash flows do not match present value", par)
+		}
+		// the invoice amount does not depend on the quoting base
+		invoice := bond.Invoice(&b, settlement, factor*109.70, 50000.0)
+		if expected := bond.Invoice(&ref, settlement, 109.70, 50000.0); math.Abs(invoice-expected) > 1e-8 {
+			t.Errorf("par %v: wrong invoice amount; got: %v, expected: %v", par, invoice, expected)
+		}
+	}
+
+	b := ref
+	b.Par = -1.0
+	if err := b.Validate(); !errors.Is(err, bond.ErrInvalidBond) {
+		t.Errorf("expected ErrInvalidBond for negative par; got: %v", err)
+	}
+}
+
+func TestStraight_ExDividend(t *testing.T) {
+	// coupon on Sunday 2021-03-07, ex-dividend date 7 business days before on 2021-02-25
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2031, 3, 7, 0, 0, 0, 0, time.UTC),
+			Frequency:  2,
+			Basis:      "ACTACT",
+		},
+		Coupon:     4.0,
+		Redemption: 100.0,
+		ExDividend: 7,
+	}
+	if err := b.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	cum := b
+	cum.ExDividend = 0
+	ts := term.Flat{R: 2.0}
+
+	testData := []struct {
+		Settlement time.Time
+		Ex         bool
+	}{
+		{time.Date(2021, 2, 24, 0, 0, 0, 0, time.UTC), false},
+		{time.Date(2021, 2, 25, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2021, 3, 8, 0, 0, 0, 0, time.UTC), false},
+	}
+	for _, test := range testData {
+		b.Settlement, cum.Settlement = test.Settlement, test.Settlement
+		if b.IsExDividend() != test.Ex {
+			t.Errorf("wrong ex-dividend flag at %v; got: %v, expected: %v", test.Settlement, b.IsExDividend(), test.Ex)
+		}
+		coupon := 0.0
+		if test.Ex {
+			coupon = 2.0
+		}
+		if a := b.Accrued(); math.Abs(a-(cum.Accrued()-coupon)) > 1e-12 {
+			t.Errorf("wrong accrued interest at %v; got: %v, expected: %v", test.Settlement, a, cum.Accrued()-coupon)
+		}
+		if pv := b.PresentValue(&ts); math.Abs(pv-(cum.PresentValue(&ts)-coupon*ts.Z(cum.Next()))) > 1e-10 {
+			t.Errorf("wrong present value at %v; got: %v", test.Settlement, pv)
+		}
+		if pv := b.Cashflows().PresentValue(&ts); math.Abs(pv-b.PresentValue(&ts)) > 1e-10 {
+			t.Errorf("cash flows inconsistent with present value at %v; got: %v, expected: %v", test.Settlement, pv, b.PresentValue(&ts))
+		}
+	}
+
+	// negative accrued interest of the 6 days to the coupon in a period of 181 days
+	b.Settlement = time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	if a := b.Accrued(); math.Abs(a+2.0*6.0/181.0) > 1e-12 {
+		t.Errorf("wrong ex-dividend accrued interest; got: %v, expected: %v", a, -2.0*6.0/181.0)
+	}
+	if a, _ := b.AccruedBig(100.0, nil).Float64(); math.Abs(a-b.Accrued()) > 1e-12 {
+		t.Errorf("wrong ex-dividend accrued amount; got: %v, expected: %v", a, b.Accrued())
+	}
+
+	// both subtract the same rounded coupon of 0.563 per 100
+	b.Coupon = 1.125
+	b.CouponRounding = &rounding.Coupon{Face: 1000.0, Policy: rounding.Policy{Decimals: 2, Mode: rounding.HalfUp}}
+	if a, _ := b.AccruedBig(100.0, nil).Float64(); math.Abs(a-b.Accrued()) > 1e-12 {
+		t.Errorf("wrong rounded ex-dividend accrued amount; got: %v, expected: %v", a, b.Accrued())
+	}
+	if expected := 1.125*b.DayCountFraction() - 0.563; math.Abs(b.Accrued()-expected) > 1e-12 {
+		t.Errorf("wrong rounded ex-dividend accrued interest; got: %v, expected: %v", b.Accrued(), expected)
+	}
+}
+
+func TestStraight_CouponRounding(t *testing.T) {
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2031, 2, 15, 0, 0, 0, 0, time.UTC),
+			Frequency:  2,
+		},
+		Coupon:         1.125,
+		Redemption:     100.0,
+		CouponRounding: &rounding.Coupon{Face: 1000.0, Policy: rounding.Policy{Decimals: 2, Mode: rounding.HalfUp}},
+	}
+	cfs := b.Cashflows()
+	// 5.625 per 1000 face is published as 5.63
+	if math.Abs(cfs[0].Amount-0.563) > 1e-12 || math.Abs(cfs[len(cfs)-1].Amount-100.563) > 1e-12 {
+		t.Errorf("wrong rounded coupons; got: %v, %v", cfs[0].Amount, cfs[len(cfs)-1].Amount)
+	}
+	ts := term.Flat{R: 1.0}
+	if pv := cfs.PresentValue(&ts); math.Abs(pv-b.PresentValue(&ts)) > 1e-10 {
+		t.Errorf("cash flows inconsistent with present value; got: %v, expected: %v", pv, b.PresentValue(&ts))
+	}
+	// accrued interest is not affected
+	if a := b.Accrued(); math.Abs(a-1.125*b.DayCountFraction()) > 1e-12 {
+		t.Errorf("wrong accrued interest; got: %v, expected: %v", a, 1.125*b.DayCountFraction())
+	}
+}
+
+func TestStraight_MaturityAdjusted(t *testing.T) {
+	// the maturity date is a Sunday
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2025, 11, 30, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+			Basis:      "30E360",
+		},
+		Coupon:     2.0,
+		Redemption: 100.0,
+	}
+	ts := term.Flat{R: 3.0}
+	unadjusted, accrued := b.PresentValue(&ts), b.Accrued()
+
+	var testData = []struct {
+		Convention calendar.Convention
+		Date       time.Time
+		T          float64
+	}{
+		{calendar.Following, time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), 526.0 / 360.0},
+		{calendar.ModifiedFollowing, time.Date(2025, 11, 28, 0, 0, 0, 0, time.UTC), 523.0 / 360.0},
+	}
+	for _, test := range testData {
+		b.MaturityAdjusted, b.MaturityConvention = true, test.Convention
+		cfs := b.Cashflows()
+		last := cfs[len(cfs)-1]
+		if !last.Date.Equal(test.Date) || math.Abs(last.T-test.T) > 1e-12 || last.Amount != 102.0 {
+			t.Errorf("wrong redemption cash flow; got: %v, expected: %v at %v", last, test.Date, test.T)
+		}
+		if expected := cfs.PresentValue(&ts); math.Abs(b.PresentValue(&ts)-expected) > 1e-12 {
+			t.Errorf("wrong present value; got: %v, expected: %v", b.PresentValue(&ts), expected)
+		}
+		if expected := 2.0*ts.Z(165.0/360.0) + 102.0*ts.Z(test.T); math.Abs(b.PresentValue(&ts)-expected) > 1e-12 {
+			t.Errorf("wrong present value; got: %v, expected: %v", b.PresentValue(&ts), expected)
+		}
+		// the accrual schedule keeps the nominal maturity date
+		if b.Accrued() != accrued || !b.Dates()[0].Equal(b.Maturity) {
+			t.Errorf("wrong accrued interest; got: %v, expected: %v", b.Accrued(), accrued)
+		}
+	}
+	if b.PresentValue(&ts) == unadjusted {
+		t.Error("present value not adjusted")
+	}
+}
+
+func TestStraight_FirstCoupon(t *testing.T) {
+	// issued off the coupon schedule, the first coupon accrues from the issue
+	// date over 105 of 360 days (30E/360)
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2026, 5, 25, 0, 0, 0, 0, time.UTC),
+			Issue:      time.Date(2021, 2, 10, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+			Basis:      "30E360",
+		},
+		Coupon:     3.0,
+		Redemption: 100.0,
+	}
+	if a := b.Accrued(); math.Abs(a-3.0*21.0/360.0) > 1e-12 {
+		t.Errorf("wrong accrued interest; got: %v, expected: %v", a, 3.0*21.0/360.0)
+	}
+	cfs := b.Cashflows()
+	if first := 3.0 * 105.0 / 360.0; math.Abs(cfs[0].Amount-first) > 1e-12 || cfs[1].Amount != 3.0 {
+		t.Errorf("wrong coupons; got: %v, %v, expected: %v, %v", cfs[0].Amount, cfs[1].Amount, first, 3.0)
+	}
+	ts := term.Flat{R: 2.0}
+	if pv := cfs.PresentValue(&ts); math.Abs(pv-b.PresentValue(&ts)) > 1e-10 {
+		t.Errorf("cash flows inconsistent with present value; got: %v, expected: %v", pv, b.PresentValue(&ts))
+	}
+
+	// the same first coupon in the history after its payment date
+	b.Settlement = time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	history, err := b.CouponHistory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 || math.Abs(history[0].Amount-cfs[0].Amount) > 1e-12 {
+		t.Errorf("first coupon inconsistent with history; got: %v, expected: %v", history, cfs[0].Amount)
+	}
+}
@@ -1,3 +1,6 @@
+// Package forward values forward contracts and forward rate agreements; the
+// contracts are only read when they are valued and can be shared across
+// goroutines.
 package forward
 
 import (
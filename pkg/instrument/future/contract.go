@@ -0,0 +1,104 @@
+// Package future analyzes bond futures and their delivery baskets; the
+// analytics only read the contracts and their deliverable bonds, which can
+// thus be shared across goroutines.
+package future
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+)
+
+// ErrNotDeliverable is returned when a bond cannot be delivered into the contract
+var ErrNotDeliverable = errors.New("bond not deliverable")
+
+// Deliverable is a bond in the delivery basket of a bond futures contract
+type Deliverable struct {
+	// Bond is the deliverable bond with the spot settlement date
+	Bond *bond.Straight
+	// Price is the clean market price of the bond for spot settlement
+	Price float64
+	// ConversionFactor is the conversion factor of the bond for the contract
+	ConversionFactor float64
+}
+
+// Contract is a bond futures contract
+type Contract struct {
+	// Name of the contract, e.g. "RXZ1"
+	Name string
+	// Delivery is the delivery date of the contract
+	Delivery time.Time
+	// Price is the futures price
+	Price float64
+	// Basket are the deliverable bonds
+	Basket []Deliverable
+}
+
+// Basis is the basis of a deliverable bond against the futures price
+type Basis struct {
+	// Gross is the clean price less the futures price times the conversion factor
+	Gross float64
+	// Carry is the coupon income less the financing of the dirty price at
+	// the repo rate to the delivery date
+	Carry float64
+	// Net is the gross basis less the carry
+	Net float64
+	// ImpliedRepo is the repo rate in percent (money market, ACT/360) at
+	// which buying the bond and delivering it into the contract breaks even
+	ImpliedRepo float64
+}
+
+// Basis returns the basis of the deliverable bond for the repo rate in
+// percent (money market, ACT/360) to the delivery date
+func (c *Contract) Basis(d Deliverable, repo float64) (Basis, error) {
+	b := d.Bond
+	if d.ConversionFactor <= 0.0 {
+		return Basis{}, fmt.Errorf("%w: conversion factor %v not positive", ErrNotDeliverable, d.ConversionFactor)
+	}
+	if !c.Delivery.After(b.Settlement) || !b.Maturity.After(c.Delivery) {
+		return Basis{}, fmt.Errorf("%w: delivery %s not between settlement %s and maturity %s",
+			ErrNotDeliverable, c.Delivery.Format("2006-01-02"), b.Settlement.Format("2006-01-02"), b.Maturity.Format("2006-01-02"))
+	}
+
+	// coupons paid to the delivery date
+	coupons := 0.0
+	for _, cf := range b.Cashflows() {
+		if !cf.Date.After(c.Delivery) {
+			coupons += cf.Amount
+		}
+	}
+	accrued := b.Accrued()
+	dirty := d.Price + accrued
+	days := c.Delivery.Sub(b.Settlement).Hours() / 24.0
+	income := coupons + b.AccruedAt(c.Delivery) - accrued
+	invoice := c.Price*d.ConversionFactor + b.AccruedAt(c.Delivery)
+
+	basis := Basis{Gross: d.Price - c.Price*d.ConversionFactor}
+	basis.Carry = income - dirty*repo/100.0*days/360.0
+	basis.Net = basis.Gross - basis.Carry
+	basis.ImpliedRepo = (invoice + coupons - dirty) / dirty * 360.0 / days * 100.0
+	return basis, nil
+}
+
+// CheapestToDeliver returns the deliverable with the lowest net basis for
+// the repo rate, i.e. the highest implied repo rate
+func (c *Contract) CheapestToDeliver(repo float64) (Deliverable, Basis, error) {
+	var ctd Deliverable
+	var best Basis
+	found := false
+	for _, d := range c.Basket {
+		basis, err := c.Basis(d, repo)
+		if err != nil {
+			return ctd, best, err
+		}
+		if !found || basis.Net < best.Net {
+			ctd, best, found = d, basis, true
+		}
+	}
+	if !found {
+		return ctd, best, fmt.Errorf("%w: empty basket of contract %s", ErrNotDeliverable, c.Name)
+	}
+	return ctd, best, nil
+}
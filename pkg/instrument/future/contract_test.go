@@ -0,0 +1,126 @@
+package future_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/instrument/future"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+)
+
+var settlement = time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC)
+
+func straight(maturityDate time.Time, coupon float64) *bond.Straight {
+	return &bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: settlement,
+			Maturity:   maturityDate,
+			Frequency:  1,
+		},
+		Coupon:     coupon,
+		Redemption: 100.0,
+	}
+}
+
+// fair returns the futures price for a zero net basis of the deliverable
+func fair(c *future.Contract, d future.Deliverable, repo float64) float64 {
+	c.Price = 0.0
+	basis, _ := c.Basis(d, repo)
+	return (d.Price - basis.Carry) / d.ConversionFactor
+}
+
+func TestContract_Basis(t *testing.T) {
+	d := future.Deliverable{
+		Bond:             straight(time.Date(2031, 2, 15, 0, 0, 0, 0, time.UTC), 1.0),
+		Price:            104.5,
+		ConversionFactor: 0.65,
+	}
+	c := future.Contract{Name: "RXZ1", Delivery: time.Date(2021, 12, 10, 0, 0, 0, 0, time.UTC)}
+	repo := -0.6
+	c.Price = fair(&c, d, repo)
+
+	basis, err := c.Basis(d, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// coupon income (99 days in 30E/360) and the negative repo rate (100
+	// days in ACT/360) both earn carry
+	carry := 1.0*99.0/360.0 - (104.5+d.Bond.Accrued())*repo/100.0*100.0/360.0
+
+	var testData = []struct {
+		Name     string
+		Value    float64
+		Expected float64
+	}{
+		{"gross basis", basis.Gross, 104.5 - c.Price*0.65},
+		{"carry", basis.Carry, carry},
+		{"net basis", basis.Net, 0.0},
+		{"implied repo", basis.ImpliedRepo, repo},
+	}
+	for _, test := range testData {
+		if math.Abs(test.Value-test.Expected) > 1e-9 {
+			t.Errorf("wrong %s; got: %v, expected: %v", test.Name, test.Value, test.Expected)
+		}
+	}
+
+	// a lower futures price increases the net basis and lowers the implied repo
+	c.Price -= 0.1
+	cheaper, _ := c.Basis(d, repo)
+	if math.Abs(cheaper.Net-0.065) > 1e-9 || cheaper.ImpliedRepo >= repo {
+		t.Errorf("wrong basis for lower futures price; got: %v", cheaper)
+	}
+}
+
+func TestContract_CheapestToDeliver(t *testing.T) {
+	c := future.Contract{
+		Name:     "RXZ1",
+		Delivery: time.Date(2021, 12, 10, 0, 0, 0, 0, time.UTC),
+		Price:    170.0,
+		Basket: []future.Deliverable{
+			{Bond: straight(time.Date(2031, 2, 15, 0, 0, 0, 0, time.UTC), 1.0), Price: 104.5, ConversionFactor: 0.615},
+			{Bond: straight(time.Date(2031, 8, 15, 0, 0, 0, 0, time.UTC), 0.5), Price: 100.0, ConversionFactor: 0.585},
+		},
+	}
+	ctd, basis, err := c.CheapestToDeliver(-0.6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ctd.Bond != c.Basket[0].Bond {
+		t.Errorf("wrong cheapest-to-deliver; got: %v, expected: %v", ctd.Bond.Maturity, c.Basket[0].Bond.Maturity)
+	}
+	for _, d := range c.Basket {
+		other, _ := c.Basis(d, -0.6)
+		if other.Net < basis.Net || other.ImpliedRepo > basis.ImpliedRepo+1e-9 {
+			t.Errorf("wrong cheapest-to-deliver basis; got: %v, expected at most: %v", basis, other)
+		}
+	}
+}
+
+func TestContract_Errors(t *testing.T) {
+	d := future.Deliverable{
+		Bond:             straight(time.Date(2021, 11, 15, 0, 0, 0, 0, time.UTC), 1.0),
+		Price:            100.0,
+		ConversionFactor: 1.0,
+	}
+	c := future.Contract{Name: "RXZ1", Delivery: time.Date(2021, 12, 10, 0, 0, 0, 0, time.UTC), Price: 100.0}
+
+	var testData = []struct {
+		Name string
+		Call func() error
+	}{
+		{"matured bond", func() error { _, err := c.Basis(d, 0.0); return err }},
+		{"conversion factor", func() error {
+			_, err := c.Basis(future.Deliverable{Bond: d.Bond, Price: 100.0}, 0.0)
+			return err
+		}},
+		{"empty basket", func() error { _, _, err := c.CheapestToDeliver(0.0); return err }},
+	}
+	for _, test := range testData {
+		if err := test.Call(); !errors.Is(err, future.ErrNotDeliverable) {
+			t.Errorf("wrong error for %s; got: %v, expected: %v", test.Name, err, future.ErrNotDeliverable)
+		}
+	}
+}
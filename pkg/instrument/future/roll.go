@@ -0,0 +1,71 @@
+package future
+
+import (
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+)
+
+// Roll is the calendar roll of a position from the front to the back contract
+type Roll struct {
+	Front *Contract
+	Back  *Contract
+	// FrontRepo and BackRepo are the repo rates in percent (money market,
+	// ACT/360) to the delivery dates of the contracts
+	FrontRepo float64
+	BackRepo  float64
+}
+
+// RollBasis is the roll of a bond deliverable into both contracts
+type RollBasis struct {
+	Bond  *bond.Straight
+	Front Basis
+	Back  Basis
+	// Spread is the calendar spread of the futures prices (front less back)
+	Spread float64
+	// Fair is the calendar spread for a zero net basis of the bond in both contracts
+	Fair float64
+	// Richness is the calendar spread less the fair spread; the roll is rich
+	// (selling the front and buying the back is favorable) if it is positive
+	Richness float64
+}
+
+// Spread returns the calendar spread of the futures prices (front less back)
+func (r *Roll) Spread() float64 {
+	return r.Front.Price - r.Back.Price
+}
+
+// Analyze returns the gross and net basis of the bonds deliverable into both
+// contracts and the richness of the calendar spread for each bond
+func (r *Roll) Analyze() ([]RollBasis, error) {
+	rolls := []RollBasis{}
+	for _, front := range r.Front.Basket {
+		back, ok := r.Back.deliverable(front.Bond)
+		if !ok {
+			continue
+		}
+		fb, err := r.Front.Basis(front, r.FrontRepo)
+		if err != nil {
+			return nil, err
+		}
+		bb, err := r.Back.Basis(back, r.BackRepo)
+		if err != nil {
+			return nil, err
+		}
+		rb := RollBasis{Bond: front.Bond, Front: fb, Back: bb, Spread: r.Spread()}
+		// fair futures price is F + net basis / conversion factor
+		rb.Fair = rb.Spread + fb.Net/front.ConversionFactor - bb.Net/back.ConversionFactor
+		rb.Richness = rb.Spread - rb.Fair
+		rolls = append(rolls, rb)
+	}
+	return rolls, nil
+}
+
+// deliverable returns the deliverable of the bond (same bond or same
+// coupon and maturity) in the basket of the contract
+func (c *Contract) deliverable(b *bond.Straight) (Deliverable, bool) {
+	for _, d := range c.Basket {
+		if d.Bond == b || (d.Bond.Coupon == b.Coupon && d.Bond.Maturity.Equal(b.Maturity)) {
+			return d, true
+		}
+	}
+	return Deliverable{}, false
+}
@@ -0,0 +1,66 @@
+package future_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/instrument/future"
+)
+
+func TestRoll_Analyze(t *testing.T) {
+	bund := straight(time.Date(2031, 2, 15, 0, 0, 0, 0, time.UTC), 1.0)
+	other := straight(time.Date(2031, 8, 15, 0, 0, 0, 0, time.UTC), 0.5)
+	front := future.Contract{
+		Name:     "RXZ1",
+		Delivery: time.Date(2021, 12, 10, 0, 0, 0, 0, time.UTC),
+		Basket: []future.Deliverable{
+			{Bond: bund, Price: 104.5, ConversionFactor: 0.615},
+			{Bond: other, Price: 100.0, ConversionFactor: 0.585},
+		},
+	}
+	// the back contract has a new deliverable and the same bond with another
+	// conversion factor
+	back := future.Contract{
+		Name:     "RXH2",
+		Delivery: time.Date(2022, 3, 10, 0, 0, 0, 0, time.UTC),
+		Basket: []future.Deliverable{
+			{Bond: straight(bund.Maturity, bund.Coupon), Price: 104.5, ConversionFactor: 0.62},
+			{Bond: straight(time.Date(2032, 2, 15, 0, 0, 0, 0, time.UTC), 0.0), Price: 98.0, ConversionFactor: 0.55},
+		},
+	}
+	front.Price = fair(&front, front.Basket[0], -0.6)
+	back.Price = fair(&back, back.Basket[0], -0.55)
+
+	r := future.Roll{Front: &front, Back: &back, FrontRepo: -0.6, BackRepo: -0.55}
+	rolls, err := r.Analyze()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rolls) != 1 || rolls[0].Bond != bund {
+		t.Fatalf("wrong deliverables in both contracts; got: %v, expected: %v", len(rolls), 1)
+	}
+	if math.Abs(rolls[0].Richness) > 1e-9 || math.Abs(rolls[0].Spread-rolls[0].Fair) > 1e-9 {
+		t.Errorf("wrong richness of fair roll; got: %v, expected: %v", rolls[0].Richness, 0.0)
+	}
+
+	// the roll richens with the front contract
+	front.Price += 0.1
+	rolls, _ = r.Analyze()
+	var testData = []struct {
+		Name     string
+		Value    float64
+		Expected float64
+	}{
+		{"spread", rolls[0].Spread, front.Price - back.Price},
+		{"fair spread", rolls[0].Fair, front.Price - back.Price - 0.1},
+		{"richness", rolls[0].Richness, 0.1},
+		{"front net basis", rolls[0].Front.Net, -0.1 * 0.615},
+		{"back net basis", rolls[0].Back.Net, 0.0},
+	}
+	for _, test := range testData {
+		if math.Abs(test.Value-test.Expected) > 1e-9 {
+			t.Errorf("wrong %s; got: %v, expected: %v", test.Name, test.Value, test.Expected)
+		}
+	}
+}
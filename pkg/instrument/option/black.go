@@ -0,0 +1,159 @@
+package option
+
+import (
+	"math"
+
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+const (
+	// Payer is the right to pay the fixed rate of a swap (call on the swap rate)
+	Payer = Call
+	// Receiver is the right to receive the fixed rate of a swap (put on the swap rate)
+	Receiver = Put
+)
+
+// Black is a European option on a forward price or a forward rate priced
+// with the Black-76 model
+type Black struct {
+	// Type is the type of the option (call=0, put=1)
+	Type int
+	// F is the forward price or forward rate at expiry
+	F float64
+	// K is the strike price (or strike rate in the units of F)
+	K float64
+	// T is the time to expiry in years
+	T float64
+	// Vola is the lognormal volatility of the forward
+	Vola float64
+}
+
+// PresentValue returns the Black-76 value discounted from the expiry
+func (b *Black) PresentValue(ts term.Structure) float64 {
+	return ts.Z(b.T) * Black76(b.Type, b.F, b.K, b.T, b.Vola)
+}
+
+// SetVola sets the volatility (needed for the calculation of the implied volatility)
+func (b *Black) SetVola(newVola float64) {
+	b.Vola = newVola
+}
+
+// BondOption is a European option on a bond with the Black-76 model on the
+// forward dirty price of the bond at expiry
+type BondOption struct {
+	// Type is the type of the option (call=0, put=1)
+	Type int
+	// Cashflows are the cash flows of the underlying bond
+	Cashflows cashflow.Cashflows
+	// Expiry is the time to expiry in years
+	Expiry float64
+	// K is the strike as dirty price at expiry
+	K float64
+	// Vola is the volatility of the forward bond price (see PriceVola)
+	Vola float64
+}
+
+// Forward returns the forward dirty price of the bond at expiry
+// (cash flows paid before or at expiry are excluded)
+func (o *BondOption) Forward(ts term.Structure) float64 {
+	value := 0.0
+	for _, cf := range o.Cashflows {
+		if cf.T > o.Expiry {
+			value += cf.Amount * ts.Z(cf.T)
+		}
+	}
+	return value / ts.Z(o.Expiry)
+}
+
+// PresentValue returns the Black-76 value of the bond option
+func (o *BondOption) PresentValue(ts term.Structure) float64 {
+	return ts.Z(o.Expiry) * Black76(o.Type, o.Forward(ts), o.K, o.Expiry, o.Vola)
+}
+
+// SetVola sets the volatility (needed for the calculation of the implied volatility)
+func (o *BondOption) SetVola(newVola float64) {
+	o.Vola = newVola
+}
+
+// PriceVola converts the lognormal volatility of the yield into the approximate
+// volatility of the bond price given the yield in percent and the modified duration
+func PriceVola(yieldVola, yield, duration float64) float64 {
+	return yieldVola * yield / 100.0 * duration
+}
+
+// Swaption is a European option to enter a fixed-for-floating interest rate
+// swap at expiry with the Black-76 model on the forward swap rate;
+// the value is per 100 notional
+type Swaption struct {
+	// Type is the type of the swaption (payer=0, receiver=1)
+	Type int
+	// Expiry is the time to expiry in years (start of the swap)
+	Expiry float64
+	// Tenor is the length of the swap in years
+	Tenor float64
+	// Frequency is the number of fixed payments per year
+	Frequency int
+	// K is the strike rate in percent
+	K float64
+	// Vola is the lognormal volatility of the forward swap rate
+	Vola float64
+}
+
+// Annuity returns the present value of the fixed leg paying 1 per year
+func (s *Swaption) Annuity(ts term.Structure) float64 {
+	value := 0.0
+	for _, t := range s.payments() {
+		value += ts.Z(t) / float64(s.Frequency)
+	}
+	return value
+}
+
+// ForwardRate returns the forward swap rate in percent
+func (s *Swaption) ForwardRate(ts term.Structure) float64 {
+	payments := s.payments()
+	if len(payments) == 0 {
+		return 0.0
+	}
+	end := payments[len(payments)-1]
+	return (ts.Z(s.Expiry) - ts.Z(end)) / s.Annuity(ts) * 100.0
+}
+
+// PresentValue returns the Black-76 value of the swaption per 100 notional
+func (s *Swaption) PresentValue(ts term.Structure) float64 {
+	return s.Annuity(ts) * Black76(s.Type, s.ForwardRate(ts), s.K, s.Expiry, s.Vola)
+}
+
+// SetVola sets the volatility (needed for the calculation of the implied volatility)
+func (s *Swaption) SetVola(newVola float64) {
+	s.Vola = newVola
+}
+
+// payments returns the payment times of the fixed leg
+func (s *Swaption) payments() []float64 {
+	if s.Frequency <= 0 {
+		return nil
+	}
+	n := int(math.Round(s.Tenor * float64(s.Frequency)))
+	payments := make([]float64, n)
+	for i := range payments {
+		payments[i] = s.Expiry + float64(i+1)/float64(s.Frequency)
+	}
+	return payments
+}
+
+// Black76 returns the undiscounted Black-76 value of a call or put on the forward F
+// with strike K, time to expiry T in years and volatility vola
+func Black76(optionType int, F, K, T, vola float64) float64 {
+	sign := 1.0
+	if optionType == Put {
+		sign = -1.0
+	}
+	if T <= 0.0 || vola <= 0.0 || F <= 0.0 || K <= 0.0 {
+		return math.Max(sign*(F-K), 0.0)
+	}
+	sd := vola * math.Sqrt(T)
+	d1 := (math.Log(F/K) + sd*sd/2.0) / sd
+	d2 := d1 - sd
+	return sign * (F*N(sign*d1) - K*N(sign*d2))
+}
@@ -0,0 +1,129 @@
+package option_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/instrument/option"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestBlack76(t *testing.T) {
+	// Hull, Options, Futures and Other Derivatives, Example 18.6 (put on futures)
+	var tests = []struct {
+		optionType    int
+		F, K, T, Vola float64
+		R, Expected   float64
+	}{
+		{option.Put, 20.0, 20.0, 4.0 / 12.0, 0.25, 9.0, 1.1166},
+		{option.Call, 20.0, 20.0, 4.0 / 12.0, 0.25, 9.0, 1.1166},
+		{option.Call, 100.0, 90.0, 1.0, 0.0, 5.0, 10.0 * math.Exp(-0.05)},
+	}
+	for _, test := range tests {
+		o := option.Black{Type: test.optionType, F: test.F, K: test.K, T: test.T, Vola: test.Vola}
+		if got := o.PresentValue(&term.Flat{R: test.R}); math.Abs(got-test.Expected) > 1e-4 {
+			t.Errorf("wrong Black-76 value; got: %v, expected: %v", got, test.Expected)
+		}
+	}
+}
+
+func TestBlack76_Parity(t *testing.T) {
+	for _, k := range []float64{80.0, 100.0, 120.0} {
+		call := option.Black76(option.Call, 100.0, k, 2.0, 0.2)
+		put := option.Black76(option.Put, 100.0, k, 2.0, 0.2)
+		if math.Abs(call-put-(100.0-k)) > 1e-10 {
+			t.Errorf("put-call parity violated for strike %v; got: %v, expected: %v", k, call-put, 100.0-k)
+		}
+	}
+}
+
+func TestBondOption(t *testing.T) {
+	// 10-month call on a 9.75-year bond with semiannual coupons of 4
+	ts := term.Flat{R: 100.0 * math.Log(1.0+0.09/2.0) * 2.0}
+	o := option.BondOption{
+		Type:   option.Call,
+		Expiry: 10.0 / 12.0,
+		K:      1008.33,
+		Vola:   0.09,
+	}
+	for i := 0; i < 20; i += 1 {
+		o.Cashflows = append(o.Cashflows, cashflow.Cashflow{T: 0.25 + 0.5*float64(i), Amount: 40.0})
+	}
+	o.Cashflows = append(o.Cashflows, cashflow.Cashflow{T: 9.75, Amount: 1000.0})
+
+	// the coupons at 3 and 9 months are paid before expiry
+	forward := 0.0
+	for _, cf := range o.Cashflows[2:] {
+		forward += cf.Amount * ts.Z(cf.T)
+	}
+	forward /= ts.Z(o.Expiry)
+	if got := o.Forward(&ts); math.Abs(got-forward) > 1e-8 {
+		t.Errorf("wrong forward price; got: %v, expected: %v", got, forward)
+	}
+
+	expected := ts.Z(o.Expiry) * option.Black76(option.Call, forward, o.K, o.Expiry, o.Vola)
+	if got := o.PresentValue(&ts); math.Abs(got-expected) > 1e-8 || got <= 0.0 {
+		t.Errorf("wrong bond option value; got: %v, expected: %v", got, expected)
+	}
+
+	// implied volatility
+	price := o.PresentValue(&ts)
+	o.SetVola(0.2)
+	vola, err := fixedincome.ImpliedVola(price, &o, &ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(vola-0.09) > 1e-5 {
+		t.Errorf("wrong implied volatility; got: %v, expected: %v", vola, 0.09)
+	}
+}
+
+func TestPriceVola(t *testing.T) {
+	if got := option.PriceVola(0.2, 5.0, 8.0); math.Abs(got-0.08) > 1e-12 {
+		t.Errorf("wrong price volatility; got: %v, expected: %v", got, 0.08)
+	}
+}
+
+func TestSwaption(t *testing.T) {
+	// Hull, Options, Futures and Other Derivatives, Example 29.5: 5 x 3 payer
+	// swaption with semiannual payments, flat curve of 6% (continuous), strike 6.2%
+	ts := term.Flat{R: 6.0}
+	s := option.Swaption{
+		Type:      option.Payer,
+		Expiry:    5.0,
+		Tenor:     3.0,
+		Frequency: 2,
+		K:         6.2,
+		Vola:      0.2,
+	}
+
+	// the forward swap rate equals the semiannually compounded flat rate
+	forward := 200.0 * (math.Exp(0.03) - 1.0)
+	if got := s.ForwardRate(&ts); math.Abs(got-forward) > 1e-8 {
+		t.Errorf("wrong forward swap rate; got: %v, expected: %v", got, forward)
+	}
+	// 2.07 for a notional of 100 in the example
+	if got := s.PresentValue(&ts); math.Abs(got-2.07) > 0.01 {
+		t.Errorf("wrong payer swaption value; got: %v, expected: %v", got, 2.07)
+	}
+
+	// payer minus receiver equals the forward swap
+	payer := s.PresentValue(&ts)
+	s.Type = option.Receiver
+	receiver := s.PresentValue(&ts)
+	if swap := s.Annuity(&ts) * (forward - s.K); math.Abs(payer-receiver-swap) > 1e-8 {
+		t.Errorf("wrong payer-receiver parity; got: %v, expected: %v", payer-receiver, swap)
+	}
+
+	// implied volatility
+	s.SetVola(0.5)
+	vola, err := fixedincome.ImpliedVola(receiver, &s, &ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(vola-0.2) > 1e-5 {
+		t.Errorf("wrong implied volatility; got: %v, expected: %v", vola, 0.2)
+	}
+}
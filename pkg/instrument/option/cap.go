@@ -0,0 +1,90 @@
+package option
+
+import (
+	"math"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+const (
+	// Caplet is a call on the floating rate
+	Caplet = Call
+	// Floorlet is a put on the floating rate
+	Floorlet = Put
+)
+
+// Cap is an interest rate cap (or floor) as a strip of caplets (or floorlets)
+// on the simply compounded forward rates between the reset times;
+// the value is per 100 notional
+type Cap struct {
+	// Type is the type of the options (caplet=0, floorlet=1)
+	Type int
+	// Resets are the ascending reset times in years; each caplet pays at the next reset
+	// time and the last one at the maturity
+	Resets []float64
+	// Maturity is the end of the last period in years
+	Maturity float64
+	// K is the strike rate in percent
+	K float64
+	// Vola is the lognormal volatility of the forward rates
+	// (or the normal volatility in percent, see Normal)
+	Vola float64
+	// Normal selects the Bachelier model with normal volatilities
+	Normal bool
+}
+
+// PresentValue returns the value of the cap per 100 notional
+func (c *Cap) PresentValue(ts term.Structure) float64 {
+	value := 0.0
+	for _, v := range c.Caplets(ts) {
+		value += v
+	}
+	return value
+}
+
+// Caplets returns the values of the caplets (or floorlets) per 100 notional
+func (c *Cap) Caplets(ts term.Structure) []float64 {
+	values := make([]float64, len(c.Resets))
+	for i, start := range c.Resets {
+		end := c.Maturity
+		if i+1 < len(c.Resets) {
+			end = c.Resets[i+1]
+		}
+		tau := end - start
+		if tau <= 0.0 {
+			continue
+		}
+		f := ForwardRate(ts, start, end)
+		value := Black76(c.Type, f, c.K, start, c.Vola)
+		if c.Normal {
+			value = Bachelier(c.Type, f, c.K, start, c.Vola)
+		}
+		values[i] = tau * ts.Z(end) * value
+	}
+	return values
+}
+
+// SetVola sets the volatility (needed for the calculation of the implied volatility)
+func (c *Cap) SetVola(newVola float64) {
+	c.Vola = newVola
+}
+
+// ForwardRate returns the simply compounded forward rate in percent between start and end in years
+func ForwardRate(ts term.Structure, start, end float64) float64 {
+	return (ts.Z(start)/ts.Z(end) - 1.0) / (end - start) * 100.0
+}
+
+// Bachelier returns the undiscounted value of a call or put on the forward F with
+// strike K, time to expiry T in years and normal volatility vola (in the units of F)
+func Bachelier(optionType int, F, K, T, vola float64) float64 {
+	sign := 1.0
+	if optionType == Put {
+		sign = -1.0
+	}
+	if T <= 0.0 || vola <= 0.0 {
+		return math.Max(sign*(F-K), 0.0)
+	}
+	sd := vola * math.Sqrt(T)
+	d := (F - K) / sd
+	return sign*(F-K)*N(sign*d) + sd*Napostroph(d)
+}
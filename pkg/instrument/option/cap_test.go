@@ -0,0 +1,84 @@
+package option_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/option"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestCap(t *testing.T) {
+	// Hull, Options, Futures and Other Derivatives, Example 29.3: caplet on the
+	// 3-month rate in one year with strike 8% on a notional of 10000,
+	// forward rate 7% (quarterly compounded), volatility 20%, and a discount factor
+	// of the 6.9394% (continuously compounded) rate for 1.25 years; value 5.162
+	ts := term.Flat{R: 6.9394}
+	c := option.Cap{
+		Type:     option.Caplet,
+		Resets:   []float64{1.0},
+		Maturity: 1.25,
+		K:        8.0,
+		Vola:     0.2,
+	}
+	f := option.ForwardRate(&ts, 1.0, 1.25)
+	expected := 0.25 * ts.Z(1.25) * option.Black76(option.Call, f, 8.0, 1.0, 0.2)
+	if got := c.PresentValue(&ts); math.Abs(got-expected) > 1e-10 {
+		t.Errorf("wrong caplet value; got: %v, expected: %v", got, expected)
+	}
+	if got := c.PresentValue(&ts) * 100.0; math.Abs(got-5.162) > 0.05 {
+		t.Errorf("wrong caplet value for 10000 notional; got: %v, expected: %v", got, 5.162)
+	}
+}
+
+func TestCap_Parity(t *testing.T) {
+	ts := term.NelsonSiegelSvensson{B0: 4.0, B1: -2.0, B2: 1.0, B3: 0.0, T1: 2.0, T2: 2.0}
+	resets := []float64{0.25, 0.5, 0.75, 1.0, 1.25, 1.5, 1.75}
+	for _, normal := range []bool{false, true} {
+		vola := 0.2
+		if normal {
+			vola = 0.8
+		}
+		cap := option.Cap{Type: option.Caplet, Resets: resets, Maturity: 2.0, K: 3.0, Vola: vola, Normal: normal}
+		floor := cap
+		floor.Type = option.Floorlet
+
+		// cap - floor = floating leg - fixed leg
+		swap := 0.0
+		for i, start := range resets {
+			end := cap.Maturity
+			if i+1 < len(resets) {
+				end = resets[i+1]
+			}
+			swap += (end - start) * ts.Z(end) * (option.ForwardRate(&ts, start, end) - cap.K)
+		}
+		if got := cap.PresentValue(&ts) - floor.PresentValue(&ts); math.Abs(got-swap) > 1e-10 {
+			t.Errorf("cap-floor parity violated (normal=%v); got: %v, expected: %v", normal, got, swap)
+		}
+
+		// implied volatility
+		price := cap.PresentValue(&ts)
+		cap.SetVola(2.0 * vola)
+		got, err := fixedincome.ImpliedVola(price, &cap, &ts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(got-vola) > 1e-5 {
+			t.Errorf("wrong implied volatility (normal=%v); got: %v, expected: %v", normal, got, vola)
+		}
+	}
+}
+
+func TestBachelier(t *testing.T) {
+	// at the money: sd / sqrt(2 pi)
+	if got, expected := option.Bachelier(option.Call, 3.0, 3.0, 4.0, 0.5), 1.0/math.Sqrt(2.0*math.Pi); math.Abs(got-expected) > 1e-12 {
+		t.Errorf("wrong at-the-money value; got: %v, expected: %v", got, expected)
+	}
+	// negative forwards and strikes are allowed
+	call := option.Bachelier(option.Call, -0.2, 0.1, 1.0, 0.6)
+	put := option.Bachelier(option.Put, -0.2, 0.1, 1.0, 0.6)
+	if math.Abs(call-put-(-0.3)) > 1e-12 {
+		t.Errorf("put-call parity violated; got: %v, expected: %v", call-put, -0.3)
+	}
+}
@@ -1,3 +1,7 @@
+// Package option values options on rates, bonds and other underlyings. The
+// options only read their terms in PresentValue and can be valued from several
+// goroutines; SetVola (and thus fixedincome.ImpliedVola) modifies the option
+// and needs a copy of a shared option.
 package option
 
 import (
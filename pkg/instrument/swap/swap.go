@@ -1,3 +1,6 @@
+// Package swap values interest rate swaps as a pair of bonds; like the bonds,
+// a swap only reads its terms when it is valued and can be shared across
+// goroutines.
 package swap
 
 import (
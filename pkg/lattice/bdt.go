@@ -0,0 +1,173 @@
+package lattice
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/khezen/rootfinding"
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// ErrCalibration is returned when the tree cannot be fitted to the term
+// structure and the volatilities (e.g. for negative rates without a shift)
+var ErrCalibration = errors.New("lattice calibration failed")
+
+// Exercise adjusts the value of a security at the node (i, j) of the tree
+// before the cash flows at step i are added, e.g. math.Min(value, call)
+// for a callable bond with the call price
+type Exercise func(i, j int, value float64) float64
+
+// BDT is a recombining binomial Black-Derman-Toy tree of the short rate
+// calibrated to the discount factors of a term structure and the yield
+// volatilities of the zero bonds
+type BDT struct {
+	// Dt is the time step in years
+	Dt float64
+	// Shift is the shift in percent of the lognormal rates (see NewShiftedBDT)
+	Shift float64
+	// Rates are the continuously compounded short rates (as decimals) for
+	// one time step at the nodes (i, j) with j up moves out of i steps
+	Rates [][]float64
+}
+
+// NewBDT calibrates the tree with n steps up to the maturity in years to the
+// term structure and the yield volatilities of the zero bonds; the short
+// rates are lognormal and must be positive (see NewShiftedBDT)
+func NewBDT(ts term.Structure, vol Volatility, maturity float64, n int) (*BDT, error) {
+	return NewShiftedBDT(ts, vol, maturity, n, 0.0)
+}
+
+// NewShiftedBDT calibrates the tree of the shifted lognormal short rates
+// with n steps up to the maturity in years, e.g. for negative rates: the
+// short rates plus the shift in percent are lognormal and the volatilities
+// are the volatilities of the zero bond yields plus the shift
+func NewShiftedBDT(ts term.Structure, vol Volatility, maturity float64, n int, shift float64) (*BDT, error) {
+	if maturity <= 0.0 || n <= 0 {
+		return nil, fmt.Errorf("%w: invalid maturity %v or steps %d", ErrCalibration, maturity, n)
+	}
+	dt := maturity / float64(n)
+	sdt := math.Sqrt(dt)
+	c := shift / 100.0
+	b := &BDT{Dt: dt, Shift: shift, Rates: make([][]float64, n)}
+
+	r0 := -math.Log(ts.Z(dt)) / dt
+	if r0+c <= 0.0 || math.IsNaN(r0) {
+		return nil, fmt.Errorf("%w: short rate %v plus the shift %v%% is not positive", ErrCalibration, r0, shift)
+	}
+	b.Rates[0] = []float64{r0}
+	if n == 1 {
+		return b, nil
+	}
+
+	// Arrow-Debreu prices at step i seen from the up and down nodes at step 1
+	up, down := []float64{0.0, 1.0}, []float64{1.0, 0.0}
+	for i := 1; i < n; i += 1 {
+		// prices of the zero bond maturing at step i+1 in the up and down
+		// states at step 1 with the ratio of their shifted yields given by
+		// the volatility: yu + c = k (yd + c) over the remaining tau years
+		k := math.Exp(2.0 * vol.Vol(float64(i+1)*dt) * sdt)
+		tau := float64(i) * dt
+		drift := math.Exp(-(k - 1.0) * c * tau)
+		target := 2.0 * ts.Z(float64(i+1)*dt) * math.Exp(r0*dt)
+		pd, err := solve(func(p float64) float64 { return p + math.Pow(p, k)*drift - target }, 1e-12, math.Exp(c*tau))
+		if err != nil {
+			return nil, fmt.Errorf("%w: zero bond prices at step %d: %v", ErrCalibration, i+1, err)
+		}
+		pu := math.Pow(pd, k) * drift
+
+		// rates r(i, j) = a exp(s j) - c matching both zero bond prices
+		price := func(q []float64, a, s float64) float64 {
+			value := 0.0
+			for j, qj := range q {
+				value += qj * math.Exp(-(a*math.Exp(s*float64(j))-c)*dt)
+			}
+			return value
+		}
+		median := func(s float64) (float64, error) {
+			// solve for the logarithm of the median rate for a relative precision
+			x, err := solve(func(x float64) float64 { return price(up, math.Exp(x), s) - pu }, -50.0, 3.0)
+			return math.Exp(x), err
+		}
+		s, err := solve(func(s float64) float64 {
+			a, err := median(s)
+			if err != nil {
+				return math.NaN()
+			}
+			return price(down, a, s) - pd
+		}, 1e-9, 2.0)
+		if err != nil {
+			return nil, fmt.Errorf("%w: rates at step %d: %v", ErrCalibration, i, err)
+		}
+		a, err := median(s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: rates at step %d: %v", ErrCalibration, i, err)
+		}
+		rates := make([]float64, i+1)
+		for j := range rates {
+			rates[j] = a*math.Exp(s*float64(j)) - c
+		}
+		b.Rates[i] = rates
+
+		// forward induction of the Arrow-Debreu prices
+		up, down = b.forward(up, i), b.forward(down, i)
+	}
+	return b, nil
+}
+
+// Steps returns the number of time steps of the tree
+func (b *BDT) Steps() int {
+	return len(b.Rates)
+}
+
+// Price values the cash flows on the tree by backward induction; the cash
+// flows are assigned to the closest time step and paid at the node before
+// discounting. The optional exercise adjusts the values at every node.
+func (b *BDT) Price(cfs cashflow.Cashflows, exercise Exercise) float64 {
+	n := b.Steps()
+	amounts := make([]float64, n+1)
+	for _, cf := range cfs {
+		i := int(math.Round(cf.T / b.Dt))
+		if i < 0 || i > n {
+			continue
+		}
+		amounts[i] += cf.Amount
+	}
+
+	values := make([]float64, n+1)
+	for j := range values {
+		values[j] = amounts[n]
+		if exercise != nil {
+			values[j] = exercise(n, j, 0.0) + amounts[n]
+		}
+	}
+	for i := n - 1; i >= 0; i -= 1 {
+		next := make([]float64, i+1)
+		for j := 0; j <= i; j += 1 {
+			value := 0.5 * (values[j] + values[j+1]) * math.Exp(-b.Rates[i][j]*b.Dt)
+			if exercise != nil {
+				value = exercise(i, j, value)
+			}
+			next[j] = value + amounts[i]
+		}
+		values = next
+	}
+	return values[0]
+}
+
+// forward returns the Arrow-Debreu prices at step i+1 from the prices at step i
+func (b *BDT) forward(q []float64, i int) []float64 {
+	next := make([]float64, i+2)
+	for j, qj := range q {
+		d := 0.5 * qj * math.Exp(-b.Rates[i][j]*b.Dt)
+		next[j] += d
+		next[j+1] += d
+	}
+	return next
+}
+
+// solve finds the root of f in [a, b]
+func solve(f func(float64) float64, a, b float64) (float64, error) {
+	return rootfinding.Brent(f, a, b, 12)
+}
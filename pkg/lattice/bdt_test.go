@@ -0,0 +1,166 @@
+package lattice_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/lattice"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestBDT_ZeroBonds(t *testing.T) {
+	ts := term.NelsonSiegelSvensson{B0: 4.0, B1: -2.0, B2: 1.0, B3: 0.0, T1: 2.0, T2: 2.0}
+	vol := &lattice.VolCurve{Maturities: []float64{1.0, 10.0}, Vols: []float64{0.2, 0.12}}
+	tree, err := lattice.NewBDT(&ts, vol, 10.0, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, maturity := range []float64{0.25, 1.0, 5.0, 10.0} {
+		zero := cashflow.Cashflows{{T: maturity, Amount: 1.0}}
+		if price := tree.Price(zero, nil); math.Abs(price-ts.Z(maturity)) > 1e-8 {
+			t.Errorf("wrong zero bond price for %v years; got: %v, expected: %v", maturity, price, ts.Z(maturity))
+		}
+	}
+}
+
+func TestBDT_YieldVolatility(t *testing.T) {
+	ts := term.Flat{R: 3.0}
+	vol := &lattice.VolCurve{Maturities: []float64{1.0, 5.0}, Vols: []float64{0.25, 0.15}}
+	n := 20
+	tree, err := lattice.NewBDT(&ts, vol, 5.0, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// zero bond prices in the up and down states after the first step
+	dt := tree.Dt
+	for _, i := range []int{2, 8, 20} {
+		pu, pd := subtree(tree, i, 1), subtree(tree, i, 0)
+		got := math.Log(math.Log(pu)/math.Log(pd)) / (2.0 * math.Sqrt(dt))
+		expected := vol.Vol(float64(i) * dt)
+		if math.Abs(got-expected) > 1e-6 {
+			t.Errorf("wrong yield volatility for step %d; got: %v, expected: %v", i, got, expected)
+		}
+	}
+}
+
+// subtree returns the price at node (1, j) of the zero bond maturing at step i
+func subtree(tree *lattice.BDT, i, j int) float64 {
+	values := make([]float64, i+1)
+	for k := range values {
+		values[k] = 1.0
+	}
+	for s := i - 1; s >= 1; s -= 1 {
+		for k := 0; k <= s; k += 1 {
+			values[k] = 0.5 * (values[k] + values[k+1]) * math.Exp(-tree.Rates[s][k]*tree.Dt)
+		}
+	}
+	return values[j]
+}
+
+func TestBDT_Callable(t *testing.T) {
+	ts := term.Flat{R: 4.0}
+	tree, err := lattice.NewBDT(&ts, lattice.FlatVol(0.2), 5.0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bond := cashflow.Cashflows{}
+	for i := 1; i <= 10; i += 1 {
+		bond = append(bond, cashflow.Cashflow{T: 0.5 * float64(i), Amount: 2.5})
+	}
+	bond = append(bond, cashflow.Cashflow{T: 5.0, Amount: 100.0})
+
+	straight := tree.Price(bond, nil)
+	if math.Abs(straight-bond.PresentValue(&ts)) > 1e-6 {
+		t.Errorf("wrong straight bond price; got: %v, expected: %v", straight, bond.PresentValue(&ts))
+	}
+
+	// callable at par after two years
+	callable := tree.Price(bond, func(i, j int, value float64) float64 {
+		if float64(i)*tree.Dt >= 2.0 && i < tree.Steps() {
+			return math.Min(value, 100.0)
+		}
+		return value
+	})
+	if callable >= straight || callable < straight-5.0 {
+		t.Errorf("wrong callable bond price; got: %v, straight: %v", callable, straight)
+	}
+
+	// putable at par after two years
+	putable := tree.Price(bond, func(i, j int, value float64) float64 {
+		if float64(i)*tree.Dt >= 2.0 && i < tree.Steps() {
+			return math.Max(value, 100.0)
+		}
+		return value
+	})
+	if putable <= straight {
+		t.Errorf("wrong putable bond price; got: %v, straight: %v", putable, straight)
+	}
+}
+
+func TestBDT_Shifted(t *testing.T) {
+	// negative CHF rates
+	ts := term.NelsonSiegelSvensson{B0: -0.266372, B1: -0.471343, B2: 5.68789, B3: -5.12324, T1: 5.74881, T2: 4.14426}
+	vol := lattice.FlatVol(0.1)
+	n, shift := 20, 2.0
+	tree, err := lattice.NewShiftedBDT(&ts, vol, 10.0, n, shift)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, maturity := range []float64{0.5, 1.0, 5.0, 10.0} {
+		zero := cashflow.Cashflows{{T: maturity, Amount: 1.0}}
+		if price := tree.Price(zero, nil); math.Abs(price-ts.Z(maturity)) > 1e-8 {
+			t.Errorf("wrong zero bond price for %v years; got: %v, expected: %v", maturity, price, ts.Z(maturity))
+		}
+	}
+
+	// volatility of the shifted zero bond yields after the first step
+	dt, c := tree.Dt, shift/100.0
+	for _, i := range []int{2, 8, 20} {
+		tau := float64(i-1) * dt
+		yu := -math.Log(subtree(tree, i, 1))/tau + c
+		yd := -math.Log(subtree(tree, i, 0))/tau + c
+		if got := math.Log(yu/yd) / (2.0 * math.Sqrt(dt)); math.Abs(got-vol.Vol(float64(i)*dt)) > 1e-6 {
+			t.Errorf("wrong shifted yield volatility for step %d; got: %v, expected: %v", i, got, vol.Vol(float64(i)*dt))
+		}
+	}
+}
+
+func TestBDT_Errors(t *testing.T) {
+	ts := term.Flat{R: -0.5}
+	if _, err := lattice.NewBDT(&ts, lattice.FlatVol(0.2), 5.0, 10); !errors.Is(err, lattice.ErrCalibration) {
+		t.Errorf("expected calibration error for negative rates; got: %v", err)
+	}
+	if _, err := lattice.NewShiftedBDT(&ts, lattice.FlatVol(0.2), 5.0, 10, 0.25); !errors.Is(err, lattice.ErrCalibration) {
+		t.Errorf("expected calibration error for a shift below the negative rates; got: %v", err)
+	}
+	if _, err := lattice.NewShiftedBDT(&ts, lattice.FlatVol(0.2), 5.0, 10, 1.0); err != nil {
+		t.Errorf("unexpected calibration error for shifted negative rates; got: %v", err)
+	}
+	if _, err := lattice.NewBDT(&term.Flat{R: 2.0}, lattice.FlatVol(0.2), 0.0, 10); !errors.Is(err, lattice.ErrCalibration) {
+		t.Errorf("expected calibration error for zero maturity; got: %v", err)
+	}
+}
+
+func TestVolCurve(t *testing.T) {
+	vol := &lattice.VolCurve{Maturities: []float64{1.0, 5.0}, Vols: []float64{0.2, 0.1}}
+	var tests = []struct {
+		t, expected float64
+	}{
+		{0.5, 0.2},
+		{1.0, 0.2},
+		{3.0, 0.15},
+		{5.0, 0.1},
+		{10.0, 0.1},
+	}
+	for _, test := range tests {
+		if got := vol.Vol(test.t); math.Abs(got-test.expected) > 1e-12 {
+			t.Errorf("wrong volatility for %v; got: %v, expected: %v", test.t, got, test.expected)
+		}
+	}
+}
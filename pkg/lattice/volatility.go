@@ -0,0 +1,40 @@
+package lattice
+
+import "sort"
+
+// Volatility is a term structure of volatilities (as decimals, e.g. 0.2 for 20%)
+type Volatility interface {
+	Vol(t float64) float64
+}
+
+// FlatVol is a constant volatility across maturities
+type FlatVol float64
+
+// Vol returns the constant volatility
+func (f FlatVol) Vol(t float64) float64 {
+	return float64(f)
+}
+
+// VolCurve interpolates the volatilities linearly between the maturities in
+// years (and extrapolates them flat)
+type VolCurve struct {
+	Maturities []float64
+	Vols       []float64
+}
+
+// Vol returns the interpolated volatility for the maturity t
+func (v *VolCurve) Vol(t float64) float64 {
+	n := len(v.Maturities)
+	if n == 0 || len(v.Vols) != n {
+		return 0.0
+	}
+	i := sort.SearchFloat64s(v.Maturities, t)
+	if i == 0 {
+		return v.Vols[0]
+	}
+	if i == n {
+		return v.Vols[n-1]
+	}
+	w := (t - v.Maturities[i-1]) / (v.Maturities[i] - v.Maturities[i-1])
+	return (1.0-w)*v.Vols[i-1] + w*v.Vols[i]
+}
@@ -0,0 +1,58 @@
+// Package logging provides the structured logger of the library. The logger
+// discards all records by default; applications set their own logger, e.g.
+//
+//	logging.SetLogger(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})))
+//
+// to trace the solver iterations and the cash flow construction.
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+)
+
+var logger atomic.Pointer[slog.Logger]
+
+func init() {
+	logger.Store(slog.New(discard{}))
+}
+
+// Logger returns the logger of the library
+func Logger() *slog.Logger {
+	return logger.Load()
+}
+
+// SetLogger sets the logger of the library (nil discards all records)
+func SetLogger(l *slog.Logger) {
+	if l == nil {
+		l = slog.New(discard{})
+	}
+	logger.Store(l)
+}
+
+// Debug reports whether debug records are logged, so that expensive
+// tracing can be skipped
+func Debug() bool {
+	return Logger().Enabled(context.Background(), slog.LevelDebug)
+}
+
+// discard is a handler that drops all records
+type discard struct{}
+
+func (discard) Enabled(context.Context, slog.Level) bool  { return false }
+func (discard) Handle(context.Context, slog.Record) error { return nil }
+func (d discard) WithAttrs([]slog.Attr) slog.Handler      { return d }
+func (d discard) WithGroup(string) slog.Handler           { return d }
+
+// Level returns the log level for the verbosity of command line flags
+// (verbose for debug records, quiet for warnings and errors only)
+func Level(verbose, quiet bool) slog.Level {
+	switch {
+	case verbose:
+		return slog.LevelDebug
+	case quiet:
+		return slog.LevelWarn
+	}
+	return slog.LevelInfo
+}
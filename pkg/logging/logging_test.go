@@ -0,0 +1,42 @@
+package logging_test
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/logging"
+)
+
+func TestLogger(t *testing.T) {
+	if logging.Debug() {
+		t.Errorf("default logger logs debug records")
+	}
+
+	var buf bytes.Buffer
+	logging.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: logging.Level(true, false)})))
+	defer logging.SetLogger(nil)
+	if !logging.Debug() {
+		t.Errorf("debug records are not logged")
+	}
+	logging.Logger().Debug("solver", "x", 1.5)
+	if !strings.Contains(buf.String(), "msg=solver x=1.5") {
+		t.Errorf("wrong log record; got: %s", buf.String())
+	}
+
+	testData := []struct {
+		Verbose, Quiet bool
+		Expected       slog.Level
+	}{
+		{false, false, slog.LevelInfo},
+		{true, false, slog.LevelDebug},
+		{false, true, slog.LevelWarn},
+		{true, true, slog.LevelDebug},
+	}
+	for _, test := range testData {
+		if level := logging.Level(test.Verbose, test.Quiet); level != test.Expected {
+			t.Errorf("wrong level for verbose=%v, quiet=%v; got: %v, expected: %v", test.Verbose, test.Quiet, level, test.Expected)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package maturity
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/konimarti/daycount"
+)
+
+// DayCounter implements a day count convention. The schedules compute the
+// maturities of the cash flows and the accrued interest with the day counter
+// of their basis, so instruments outside of this module can reuse the same
+// conventions (see Lookup).
+type DayCounter interface {
+	// Days returns the number of days between the two dates
+	Days(start, end time.Time) float64
+	// Fraction returns the accrued fraction of the period from start to next
+	// at the date end (e.g. 1 for a full period)
+	Fraction(start, end, next time.Time) float64
+}
+
+// YearFraction returns the time in years between the two dates with the day counter
+func YearFraction(dc DayCounter, start, end time.Time) float64 {
+	return dc.Fraction(start, end, start.AddDate(1, 0, 0))
+}
+
+// convention is a day count convention of the daycount package
+type convention string
+
+func (c convention) Days(start, end time.Time) float64 {
+	days, _ := daycount.Days(start, end, string(c))
+	return days
+}
+
+func (c convention) Fraction(start, end, next time.Time) float64 {
+	frac, _ := daycount.Fraction(start, end, next, string(c))
+	return frac
+}
+
+var (
+	countersMu sync.RWMutex
+	counters   = map[string]DayCounter{}
+)
+
+func init() {
+	for _, name := range daycount.Implemented() {
+		counters[name] = convention(name)
+	}
+	counters["ACT365"] = Actual{Basis: 365.0}
+	// the period of ACT/360 is counted in 30/360 days by the daycount
+	// package, which is off for periods ending on the last day of February
+	counters["ACT360"] = Actual{Basis: 360.0}
+}
+
+// Actual counts the actual days on a basis of a fixed number of days per year,
+// e.g. 365 for ACT/365 Fixed and 360 for ACT/360
+type Actual struct {
+	Basis float64
+}
+
+// Days returns the actual number of days between the two dates
+func (a Actual) Days(start, end time.Time) float64 {
+	return math.Round(end.Sub(start).Hours() / 24.0)
+}
+
+// Fraction returns the actual days from start to end on the basis of the
+// period from start to next in whole months (e.g. 182.5 days for a semi-annual
+// period on ACT/365), so leap days accrue like any other day
+func (a Actual) Fraction(start, end, next time.Time) float64 {
+	months := math.Max(math.Round(a.Days(start, next)/(365.25/12.0)), 1.0)
+	return a.Days(start, end) / (a.Basis * months / 12.0)
+}
+
+// Register adds the day counter for the basis name (or replaces the day
+// counter of an existing basis)
+func Register(name string, dc DayCounter) {
+	countersMu.Lock()
+	defer countersMu.Unlock()
+	counters[name] = dc
+}
+
+// Lookup returns the day counter for the basis name
+// (default: "" for daycount.Default) or an error wrapping ErrInvalidSchedule
+func Lookup(name string) (DayCounter, error) {
+	if name == "" {
+		name = daycount.Default
+	}
+	countersMu.RLock()
+	dc, ok := counters[name]
+	countersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: day count convention %s is not supported", ErrInvalidSchedule, name)
+	}
+	return dc, nil
+}
+
+// Conventions returns the sorted names of the day count conventions
+func Conventions() []string {
+	countersMu.RLock()
+	defer countersMu.RUnlock()
+	names := make([]string, 0, len(counters))
+	for name := range counters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,169 @@
+package maturity_test
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/konimarti/daycount"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+)
+
+// weeks counts whole weeks on a basis of 52 weeks per year
+type weeks struct{}
+
+func (weeks) Days(start, end time.Time) float64 {
+	return math.Floor(end.Sub(start).Hours()/24.0/7.0) * 7.0
+}
+
+func (w weeks) Fraction(start, end, next time.Time) float64 {
+	return w.Days(start, end) / w.Days(start, next)
+}
+
+func TestLookup(t *testing.T) {
+	start := time.Date(2021, 2, 15, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, 11, 30, 0, 0, 0, 0, time.UTC)
+
+	for _, basis := range daycount.Implemented() {
+		dc, err := maturity.Lookup(basis)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, _ := daycount.Fraction(start, end, start.AddDate(1, 0, 0), basis)
+		if frac := maturity.YearFraction(dc, start, end); frac != expected {
+			t.Errorf("wrong year fraction for %s; got: %v, expected: %v", basis, frac, expected)
+		}
+		days, _ := daycount.Days(start, end, basis)
+		if dc.Days(start, end) != days {
+			t.Errorf("wrong days for %s; got: %v, expected: %v", basis, dc.Days(start, end), days)
+		}
+	}
+
+	// the default convention
+	dc, err := maturity.Lookup("")
+	if err != nil || dc.Days(start, end) != 285.0 {
+		t.Errorf("wrong default day counter; got: %v, expected: %v", dc.Days(start, end), 285.0)
+	}
+
+	if _, err := maturity.Lookup("ACT999"); !errors.Is(err, maturity.ErrInvalidSchedule) {
+		t.Errorf("expected ErrInvalidSchedule for an unknown convention; got: %v", err)
+	}
+
+	conventions := maturity.Conventions()
+	if !sort.StringsAreSorted(conventions) || len(conventions) < len(daycount.Implemented()) {
+		t.Errorf("wrong conventions; got: %v", conventions)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	maturity.Register("WEEKS", weeks{})
+
+	m := maturity.Schedule{
+		Settlement: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		Maturity:   time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		Frequency:  1,
+		Basis:      "WEEKS",
+	}
+	if err := m.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	// 104 and 52 full weeks of the 52 weeks of the first year
+	expected := []float64{104.0 / 52.0, 1.0}
+	for i, maturity := range m.M() {
+		if math.Abs(maturity-expected[i]) > 1e-12 {
+			t.Errorf("wrong maturity; got: %v, expected: %v", maturity, expected[i])
+		}
+	}
+}
+
+func TestSchedule_AccrualLeapYear(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	testData := []struct {
+		Settlement time.Time
+		Maturity   time.Time
+		Frequency  int
+		Basis      string
+		Previous   time.Time
+		Days       float64
+		PeriodDays float64
+		Expected   float64
+	}{
+		// annual period from 2023-06-30 to 2024-06-30 with February 29
+		{date(2024, 3, 1), date(2026, 6, 30), 1, "ACT365", date(2023, 6, 30), 245.0, 366.0, 245.0 / 365.0},
+		{date(2024, 3, 1), date(2026, 6, 30), 1, "ACTACT", date(2023, 6, 30), 245.0, 366.0, 245.0 / 366.0},
+		{date(2024, 3, 1), date(2026, 6, 30), 1, "ACT360", date(2023, 6, 30), 245.0, 366.0, 245.0 / 360.0},
+		{date(2024, 3, 1), date(2026, 6, 30), 1, "30E360", date(2023, 6, 30), 241.0, 360.0, 241.0 / 360.0},
+		// semi-annual period starting on February 29
+		{date(2024, 3, 1), date(2026, 8, 31), 2, "ACT365", date(2024, 2, 29), 1.0, 184.0, 1.0 / 365.0},
+		{date(2024, 3, 1), date(2026, 8, 31), 2, "ACTACT", date(2024, 2, 29), 1.0, 184.0, 1.0 / 184.0 / 2.0},
+		{date(2024, 3, 1), date(2026, 8, 31), 2, "ACT360", date(2024, 2, 29), 1.0, 184.0, 1.0 / 360.0},
+		{date(2024, 2, 29), date(2026, 8, 31), 2, "ACT365", date(2024, 2, 29), 0.0, 184.0, 0.0},
+		// quarterly period over February 29
+		{date(2024, 3, 10), date(2025, 5, 15), 4, "ACT365", date(2024, 2, 15), 24.0, 90.0, 24.0 / 365.0},
+		{date(2024, 3, 10), date(2025, 5, 15), 4, "ACTACT", date(2024, 2, 15), 24.0, 90.0, 24.0 / 90.0 / 4.0},
+		{date(2024, 3, 10), date(2025, 5, 15), 4, "ACT360", date(2024, 2, 15), 24.0, 90.0, 24.0 / 360.0},
+		// the same period in a common year
+		{date(2023, 3, 10), date(2025, 5, 15), 4, "ACT365", date(2023, 2, 15), 23.0, 89.0, 23.0 / 365.0},
+		{date(2023, 3, 10), date(2025, 5, 15), 4, "ACT360", date(2023, 2, 15), 23.0, 89.0, 23.0 / 360.0},
+	}
+
+	for nr, test := range testData {
+		m := maturity.Schedule{
+			Settlement: test.Settlement,
+			Maturity:   test.Maturity,
+			Frequency:  test.Frequency,
+			Basis:      test.Basis,
+		}
+		a, err := m.Accrual()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !a.Previous.Equal(test.Previous) || a.Days != test.Days || a.PeriodDays != test.PeriodDays {
+			t.Errorf("wrong day counts for test nr %d, got: %s %v/%v, expected: %s %v/%v", nr,
+				a.Previous.Format("2006-01-02"), a.Days, a.PeriodDays, test.Previous.Format("2006-01-02"), test.Days, test.PeriodDays)
+		}
+		if math.Abs(a.Fraction-test.Expected) > 1e-12 || a.Fraction != m.DayCountFraction() {
+			t.Errorf("wrong accrued fraction for test nr %d, got: %v, expected: %v", nr, a.Fraction, test.Expected)
+		}
+	}
+
+	// interest accrues from the issue date
+	m := maturity.Schedule{
+		Settlement: date(2024, 3, 1),
+		Maturity:   date(2026, 6, 30),
+		Frequency:  1,
+		Basis:      "ACT365",
+		Issue:      date(2024, 2, 28),
+	}
+	if a, _ := m.Accrual(); !a.Start.Equal(m.Issue) || a.Days != 2.0 || math.Abs(a.Fraction-2.0/365.0) > 1e-12 {
+		t.Errorf("wrong accrual from the issue date, got: %v", a)
+	}
+}
+
+func TestSchedule_AccrualAtMaturity(t *testing.T) {
+	maturityDate := time.Date(2026, 5, 15, 0, 0, 0, 0, time.UTC)
+	m := maturity.Schedule{
+		Settlement: maturityDate,
+		Maturity:   maturityDate,
+		Frequency:  2,
+		Basis:      "ACTACT",
+	}
+	a, err := m.Accrual()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !a.Next.IsZero() || a.Days != 0.0 || a.PeriodDays != 0.0 || a.Fraction != 0.0 {
+		t.Errorf("wrong accrual at maturity; got: %+v", a)
+	}
+	if days := m.DaysInPeriod(); days != 0.0 {
+		t.Errorf("wrong days in period at maturity; got: %v, expected: %v", days, 0.0)
+	}
+	if days := m.DaysToNextCoupon(); days != 0.0 {
+		t.Errorf("wrong days to next coupon at maturity; got: %v, expected: %v", days, 0.0)
+	}
+}
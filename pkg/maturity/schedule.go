@@ -1,12 +1,15 @@
 package maturity
 
 import (
+	"errors"
+	"fmt"
 	"sort"
 	"time"
-
-	"github.com/konimarti/daycount"
 )
 
+// ErrInvalidSchedule is returned for schedules that cannot generate the cash flows
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
 // Schedule contain the information about the term maturities of fixed income security's cash flows
 type Schedule struct {
 	// Settlement represent the date of valuation (or settlement)
@@ -17,6 +20,51 @@ type Schedule struct {
 	Frequency int
 	// Basis represents the day count convention (default: "" for 30E/360 ISDA)
 	Basis string
+	// Issue is the issue date from which interest accrues (optional)
+	Issue time.Time
+	// EndOfMonth rolls the coupon dates to the last day of the month if the
+	// maturity date is the last day of a month (e.g. Feb 28, May 31, Aug 31
+	// and Nov 30 for a quarterly bond maturing on May 31)
+	EndOfMonth bool
+}
+
+// AsOf returns a copy of the schedule for the valuation date, i.e. with
+// the remaining cash flows and accrued interest as of that date
+func (m Schedule) AsOf(date time.Time) (Schedule, error) {
+	if !m.Issue.IsZero() && date.Before(m.Issue) {
+		return m, fmt.Errorf("%w: valuation date %s is before issue date %s", ErrInvalidSchedule, date.Format("2006-01-02"), m.Issue.Format("2006-01-02"))
+	}
+	if !date.Before(m.Maturity) {
+		return m, fmt.Errorf("%w: valuation date %s is not before maturity date %s", ErrInvalidSchedule, date.Format("2006-01-02"), m.Maturity.Format("2006-01-02"))
+	}
+	m.Settlement = date
+	return m, nil
+}
+
+// Validate checks that the schedule can generate cash flows and returns an
+// error wrapping ErrInvalidSchedule otherwise
+func (m *Schedule) Validate() error {
+	if m.Maturity.IsZero() {
+		return fmt.Errorf("%w: maturity date is missing", ErrInvalidSchedule)
+	}
+	if !m.Settlement.Before(m.Maturity) {
+		return fmt.Errorf("%w: maturity date %s is not after settlement date %s", ErrInvalidSchedule, m.Maturity.Format("2006-01-02"), m.Settlement.Format("2006-01-02"))
+	}
+	if !m.Issue.IsZero() && !m.Issue.Before(m.Maturity) {
+		return fmt.Errorf("%w: issue date %s is not before maturity date %s", ErrInvalidSchedule, m.Issue.Format("2006-01-02"), m.Maturity.Format("2006-01-02"))
+	}
+	if _, err := m.step(); err != nil {
+		return err
+	}
+	if _, err := m.DayCounter(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DayCounter returns the day counter of the basis (see Lookup)
+func (m *Schedule) DayCounter() (DayCounter, error) {
+	return Lookup(m.Basis)
 }
 
 //Compounding returns the annual compounding frequency
@@ -34,26 +82,132 @@ func (m *Schedule) EffectiveCoupon(annualCoupon float64) float64 {
 	return annualCoupon / n
 }
 
-//M returns a slice of the effective maturities in years of the bond's cash flows
+//M returns a slice of the effective maturities in years of the bond's cash flows.
+//An invalid schedule has no cash flows (see Maturities for the error).
 func (m *Schedule) M() []float64 {
+	maturities, err := m.Maturities()
+	if err != nil {
+		return []float64{}
+	}
+	return maturities
+}
+
+//Maturities returns a slice of the effective maturities in years of the bond's
+//cash flows or an error wrapping ErrInvalidSchedule
+func (m *Schedule) Maturities() ([]float64, error) {
 	maturities := []float64{}
 
-	if m.Compounding() > 12 {
-		panic("more than 12 compounding periods not implemented yet")
+	step, err := m.step()
+	if err != nil {
+		return maturities, err
+	}
+	dc, err := m.DayCounter()
+	if err != nil {
+		return maturities, err
 	}
-	step := 12 / m.Compounding()
 
 	// walk back from maturity date to quote date
 	quote := m.Settlement
-	for current := m.Maturity; current.Sub(quote) > 0; current = current.AddDate(0, -step, 0) {
-		frac, err := daycount.Fraction(quote, current, quote.AddDate(1, 0, 0), m.Basis)
-		if err != nil {
-			panic(err)
+	for k, current := 0, m.Maturity; current.Sub(quote) > 0; k, current = k+1, m.couponDate(k+1, step) {
+		maturities = append(maturities, YearFraction(dc, quote, current))
+	}
+
+	return maturities, nil
+}
+
+//Dates returns a slice of the payment dates of the bond's cash flows
+//(in the same order as the maturities returned by M).
+//An invalid schedule has no payment dates (see PaymentDates for the error).
+func (m *Schedule) Dates() []time.Time {
+	dates, err := m.PaymentDates()
+	if err != nil {
+		return []time.Time{}
+	}
+	return dates
+}
+
+//PaymentDates returns a slice of the payment dates of the bond's cash flows
+//or an error wrapping ErrInvalidSchedule
+func (m *Schedule) PaymentDates() ([]time.Time, error) {
+	dates := []time.Time{}
+
+	step, err := m.step()
+	if err != nil {
+		return dates, err
+	}
+
+	// walk back from maturity date to quote date
+	for k, current := 0, m.Maturity; current.Sub(m.Settlement) > 0; k, current = k+1, m.couponDate(k+1, step) {
+		dates = append(dates, current)
+	}
+
+	return dates, nil
+}
+
+// PastDates returns the coupon dates after the issue date up to and including
+// the settlement date in chronological order or an error wrapping
+// ErrInvalidSchedule if the issue date is missing
+func (m *Schedule) PastDates() ([]time.Time, error) {
+	if m.Issue.IsZero() {
+		return nil, fmt.Errorf("%w: issue date is missing", ErrInvalidSchedule)
+	}
+	step, err := m.step()
+	if err != nil {
+		return nil, err
+	}
+	dates := []time.Time{}
+	for k, current := 0, m.Maturity; current.After(m.Issue); k, current = k+1, m.couponDate(k+1, step) {
+		if !current.After(m.Settlement) {
+			dates = append(dates, current)
 		}
-		maturities = append(maturities, frac)
 	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	return dates, nil
+}
 
-	return maturities
+// step returns the number of months between two coupon dates or an error
+// wrapping ErrInvalidSchedule if the coupon dates cannot be generated
+func (m *Schedule) step() (int, error) {
+	if m.Maturity.IsZero() {
+		return 0, fmt.Errorf("%w: maturity date is missing", ErrInvalidSchedule)
+	}
+	if m.Frequency < 0 {
+		return 0, fmt.Errorf("%w: negative frequency %d", ErrInvalidSchedule, m.Frequency)
+	}
+	if n := m.Compounding(); n > 12 || 12%n != 0 {
+		return 0, fmt.Errorf("%w: frequency %d is not supported (use 1, 2, 3, 4, 6 or 12)", ErrInvalidSchedule, m.Frequency)
+	}
+	return 12 / m.Compounding(), nil
+}
+
+// CouponDate returns the k-th coupon date before the maturity date (k = 0 is
+// the maturity date) or an error wrapping ErrInvalidSchedule
+func (m *Schedule) CouponDate(k int) (time.Time, error) {
+	step, err := m.step()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return m.couponDate(k, step), nil
+}
+
+// couponDate returns the k-th coupon date before the maturity date with a step
+// of months between the coupon dates. The coupon dates are rolled from the
+// maturity date (not from the previous coupon date), so they keep the day of
+// the maturity date or fall on the last day of shorter months.
+func (m *Schedule) couponDate(k, step int) time.Time {
+	year, month, day := m.Maturity.Date()
+	hour, min, sec := m.Maturity.Clock()
+	first := time.Date(year, month-time.Month(k*step), 1, hour, min, sec, m.Maturity.Nanosecond(), m.Maturity.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	if day > last || (m.EndOfMonth && isLastDay(m.Maturity)) {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+// isLastDay checks if the date is the last day of its month
+func isLastDay(date time.Time) bool {
+	return date.AddDate(0, 0, 1).Day() == 1
 }
 
 //Last returns the latest maturity value in years (i.e. the years to maturity)
@@ -76,32 +230,142 @@ func (m *Schedule) Next() float64 {
 	return t[0]
 }
 
-// DayCountFraction returns year fraction since last coupon
+// YearsToMaturity returns the time in years from the settlement date to the
+// maturity date with the day count convention basis (e.g. ACT360 for money
+// market quotes) or an error wrapping ErrInvalidSchedule
+func (m *Schedule) YearsToMaturity(basis string) (float64, error) {
+	dc, err := Lookup(basis)
+	if err != nil {
+		return 0.0, err
+	}
+	if !m.Maturity.After(m.Settlement) {
+		return 0.0, nil
+	}
+	return YearFraction(dc, m.Settlement, m.Maturity), nil
+}
+
+// DayCountFraction returns year fraction since last coupon.
+// An invalid schedule has no accrued interest (see AccruedFraction for the error).
 func (m *Schedule) DayCountFraction() float64 {
-	if m.Maturity.Before(m.Settlement) {
+	frac, err := m.AccruedFraction()
+	if err != nil {
 		return 0.0
 	}
+	return frac
+}
+
+// AccruedFraction returns year fraction since last coupon or an error
+// wrapping ErrInvalidSchedule
+func (m *Schedule) AccruedFraction() (float64, error) {
+	a, err := m.Accrual()
+	return a.Fraction, err
+}
+
+// Accrual is the coupon period at the settlement date with the day counts
+// of the accrued interest
+type Accrual struct {
+	// Previous is the last coupon date on or before the settlement date
+	Previous time.Time
+	// Next is the next coupon date after the settlement date
+	Next time.Time
+	// Start is the date from which interest accrues (the last coupon date or
+	// the issue date in the first coupon period)
+	Start time.Time
+	// Days is the number of days from Start to the settlement date
+	Days float64
+	// PeriodDays is the number of days in the coupon period
+	PeriodDays float64
+	// Fraction is the accrued year fraction
+	Fraction float64
+}
+
+// Accrual returns the accrual of the coupon period at the settlement date or
+// an error wrapping ErrInvalidSchedule; there is no accrual (and no next
+// coupon) on or after maturity
+func (m *Schedule) Accrual() (Accrual, error) {
+	step, err := m.step()
+	if err != nil {
+		return Accrual{}, err
+	}
+	if m.Maturity.Before(m.Settlement) {
+		return Accrual{}, nil
+	}
 
 	d1 := m.Maturity
 	d2 := m.Settlement
 	d3 := time.Time{}
 
 	// iterate maturity date backwards until last coupon date before settlement date
-	if m.Compounding() > 12 {
-		panic("more than 12 compounding periods not implemented yet")
+	dc, err := m.DayCounter()
+	if err != nil {
+		return Accrual{}, err
 	}
-	step := 12 / m.Compounding()
-	for ; d1.Sub(d2) > 0; d1 = d1.AddDate(0, -step, 0) {
+	for k := 0; d1.Sub(d2) > 0; k, d1 = k+1, m.couponDate(k+1, step) {
 		d3 = d1
 	}
+	// no next coupon and no accrual on the maturity date
+	if d3.IsZero() {
+		return Accrual{Previous: d1, Start: d1}, nil
+	}
+	a := Accrual{Previous: d1, Next: d3, Start: d1, PeriodDays: dc.Days(d1, d3)}
 
 	// calculate day count fraction
-	frac, err := daycount.Fraction(d1, d2, d3, m.Basis)
-	if err != nil {
-		panic(err)
+	frac := dc.Fraction(d1, d2, d3)
+
+	// interest accrues from the issue date in the first coupon period
+	if m.Issue.After(d1) {
+		a.Start = m.Issue
+		if !m.Issue.Before(d2) {
+			return a, nil
+		}
+		frac -= dc.Fraction(d1, m.Issue, d3)
 	}
 
-	return frac / float64(m.Compounding())
+	a.Days = dc.Days(a.Start, d2)
+	a.Fraction = frac / float64(m.Compounding())
+	return a, nil
+}
+
+// PreviousCoupon returns the last coupon date on or before the settlement
+// date (a quasi-coupon date before the issue date in the first period)
+func (m *Schedule) PreviousCoupon() time.Time {
+	a, _ := m.Accrual()
+	return a.Previous
+}
+
+// NextCoupon returns the next coupon date after the settlement date
+// (zero if the schedule is invalid)
+func (m *Schedule) NextCoupon() time.Time {
+	a, _ := m.Accrual()
+	return a.Next
+}
+
+// DaysAccrued returns the number of days of accrued interest with the day
+// count convention of the basis
+func (m *Schedule) DaysAccrued() float64 {
+	a, _ := m.Accrual()
+	return a.Days
+}
+
+// DaysInPeriod returns the number of days of the current coupon period
+// with the day count convention of the basis
+func (m *Schedule) DaysInPeriod() float64 {
+	a, _ := m.Accrual()
+	return a.PeriodDays
+}
+
+// DaysToNextCoupon returns the number of days from the settlement date to
+// the next coupon date with the day count convention of the basis
+func (m *Schedule) DaysToNextCoupon() float64 {
+	a, err := m.Accrual()
+	if err != nil || a.Next.IsZero() {
+		return 0.0
+	}
+	dc, err := m.DayCounter()
+	if err != nil {
+		return 0.0
+	}
+	return dc.Days(m.Settlement, a.Next)
 }
 
 // Actual difference between two dates in years
@@ -1,6 +1,7 @@
 package maturity_test
 
 import (
+	"errors"
 	"math"
 	"testing"
 	"time"
@@ -185,3 +186,267 @@ func TestSchedule_Last(t *testing.T) {
 
 	}
 }
+
+func TestSchedule_AsOf(t *testing.T) {
+	m := maturity.Schedule{
+		Maturity:  time.Date(2026, 5, 28, 0, 0, 0, 0, time.UTC),
+		Frequency: 1,
+		Basis:     "30E360",
+		Issue:     time.Date(2021, 2, 15, 0, 0, 0, 0, time.UTC),
+	}
+
+	if _, err := m.AsOf(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Errorf("valuation before issue date should fail")
+	}
+	if _, err := m.AsOf(time.Date(2026, 5, 28, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Errorf("valuation at maturity date should fail")
+	}
+
+	testData := []struct {
+		Date             time.Time
+		ExpectedAccrued  float64
+		ExpectedPayments int
+	}{
+		{
+			// first coupon period: interest accrues from issue date
+			Date:             time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			ExpectedAccrued:  46.0 / 360.0,
+			ExpectedPayments: 6,
+		},
+		{
+			Date:             time.Date(2021, 2, 15, 0, 0, 0, 0, time.UTC),
+			ExpectedAccrued:  0.0,
+			ExpectedPayments: 6,
+		},
+		{
+			Date:             time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC),
+			ExpectedAccrued:  303.0 / 360.0,
+			ExpectedPayments: 4,
+		},
+	}
+
+	for nr, test := range testData {
+		s, err := m.AsOf(test.Date)
+		if err != nil {
+			t.Errorf("test nr %d: %v", nr, err)
+			continue
+		}
+		if math.Abs(s.DayCountFraction()-test.ExpectedAccrued) > 1e-9 {
+			t.Errorf("wrong accrued fraction for test nr %d, got: %f, expected: %f", nr, s.DayCountFraction(), test.ExpectedAccrued)
+		}
+		if len(s.M()) != test.ExpectedPayments {
+			t.Errorf("wrong number of payments for test nr %d, got: %d, expected: %d", nr, len(s.M()), test.ExpectedPayments)
+		}
+	}
+}
+
+func TestSchedule_Errors(t *testing.T) {
+	testData := []maturity.Schedule{
+		{
+			Settlement: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2029, 1, 1, 0, 0, 0, 0, time.UTC),
+			Frequency:  24,
+		},
+		{
+			Settlement: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2029, 1, 1, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+			Basis:      "NOTIMPLEMENTED",
+		},
+		{
+			Settlement: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2029, 1, 1, 0, 0, 0, 0, time.UTC),
+			Frequency:  5,
+		},
+		{
+			Settlement: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2029, 1, 1, 0, 0, 0, 0, time.UTC),
+			Frequency:  -2,
+		},
+		{
+			Settlement: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+		},
+	}
+
+	for nr, m := range testData {
+		if err := m.Validate(); !errors.Is(err, maturity.ErrInvalidSchedule) {
+			t.Errorf("test nr %d, expected ErrInvalidSchedule from Validate; got: %v", nr, err)
+		}
+		if _, err := m.Maturities(); !errors.Is(err, maturity.ErrInvalidSchedule) {
+			t.Errorf("test nr %d, expected ErrInvalidSchedule from Maturities; got: %v", nr, err)
+		}
+		if _, err := m.AccruedFraction(); !errors.Is(err, maturity.ErrInvalidSchedule) {
+			t.Errorf("test nr %d, expected ErrInvalidSchedule from AccruedFraction; got: %v", nr, err)
+		}
+		if len(m.M()) != 0 || m.DayCountFraction() != 0.0 {
+			t.Errorf("test nr %d, invalid schedule should not have cash flows", nr)
+		}
+		if m.Basis == "" {
+			// payment dates do not depend on the day count convention
+			if len(m.Dates()) != 0 {
+				t.Errorf("test nr %d, invalid schedule should not have payment dates", nr)
+			}
+			if _, err := m.PaymentDates(); !errors.Is(err, maturity.ErrInvalidSchedule) {
+				t.Errorf("test nr %d, expected ErrInvalidSchedule from PaymentDates; got: %v", nr, err)
+			}
+		}
+	}
+}
+
+func FuzzSchedule(f *testing.F) {
+	f.Add(0, 365, 1, "30E360")
+	f.Add(0, 0, 1, "ACT360")
+	f.Add(100, 10, 2, "")
+	f.Add(-3650, 36500, 12, "ACTACT")
+	f.Add(0, 1, 7, "unknown")
+
+	settlement := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	f.Fuzz(func(t *testing.T, start, end, frequency int, basis string) {
+		// limit to schedules of at most 100 years
+		if start < -36500 || start > 36500 || end < -36500 || end > 36500 {
+			return
+		}
+		m := maturity.Schedule{
+			Settlement: settlement.AddDate(0, 0, start),
+			Maturity:   settlement.AddDate(0, 0, end),
+			Frequency:  frequency,
+			Basis:      basis,
+		}
+		maturities, errM := m.Maturities()
+		dates, errD := m.PaymentDates()
+		accrued, errA := m.AccruedFraction()
+		if m.Validate() != nil {
+			return
+		}
+		if errM != nil || errD != nil || errA != nil {
+			t.Fatalf("valid schedule returned errors: %v, %v, %v", errM, errD, errA)
+		}
+		if len(maturities) == 0 || len(maturities) != len(dates) {
+			t.Fatalf("got %d maturities for %d payment dates", len(maturities), len(dates))
+		}
+		for _, v := range maturities {
+			if math.IsNaN(v) || math.IsInf(v, 0) || v < 0.0 {
+				t.Fatalf("invalid maturity %v", v)
+			}
+		}
+		// at most one period (ACT/360 may exceed one year)
+		if math.IsNaN(accrued) || accrued < 0.0 || accrued > 366.0/360.0 {
+			t.Fatalf("invalid accrued fraction %v", accrued)
+		}
+	})
+}
+
+func TestSchedule_EndOfMonth(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	testData := []struct {
+		Maturity   time.Time
+		Frequency  int
+		EndOfMonth bool
+		Expected   []time.Time
+	}{
+		{
+			// the day of the maturity is kept or set to the last day of shorter months
+			Maturity:  date(2022, 5, 31),
+			Frequency: 4,
+			Expected:  []time.Time{date(2022, 5, 31), date(2022, 2, 28), date(2021, 11, 30), date(2021, 8, 31)},
+		},
+		{
+			Maturity:  date(2022, 2, 28),
+			Frequency: 12,
+			Expected:  []time.Time{date(2022, 2, 28), date(2022, 1, 28), date(2021, 12, 28), date(2021, 11, 28), date(2021, 10, 28), date(2021, 9, 28), date(2021, 8, 28)},
+		},
+		{
+			Maturity:   date(2022, 2, 28),
+			Frequency:  12,
+			EndOfMonth: true,
+			Expected:   []time.Time{date(2022, 2, 28), date(2022, 1, 31), date(2021, 12, 31), date(2021, 11, 30), date(2021, 10, 31), date(2021, 9, 30), date(2021, 8, 31)},
+		},
+		{
+			// leap year
+			Maturity:   date(2024, 11, 30),
+			Frequency:  4,
+			EndOfMonth: true,
+			Expected:   []time.Time{date(2024, 11, 30), date(2024, 8, 31), date(2024, 5, 31), date(2024, 2, 29), date(2023, 11, 30), date(2023, 8, 31), date(2023, 5, 31), date(2023, 2, 28), date(2022, 11, 30), date(2022, 8, 31)},
+		},
+		{
+			Maturity:  date(2024, 11, 30),
+			Frequency: 4,
+			Expected:  []time.Time{date(2024, 11, 30), date(2024, 8, 30), date(2024, 5, 30), date(2024, 2, 29), date(2023, 11, 30), date(2023, 8, 30), date(2023, 5, 30), date(2023, 2, 28), date(2022, 11, 30), date(2022, 8, 30)},
+		},
+	}
+
+	for nr, test := range testData {
+		m := maturity.Schedule{
+			Settlement: date(2022, 8, 15),
+			Maturity:   test.Maturity,
+			Frequency:  test.Frequency,
+			EndOfMonth: test.EndOfMonth,
+			Basis:      "ACTACT",
+		}
+		if test.Maturity.Before(m.Settlement) {
+			m.Settlement = date(2021, 8, 15)
+		}
+		dates := m.Dates()
+		if len(dates) != len(test.Expected) {
+			t.Errorf("wrong number of dates for test nr %d, got: %d, expected: %d", nr, len(dates), len(test.Expected))
+			continue
+		}
+		for i, d := range dates {
+			if !d.Equal(test.Expected[i]) {
+				t.Errorf("wrong coupon date for test nr %d, got: %s, expected: %s", nr, d.Format("2006-01-02"), test.Expected[i].Format("2006-01-02"))
+			}
+		}
+		for k, expected := range test.Expected {
+			if d, err := m.CouponDate(k); err != nil || !d.Equal(expected) {
+				t.Errorf("wrong coupon date %d for test nr %d, got: %s (%v), expected: %s", k, nr, d.Format("2006-01-02"), err, expected.Format("2006-01-02"))
+			}
+		}
+		if len(m.M()) != len(dates) {
+			t.Errorf("maturities do not match dates for test nr %d, got: %d, expected: %d", nr, len(m.M()), len(dates))
+		}
+	}
+
+	// the accrued interest starts at the last coupon date on the month end
+	m := maturity.Schedule{
+		Settlement: date(2021, 12, 15),
+		Maturity:   date(2022, 2, 28),
+		Frequency:  12,
+		EndOfMonth: true,
+		Basis:      "ACTACT",
+	}
+	if expected := 15.0 / 31.0 / 12.0; math.Abs(m.DayCountFraction()-expected) > 1e-12 {
+		t.Errorf("wrong accrued fraction, got: %v, expected: %v", m.DayCountFraction(), expected)
+	}
+}
+
+func TestSchedule_Coupons(t *testing.T) {
+	m := maturity.Schedule{
+		Settlement: time.Date(2021, 4, 17, 0, 0, 0, 0, time.UTC),
+		Maturity:   time.Date(2023, 5, 25, 0, 0, 0, 0, time.UTC),
+		Frequency:  2,
+		Basis:      "30E360",
+	}
+	if d := m.PreviousCoupon(); !d.Equal(time.Date(2020, 11, 25, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("wrong previous coupon date, got: %v", d)
+	}
+	if d := m.NextCoupon(); !d.Equal(time.Date(2021, 5, 25, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("wrong next coupon date, got: %v", d)
+	}
+	// 30E360 days from 2020-11-25 to 2021-04-17
+	if m.DaysAccrued() != 142.0 || m.DaysInPeriod() != 180.0 || m.DaysToNextCoupon() != 38.0 {
+		t.Errorf("wrong days, got: %v, %v, %v, expected: %v, %v, %v", m.DaysAccrued(), m.DaysInPeriod(), m.DaysToNextCoupon(), 142.0, 180.0, 38.0)
+	}
+	if expected := m.DaysAccrued() / m.DaysInPeriod() / 2.0; math.Abs(m.DayCountFraction()-expected) > 1e-12 {
+		t.Errorf("wrong accrued fraction, got: %v, expected: %v", m.DayCountFraction(), expected)
+	}
+
+	// no coupon after maturity
+	m.Settlement = m.Maturity
+	if !m.NextCoupon().IsZero() || m.DaysToNextCoupon() != 0.0 {
+		t.Errorf("wrong next coupon after maturity, got: %v", m.NextCoupon())
+	}
+}
@@ -3,8 +3,8 @@ package holee
 import (
 	"math"
 	"math/rand"
-	"time"
 
+	"github.com/konimarti/fixedincome/pkg/mc"
 	"github.com/konimarti/fixedincome/pkg/term"
 )
 
@@ -34,7 +34,7 @@ func New(ts term.Structure, sigma, t float64, n int, payoff func([]float64) floa
 		T:      t,
 		N:      n,
 		Theta:  make([]float64, n),
-		Rng:    rand.New(rand.NewSource(time.Now().UnixNano())),
+		Rng:    mc.NewRand(),
 		Payoff: payoff,
 	}
 	err := Calibrate(hl, ts)
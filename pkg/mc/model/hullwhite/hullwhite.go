@@ -0,0 +1,75 @@
+package hullwhite
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/konimarti/fixedincome/pkg/mc"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// HullWhite implements the one-factor Hull-White (extended Vasicek) model
+// dr = (theta(t) - a r) dt + sigma dW fitted to the initial term structure,
+// i.e. r(t) = x(t) + alpha(t) with the Ornstein-Uhlenbeck process x(0) = 0
+type HullWhite struct {
+	// A is the mean reversion speed
+	A float64
+	// Sigma is the volatility of the short rate
+	Sigma float64
+	// T is the maturity (up to which to simulate the interest rates)
+	T float64
+	// N represents number of steps
+	N int
+	// Alpha is the deterministic shift on the grid that fits the term structure
+	Alpha []float64
+}
+
+// New creates a Hull-White model fitted to the term structure
+func New(ts term.Structure, a, sigma, t float64, n int) (*HullWhite, error) {
+	if a <= 0.0 || sigma < 0.0 || t <= 0.0 || n <= 0 {
+		return nil, fmt.Errorf("invalid Hull-White parameters a=%v, sigma=%v, t=%v, n=%d", a, sigma, t, n)
+	}
+	hw := &HullWhite{A: a, Sigma: sigma, T: t, N: n, Alpha: make([]float64, n+1)}
+	dt := t / float64(n)
+	for i := 0; i <= n; i += 1 {
+		hw.Alpha[i] = forward(ts, float64(i)*dt, dt) + hw.shift(float64(i)*dt)
+	}
+	return hw, nil
+}
+
+// shift returns the convexity shift sigma^2 / (2 a^2) (1 - exp(-a t))^2
+func (hw *HullWhite) shift(t float64) float64 {
+	b := (1.0 - math.Exp(-hw.A*t)) / hw.A
+	return 0.5 * hw.Sigma * hw.Sigma * b * b
+}
+
+// forward returns the instantaneous forward rate (as decimal) at t
+func forward(ts term.Structure, t, dt float64) float64 {
+	h := math.Min(dt, 1e-3)
+	if t < h {
+		return -math.Log(ts.Z(t+h)) / h
+	}
+	return -math.Log(ts.Z(t+h)/ts.Z(t-h)) / (2.0 * h)
+}
+
+// Steps returns the number of normals drawn per path (see mc.Stepper)
+func (hw *HullWhite) Steps() int {
+	return hw.N
+}
+
+// Path simulates the short rate with the exact transition of the
+// Ornstein-Uhlenbeck process
+func (hw *HullWhite) Path(rng mc.Normal) mc.Path {
+	dt := hw.T / float64(hw.N)
+	decay := math.Exp(-hw.A * dt)
+	vol := hw.Sigma * math.Sqrt((1.0-decay*decay)/(2.0*hw.A))
+
+	rates := make([]float64, hw.N+1)
+	x := 0.0
+	rates[0] = hw.Alpha[0]
+	for i := 1; i <= hw.N; i += 1 {
+		x = x*decay + vol*rng.NormFloat64()
+		rates[i] = x + hw.Alpha[i]
+	}
+	return mc.Path{Dt: dt, Rates: rates}
+}
@@ -0,0 +1,50 @@
+package hullwhite_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/mc"
+	"github.com/konimarti/fixedincome/pkg/mc/model/hullwhite"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestHullWhite_ZeroBonds(t *testing.T) {
+	ts := term.NelsonSiegelSvensson{B0: 3.0, B1: -2.0, B2: 1.0, B3: 0.0, T1: 2.0, T2: 2.0}
+	hw, err := hullwhite.New(&ts, 0.1, 0.01, 10.0, 520)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, maturity := range []float64{1.0, 5.0, 10.0} {
+		zero := mc.PayoffFunc(func(p mc.Path) float64 {
+			return p.Discount(p.Index(maturity))
+		})
+		price, stderr, err := mc.Price(hw, zero, 20000, 42)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(price-ts.Z(maturity)) > 3.0*stderr+1e-4 {
+			t.Errorf("wrong zero bond price for %v years; got: %v (+/- %v), expected: %v", maturity, price, stderr, ts.Z(maturity))
+		}
+	}
+}
+
+func TestHullWhite_Deterministic(t *testing.T) {
+	ts := term.Flat{R: 2.0}
+	hw, err := hullwhite.New(&ts, 0.1, 0.0, 5.0, 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	price, stderr, err := mc.Price(hw, mc.PayoffFunc(func(p mc.Path) float64 { return p.Discount(50) }), 10, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stderr > 1e-12 || math.Abs(price-ts.Z(5.0)) > 1e-9 {
+		t.Errorf("wrong price without volatility; got: %v (+/- %v), expected: %v", price, stderr, ts.Z(5.0))
+	}
+
+	if _, err := hullwhite.New(&ts, 0.0, 0.01, 5.0, 50); err == nil {
+		t.Errorf("expected error for zero mean reversion")
+	}
+}
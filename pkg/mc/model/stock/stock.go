@@ -3,7 +3,8 @@ package stock
 import (
 	"math"
 	"math/rand"
-	"time"
+
+	"github.com/konimarti/fixedincome/pkg/mc"
 )
 
 // Stock implements the log-normal simulation for the Monte Carlo engine
@@ -32,7 +33,7 @@ func New(r, s0, sigma, t float64, n int, payoff func([]float64) float64) *Stock
 		Sigma:  sigma,
 		T:      t,
 		N:      n,
-		Rng:    rand.New(rand.NewSource(time.Now().UnixNano())),
+		Rng:    mc.NewRand(),
 		Payoff: payoff,
 	}
 	return s
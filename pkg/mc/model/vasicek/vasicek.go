@@ -3,8 +3,8 @@ package vasicek
 import (
 	"math"
 	"math/rand"
-	"time"
 
+	"github.com/konimarti/fixedincome/pkg/mc"
 	"github.com/konimarti/fixedincome/pkg/term"
 	"gonum.org/v1/gonum/optimize"
 )
@@ -38,7 +38,7 @@ func New(ts term.Structure, sigma, t float64, n int, payoff func([]float64) floa
 		Sigma:  sigma,
 		T:      t,
 		N:      n,
-		Rng:    rand.New(rand.NewSource(time.Now().UnixNano())),
+		Rng:    mc.NewRand(),
 		Payoff: payoff,
 	}
 	err := Calibrate(v, ts)
@@ -102,6 +102,22 @@ func (v *Vasicek) Z(r, t, T float64) float64 {
 	return math.Exp(A - B*r)
 }
 
+// Steps returns the number of normals drawn per path (see mc.Stepper)
+func (v *Vasicek) Steps() int {
+	return v.N
+}
+
+// Path simulates the short rate for the Monte Carlo engine (see mc.Generator)
+func (v *Vasicek) Path(rng mc.Normal) mc.Path {
+	dt := v.T / float64(v.N)
+	rates := make([]float64, v.N+1)
+	rates[0] = v.R0
+	for i := 0; i < v.N; i += 1 {
+		rates[i+1] = rates[i] + v.Gamma*(v.Rbar-rates[i])*dt + v.Sigma*math.Sqrt(dt)*rng.NormFloat64()
+	}
+	return mc.Path{Dt: dt, Rates: rates}
+}
+
 // Measurement implements the model interface for the Monte Carlo engine
 func (v *Vasicek) Measurement() float64 {
 	n := v.N
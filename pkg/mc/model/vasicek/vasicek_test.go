@@ -59,3 +59,20 @@ func TestVasicek(t *testing.T) {
 	}
 
 }
+
+func TestVasicek_Path(t *testing.T) {
+	ts := term.NelsonSiegelSvensson{-0.43381, -0.308942, 4.83643, -4.10991, 4.65211, 3.33637, 0.0}
+	T, N := 5.0, 5*52
+	model, err := vasicek.New(&ts, 0.05, T, N, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zero := mc.PayoffFunc(func(p mc.Path) float64 { return 100.0 * p.Discount(N) })
+	price, _, err := mc.Price(model, zero, 10000, 99)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(price-ts.Z(T)*100.0) > 0.5 {
+		t.Errorf("vasicek paths failed to price zero bond; got: %v, expected: %v", price, ts.Z(T)*100.0)
+	}
+}
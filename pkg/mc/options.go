@@ -0,0 +1,82 @@
+package mc
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Seed returns the seed of the models that are created without an explicit
+// seed (default: the current time); set it to a constant function for
+// reproducible results
+var Seed = func() int64 { return time.Now().UnixNano() }
+
+// NewRand returns a random number generator seeded with Seed
+func NewRand() *rand.Rand {
+	return rand.New(rand.NewSource(Seed()))
+}
+
+// Options are the settings of a Monte Carlo valuation
+type Options struct {
+	// Nsim is the number of measurements (pairs of paths with Antithetic)
+	Nsim int
+	// Seed is the seed of the random number generator
+	Seed int64
+	// Antithetic enables antithetic variates
+	Antithetic bool
+	// Sobol is the dimension of the Sobol sequence, i.e. the number of
+	// normals per path (default: 0 for pseudo-random numbers); Run returns an
+	// error wrapping ErrExhausted if a path draws more normals
+	Sobol int
+}
+
+// Result is the price of a Monte Carlo valuation with its standard error
+type Result struct {
+	Price float64
+	// StdError is the standard error of the measurements; for the Sobol
+	// sequence it is the error of pseudo-random paths and overstates the
+	// error of the quasi-random estimate
+	StdError float64
+	// Paths is the number of simulated paths
+	Paths int
+}
+
+// Run values the payoff on the paths of the generator with the options;
+// the same options give the same result
+func Run(g Generator, p Payoff, o Options) (Result, error) {
+	if o.Nsim <= 0 {
+		return Result{}, errors.New("number of simulations must be positive")
+	}
+	s := NewSimulation(g, p, o.Seed)
+	s.Antithetic = o.Antithetic
+	if o.Sobol > 0 {
+		if st, ok := g.(Stepper); ok && st.Steps() > o.Sobol {
+			return Result{}, fmt.Errorf("%w: %d steps and dimension %d", ErrExhausted, st.Steps(), o.Sobol)
+		}
+		sobol, err := NewSobol(o.Sobol)
+		if err != nil {
+			return Result{}, err
+		}
+		s.Sobol = sobol
+	}
+	engine := New(s, o.Nsim)
+	if err := engine.Run(); err != nil {
+		return Result{}, err
+	}
+	if s.Sobol != nil {
+		if err := s.Sobol.Err(); err != nil {
+			return Result{}, err
+		}
+	}
+	r := Result{Paths: o.Nsim}
+	if o.Antithetic {
+		r.Paths *= 2
+	}
+	var err error
+	if r.Price, err = engine.Estimate(); err != nil {
+		return r, err
+	}
+	r.StdError, err = engine.StdError()
+	return r, err
+}
@@ -0,0 +1,100 @@
+package mc_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/mc"
+)
+
+// walk generates paths of a short rate following a random walk
+type walk struct {
+	r, sigma float64
+	n        int
+}
+
+func (w walk) Path(rng mc.Normal) mc.Path {
+	dt := 5.0 / float64(w.n)
+	rates := make([]float64, w.n+1)
+	rates[0] = w.r
+	for i := 1; i <= w.n; i += 1 {
+		rates[i] = rates[i-1] + w.sigma*math.Sqrt(dt)*rng.NormFloat64()
+	}
+	return mc.Path{Dt: dt, Rates: rates}
+}
+
+func TestRun(t *testing.T) {
+	g := walk{r: 0.02, sigma: 0.01, n: 16}
+	zero := mc.PayoffFunc(func(p mc.Path) float64 { return p.Discount(g.n) })
+	// the integral of the short rate is normal
+	variance := 0.0
+	for k := 1; k <= g.n; k += 1 {
+		// weight of the k-th increment in the trapezoidal integral
+		w := (float64(g.n-k) + 0.5) * 5.0 / float64(g.n)
+		variance += w * w * g.sigma * g.sigma * 5.0 / float64(g.n)
+	}
+	expected := math.Exp(-0.02*5.0 + 0.5*variance)
+
+	plain, err := mc.Run(g, zero, mc.Options{Nsim: 4000, Seed: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, _ := mc.Run(g, zero, mc.Options{Nsim: 4000, Seed: 7})
+	if again != plain {
+		t.Errorf("results are not reproducible; got: %v, expected: %v", again, plain)
+	}
+	if price, stderr, _ := mc.Price(g, zero, 4000, 7); price != plain.Price || stderr != plain.StdError {
+		t.Errorf("wrong price; got: %v (+/- %v), expected: %v", price, stderr, plain)
+	}
+
+	antithetic, err := mc.Run(g, zero, mc.Options{Nsim: 4000, Seed: 7, Antithetic: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sobol, err := mc.Run(g, zero, mc.Options{Nsim: 4000, Sobol: g.n})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range []mc.Result{plain, antithetic, sobol} {
+		if math.Abs(r.Price-expected) > 3.0*r.StdError+1e-6 {
+			t.Errorf("wrong zero bond price; got: %v (+/- %v), expected: %v", r.Price, r.StdError, expected)
+		}
+	}
+	if antithetic.Paths != 8000 || antithetic.StdError > 0.2*plain.StdError {
+		t.Errorf("wrong antithetic variates; got: %v, expected less than 20%% of the error: %v", antithetic, plain.StdError)
+	}
+	if math.Abs(sobol.Price-expected) > 0.1*plain.StdError {
+		t.Errorf("wrong Sobol estimate; got: %v, expected: %v (+/- %v)", sobol.Price, expected, 0.1*plain.StdError)
+	}
+
+	if _, err := mc.Run(g, zero, mc.Options{}); err == nil {
+		t.Errorf("expected error for zero simulations")
+	}
+
+	// more normals per path than the dimension of the Sobol sequence
+	if _, err := mc.Run(g, zero, mc.Options{Nsim: 10, Sobol: g.n - 1}); !errors.Is(err, mc.ErrExhausted) {
+		t.Errorf("expected ErrExhausted for too many draws; got: %v", err)
+	}
+	if _, err := mc.Run(stepped{g}, zero, mc.Options{Nsim: 10, Sobol: g.n - 1}); !errors.Is(err, mc.ErrExhausted) {
+		t.Errorf("expected ErrExhausted for too many steps; got: %v", err)
+	}
+}
+
+// stepped is a random walk that reports its number of steps
+type stepped struct {
+	walk
+}
+
+func (s stepped) Steps() int {
+	return s.n
+}
+
+func TestSeed(t *testing.T) {
+	defer func(seed func() int64) { mc.Seed = seed }(mc.Seed)
+	mc.Seed = func() int64 { return 42 }
+	if a, b := mc.NewRand().Float64(), mc.NewRand().Float64(); a != b {
+		t.Errorf("random numbers are not reproducible; got: %v and %v", a, b)
+	}
+}
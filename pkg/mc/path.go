@@ -0,0 +1,143 @@
+package mc
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Path is a simulated path of the short rate on a uniform time grid
+type Path struct {
+	// Dt is the time step in years
+	Dt float64
+	// Rates are the short rates (as decimals) at the times i * Dt
+	Rates []float64
+}
+
+// Time returns the time in years of the grid point i
+func (p Path) Time(i int) float64 {
+	return float64(i) * p.Dt
+}
+
+// Index returns the grid point closest to the time t in years
+func (p Path) Index(t float64) int {
+	i := int(math.Round(t / p.Dt))
+	if i < 0 {
+		return 0
+	}
+	if i >= len(p.Rates) {
+		return len(p.Rates) - 1
+	}
+	return i
+}
+
+// Discount returns the discount factor along the path from time 0 to the
+// grid point i (trapezoidal integration of the short rate)
+func (p Path) Discount(i int) float64 {
+	integral := 0.0
+	for k := 0; k < i && k+1 < len(p.Rates); k += 1 {
+		integral += 0.5 * (p.Rates[k] + p.Rates[k+1]) * p.Dt
+	}
+	return math.Exp(-integral)
+}
+
+// Normal provides standard normal draws, e.g. *rand.Rand or *Sobol
+type Normal interface {
+	NormFloat64() float64
+}
+
+// Generator simulates paths of the short rate, e.g. the Hull-White or Vasicek model
+type Generator interface {
+	Path(rng Normal) Path
+}
+
+// Stepper is a generator that reports the number of normals drawn per path
+// (e.g. the number of time steps), which Run checks against the dimension of
+// the Sobol sequence
+type Stepper interface {
+	Steps() int
+}
+
+// Payoff returns the value discounted to time 0 of a (path-dependent)
+// instrument for a simulated path, e.g. of a callable note, a range accrual
+// or a target redemption note
+type Payoff interface {
+	Value(p Path) float64
+}
+
+// PayoffFunc is an adapter to use a function as a Payoff
+type PayoffFunc func(p Path) float64
+
+// Value calls f(p)
+func (f PayoffFunc) Value(p Path) float64 {
+	return f(p)
+}
+
+// Simulation is the model for the Monte Carlo engine that values the payoff
+// on the paths of the generator
+type Simulation struct {
+	Generator Generator
+	Payoff    Payoff
+	Rng       *rand.Rand
+	// Antithetic values each path together with the path of the negated
+	// normals and measures the average of the pair
+	Antithetic bool
+	// Sobol draws the normals of each path from the quasi-random sequence
+	// instead of Rng (default: nil for Rng)
+	Sobol *Sobol
+}
+
+// NewSimulation returns the simulation for the generator and the payoff with
+// a random number generator seeded with seed
+func NewSimulation(g Generator, p Payoff, seed int64) *Simulation {
+	return &Simulation{Generator: g, Payoff: p, Rng: rand.New(rand.NewSource(seed))}
+}
+
+// Measurement implements the model interface for the Monte Carlo engine
+func (s *Simulation) Measurement() float64 {
+	var rng Normal = s.Rng
+	if s.Sobol != nil {
+		s.Sobol.Next()
+		rng = s.Sobol
+	}
+	if !s.Antithetic {
+		return s.Payoff.Value(s.Generator.Path(rng))
+	}
+	r := recorder{rng: rng}
+	value := s.Payoff.Value(s.Generator.Path(&r))
+	m := mirror{rng: rng, draws: r.draws}
+	return 0.5 * (value + s.Payoff.Value(s.Generator.Path(&m)))
+}
+
+// recorder records the normal draws of a path
+type recorder struct {
+	rng   Normal
+	draws []float64
+}
+
+func (r *recorder) NormFloat64() float64 {
+	z := r.rng.NormFloat64()
+	r.draws = append(r.draws, z)
+	return z
+}
+
+// mirror replays the negated normal draws of a path
+type mirror struct {
+	rng   Normal
+	draws []float64
+	i     int
+}
+
+func (m *mirror) NormFloat64() float64 {
+	if m.i >= len(m.draws) {
+		return m.rng.NormFloat64()
+	}
+	m.i += 1
+	return -m.draws[m.i-1]
+}
+
+// Price values the payoff with nsim paths of the generator and returns
+// the price and its standard error
+func Price(g Generator, p Payoff, nsim int, seed int64) (float64, float64, error) {
+	r, err := Run(g, p, Options{Nsim: nsim, Seed: seed})
+	return r.Price, r.StdError, err
+}
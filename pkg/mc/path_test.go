@@ -0,0 +1,56 @@
+package mc_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/mc"
+)
+
+// constant generates paths with a constant short rate
+type constant float64
+
+func (c constant) Path(rng mc.Normal) mc.Path {
+	rates := make([]float64, 101)
+	for i := range rates {
+		rates[i] = float64(c)
+	}
+	return mc.Path{Dt: 0.05, Rates: rates}
+}
+
+func TestPath(t *testing.T) {
+	p := constant(0.03).Path(nil)
+	if i := p.Index(2.5); i != 50 || p.Time(i) != 2.5 {
+		t.Errorf("wrong index; got: %v, expected: %v", i, 50)
+	}
+	if i := p.Index(10.0); i != 100 {
+		t.Errorf("index beyond the path; got: %v, expected: %v", i, 100)
+	}
+	if z := p.Discount(50); math.Abs(z-math.Exp(-0.03*2.5)) > 1e-12 {
+		t.Errorf("wrong discount factor; got: %v, expected: %v", z, math.Exp(-0.03*2.5))
+	}
+
+	// coupon note paying 1 each year and 100 after 5 years
+	note := mc.PayoffFunc(func(p mc.Path) float64 {
+		value := 0.0
+		for year := 1.0; year <= 5.0; year += 1.0 {
+			value += p.Discount(p.Index(year))
+		}
+		return value + 100.0*p.Discount(p.Index(5.0))
+	})
+	price, stderr, err := mc.Price(constant(0.03), note, 10, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := 100.0 * math.Exp(-0.15)
+	for year := 1.0; year <= 5.0; year += 1.0 {
+		expected += math.Exp(-0.03 * year)
+	}
+	if math.Abs(price-expected) > 1e-9 || stderr > 1e-12 {
+		t.Errorf("wrong price; got: %v (+/- %v), expected: %v", price, stderr, expected)
+	}
+
+	if _, _, err := mc.Price(constant(0.03), note, 0, 1); err == nil {
+		t.Errorf("expected error for zero simulations")
+	}
+}
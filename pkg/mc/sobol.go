@@ -0,0 +1,191 @@
+package mc
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// ErrDimension is returned for a Sobol sequence without dimensions
+var ErrDimension = errors.New("dimension of the Sobol sequence must be positive")
+
+// ErrExhausted is returned if a path draws more normals than the dimension of
+// the Sobol sequence
+var ErrExhausted = errors.New("more draws per path than the dimension of the Sobol sequence")
+
+// Sobol is a quasi-random Sobol sequence in Gray code order that provides the
+// normals of one path per point, i.e. the dimension is the number of normals
+// drawn per path (e.g. the number of time steps). The first dimension is the
+// van der Corput sequence; the other dimensions use the primitive
+// polynomials over GF(2) in ascending order with all initial direction
+// numbers set to 1 (not the optimized Joe-Kuo direction numbers).
+type Sobol struct {
+	v [][32]uint32
+	x []uint32
+	n uint32
+	i int
+	// draws is the largest number of draws of a point
+	draws int
+}
+
+// NewSobol returns the Sobol sequence of the dimension
+func NewSobol(dimension int) (*Sobol, error) {
+	if dimension <= 0 {
+		return nil, ErrDimension
+	}
+	s := &Sobol{v: make([][32]uint32, dimension), x: make([]uint32, dimension)}
+	for k := 0; k < 32; k += 1 {
+		s.v[0][k] = 1 << (31 - k)
+	}
+	polynomials := primitives(dimension - 1)
+	for d := 1; d < dimension; d += 1 {
+		s.v[d] = directions(polynomials[d-1])
+	}
+	return s, nil
+}
+
+// Dimension returns the dimension of the sequence
+func (s *Sobol) Dimension() int {
+	return len(s.x)
+}
+
+// Next advances the sequence to the next point (the first point is 0.5 in all dimensions)
+func (s *Sobol) Next() {
+	c := bits.TrailingZeros32(^s.n)
+	for d := range s.x {
+		s.x[d] ^= s.v[d][c]
+	}
+	s.n += 1
+	s.i = 0
+}
+
+// Float64 returns the next coordinate of the current point in (0, 1); the
+// draws beyond the dimension return 0.5 (see Err)
+func (s *Sobol) Float64() float64 {
+	if s.n == 0 {
+		s.Next()
+	}
+	s.i += 1
+	if s.i > s.draws {
+		s.draws = s.i
+	}
+	if s.i > len(s.x) {
+		return 0.5
+	}
+	return float64(s.x[s.i-1]) / (1 << 32)
+}
+
+// Err returns an error wrapping ErrExhausted if a point was drawn more often
+// than the dimension of the sequence
+func (s *Sobol) Err() error {
+	if s.draws > len(s.x) {
+		return fmt.Errorf("%w: %d draws and dimension %d", ErrExhausted, s.draws, len(s.x))
+	}
+	return nil
+}
+
+// NormFloat64 returns the next coordinate of the current point transformed to
+// a standard normal with the inverse cumulative distribution function
+func (s *Sobol) NormFloat64() float64 {
+	return distuv.UnitNormal.Quantile(s.Float64())
+}
+
+// directions returns the direction numbers for the primitive polynomial p of
+// degree s (bit i is the coefficient of x^i)
+func directions(p uint64) [32]uint32 {
+	degree := bits.Len64(p) - 1
+	m := make([]uint64, 33)
+	for k := 1; k <= degree && k <= 32; k += 1 {
+		m[k] = 1
+	}
+	for k := degree + 1; k <= 32; k += 1 {
+		m[k] = m[k-degree] ^ (m[k-degree] << degree)
+		for j := 1; j < degree; j += 1 {
+			if p>>(degree-j)&1 == 1 {
+				m[k] ^= m[k-j] << j
+			}
+		}
+	}
+	var v [32]uint32
+	for k := 1; k <= 32; k += 1 {
+		v[k-1] = uint32(m[k] << (32 - k))
+	}
+	return v
+}
+
+// primitives returns the first n primitive polynomials over GF(2) by degree
+func primitives(n int) []uint64 {
+	var polynomials []uint64
+	for degree := 1; len(polynomials) < n; degree += 1 {
+		for p := uint64(1)<<degree | 1; p < 1<<(degree+1) && len(polynomials) < n; p += 2 {
+			if primitive(p, degree) {
+				polynomials = append(polynomials, p)
+			}
+		}
+	}
+	return polynomials
+}
+
+// primitive reports whether x has the order 2^degree - 1 modulo p
+func primitive(p uint64, degree int) bool {
+	order := uint64(1)<<degree - 1
+	if power(2, order, p) != 1 {
+		return false
+	}
+	for _, q := range factors(order) {
+		if power(2, order/q, p) == 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// power returns a^e modulo p in GF(2)[x]
+func power(a, e, p uint64) uint64 {
+	result := uint64(1)
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			result = mulmod(result, a, p)
+		}
+		a = mulmod(a, a, p)
+	}
+	return result
+}
+
+// mulmod returns a*b modulo p in GF(2)[x]
+func mulmod(a, b, p uint64) uint64 {
+	degree := bits.Len64(p) - 1
+	for l := bits.Len64(a) - 1; l >= degree; l = bits.Len64(a) - 1 {
+		a ^= p << (l - degree)
+	}
+	result := uint64(0)
+	for ; b > 0; b >>= 1 {
+		if b&1 == 1 {
+			result ^= a
+		}
+		a <<= 1
+		if a>>degree&1 == 1 {
+			a ^= p
+		}
+	}
+	return result
+}
+
+// factors returns the distinct prime factors of n
+func factors(n uint64) []uint64 {
+	var primes []uint64
+	for q := uint64(2); q*q <= n; q += 1 {
+		if n%q == 0 {
+			primes = append(primes, q)
+			for n%q == 0 {
+				n /= q
+			}
+		}
+	}
+	if n > 1 {
+		primes = append(primes, n)
+	}
+	return primes
+}
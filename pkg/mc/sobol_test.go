@@ -0,0 +1,60 @@
+package mc_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/mc"
+)
+
+func TestSobol(t *testing.T) {
+	s, err := mc.NewSobol(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Dimension() != 8 {
+		t.Errorf("wrong dimension; got: %v, expected: %v", s.Dimension(), 8)
+	}
+
+	// first points of the first two dimensions
+	expected := [][2]float64{{0.5, 0.5}, {0.75, 0.25}, {0.25, 0.75}, {0.375, 0.375}, {0.875, 0.875}}
+	for i, point := range expected {
+		s.Next()
+		x, y := s.Float64(), s.Float64()
+		if x != point[0] || y != point[1] {
+			t.Errorf("wrong point %d; got: (%v, %v), expected: %v", i+1, x, y, point)
+		}
+	}
+
+	// the first 2^10 - 1 points are evenly spread in every dimension
+	s, _ = mc.NewSobol(8)
+	sums := make([]float64, 8)
+	n := 1<<10 - 1
+	for i := 0; i < n; i += 1 {
+		s.Next()
+		for d := range sums {
+			sums[d] += s.Float64()
+		}
+	}
+	for d, sum := range sums {
+		if math.Abs(sum/float64(n)-0.5) > 1e-12 {
+			t.Errorf("wrong mean of dimension %d; got: %v, expected: %v", d+1, sum/float64(n), 0.5)
+		}
+	}
+
+	// draws beyond the dimension
+	s, _ = mc.NewSobol(2)
+	s.Next()
+	s.Float64()
+	s.Float64()
+	if err := s.Err(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if u := s.Float64(); u != 0.5 || s.Err() == nil {
+		t.Errorf("expected error for draws beyond the dimension; got: %v (%v)", u, s.Err())
+	}
+
+	if _, err := mc.NewSobol(0); err == nil {
+		t.Errorf("expected error for zero dimension")
+	}
+}
@@ -0,0 +1,157 @@
+package mc
+
+import "math"
+
+// Index returns the value of a rate index (as decimal) observed at the grid
+// point i of the path, e.g. the short rate, a simple term rate or a swap rate
+// from the bond price formula of the model
+type Index func(p Path, i int) float64
+
+// Short is the short rate of the path
+func Short(p Path, i int) float64 {
+	return p.Rates[i]
+}
+
+// Simple returns the simply compounded rate for the tenor in years implied by
+// a constant short rate over the tenor (e.g. 0.25 for a 3-month rate)
+func Simple(tenor float64) Index {
+	return func(p Path, i int) float64 {
+		return (math.Exp(p.Rates[i]*tenor) - 1.0) / tenor
+	}
+}
+
+// Coupon returns the annualized coupon rate (as decimal) of the period from the
+// grid point start to the grid point end of the path
+type Coupon interface {
+	Rate(p Path, start, end int) float64
+}
+
+// CouponFunc is an adapter to use a function as a Coupon
+type CouponFunc func(p Path, start, end int) float64
+
+// Rate calls f(p, start, end)
+func (f CouponFunc) Rate(p Path, start, end int) float64 {
+	return f(p, start, end)
+}
+
+// Fixed pays a fixed coupon rate
+type Fixed float64
+
+// Rate returns the fixed rate
+func (f Fixed) Rate(p Path, start, end int) float64 {
+	return float64(f)
+}
+
+// Floating pays Leverage times the index fixed at the start of the period plus the spread
+type Floating struct {
+	Index Index
+	// Leverage is the multiplier of the index (e.g. -1 for an inverse floater)
+	Leverage float64
+	Spread   float64
+}
+
+// Rate returns the floating coupon rate
+func (f Floating) Rate(p Path, start, end int) float64 {
+	return f.Leverage*f.Index(p, start) + f.Spread
+}
+
+// SpreadLinked pays Leverage times the spread between two indices fixed at the
+// start of the period plus the margin, e.g. a steepener on the 10y-2y spread
+type SpreadLinked struct {
+	Long     Index
+	Short    Index
+	Leverage float64
+	Margin   float64
+}
+
+// Rate returns the spread-linked coupon rate
+func (s SpreadLinked) Rate(p Path, start, end int) float64 {
+	return s.Leverage*(s.Long(p, start)-s.Short(p, start)) + s.Margin
+}
+
+// Digital pays the coupon if the index fixed at the start of the period is at or
+// above the strike (or below the strike if Below is set) and the fallback otherwise
+type Digital struct {
+	Index    Index
+	Strike   float64
+	Below    bool
+	Coupon   Coupon
+	Fallback Coupon
+}
+
+// Rate returns the digital coupon rate
+func (d Digital) Rate(p Path, start, end int) float64 {
+	hit := d.Index(p, start) >= d.Strike
+	if d.Below {
+		hit = !hit
+	}
+	if hit {
+		return d.Coupon.Rate(p, start, end)
+	}
+	if d.Fallback == nil {
+		return 0.0
+	}
+	return d.Fallback.Rate(p, start, end)
+}
+
+// RangeAccrual pays the coupon for the fraction of the observations in the period
+// at which the index is within the range [Lower, Upper]
+type RangeAccrual struct {
+	Index  Index
+	Lower  float64
+	Upper  float64
+	Coupon Coupon
+}
+
+// Rate returns the accrued coupon rate
+func (r RangeAccrual) Rate(p Path, start, end int) float64 {
+	if end <= start {
+		return 0.0
+	}
+	in := 0
+	for i := start; i < end; i += 1 {
+		if v := r.Index(p, i); v >= r.Lower && v <= r.Upper {
+			in += 1
+		}
+	}
+	return r.Coupon.Rate(p, start, end) * float64(in) / float64(end-start)
+}
+
+// Collar bounds the coupon rate by the cap and the floor
+type Collar struct {
+	Coupon Coupon
+	Cap    float64
+	Floor  float64
+}
+
+// Rate returns the coupon rate within the floor and the cap
+func (c Collar) Rate(p Path, start, end int) float64 {
+	return math.Max(math.Min(c.Coupon.Rate(p, start, end), c.Cap), c.Floor)
+}
+
+// Note is a structured note that pays the coupons with the frequency per year
+// and the notional at maturity; it implements the Payoff interface
+type Note struct {
+	Coupon    Coupon
+	Maturity  float64
+	Frequency int
+	// Notional is the amount repaid at maturity (default: 0 for 100)
+	Notional float64
+}
+
+// Value returns the discounted coupons and notional along the path
+func (n Note) Value(p Path) float64 {
+	notional := n.Notional
+	if notional == 0.0 {
+		notional = 100.0
+	}
+	periods := int(math.Round(n.Maturity * float64(n.Frequency)))
+	value, start := 0.0, 0
+	for k := 1; k <= periods; k += 1 {
+		end := p.Index(float64(k) / float64(n.Frequency))
+		tau := p.Time(end) - p.Time(start)
+		value += notional * n.Coupon.Rate(p, start, end) * tau * p.Discount(end)
+		start = end
+	}
+	return value + notional*p.Discount(p.Index(n.Maturity))
+}
@@ -0,0 +1,80 @@
+package mc_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/mc"
+)
+
+// linear generates paths with the short rate rising from 0 by the slope per year
+type linear float64
+
+func (l linear) Path(rng mc.Normal) mc.Path {
+	rates := make([]float64, 101)
+	for i := range rates {
+		rates[i] = float64(l) * 0.05 * float64(i)
+	}
+	return mc.Path{Dt: 0.05, Rates: rates}
+}
+
+func TestNote(t *testing.T) {
+	p := constant(0.03).Path(nil)
+
+	// fixed note discounted at the short rate
+	note := mc.Note{Coupon: mc.Fixed(0.03), Maturity: 5.0, Frequency: 1}
+	expected := 100.0 * math.Exp(-0.15)
+	for year := 1.0; year <= 5.0; year += 1.0 {
+		expected += 3.0 * math.Exp(-0.03*year)
+	}
+	if got := note.Value(p); math.Abs(got-expected) > 1e-9 {
+		t.Errorf("wrong fixed note value; got: %v, expected: %v", got, expected)
+	}
+
+	// floating note paying the simple rate of the period prices at par
+	floater := mc.Note{Coupon: mc.Floating{Index: mc.Simple(0.5), Leverage: 1.0}, Maturity: 5.0, Frequency: 2}
+	if got := floater.Value(p); math.Abs(got-100.0) > 1e-9 {
+		t.Errorf("wrong floating note value; got: %v, expected: %v", got, 100.0)
+	}
+
+	// range accrual note priced with the Monte Carlo engine
+	ra := mc.Note{
+		Coupon:    mc.RangeAccrual{Index: mc.Short, Lower: 0.0, Upper: 0.04, Coupon: mc.Fixed(0.05)},
+		Maturity:  5.0,
+		Frequency: 4,
+	}
+	price, _, err := mc.Price(constant(0.03), ra, 10, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixed := mc.Note{Coupon: mc.Fixed(0.05), Maturity: 5.0, Frequency: 4}
+	if expected := fixed.Value(p); math.Abs(price-expected) > 1e-9 {
+		t.Errorf("wrong range accrual note price; got: %v, expected: %v", price, expected)
+	}
+}
+
+func TestStructuredCoupons(t *testing.T) {
+	p := linear(0.01).Path(nil) // short rate 0% to 5% over 5 years
+	start, end := 20, 40        // year 1 to 2
+
+	var tests = []struct {
+		name     string
+		coupon   mc.Coupon
+		expected float64
+	}{
+		{"fixed", mc.Fixed(0.02), 0.02},
+		{"inverse", mc.Floating{Index: mc.Short, Leverage: -2.0, Spread: 0.05}, 0.03},
+		{"digital above", mc.Digital{Index: mc.Short, Strike: 0.005, Coupon: mc.Fixed(0.04)}, 0.04},
+		{"digital below", mc.Digital{Index: mc.Short, Strike: 0.005, Below: true, Coupon: mc.Fixed(0.04), Fallback: mc.Fixed(0.01)}, 0.01},
+		// 0.01 to 0.0145 in range for 10 of the 20 observations 0.01, 0.0105, ..., 0.0195
+		{"range accrual", mc.RangeAccrual{Index: mc.Short, Lower: 0.0, Upper: 0.01475, Coupon: mc.Fixed(0.06)}, 0.03},
+		{"spread-linked", mc.SpreadLinked{Long: func(p mc.Path, i int) float64 { return 0.03 }, Short: mc.Short, Leverage: 2.0, Margin: 0.001}, 0.041},
+		{"collar", mc.Collar{Coupon: mc.Floating{Index: mc.Short, Leverage: 10.0}, Cap: 0.05, Floor: 0.02}, 0.05},
+		{"closure", mc.CouponFunc(func(p mc.Path, start, end int) float64 { return p.Rates[end] }), 0.02},
+	}
+	for _, test := range tests {
+		if got := test.coupon.Rate(p, start, end); math.Abs(got-test.expected) > 1e-12 {
+			t.Errorf("wrong %s coupon; got: %v, expected: %v", test.name, got, test.expected)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package portfolio
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Stats are the aggregated analytics of a group of positions; yield,
+// duration and spread are weighted by market value
+type Stats struct {
+	// Positions is the number of positions in the group
+	Positions int
+	// MarketValue is the value in the base currency
+	MarketValue float64
+	// Weight is the share of the portfolio's market value
+	Weight float64
+	// Yield is the yield-to-maturity in percent (see fixedincome.Irr)
+	Yield float64
+	// Duration is the duration (see fixedincome.TermSecurity)
+	Duration float64
+	// Spread is the static spread in bps over the term structure of the currency
+	Spread float64
+}
+
+// Buckets returns the analytics per value of the tag (e.g. per "rating"),
+// positions without the tag are reported in the bucket "". All securities
+// must implement fixedincome.TermSecurity.
+func (p *Portfolio) Buckets(tag string, curves Curves) (map[string]Stats, error) {
+	return p.group(curves, func(pos Position) []string { return []string{pos.Tag(tag)} })
+}
+
+// Labels returns the analytics per ESG label of the securities' metadata
+// (e.g. "green"); a position with several labels is reported in each of its
+// buckets and positions without labels in the bucket "", so that the weights
+// are the shares of the portfolio's market value per label
+func (p *Portfolio) Labels(curves Curves) (map[string]Stats, error) {
+	return p.group(curves, func(pos Position) []string {
+		if m := fixedincome.MetadataOf(pos.Security); m != nil && len(m.Labels) > 0 {
+			return m.Labels
+		}
+		return []string{""}
+	})
+}
+
+// UseOfProceeds returns the analytics per use-of-proceeds category of the
+// securities' metadata (see Labels)
+func (p *Portfolio) UseOfProceeds(curves Curves) (map[string]Stats, error) {
+	return p.group(curves, func(pos Position) []string {
+		if m := fixedincome.MetadataOf(pos.Security); m != nil && len(m.UseOfProceeds) > 0 {
+			return m.UseOfProceeds
+		}
+		return []string{""}
+	})
+}
+
+// group aggregates the analytics of the positions per key
+func (p *Portfolio) group(curves Curves, keys func(pos Position) []string) (map[string]Stats, error) {
+	buckets := map[string]Stats{}
+	total := 0.0
+	err := p.each(curves, func(pos Position, ts term.Structure, fx float64) error {
+		s, ok := pos.Security.(fixedincome.TermSecurity)
+		if !ok {
+			return fmt.Errorf("security %T does not provide a duration", pos.Security)
+		}
+
+		price := pos.Price
+		if price == 0.0 {
+			price = s.PresentValue(ts)
+		}
+		irr, err := fixedincome.Irr(price, s)
+		if err != nil {
+			return err
+		}
+		spread, err := fixedincome.Spread(price, s, ts)
+		if err != nil {
+			return err
+		}
+
+		value := fx * pos.Quantity * price
+		for _, key := range keys(pos) {
+			b := buckets[key]
+			b.Positions += 1
+			b.MarketValue += value
+			b.Yield += value * irr
+			b.Duration += value * s.Duration(ts)
+			b.Spread += value * spread
+			buckets[key] = b
+		}
+		total += value
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for name, b := range buckets {
+		if b.MarketValue != 0.0 {
+			b.Yield /= b.MarketValue
+			b.Duration /= b.MarketValue
+			b.Spread /= b.MarketValue
+		}
+		if total != 0.0 {
+			b.Weight = b.MarketValue / total
+		}
+		buckets[name] = b
+	}
+	return buckets, nil
+}
+
+// Tags returns the sorted distinct values of the tag in the portfolio
+func (p *Portfolio) Tags(tag string) []string {
+	seen := map[string]bool{}
+	values := []string{}
+	for _, pos := range p.Positions {
+		if v := pos.Tag(tag); !seen[v] {
+			seen[v] = true
+			values = append(values, v)
+		}
+	}
+	sort.Strings(values)
+	return values
+}
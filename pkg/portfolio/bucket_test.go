@@ -0,0 +1,77 @@
+package portfolio_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/portfolio"
+)
+
+func TestPortfolio_Buckets(t *testing.T) {
+	securities := universe(12)
+	ratings := []string{"AAA", "AA", "A"}
+
+	p := portfolio.Portfolio{Base: "CHF"}
+	for i, s := range securities {
+		p.Positions = append(p.Positions, portfolio.Position{
+			Security: s,
+			Quantity: float64(i + 1),
+			Price:    s.PresentValue(&nss) * 0.99,
+			Tags:     map[string]string{"rating": ratings[i%3]},
+		})
+	}
+	// untagged position
+	p.Positions = append(p.Positions, portfolio.Position{Security: securities[0], Quantity: 1.0})
+
+	buckets, err := p.Buckets("rating", portfolio.Curves{"CHF": &nss})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buckets) != 4 {
+		t.Fatalf("wrong number of buckets; got: %d, expected: %d", len(buckets), 4)
+	}
+	if tags := p.Tags("rating"); len(tags) != 4 || tags[0] != "" || tags[1] != "A" {
+		t.Errorf("wrong tags; got: %v", tags)
+	}
+
+	// recompute the AA bucket
+	value, duration, spread := 0.0, 0.0, 0.0
+	for _, pos := range p.Positions {
+		if pos.Tags["rating"] != "AA" {
+			continue
+		}
+		s := pos.Security.(fixedincome.TermSecurity)
+		sp, _ := fixedincome.Spread(pos.Price, s, &nss)
+		v := pos.Quantity * pos.Price
+		value += v
+		duration += v * s.Duration(&nss)
+		spread += v * sp
+	}
+	aa := buckets["AA"]
+	if aa.Positions != 4 {
+		t.Errorf("wrong number of positions; got: %d, expected: %d", aa.Positions, 4)
+	}
+	if math.Abs(aa.MarketValue-value) > 1e-8 {
+		t.Errorf("wrong market value; got: %v, expected: %v", aa.MarketValue, value)
+	}
+	if math.Abs(aa.Duration-duration/value) > 1e-8 {
+		t.Errorf("wrong duration; got: %v, expected: %v", aa.Duration, duration/value)
+	}
+	if math.Abs(aa.Spread-spread/value) > 1e-4 {
+		t.Errorf("wrong spread; got: %v, expected: %v", aa.Spread, spread/value)
+	}
+
+	// untagged position at model price has no spread
+	if math.Abs(buckets[""].Spread) > 1e-4 {
+		t.Errorf("position at model price should have zero spread; got: %v", buckets[""].Spread)
+	}
+
+	weights := 0.0
+	for _, b := range buckets {
+		weights += b.Weight
+	}
+	if math.Abs(weights-1.0) > 1e-12 {
+		t.Errorf("weights do not add up to one; got: %v", weights)
+	}
+}
@@ -0,0 +1,156 @@
+package portfolio
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// ErrUnknownCurrency is returned if an exchange rate or a term structure is missing for a currency
+var ErrUnknownCurrency = errors.New("unknown currency")
+
+// FX provides exchange rates
+type FX interface {
+	// Rate returns the units of currency to for one unit of currency from
+	Rate(from, to string) (float64, error)
+}
+
+// Spot is an FX provider with spot rates against a pivot currency
+type Spot struct {
+	// Pivot is the currency in which the rates are quoted (e.g. "CHF")
+	Pivot string
+	// Rates are the units of the pivot currency for one unit of the currency
+	// (e.g. "EUR": 1.08 for EUR/CHF)
+	Rates map[string]float64
+}
+
+// Rate returns the cross rate from the rates against the pivot currency
+func (s *Spot) Rate(from, to string) (float64, error) {
+	f, err := s.rate(from)
+	if err != nil {
+		return 0.0, err
+	}
+	t, err := s.rate(to)
+	if err != nil {
+		return 0.0, err
+	}
+	return f / t, nil
+}
+
+func (s *Spot) rate(currency string) (float64, error) {
+	if currency == s.Pivot {
+		return 1.0, nil
+	}
+	r, ok := s.Rates[currency]
+	if !ok || r <= 0.0 {
+		return 0.0, fmt.Errorf("%w: no exchange rate for %s%s", ErrUnknownCurrency, currency, s.Pivot)
+	}
+	return r, nil
+}
+
+// Curves are the term structures for discounting per currency
+type Curves map[string]term.Structure
+
+// MarketValue returns the value of the portfolio in the base currency where
+// each position is discounted with the term structure of its currency
+func (p *Portfolio) MarketValue(curves Curves) (float64, error) {
+	securities := make([]fixedincome.Security, 0, len(p.Positions))
+	factors := make([]float64, 0, len(p.Positions))
+	err := p.each(curves, func(pos Position, ts term.Structure, fx float64) error {
+		securities = append(securities, &discounted{pos.Security, ts})
+		factors = append(factors, fx*pos.Quantity)
+		return nil
+	})
+	if err != nil {
+		return 0.0, err
+	}
+
+	value := 0.0
+	for i, v := range PresentValues(securities, nil, p.Workers) {
+		value += factors[i] * v
+	}
+	return value, nil
+}
+
+// discounted binds a security to the term structure of its currency
+type discounted struct {
+	s  fixedincome.Security
+	ts term.Structure
+}
+
+func (d *discounted) PresentValue(term.Structure) float64 {
+	return d.s.PresentValue(d.ts)
+}
+
+// DV01 returns the change in value in the base currency for a parallel
+// increase of all term structures by one basis point (see fixedincome.DV01);
+// all securities must provide their cash flows
+func (p *Portfolio) DV01(curves Curves) (float64, error) {
+	dv01 := 0.0
+	err := p.each(curves, func(pos Position, ts term.Structure, fx float64) error {
+		s, ok := pos.Security.(fixedincome.CashflowSecurity)
+		if !ok {
+			return fmt.Errorf("security %T does not provide cash flows", pos.Security)
+		}
+		dv01 += fx * pos.Quantity * fixedincome.DV01(s, ts)
+		return nil
+	})
+	return dv01, err
+}
+
+// Ladder returns the outstanding cash flows of the portfolio aggregated per
+// payment date and converted at spot into the base currency; all securities
+// must provide their cash flows
+func (p *Portfolio) Ladder() (cashflow.Cashflows, error) {
+	ladder := cashflow.Cashflows{}
+	index := map[int64]int{}
+	for _, pos := range p.Positions {
+		s, ok := pos.Security.(fixedincome.CashflowSecurity)
+		if !ok {
+			return nil, fmt.Errorf("security %T does not provide cash flows", pos.Security)
+		}
+		fx, err := p.rate(pos.Currency)
+		if err != nil {
+			return nil, err
+		}
+		for _, cf := range s.Cashflows() {
+			i, ok := index[cf.Date.Unix()]
+			if !ok {
+				i = len(ladder)
+				index[cf.Date.Unix()] = i
+				ladder = append(ladder, cashflow.Cashflow{Date: cf.Date, T: cf.T})
+			}
+			ladder[i].Amount += fx * pos.Quantity * cf.Amount
+		}
+	}
+	ladder.Sort()
+	return ladder, nil
+}
+
+// rate returns the exchange rate from the currency into the base currency
+func (p *Portfolio) rate(currency string) (float64, error) {
+	if currency == "" || currency == p.Base {
+		return 1.0, nil
+	}
+	if p.FX == nil {
+		return 0.0, fmt.Errorf("%w: no FX provider for %s", ErrUnknownCurrency, currency)
+	}
+	return p.FX.Rate(currency, p.Base)
+}
+
+// each calls f for every position with the term structure and exchange rate of its currency
+func (p *Portfolio) each(curves Curves, f func(pos Position, ts term.Structure, fx float64) error) error {
+	for _, pos := range p.Positions {
+		ts, fx, err := p.curve(pos, curves)
+		if err != nil {
+			return err
+		}
+		if err := f(pos, ts, fx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
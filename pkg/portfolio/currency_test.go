@@ -0,0 +1,107 @@
+package portfolio_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/portfolio"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestSpot(t *testing.T) {
+	fx := portfolio.Spot{Pivot: "CHF", Rates: map[string]float64{"EUR": 1.08, "USD": 0.90}}
+
+	testData := []struct {
+		From, To string
+		Expected float64
+	}{
+		{"EUR", "CHF", 1.08},
+		{"CHF", "EUR", 1.0 / 1.08},
+		{"EUR", "USD", 1.2},
+		{"CHF", "CHF", 1.0},
+	}
+	for _, test := range testData {
+		r, err := fx.Rate(test.From, test.To)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(r-test.Expected) > 1e-12 {
+			t.Errorf("wrong rate %s%s; got: %v, expected: %v", test.From, test.To, r, test.Expected)
+		}
+	}
+	if _, err := fx.Rate("GBP", "CHF"); !errors.Is(err, portfolio.ErrUnknownCurrency) {
+		t.Errorf("expected ErrUnknownCurrency; got: %v", err)
+	}
+}
+
+func TestPortfolio_MultiCurrency(t *testing.T) {
+	securities := universe(20)
+	eur := term.Flat{R: 0.5}
+	curves := portfolio.Curves{"CHF": &nss, "EUR": &eur}
+
+	p := portfolio.Portfolio{
+		Base: "CHF",
+		FX:   &portfolio.Spot{Pivot: "CHF", Rates: map[string]float64{"EUR": 1.08}},
+	}
+	value, dv01 := 0.0, 0.0
+	flows := 0.0
+	for i, s := range securities {
+		pos := portfolio.Position{Security: s, Quantity: 10.0, Currency: "CHF"}
+		ts, fx := term.Structure(&nss), 1.0
+		if i%2 == 1 {
+			pos.Currency, ts, fx = "EUR", &eur, 1.08
+		}
+		p.Positions = append(p.Positions, pos)
+
+		cs := s.(fixedincome.CashflowSecurity)
+		value += fx * 10.0 * s.PresentValue(ts)
+		dv01 += fx * 10.0 * fixedincome.DV01(cs, ts)
+		for _, cf := range cs.Cashflows() {
+			flows += fx * 10.0 * cf.Amount
+		}
+	}
+
+	mv, err := p.MarketValue(curves)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(mv-value) > 1e-8 {
+		t.Errorf("wrong market value; got: %v, expected: %v", mv, value)
+	}
+
+	d, err := p.DV01(curves)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(d-dv01) > 1e-8 {
+		t.Errorf("wrong DV01; got: %v, expected: %v", d, dv01)
+	}
+
+	ladder, err := p.Ladder()
+	if err != nil {
+		t.Fatal(err)
+	}
+	total := 0.0
+	for i, cf := range ladder {
+		total += cf.Amount
+		if i > 0 && !cf.Date.After(ladder[i-1].Date) {
+			t.Errorf("ladder is not ordered by unique payment dates at %v", cf.Date)
+		}
+	}
+	if math.Abs(total-flows) > 1e-8 {
+		t.Errorf("wrong total of cash flow ladder; got: %v, expected: %v", total, flows)
+	}
+
+	// missing term structure
+	if _, err := p.MarketValue(portfolio.Curves{"CHF": &nss}); !errors.Is(err, portfolio.ErrUnknownCurrency) {
+		t.Errorf("expected ErrUnknownCurrency for missing term structure; got: %v", err)
+	}
+
+	// missing FX provider
+	p.FX = nil
+	if _, err := p.MarketValue(curves); !errors.Is(err, portfolio.ErrUnknownCurrency) {
+		t.Errorf("expected ErrUnknownCurrency for missing FX provider; got: %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package portfolio
+
+import (
+	"fmt"
+
+	"github.com/konimarti/fixedincome"
+)
+
+// Hedge is the instrument used to neutralize the interest rate risk of a portfolio
+type Hedge struct {
+	// Security is the hedge bond or the cheapest-to-deliver bond of a bond future
+	Security fixedincome.CashflowSecurity
+	// Currency is the currency of the hedge (default: "" for the base currency)
+	Currency string
+	// ConversionFactor of the cheapest-to-deliver bond (bond futures only)
+	ConversionFactor float64
+	// ContractSize is the nominal per futures contract, e.g. 100000
+	// (default: 0 for hedging with the bond itself)
+	ContractSize float64
+}
+
+// hedgeDV01 returns the DV01 of one unit of the hedge in the base currency, i.e.
+// of one unit of the bond or of one futures contract (DV01 of the
+// cheapest-to-deliver bond divided by its conversion factor)
+func (p *Portfolio) hedgeDV01(h Hedge, curves Curves) (float64, error) {
+	currency := h.Currency
+	if currency == "" {
+		currency = p.Base
+	}
+	ts, ok := curves[currency]
+	if !ok {
+		return 0.0, fmt.Errorf("%w: no term structure for %s", ErrUnknownCurrency, currency)
+	}
+	fx, err := p.rate(h.Currency)
+	if err != nil {
+		return 0.0, err
+	}
+
+	dv01 := fx * fixedincome.DV01(h.Security, ts)
+	if h.ContractSize > 0.0 {
+		if h.ConversionFactor <= 0.0 {
+			return 0.0, fmt.Errorf("conversion factor %v of the future is not positive", h.ConversionFactor)
+		}
+		dv01 *= h.ContractSize / 100.0 / h.ConversionFactor
+	}
+	return dv01, nil
+}
+
+// HedgeRatio returns the quantity of the hedge (units of the bond or number
+// of futures contracts) that neutralizes the DV01 of the portfolio. A
+// negative quantity is a short position in the hedge.
+func (p *Portfolio) HedgeRatio(h Hedge, curves Curves) (float64, error) {
+	dv01, err := p.DV01(curves)
+	if err != nil {
+		return 0.0, err
+	}
+	unit, err := p.hedgeDV01(h, curves)
+	if err != nil {
+		return 0.0, err
+	}
+	if unit == 0.0 {
+		return 0.0, fmt.Errorf("hedge %T has no interest rate sensitivity", h.Security)
+	}
+	return -dv01 / unit, nil
+}
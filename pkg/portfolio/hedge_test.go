@@ -0,0 +1,53 @@
+package portfolio_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/portfolio"
+)
+
+func TestPortfolio_HedgeRatio(t *testing.T) {
+	securities := universe(30)
+	curves := portfolio.Curves{"CHF": &nss}
+
+	p := portfolio.Portfolio{Base: "CHF"}
+	for _, s := range securities {
+		p.Positions = append(p.Positions, portfolio.Position{Security: s, Quantity: 1000.0})
+	}
+	ctd := securities[9].(fixedincome.CashflowSecurity)
+
+	testData := []portfolio.Hedge{
+		{Security: ctd},
+		{Security: ctd, ConversionFactor: 0.85, ContractSize: 100000.0},
+	}
+	for nr, h := range testData {
+		ratio, err := p.HedgeRatio(h, curves)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ratio >= 0.0 {
+			t.Errorf("test nr %d: long bond portfolio requires a short hedge; got: %v", nr, ratio)
+		}
+
+		// the hedged portfolio has no DV01
+		hedged := p
+		hedge := portfolio.Position{Security: ctd, Quantity: ratio}
+		if h.ContractSize > 0.0 {
+			hedge.Quantity *= h.ContractSize / 100.0 / h.ConversionFactor
+		}
+		hedged.Positions = append(append([]portfolio.Position{}, p.Positions...), hedge)
+		dv01, err := hedged.DV01(curves)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(dv01) > 1e-8 {
+			t.Errorf("test nr %d: hedged portfolio has DV01 %v", nr, dv01)
+		}
+	}
+
+	if _, err := p.HedgeRatio(portfolio.Hedge{Security: ctd, ContractSize: 100000.0}, curves); err == nil {
+		t.Errorf("expected an error for a future without conversion factor")
+	}
+}
@@ -0,0 +1,91 @@
+package portfolio
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/term"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize/convex/lp"
+)
+
+// Match selects the holdings of the candidate bonds with minimum cost whose
+// cash flows cover the liabilities. Surplus cash is carried forward to later
+// liabilities without reinvestment income. The cost of a candidate is its
+// Price or the present value with ts if no price is given; the quantities of
+// the candidates are ignored. The optimization is solved as a linear program.
+func Match(candidates []Position, liabilities cashflow.Cashflows, ts term.Structure) (Portfolio, error) {
+	if len(liabilities) == 0 {
+		return Portfolio{}, nil
+	}
+	dates := make(cashflow.Cashflows, len(liabilities))
+	copy(dates, liabilities)
+	sort.SliceStable(dates, func(i, j int) bool { return dates[i].Date.Before(dates[j].Date) })
+	last := dates[len(dates)-1].Date
+
+	// cumulative cash flows per liability date of the eligible candidates
+	eligible := []Position{}
+	prices := []float64{}
+	columns := [][]float64{}
+	for _, pos := range candidates {
+		s, ok := pos.Security.(fixedincome.CashflowSecurity)
+		if !ok {
+			return Portfolio{}, fmt.Errorf("security %T does not provide cash flows", pos.Security)
+		}
+		column := make([]float64, len(dates))
+		covers := false
+		for _, cf := range s.Cashflows() {
+			if cf.Date.After(last) {
+				continue
+			}
+			for k := range dates {
+				if !cf.Date.After(dates[k].Date) {
+					column[k] += cf.Amount
+					covers = covers || cf.Amount != 0.0
+				}
+			}
+		}
+		if !covers {
+			continue
+		}
+		price := pos.Price
+		if price == 0.0 {
+			price = s.PresentValue(ts)
+		}
+		eligible = append(eligible, pos)
+		prices = append(prices, price)
+		columns = append(columns, column)
+	}
+
+	// standard form: min c'x s.t. Ax = b, x >= 0 with a surplus variable per date
+	n, m := len(eligible), len(dates)
+	c := make([]float64, n+m)
+	copy(c, prices)
+	A := mat.NewDense(m, n+m, nil)
+	b := make([]float64, m)
+	cumulated := 0.0
+	for k := range dates {
+		cumulated += dates[k].Amount
+		b[k] = cumulated
+		for j := range eligible {
+			A.Set(k, j, columns[j][k])
+		}
+		A.Set(k, n+k, -1.0)
+	}
+
+	_, x, err := lp.Simplex(c, A, b, 0, nil)
+	if err != nil {
+		return Portfolio{}, fmt.Errorf("cash flow matching failed: %w", err)
+	}
+
+	p := Portfolio{}
+	for j, pos := range eligible {
+		if x[j] > 1e-10 {
+			pos.Quantity = x[j]
+			p.Positions = append(p.Positions, pos)
+		}
+	}
+	return p, nil
+}
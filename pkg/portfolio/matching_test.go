@@ -0,0 +1,68 @@
+package portfolio_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/portfolio"
+	"gonum.org/v1/gonum/optimize/convex/lp"
+)
+
+func TestMatch(t *testing.T) {
+	candidates := []portfolio.Position{}
+	for _, s := range universe(60) {
+		candidates = append(candidates, portfolio.Position{Security: s})
+	}
+
+	liabilities := cashflow.Cashflows{
+		{Date: time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC), Amount: 1000.0},
+		{Date: time.Date(2022, 6, 30, 0, 0, 0, 0, time.UTC), Amount: 1000.0},
+		{Date: time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC), Amount: 5000.0},
+	}
+
+	p, err := portfolio.Match(candidates, liabilities, &nss)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Positions) == 0 {
+		t.Fatal("no positions selected")
+	}
+
+	// cumulative cash covers the cumulative liabilities
+	for _, l := range liabilities {
+		cash, due := 0.0, 0.0
+		for _, pos := range p.Positions {
+			for _, cf := range pos.Security.(fixedincome.CashflowSecurity).Cashflows() {
+				if !cf.Date.After(l.Date) {
+					cash += pos.Quantity * cf.Amount
+				}
+			}
+		}
+		for _, other := range liabilities {
+			if !other.Date.After(l.Date) {
+				due += other.Amount
+			}
+		}
+		if cash < due-1e-6 {
+			t.Errorf("liabilities up to %v are not covered; cash: %v, due: %v", l.Date, cash, due)
+		}
+	}
+
+	cost := p.PresentValue(&nss)
+	if cost <= 0.0 || math.IsNaN(cost) {
+		t.Errorf("invalid cost of matched portfolio: %v", cost)
+	}
+
+	// liabilities before the first cash flow cannot be matched
+	early := cashflow.Cashflows{
+		{Date: time.Date(2021, 4, 2, 0, 0, 0, 0, time.UTC), Amount: 1.0},
+		{Date: time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), Amount: 1.0},
+	}
+	if _, err := portfolio.Match(candidates, early, &nss); !errors.Is(err, lp.ErrInfeasible) {
+		t.Errorf("expected an infeasible problem; got: %v", err)
+	}
+}
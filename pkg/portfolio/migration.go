@@ -0,0 +1,121 @@
+package portfolio
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/credit"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// ErrUnknownMigration is returned if the spread change of a rating transition is missing
+var ErrUnknownMigration = errors.New("unknown rating migration")
+
+// Migration maps rating transitions to spread changes in bps, e.g.
+// Migration{"A": {"BBB": 60.0, "BB": 250.0}} for downgrades from A
+type Migration map[string]map[string]float64
+
+// Change returns the spread change in bps of the transition from one rating to another
+func (m Migration) Change(from, to string) (float64, error) {
+	from, to = strings.ToUpper(strings.TrimSpace(from)), strings.ToUpper(strings.TrimSpace(to))
+	if from == to {
+		return 0.0, nil
+	}
+	change, ok := m[from][to]
+	if !ok {
+		return 0.0, fmt.Errorf("%w: no spread change from %s to %s", ErrUnknownMigration, from, to)
+	}
+	return change, nil
+}
+
+// RatingScenario is a rating migration of the positions with the rating
+// given by their "rating" tag; unrated positions are not migrated
+type RatingScenario struct {
+	Name string
+	// Transitions maps the current ratings to the ratings in the scenario
+	Transitions map[string]string
+	// Notches downgrades the ratings without a transition by the number of
+	// notches on credit.Scale (default: 0 for no change)
+	Notches int
+}
+
+// rating returns the rating in the scenario
+func (s *RatingScenario) rating(current string) (string, error) {
+	current = strings.ToUpper(strings.TrimSpace(current))
+	if to, ok := s.Transitions[current]; ok {
+		return strings.ToUpper(strings.TrimSpace(to)), nil
+	}
+	if s.Notches == 0 {
+		return current, nil
+	}
+	for i, r := range credit.Scale {
+		if r == current {
+			k := i + s.Notches
+			if k < 0 {
+				k = 0
+			}
+			if k >= len(credit.Scale) {
+				k = len(credit.Scale) - 1
+			}
+			return credit.Scale[k], nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown rating %q", ErrUnknownMigration, current)
+}
+
+// Repricing is the profit and loss of a position under a rating scenario
+type Repricing struct {
+	Position Position
+	From     string
+	To       string
+	// Change is the spread change in bps
+	Change float64
+	// Value and Scenario are the market values in the base currency before
+	// and after the migration
+	Value    float64
+	Scenario float64
+	PnL      float64
+}
+
+// Migrate reprices the positions with the spread changes of the rating
+// transitions in the scenario; positions are repriced at their static spread
+// over the curve (implied by the market price if there is one) plus the
+// spread change
+func (p *Portfolio) Migrate(curves Curves, m Migration, s RatingScenario) ([]Repricing, error) {
+	repricings := make([]Repricing, 0, len(p.Positions))
+	err := p.each(curves, func(pos Position, ts term.Structure, fx float64) error {
+		r := Repricing{Position: pos, From: pos.Tag("rating")}
+		price := pos.Price
+		if price == 0.0 {
+			price = pos.Security.PresentValue(ts)
+		}
+		r.Value = fx * pos.Quantity * price
+		r.Scenario = r.Value
+		if r.From != "" {
+			to, err := s.rating(r.From)
+			if err != nil {
+				return err
+			}
+			r.To = to
+			if r.Change, err = m.Change(r.From, to); err != nil {
+				return err
+			}
+		}
+		if r.Change != 0.0 {
+			spread, err := fixedincome.Spread(price, pos.Security, ts)
+			if err != nil {
+				return fmt.Errorf("spread of %q: %w", pos.Tag("isin"), err)
+			}
+			r.Scenario = fx * pos.Quantity * pos.Security.PresentValue(term.WithSpread(ts, spread+r.Change))
+		}
+		r.PnL = r.Scenario - r.Value
+		repricings = append(repricings, r)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return repricings, nil
+}
@@ -0,0 +1,83 @@
+package portfolio_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/portfolio"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestPortfolio_Migrate(t *testing.T) {
+	securities := universe(6)
+	ratings := []string{"AA", "A", "BBB"}
+	p := portfolio.Portfolio{Base: "CHF"}
+	for i, s := range securities {
+		p.Positions = append(p.Positions, portfolio.Position{
+			Security: s,
+			Quantity: 10.0,
+			Tags:     map[string]string{"rating": ratings[i%3]},
+		})
+	}
+	// quoted and unrated positions
+	p.Positions[1].Price = securities[1].PresentValue(&nss) - 1.0
+	p.Positions = append(p.Positions, portfolio.Position{Security: securities[0], Quantity: 1.0})
+
+	m := portfolio.Migration{
+		"AA":  {"AA-": 10.0, "A": 25.0},
+		"A":   {"A-": 15.0, "BBB": 60.0},
+		"BBB": {"BBB-": 30.0, "BB": 200.0, "BB+": 120.0},
+	}
+	s := portfolio.RatingScenario{
+		Name:        "fallen angels",
+		Transitions: map[string]string{"BBB": "BB"},
+		Notches:     1,
+	}
+	repricings, err := p.Migrate(portfolio.Curves{"CHF": &nss}, m, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repricings) != len(p.Positions) {
+		t.Fatalf("wrong number of repricings; got: %v, expected: %v", len(repricings), len(p.Positions))
+	}
+
+	var testData = []struct {
+		To     string
+		Change float64
+	}{
+		{"AA-", 10.0},
+		{"A-", 15.0},
+		{"BB", 200.0},
+		{"AA-", 10.0},
+		{"A-", 15.0},
+		{"BB", 200.0},
+		{"", 0.0},
+	}
+	for i, test := range testData {
+		r := repricings[i]
+		if r.To != test.To || r.Change != test.Change {
+			t.Errorf("wrong migration of position %d; got: %v (%v bps), expected: %v (%v bps)", i, r.To, r.Change, test.To, test.Change)
+		}
+		pos := p.Positions[i]
+		price := pos.Price
+		if price == 0.0 {
+			price = pos.Security.PresentValue(&nss)
+		}
+		spread, _ := fixedincome.Spread(price, pos.Security, &nss)
+		expected := pos.Quantity * (pos.Security.PresentValue(term.WithSpread(&nss, spread+test.Change)) - price)
+		if math.Abs(r.PnL-expected) > 1e-6 || math.Abs(r.Scenario-r.Value-r.PnL) > 1e-9 {
+			t.Errorf("wrong P&L of position %d; got: %v, expected: %v", i, r.PnL, expected)
+		}
+		if test.Change > 0.0 && r.PnL >= 0.0 {
+			t.Errorf("wrong sign of P&L of downgrade of position %d; got: %v", i, r.PnL)
+		}
+	}
+
+	// missing spread change
+	s.Notches = 2
+	if _, err := p.Migrate(portfolio.Curves{"CHF": &nss}, m, s); !errors.Is(err, portfolio.ErrUnknownMigration) {
+		t.Errorf("wrong error; got: %v, expected: %v", err, portfolio.ErrUnknownMigration)
+	}
+}
@@ -0,0 +1,106 @@
+package portfolio
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Position represents the holding of a security in a portfolio
+type Position struct {
+	// Security is the held instrument
+	Security fixedincome.Security
+	// Quantity is the number of units held
+	// (e.g. nominal / 100 for bonds quoted in percent of par)
+	Quantity float64
+	// Currency is the currency of the security (default: "" for the base currency)
+	Currency string
+	// Price is the dirty market price per unit for yield and spread analytics
+	// (default: 0 for the model price)
+	Price float64
+	// Tags classify the position for reports, e.g. "issuer", "sector",
+	// "rating" or "country"
+	Tags map[string]string
+}
+
+// Tag returns the value of the tag of the position or the reporting field of
+// the security's metadata (see fixedincome.Metadata.Field) if it is not tagged
+func (pos Position) Tag(name string) string {
+	if v, ok := pos.Tags[name]; ok {
+		return v
+	}
+	return fixedincome.MetadataOf(pos.Security).Field(name)
+}
+
+// Portfolio represents a collection of positions that are valued
+// against the same term structure (or one term structure per currency
+// with the values reported in the base currency, see MarketValue)
+type Portfolio struct {
+	Positions []Position
+	// Workers is the number of goroutines used for pricing
+	// (default: 0 for runtime.NumCPU())
+	Workers int
+	// Base is the reporting currency of the portfolio (e.g. "CHF")
+	Base string
+	// FX provides the exchange rates for positions in other currencies
+	FX FX
+}
+
+// Values returns the market values of all positions (quantity times present value)
+func (p *Portfolio) Values(ts term.Structure) []float64 {
+	securities := make([]fixedincome.Security, len(p.Positions))
+	for i, pos := range p.Positions {
+		securities[i] = pos.Security
+	}
+	values := PresentValues(securities, ts, p.Workers)
+	for i, pos := range p.Positions {
+		values[i] *= pos.Quantity
+	}
+	return values
+}
+
+// PresentValue returns the market value of the portfolio
+func (p *Portfolio) PresentValue(ts term.Structure) float64 {
+	value := 0.0
+	for _, v := range p.Values(ts) {
+		value += v
+	}
+	return value
+}
+
+// PresentValues prices the securities concurrently with a pool of workers
+// and returns the present values in the same order as the securities.
+// The term structure is only read during pricing and is shared across all workers.
+// If workers is zero or negative, runtime.NumCPU() workers are used.
+func PresentValues(securities []fixedincome.Security, ts term.Structure, workers int) []float64 {
+	values := make([]float64, len(securities))
+
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(securities) {
+		workers = len(securities)
+	}
+
+	// distribute indices over the worker pool
+	jobs := make(chan int, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w += 1 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				values[i] = securities[i].PresentValue(ts)
+			}
+		}()
+	}
+	for i := range securities {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return values
+}
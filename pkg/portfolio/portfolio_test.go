@@ -0,0 +1,121 @@
+package portfolio_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/portfolio"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+var (
+	// term structure (parameters per 2021-04-01 for CH govt bonds)
+	nss = term.NelsonSiegelSvensson{
+		B0: -0.266372,
+		B1: -0.471343,
+		B2: 5.68789,
+		B3: -5.12324,
+		T1: 5.74881,
+		T2: 4.14426,
+	}
+	settlement = time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// universe creates n straight bonds with maturities between 1 and 30 years
+func universe(n int) []fixedincome.Security {
+	securities := make([]fixedincome.Security, n)
+	for i := 0; i < n; i += 1 {
+		securities[i] = &bond.Straight{
+			Schedule: maturity.Schedule{
+				Settlement: settlement,
+				Maturity:   settlement.AddDate(1+i%30, i%12, 0),
+				Frequency:  1,
+				Basis:      "30E360",
+			},
+			Coupon:     0.25 * float64(i%12),
+			Redemption: 100.0,
+		}
+	}
+	return securities
+}
+
+func TestPresentValues(t *testing.T) {
+	securities := universe(500)
+
+	values := portfolio.PresentValues(securities, &nss, 4)
+	if len(values) != len(securities) {
+		t.Fatalf("wrong number of values; got: %d, expected: %d", len(values), len(securities))
+	}
+	for i, s := range securities {
+		expected := s.PresentValue(&nss)
+		if math.Abs(values[i]-expected) > 1e-12 {
+			t.Errorf("value of security nr %d does not match; got: %v, expected: %v", i, values[i], expected)
+		}
+	}
+}
+
+func TestPortfolio_PresentValue(t *testing.T) {
+	securities := universe(100)
+
+	p := portfolio.Portfolio{}
+	expected := 0.0
+	for i, s := range securities {
+		quantity := float64(i + 1)
+		p.Positions = append(p.Positions, portfolio.Position{Security: s, Quantity: quantity})
+		expected += quantity * s.PresentValue(&nss)
+	}
+
+	value := p.PresentValue(&nss)
+	if math.Abs(value-expected) > 1e-8 {
+		t.Errorf("wrong portfolio value; got: %v, expected: %v", value, expected)
+	}
+
+	// empty portfolio
+	empty := portfolio.Portfolio{}
+	if v := empty.PresentValue(&nss); v != 0.0 {
+		t.Errorf("empty portfolio should have zero value; got: %v", v)
+	}
+}
+
+func benchmarkPresentValues(b *testing.B, workers int) {
+	securities := universe(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i += 1 {
+		portfolio.PresentValues(securities, &nss, workers)
+	}
+}
+
+func BenchmarkPresentValues_Sequential(b *testing.B) { benchmarkPresentValues(b, 1) }
+func BenchmarkPresentValues_Parallel(b *testing.B)   { benchmarkPresentValues(b, 0) }
+
+func BenchmarkPresentValues_Cache(b *testing.B) {
+	securities := universe(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i += 1 {
+		portfolio.PresentValues(securities, term.NewCache(&nss), 0)
+	}
+}
+
+// benchmarkScenarios reprices the portfolio for a series of spread bumps
+func benchmarkScenarios(b *testing.B, cached bool) {
+	securities := universe(10000)
+	bumps := []float64{-10.0, -1.0, 0.0, 1.0, 10.0}
+	b.ResetTimer()
+	for i := 0; i < b.N; i += 1 {
+		for _, bump := range bumps {
+			curve := nss
+			var ts term.Structure = curve.SetSpread(bump)
+			if cached {
+				ts = term.NewCache(ts)
+			}
+			portfolio.PresentValues(securities, ts, 0)
+		}
+	}
+}
+
+func BenchmarkScenarios(b *testing.B)       { benchmarkScenarios(b, false) }
+func BenchmarkScenarios_Cache(b *testing.B) { benchmarkScenarios(b, true) }
@@ -0,0 +1,101 @@
+package portfolio
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Holding is a line of the holdings report with the metadata of the security
+type Holding struct {
+	ISIN     string
+	Issuer   string
+	Currency string
+	Quantity float64
+	// MarketValue is the value in the base currency
+	MarketValue float64
+	// Weight is the share of the portfolio's market value
+	Weight        float64
+	Labels        []string
+	UseOfProceeds []string
+	// Tags are the tags of the position and the reporting fields of the metadata
+	Tags map[string]string
+}
+
+// Holdings returns the holdings report of the positions valued at their
+// market price (or the model price if there is no market price)
+func (p *Portfolio) Holdings(curves Curves) ([]Holding, error) {
+	holdings := make([]Holding, 0, len(p.Positions))
+	total := 0.0
+	err := p.each(curves, func(pos Position, ts term.Structure, fx float64) error {
+		price := pos.Price
+		if price == 0.0 {
+			price = pos.Security.PresentValue(ts)
+		}
+		h := Holding{
+			Currency:    pos.Currency,
+			Quantity:    pos.Quantity,
+			MarketValue: fx * pos.Quantity * price,
+			Tags:        map[string]string{},
+		}
+		if h.Currency == "" {
+			h.Currency = p.Base
+		}
+		if m := fixedincome.MetadataOf(pos.Security); m != nil {
+			h.ISIN, h.Issuer = m.ISIN, m.Issuer
+			h.Labels, h.UseOfProceeds = m.Labels, m.UseOfProceeds
+			for k, v := range m.Fields {
+				h.Tags[k] = v
+			}
+		}
+		for k, v := range pos.Tags {
+			h.Tags[k] = v
+		}
+		holdings = append(holdings, h)
+		total += h.MarketValue
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if total != 0.0 {
+		for i := range holdings {
+			holdings[i].Weight = holdings[i].MarketValue / total
+		}
+	}
+	return holdings, nil
+}
+
+// WriteHoldings writes the holdings report as csv with a header line and
+// one column per tag; multiple labels are separated by semicolons
+func WriteHoldings(w io.Writer, holdings []Holding, tags ...string) error {
+	cw := csv.NewWriter(w)
+	header := append([]string{"isin", "issuer", "currency", "quantity", "market value", "weight", "labels", "use of proceeds"}, tags...)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, h := range holdings {
+		record := []string{
+			h.ISIN,
+			h.Issuer,
+			h.Currency,
+			strconv.FormatFloat(h.Quantity, 'f', -1, 64),
+			strconv.FormatFloat(h.MarketValue, 'f', 2, 64),
+			strconv.FormatFloat(h.Weight, 'f', 6, 64),
+			strings.Join(h.Labels, ";"),
+			strings.Join(h.UseOfProceeds, ";"),
+		}
+		for _, tag := range tags {
+			record = append(record, h.Tags[tag])
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
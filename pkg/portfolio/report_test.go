@@ -0,0 +1,102 @@
+package portfolio_test
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/portfolio"
+)
+
+// labelled returns a portfolio with a green, a social and sustainability and an unlabelled bond
+func labelled() portfolio.Portfolio {
+	securities := universe(3)
+	metadata := []*fixedincome.Metadata{
+		{ISIN: "CH0000000001", Issuer: "Canton A", Labels: []string{"green"}, UseOfProceeds: []string{"renewable energy", "clean transportation"}},
+		{ISIN: "CH0000000002", Issuer: "Bank B", Labels: []string{"social", "sustainability"}, UseOfProceeds: []string{"affordable housing"}, Fields: map[string]string{"sector": "financials"}},
+		nil,
+	}
+	p := portfolio.Portfolio{Base: "CHF"}
+	for i, s := range securities {
+		s.(*bond.Straight).Metadata = metadata[i]
+		p.Positions = append(p.Positions, portfolio.Position{Security: s, Quantity: float64(i + 1)})
+	}
+	p.Positions[0].Tags = map[string]string{"sector": "public"}
+	return p
+}
+
+func TestPortfolio_Labels(t *testing.T) {
+	p := labelled()
+	curves := portfolio.Curves{"CHF": &nss}
+
+	labels, err := p.Labels(curves)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(labels) != 4 {
+		t.Fatalf("wrong number of labels; got: %d, expected: %d", len(labels), 4)
+	}
+	total, _ := p.MarketValue(curves)
+	green := p.Positions[0].Quantity * p.Positions[0].Security.PresentValue(&nss)
+	if math.Abs(labels["green"].Weight-green/total) > 1e-12 {
+		t.Errorf("wrong weight of green bonds; got: %v, expected: %v", labels["green"].Weight, green/total)
+	}
+	if labels["social"].MarketValue != labels["sustainability"].MarketValue || labels[""].Positions != 1 {
+		t.Errorf("wrong label buckets; got: %v", labels)
+	}
+
+	proceeds, err := p.UseOfProceeds(curves)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if proceeds["renewable energy"].Positions != 1 || proceeds["affordable housing"].Positions != 1 || len(proceeds) != 4 {
+		t.Errorf("wrong use of proceeds buckets; got: %v", proceeds)
+	}
+
+	// tags fall back to the metadata
+	if tags := p.Tags("issuer"); len(tags) != 3 || tags[1] != "Bank B" {
+		t.Errorf("wrong issuer tags; got: %v", tags)
+	}
+	if got := p.Positions[0].Tag("sector"); got != "public" {
+		t.Errorf("wrong tag of the position; got: %v, expected: %v", got, "public")
+	}
+	if got := p.Positions[1].Tag("sector"); got != "financials" {
+		t.Errorf("wrong tag of the metadata; got: %v, expected: %v", got, "financials")
+	}
+}
+
+func TestPortfolio_Holdings(t *testing.T) {
+	p := labelled()
+	holdings, err := p.Holdings(portfolio.Curves{"CHF": &nss})
+	if err != nil {
+		t.Fatal(err)
+	}
+	weights := 0.0
+	for _, h := range holdings {
+		weights += h.Weight
+	}
+	if len(holdings) != 3 || math.Abs(weights-1.0) > 1e-12 || holdings[0].ISIN != "CH0000000001" {
+		t.Errorf("wrong holdings; got: %v", holdings)
+	}
+
+	var buf bytes.Buffer
+	if err := portfolio.WriteHoldings(&buf, holdings, "sector"); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("wrong number of lines; got: %d, expected: %d", len(lines), 4)
+	}
+	if lines[0] != "isin,issuer,currency,quantity,market value,weight,labels,use of proceeds,sector" {
+		t.Errorf("wrong header; got: %v", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "CH0000000001,Canton A,CHF,1,") || !strings.HasSuffix(lines[1], ",green,renewable energy;clean transportation,public") {
+		t.Errorf("wrong line; got: %v", lines[1])
+	}
+	if !strings.HasSuffix(lines[2], ",financials") {
+		t.Errorf("wrong line with metadata field; got: %v", lines[2])
+	}
+}
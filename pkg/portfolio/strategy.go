@@ -0,0 +1,128 @@
+package portfolio
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// ErrTargetDuration is returned if the target duration cannot be reached with the universe
+var ErrTargetDuration = errors.New("target duration cannot be reached")
+
+// Bullet returns a portfolio with the market value concentrated at the target
+// duration that holds the two bonds adjacent to the target. The target is given
+// in absolute terms, i.e. 5.0 for a security with a Duration of -5.0.
+func Bullet(universe []fixedincome.TermSecurity, ts term.Structure, target, value float64) (Portfolio, error) {
+	c, err := candidates(universe, ts, target)
+	if err != nil {
+		return Portfolio{}, err
+	}
+	i := sort.Search(len(c), func(i int) bool { return c[i].duration >= target })
+	if i == 0 {
+		i = 1
+	}
+	return combine(c[i-1], c[i], target, value), nil
+}
+
+// Barbell returns a portfolio with the market value in the bonds with the
+// shortest and the longest duration combined to the (absolute) target duration
+func Barbell(universe []fixedincome.TermSecurity, ts term.Structure, target, value float64) (Portfolio, error) {
+	c, err := candidates(universe, ts, target)
+	if err != nil {
+		return Portfolio{}, err
+	}
+	return combine(c[0], c[len(c)-1], target, value), nil
+}
+
+// Ladder returns a portfolio with the market value equally weighted in a number
+// of consecutive bonds (rungs ordered by duration) whose average duration is
+// closest to the (absolute) target duration
+func Ladder(universe []fixedincome.TermSecurity, ts term.Structure, target, value float64, rungs int) (Portfolio, error) {
+	c, err := candidates(universe, ts, target)
+	if err != nil {
+		return Portfolio{}, err
+	}
+	if rungs <= 0 || rungs > len(c) {
+		return Portfolio{}, fmt.Errorf("%w: %d rungs for %d bonds", ErrTargetDuration, rungs, len(c))
+	}
+
+	best, bestError := 0, math.Inf(1)
+	for i := 0; i+rungs <= len(c); i += 1 {
+		d := 0.0
+		for _, cand := range c[i : i+rungs] {
+			d += cand.duration
+		}
+		if e := math.Abs(d/float64(rungs) - target); e < bestError {
+			best, bestError = i, e
+		}
+	}
+
+	p := Portfolio{}
+	for _, cand := range c[best : best+rungs] {
+		p.Positions = append(p.Positions, cand.position(value/float64(rungs)))
+	}
+	return p, nil
+}
+
+// Duration returns the market value weighted duration of the portfolio;
+// all securities must implement fixedincome.TermSecurity
+func (p *Portfolio) Duration(ts term.Structure) (float64, error) {
+	value, duration := 0.0, 0.0
+	for _, pos := range p.Positions {
+		s, ok := pos.Security.(fixedincome.TermSecurity)
+		if !ok {
+			return 0.0, fmt.Errorf("security %T does not provide a duration", pos.Security)
+		}
+		v := pos.Quantity * s.PresentValue(ts)
+		value += v
+		duration += v * s.Duration(ts)
+	}
+	if value == 0.0 {
+		return 0.0, nil
+	}
+	return duration / value, nil
+}
+
+// candidate is a bond of the universe with its (absolute) duration and price
+type candidate struct {
+	security fixedincome.TermSecurity
+	duration float64
+	price    float64
+}
+
+func (c candidate) position(value float64) Position {
+	return Position{Security: c.security, Quantity: value / c.price}
+}
+
+// candidates returns the bonds with a positive price ordered by duration
+func candidates(universe []fixedincome.TermSecurity, ts term.Structure, target float64) ([]candidate, error) {
+	c := []candidate{}
+	for _, s := range universe {
+		if price := s.PresentValue(ts); price > 0.0 {
+			c = append(c, candidate{s, math.Abs(s.Duration(ts)), price})
+		}
+	}
+	sort.SliceStable(c, func(i, j int) bool { return c[i].duration < c[j].duration })
+	if len(c) < 2 || target < c[0].duration || target > c[len(c)-1].duration {
+		return nil, fmt.Errorf("%w: %v is outside the durations of the universe", ErrTargetDuration, target)
+	}
+	return c, nil
+}
+
+// combine weights the short and the long bond to the target duration
+func combine(short, long candidate, target, value float64) Portfolio {
+	w := 1.0
+	if long.duration > short.duration {
+		w = (long.duration - target) / (long.duration - short.duration)
+	}
+	return Portfolio{
+		Positions: []Position{
+			short.position(w * value),
+			long.position((1.0 - w) * value),
+		},
+	}
+}
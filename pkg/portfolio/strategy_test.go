@@ -0,0 +1,71 @@
+package portfolio_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/portfolio"
+)
+
+func TestStrategies(t *testing.T) {
+	universe := []fixedincome.TermSecurity{}
+	for _, s := range universe30() {
+		universe = append(universe, s.(fixedincome.TermSecurity))
+	}
+	target, value := 7.5, 1e6
+
+	bullet, err := portfolio.Bullet(universe, &nss, target, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	barbell, err := portfolio.Barbell(universe, &nss, target, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ladder, err := portfolio.Ladder(universe, &nss, target, value, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testData := []struct {
+		Name      string
+		Portfolio portfolio.Portfolio
+		Positions int
+		Tolerance float64
+	}{
+		{"bullet", bullet, 2, 1e-8},
+		{"barbell", barbell, 2, 1e-8},
+		{"ladder", ladder, 10, 0.5},
+	}
+	for _, test := range testData {
+		if len(test.Portfolio.Positions) != test.Positions {
+			t.Errorf("%s: wrong number of positions; got: %d, expected: %d", test.Name, len(test.Portfolio.Positions), test.Positions)
+		}
+		if v := test.Portfolio.PresentValue(&nss); math.Abs(v-value) > 1e-6 {
+			t.Errorf("%s: wrong market value; got: %v, expected: %v", test.Name, v, value)
+		}
+		d, err := test.Portfolio.Duration(&nss)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(-d-target) > test.Tolerance {
+			t.Errorf("%s: wrong duration; got: %v, expected: %v", test.Name, -d, target)
+		}
+	}
+
+	if _, err := portfolio.Bullet(universe, &nss, 100.0, value); !errors.Is(err, portfolio.ErrTargetDuration) {
+		t.Errorf("expected ErrTargetDuration; got: %v", err)
+	}
+}
+
+// universe30 returns 30 bonds of the universe with different maturities
+func universe30() []fixedincome.Security {
+	securities := universe(360)
+	bullets := []fixedincome.Security{}
+	for i := 0; i < len(securities); i += 12 {
+		bullets = append(bullets, securities[i])
+	}
+	return bullets
+}
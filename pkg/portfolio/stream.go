@@ -0,0 +1,148 @@
+package portfolio
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Source provides the positions of a portfolio one at a time;
+// Next returns io.EOF after the last position
+type Source interface {
+	Next() (Position, error)
+}
+
+// Slice is a source with the positions of a slice
+type Slice struct {
+	Positions []Position
+	next      int
+}
+
+// Next returns the next position of the slice
+func (s *Slice) Next() (Position, error) {
+	if s.next >= len(s.Positions) {
+		return Position{}, io.EOF
+	}
+	s.next += 1
+	return s.Positions[s.next-1], nil
+}
+
+// Record is a straight bond position in a stream of JSON objects
+type Record struct {
+	ISIN   string `json:"isin"`
+	Issuer string `json:"issuer"`
+	// Maturity is the maturity date formatted as YYYY-MM-DD
+	Maturity   string            `json:"maturity"`
+	Coupon     float64           `json:"coupon"`
+	Frequency  int               `json:"frequency"`
+	Basis      string            `json:"basis"`
+	Redemption float64           `json:"redemption"`
+	Quantity   float64           `json:"quantity"`
+	Currency   string            `json:"currency"`
+	Price      float64           `json:"price"`
+	Tags       map[string]string `json:"tags"`
+}
+
+// Decoder reads the positions from a stream of JSON records (e.g. one record per line)
+type Decoder struct {
+	// Settlement is the settlement date of the bonds
+	Settlement time.Time
+	dec        *json.Decoder
+	line       int
+}
+
+// NewDecoder returns a decoder reading from r with bonds settling on settlement
+func NewDecoder(r io.Reader, settlement time.Time) *Decoder {
+	return &Decoder{Settlement: settlement, dec: json.NewDecoder(r)}
+}
+
+// Next decodes the next record into a position
+func (d *Decoder) Next() (Position, error) {
+	var r Record
+	if err := d.dec.Decode(&r); err != nil {
+		if errors.Is(err, io.EOF) {
+			return Position{}, io.EOF
+		}
+		return Position{}, fmt.Errorf("record %d: %w", d.line+1, err)
+	}
+	d.line += 1
+	date, err := time.Parse("2006-01-02", r.Maturity)
+	if err != nil {
+		return Position{}, fmt.Errorf("record %d: %w", d.line, err)
+	}
+	if r.Frequency == 0 {
+		r.Frequency = 1
+	}
+	if r.Basis == "" {
+		r.Basis = "30E360"
+	}
+	if r.Redemption == 0.0 {
+		r.Redemption = 100.0
+	}
+	b := &bond.Straight{
+		Schedule:   maturity.Schedule{Settlement: d.Settlement, Maturity: date, Frequency: r.Frequency, Basis: r.Basis},
+		Coupon:     r.Coupon,
+		Redemption: r.Redemption,
+	}
+	if r.ISIN != "" || r.Issuer != "" {
+		b.Metadata = &fixedincome.Metadata{ISIN: r.ISIN, Issuer: r.Issuer}
+	}
+	if err := b.Validate(); err != nil {
+		return Position{}, fmt.Errorf("record %d: %w", d.line, err)
+	}
+	return Position{Security: b, Quantity: r.Quantity, Currency: r.Currency, Price: r.Price, Tags: r.Tags}, nil
+}
+
+// Stream prices the positions of the source one at a time without keeping
+// them in memory and calls f with each position and its model value in the
+// base currency; it returns the number of positions and the total value.
+// The positions of the portfolio are ignored, only the base currency and the
+// FX provider are used.
+func (p *Portfolio) Stream(src Source, curves Curves, f func(pos Position, value float64) error) (int, float64, error) {
+	count, total := 0, 0.0
+	for {
+		pos, err := src.Next()
+		if errors.Is(err, io.EOF) {
+			return count, total, nil
+		}
+		if err != nil {
+			return count, total, err
+		}
+		ts, fx, err := p.curve(pos, curves)
+		if err != nil {
+			return count, total, err
+		}
+		value := fx * pos.Quantity * pos.Security.PresentValue(ts)
+		if f != nil {
+			if err := f(pos, value); err != nil {
+				return count, total, err
+			}
+		}
+		count += 1
+		total += value
+	}
+}
+
+// curve returns the term structure and the exchange rate of the currency of the position
+func (p *Portfolio) curve(pos Position, curves Curves) (term.Structure, float64, error) {
+	currency := pos.Currency
+	if currency == "" {
+		currency = p.Base
+	}
+	ts, ok := curves[currency]
+	if !ok {
+		return nil, 0.0, fmt.Errorf("%w: no term structure for %s", ErrUnknownCurrency, currency)
+	}
+	fx, err := p.rate(pos.Currency)
+	if err != nil {
+		return nil, 0.0, err
+	}
+	return ts, fx, nil
+}
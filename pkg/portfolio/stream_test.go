@@ -0,0 +1,96 @@
+package portfolio_test
+
+import (
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/portfolio"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestStream(t *testing.T) {
+	eur := term.Flat{R: 1.0}
+	curves := portfolio.Curves{"CHF": &nss, "EUR": &eur}
+	p := portfolio.Portfolio{Base: "CHF", FX: &portfolio.Spot{Pivot: "CHF", Rates: map[string]float64{"EUR": 1.08}}}
+
+	input := `{"isin": "CH0000000001", "maturity": "2031-04-01", "coupon": 1.25, "quantity": 1000}
+{"isin": "XS0000000002", "maturity": "2026-06-15", "coupon": 0.5, "frequency": 2, "basis": "ACT360", "quantity": 500, "currency": "EUR", "tags": {"rating": "AA"}}
+`
+	values := []float64{}
+	count, total, err := p.Stream(portfolio.NewDecoder(strings.NewReader(input), settlement), curves, func(pos portfolio.Position, value float64) error {
+		values = append(values, value)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 || len(values) != 2 {
+		t.Fatalf("wrong number of positions; got: %d, expected: %d", count, 2)
+	}
+
+	// the same positions priced in memory
+	dec := portfolio.NewDecoder(strings.NewReader(input), settlement)
+	for {
+		pos, err := dec.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.Positions = append(p.Positions, pos)
+	}
+	expected, err := p.MarketValue(curves)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(total-expected) > 1e-8 || math.Abs(values[0]+values[1]-expected) > 1e-8 {
+		t.Errorf("wrong streamed value; got: %v, expected: %v", total, expected)
+	}
+	if p.Positions[1].Tag("rating") != "AA" || p.Positions[1].Tag("isin") != "XS0000000002" {
+		t.Errorf("wrong tags of decoded position; got: %v", p.Positions[1].Tags)
+	}
+
+	// slice source
+	_, total, err = p.Stream(&portfolio.Slice{Positions: p.Positions}, curves, nil)
+	if err != nil || math.Abs(total-expected) > 1e-8 {
+		t.Errorf("wrong streamed value of slice; got: %v, expected: %v (%v)", total, expected, err)
+	}
+
+	// errors
+	for _, bad := range []string{
+		`{"maturity": "2031-13-01", "quantity": 1}`,
+		`{"maturity": "2031-04-01", "quantity": 1, "currency": "USD"}`,
+		`{"maturity": "2031-04-01"`,
+	} {
+		if _, _, err := p.Stream(portfolio.NewDecoder(strings.NewReader(bad), settlement), curves, nil); err == nil {
+			t.Errorf("expected error for record %s", bad)
+		}
+	}
+}
+
+// generator creates the positions of the universe one at a time
+type generator struct {
+	n, next int
+}
+
+func (g *generator) Next() (portfolio.Position, error) {
+	if g.next >= g.n {
+		return portfolio.Position{}, io.EOF
+	}
+	g.next += 1
+	return portfolio.Position{Security: universe(1)[0], Quantity: 1000.0}, nil
+}
+
+func BenchmarkStream(b *testing.B) {
+	p := portfolio.Portfolio{Base: "CHF"}
+	curves := portfolio.Curves{"CHF": &nss}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i += 1 {
+		if _, _, err := p.Stream(&generator{n: 10000}, curves, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
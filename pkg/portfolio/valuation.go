@@ -0,0 +1,149 @@
+package portfolio
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Level is the level of the fair value hierarchy (IFRS 13, ASC 820)
+type Level int
+
+const (
+	// Level1 are quoted prices for the security in active markets
+	Level1 Level = iota + 1
+	// Level2 are valuations with observable inputs such as market curves
+	Level2
+	// Level3 are valuations with unobservable inputs
+	Level3
+)
+
+func (l Level) String() string {
+	return "Level " + strconv.Itoa(int(l))
+}
+
+// Sensitivities are the risk figures of a valuation; the figures that are not
+// supported by the security are zero
+type Sensitivities struct {
+	Duration  float64 `json:"duration"`
+	Convexity float64 `json:"convexity"`
+	// DV01 is the change in value per unit for a parallel increase of one bp
+	DV01 float64 `json:"dv01"`
+	// Yield is the continuously compounded internal rate of return in percent
+	Yield float64 `json:"yield"`
+	// Spread is the implied static spread in bps over the curve
+	Spread float64 `json:"spread"`
+}
+
+// Curve describes the term structure used for a valuation
+type Curve struct {
+	// Source is the provider or the fixing of the curve (e.g. "SNB 2022-03-31")
+	Source string `json:"source"`
+	// Model is the registered name of the term structure type
+	Model string `json:"model"`
+	// Parameters are the JSON parameters of the term structure
+	Parameters json.RawMessage `json:"parameters,omitempty"`
+}
+
+// Valuation is the valuation report of a position for audit and fair value
+// disclosures; prices are per unit of the position
+type Valuation struct {
+	ISIN     string  `json:"isin"`
+	Issuer   string  `json:"issuer"`
+	Currency string  `json:"currency"`
+	Quantity float64 `json:"quantity"`
+	Level    Level   `json:"level"`
+	// Inputs are the market price and the exchange rate used for the valuation
+	Inputs map[string]float64 `json:"inputs"`
+	Curve  Curve              `json:"curve"`
+	// ModelPrice is the price of the security on the curve
+	ModelPrice float64 `json:"modelprice"`
+	// Price is the price used for the fair value: the market price if there
+	// is one and the model price otherwise
+	Price float64 `json:"price"`
+	// FairValue is the value of the position in the base currency
+	FairValue     float64       `json:"fairvalue"`
+	Sensitivities Sensitivities `json:"sensitivities"`
+}
+
+// Valuations returns the valuation reports of the positions with the curve
+// sources by currency. Positions with a market price are classified in Level 1
+// and positions valued on the curve in Level 2 unless the position (or the
+// metadata of the security) has a "level" tag with the level 1, 2 or 3.
+func (p *Portfolio) Valuations(curves Curves, sources map[string]string) ([]Valuation, error) {
+	valuations := make([]Valuation, 0, len(p.Positions))
+	err := p.each(curves, func(pos Position, ts term.Structure, fx float64) error {
+		v := Valuation{
+			Currency:   pos.Currency,
+			Quantity:   pos.Quantity,
+			Level:      Level2,
+			Inputs:     map[string]float64{"fx": fx},
+			ModelPrice: pos.Security.PresentValue(ts),
+		}
+		if v.Currency == "" {
+			v.Currency = p.Base
+		}
+		if m := fixedincome.MetadataOf(pos.Security); m != nil {
+			v.ISIN, v.Issuer = m.ISIN, m.Issuer
+		}
+
+		v.Curve.Source = sources[v.Currency]
+		if data, err := term.Marshal(ts); err == nil {
+			fields := struct {
+				Type string `json:"type"`
+			}{}
+			json.Unmarshal(data, &fields)
+			v.Curve.Model, v.Curve.Parameters = fields.Type, data
+		}
+
+		v.Price = v.ModelPrice
+		if pos.Price != 0.0 {
+			v.Price, v.Level = pos.Price, Level1
+			v.Inputs["price"] = pos.Price
+		}
+		if tag := pos.Tag("level"); tag != "" {
+			level, err := strconv.Atoi(tag)
+			if err != nil || level < int(Level1) || level > int(Level3) {
+				return fmt.Errorf("invalid fair value level %q of position %s", tag, v.ISIN)
+			}
+			v.Level = Level(level)
+		}
+		v.FairValue = fx * pos.Quantity * v.Price
+		v.Sensitivities = sensitivities(pos.Security, v.Price, ts)
+		valuations = append(valuations, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return valuations, nil
+}
+
+// sensitivities returns the risk figures supported by the security at the price
+func sensitivities(s fixedincome.Security, price float64, ts term.Structure) Sensitivities {
+	var r Sensitivities
+	if t, ok := s.(fixedincome.TermSecurity); ok {
+		r.Duration, r.Convexity = t.Duration(ts), t.Convexity(ts)
+	}
+	if cs, ok := s.(fixedincome.CashflowSecurity); ok {
+		r.DV01 = fixedincome.DV01(cs, ts)
+	}
+	if y, err := fixedincome.Irr(price, s); err == nil {
+		r.Yield = y
+	}
+	if spread, err := fixedincome.Spread(price, s, ts); err == nil {
+		r.Spread = spread
+	}
+	return r
+}
+
+// WriteValuations writes the valuation reports as indented JSON
+func WriteValuations(w io.Writer, valuations []Valuation) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(valuations)
+}
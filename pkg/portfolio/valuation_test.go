@@ -0,0 +1,70 @@
+package portfolio_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/portfolio"
+)
+
+func TestPortfolio_Valuations(t *testing.T) {
+	p := labelled()
+	curves := portfolio.Curves{"CHF": &nss}
+	p.Positions[1].Price = p.Positions[1].Security.PresentValue(&nss) * 0.99
+	p.Positions[2].Tags = map[string]string{"level": "3"}
+
+	valuations, err := p.Valuations(curves, map[string]string{"CHF": "SNB"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(valuations) != 3 {
+		t.Fatalf("wrong number of valuations; got: %d, expected: %d", len(valuations), 3)
+	}
+
+	levels := []portfolio.Level{portfolio.Level2, portfolio.Level1, portfolio.Level3}
+	for i, v := range valuations {
+		if v.Level != levels[i] {
+			t.Errorf("wrong level of position %d; got: %v, expected: %v", i, v.Level, levels[i])
+		}
+		if v.Curve.Source != "SNB" || v.Curve.Model != "nss" {
+			t.Errorf("wrong curve of position %d; got: %v", i, v.Curve)
+		}
+		s := p.Positions[i].Security.(*bond.Straight)
+		if expected := s.Duration(&nss); math.Abs(v.Sensitivities.Duration-expected) > 1e-12 {
+			t.Errorf("wrong duration of position %d; got: %v, expected: %v", i, v.Sensitivities.Duration, expected)
+		}
+		if expected := p.Positions[i].Quantity * v.Price; math.Abs(v.FairValue-expected) > 1e-9 {
+			t.Errorf("wrong fair value of position %d; got: %v, expected: %v", i, v.FairValue, expected)
+		}
+	}
+
+	// spread of the market price over the curve
+	expected, _ := fixedincome.Spread(p.Positions[1].Price, p.Positions[1].Security, &nss)
+	if math.Abs(valuations[1].Sensitivities.Spread-expected) > 1e-6 || valuations[1].Inputs["price"] != p.Positions[1].Price {
+		t.Errorf("wrong valuation of the quoted position; got: %v", valuations[1])
+	}
+	if math.Abs(valuations[0].Sensitivities.Spread) > 1e-4 {
+		t.Errorf("wrong spread at the model price; got: %v, expected: %v", valuations[0].Sensitivities.Spread, 0.0)
+	}
+
+	var buf bytes.Buffer
+	if err := portfolio.WriteValuations(&buf, valuations); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []portfolio.Valuation
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 3 || decoded[0].ISIN != "CH0000000001" || decoded[2].Level != portfolio.Level3 {
+		t.Errorf("wrong json report; got: %s", buf.String())
+	}
+
+	p.Positions[0].Tags["level"] = "4"
+	if _, err := p.Valuations(curves, nil); err == nil {
+		t.Error("expected error for an invalid level")
+	}
+}
@@ -27,3 +27,62 @@ func Annual(r float64, n int) float64 {
 func EffectiveAnnual(i float64, n int) float64 {
 	return math.Pow(1+(i/100.0)/float64(n), float64(n))
 }
+
+// Convert converts an annual rate compounded from times per year into an
+// annual rate compounded to times per year (e.g. Convert(i, 2, 1) converts a
+// semi-annual bond-equivalent yield into an annual effective yield)
+// Exepects an annual rate i in percent (e.g. 3.0)
+func Convert(i float64, from, to int) float64 {
+	if from == 0 {
+		from = 1
+	}
+	if to == 0 {
+		to = 1
+	}
+	growth := math.Pow(1+i/100.0/float64(from), float64(from)/float64(to))
+	return float64(to) * (growth - 1.0) * 100.0
+}
+
+// BondEquivalent converts an annual rate compounded n times per year into a
+// semi-annual bond-equivalent yield
+func BondEquivalent(i float64, n int) float64 {
+	return Convert(i, n, 2)
+}
+
+// DiscountToMoneyMarket converts a discount rate (ACT/360) of an instrument
+// with the given days to maturity into a money-market yield (ACT/360)
+// Exepects a discount rate d in percent (e.g. 3.0)
+func DiscountToMoneyMarket(d float64, days int) float64 {
+	return d / (1.0 - d/100.0*float64(days)/360.0)
+}
+
+// MoneyMarketToDiscount converts a money-market yield (ACT/360) of an
+// instrument with the given days to maturity into a discount rate (ACT/360)
+// Exepects a money-market yield mmy in percent (e.g. 3.0)
+func MoneyMarketToDiscount(mmy float64, days int) float64 {
+	return mmy / (1.0 + mmy/100.0*float64(days)/360.0)
+}
+
+// MoneyMarketToPeriodic converts a money-market yield (ACT/360) of an
+// instrument with the given days to maturity into an annual rate compounded
+// n times per year (ACT/365), e.g. n = 2 for the bond-equivalent yield
+// Exepects a money-market yield mmy in percent (e.g. 3.0)
+func MoneyMarketToPeriodic(mmy float64, days, n int) float64 {
+	if n == 0 {
+		n = 1
+	}
+	growth := 1.0 + mmy/100.0*float64(days)/360.0
+	return float64(n) * (math.Pow(growth, 365.0/float64(days)/float64(n)) - 1.0) * 100.0
+}
+
+// PeriodicToMoneyMarket converts an annual rate compounded n times per year
+// (ACT/365) into a money-market yield (ACT/360) of an instrument with the
+// given days to maturity
+// Exepects an annual rate i in percent (e.g. 3.0)
+func PeriodicToMoneyMarket(i float64, n, days int) float64 {
+	if n == 0 {
+		n = 1
+	}
+	growth := math.Pow(1.0+i/100.0/float64(n), float64(n)*float64(days)/365.0)
+	return (growth - 1.0) * 360.0 / float64(days) * 100.0
+}
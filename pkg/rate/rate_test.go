@@ -31,3 +31,37 @@ func TestEffectiveAnnual(t *testing.T) {
 		t.Errorf("conversion from cc to annual rate failed")
 	}
 }
+
+func TestConvert(t *testing.T) {
+	// 12% monthly is 12.6825% annual effective
+	if got := rate.Convert(annualRate, 12, 1); math.Abs(got-(monthlyEffectiveRate-1.0)*100.0) > 1e-9 {
+		t.Errorf("conversion from monthly to annual rate failed; got: %v, expected: %v", got, (monthlyEffectiveRate-1.0)*100.0)
+	}
+	// 6% semi-annual is 6.09% annual effective
+	if got := rate.Convert(6.0, 2, 1); math.Abs(got-6.09) > 1e-9 {
+		t.Errorf("conversion from semi-annual to annual rate failed; got: %v, expected: %v", got, 6.09)
+	}
+	if got := rate.BondEquivalent(6.09, 1); math.Abs(got-6.0) > 1e-9 {
+		t.Errorf("conversion from annual to bond-equivalent yield failed; got: %v, expected: %v", got, 6.0)
+	}
+}
+
+func TestMoneyMarket(t *testing.T) {
+	// 90 day bill at a discount rate of 4%: price 99, money-market yield 4/0.99
+	mmy := rate.DiscountToMoneyMarket(4.0, 90)
+	if math.Abs(mmy-4.0/0.99) > 1e-9 {
+		t.Errorf("conversion from discount rate to money-market yield failed; got: %v, expected: %v", mmy, 4.0/0.99)
+	}
+	if d := rate.MoneyMarketToDiscount(mmy, 90); math.Abs(d-4.0) > 1e-9 {
+		t.Errorf("conversion from money-market yield to discount rate failed; got: %v, expected: %v", d, 4.0)
+	}
+
+	// simple annual yield for 365 days (ACT/365 vs ACT/360)
+	if y := rate.MoneyMarketToPeriodic(3.6, 365, 1); math.Abs(y-3.65) > 1e-9 {
+		t.Errorf("conversion from money-market yield to annual rate failed; got: %v, expected: %v", y, 3.65)
+	}
+	bey := rate.MoneyMarketToPeriodic(mmy, 90, 2)
+	if back := rate.PeriodicToMoneyMarket(bey, 2, 90); math.Abs(back-mmy) > 1e-9 {
+		t.Errorf("round trip of money-market yield failed; got: %v, expected: %v", back, mmy)
+	}
+}
@@ -0,0 +1,217 @@
+package rounding
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+)
+
+// Mode specifies how amounts are rounded
+type Mode int
+
+const (
+	// HalfUp rounds half away from zero (commercial rounding)
+	HalfUp Mode = iota
+	// HalfEven rounds half to the nearest even digit (banker's rounding)
+	HalfEven
+	// Down truncates towards zero
+	Down
+	// Up rounds away from zero
+	Up
+)
+
+// Policy is the rounding rule for amounts in a currency
+type Policy struct {
+	// Decimals is the number of decimal places of the currency
+	Decimals int
+	// Mode is the rounding mode
+	Mode Mode
+	// PerCashflow rounds every amount (principal, accrued interest, coupons)
+	// individually before they are added up; otherwise only the final amounts are rounded
+	PerCashflow bool
+}
+
+var (
+	// Default is the rounding policy for unknown currencies
+	Default = Policy{Decimals: 2, Mode: HalfUp, PerCashflow: true}
+
+	mu       sync.RWMutex
+	policies = map[string]Policy{
+		"CHF": {Decimals: 2, Mode: HalfUp, PerCashflow: true},
+		"EUR": {Decimals: 2, Mode: HalfUp, PerCashflow: true},
+		"GBP": {Decimals: 2, Mode: HalfUp, PerCashflow: true},
+		"USD": {Decimals: 2, Mode: HalfUp, PerCashflow: true},
+		"JPY": {Decimals: 0, Mode: Down, PerCashflow: true},
+	}
+)
+
+// Register sets the rounding policy for the currency code (e.g. "CHF")
+func Register(currency string, p Policy) {
+	mu.Lock()
+	defer mu.Unlock()
+	policies[strings.ToUpper(currency)] = p
+}
+
+// Unregister removes the rounding policy for the currency code
+func Unregister(currency string) {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(policies, strings.ToUpper(currency))
+}
+
+// ForCurrency returns the rounding policy for the currency code;
+// the Default policy is returned for unknown currencies
+func ForCurrency(currency string) Policy {
+	mu.RLock()
+	defer mu.RUnlock()
+	if p, ok := policies[strings.ToUpper(currency)]; ok {
+		return p
+	}
+	return Default
+}
+
+// Round rounds the amount according to the policy. The amount is rounded
+// on its shortest decimal representation, so that e.g. 1.005 is rounded to
+// 1.01 with HalfUp (and not to 1.00 due to the binary representation).
+func (p Policy) Round(x float64) float64 {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return x
+	}
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(x, 'g', 15, 64))
+	if !ok {
+		return x
+	}
+	f, _ := round(r, p.Decimals, p.Mode).Float64()
+	return f
+}
+
+// RoundBig rounds the high-precision amount according to the policy. Like
+// Round, the amount is rounded on its decimal representation with fewer
+// digits than the precision of its mantissa.
+func (p Policy) RoundBig(x *big.Float) *big.Float {
+	if x.IsInf() {
+		return x
+	}
+	digits := int(float64(x.Prec())*math.Log10(2.0)) - 2
+	r, ok := new(big.Rat).SetString(x.Text('g', digits))
+	if !ok {
+		return x
+	}
+	prec := x.Prec()
+	if prec < cashflow.BigPrecision {
+		prec = cashflow.BigPrecision
+	}
+	return new(big.Float).SetPrec(prec).SetRat(round(r, p.Decimals, p.Mode))
+}
+
+// Cashflows returns a copy of the cash flows with the amounts for the given
+// nominal rounded per cash flow; par is the notional base of the amounts
+// (e.g. 100 or the ParValue of the bond)
+func (p Policy) Cashflows(cfs cashflow.Cashflows, nominal, par float64) cashflow.Cashflows {
+	rounded := make(cashflow.Cashflows, len(cfs))
+	for i, cf := range cfs {
+		cf.Amount = p.Round(cf.Amount * nominal / par)
+		rounded[i] = cf
+	}
+	return rounded
+}
+
+// Accrued returns the accrued interest amount for the nominal given the
+// accrued interest per 100 of par
+func (p Policy) Accrued(nominal, accrued float64) float64 {
+	return p.Round(nominal * accrued / 100.0)
+}
+
+// Invoice returns the settlement amount for the nominal given the clean price
+// and the accrued interest (both per 100 of par). With PerCashflow the
+// principal and accrued amounts are rounded individually, otherwise only the total.
+func (p Policy) Invoice(nominal, clean, accrued float64) float64 {
+	principal := nominal * clean / 100.0
+	interest := nominal * accrued / 100.0
+	if p.PerCashflow {
+		return p.Round(p.Round(principal) + p.Round(interest))
+	}
+	return p.Round(principal + interest)
+}
+
+// round rounds the rational number r to the given decimals
+func round(r *big.Rat, decimals int, mode Mode) *big.Rat {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(decimals))), nil)
+	scaled := new(big.Rat).Set(r)
+	if decimals >= 0 {
+		scaled.Mul(scaled, new(big.Rat).SetInt(scale))
+	} else {
+		scaled.Quo(scaled, new(big.Rat).SetInt(scale))
+	}
+
+	// split into integer part and remainder (truncated towards zero)
+	neg := scaled.Sign() < 0
+	scaled.Abs(scaled)
+	q, m := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+
+	// compare twice the remainder with the denominator to detect halves
+	cmp := new(big.Int).Mul(m, big.NewInt(2)).Cmp(scaled.Denom())
+	switch mode {
+	case HalfUp:
+		if cmp >= 0 {
+			q.Add(q, big.NewInt(1))
+		}
+	case HalfEven:
+		if cmp > 0 || (cmp == 0 && q.Bit(0) == 1) {
+			q.Add(q, big.NewInt(1))
+		}
+	case Up:
+		if m.Sign() != 0 {
+			q.Add(q, big.NewInt(1))
+		}
+	case Down:
+	}
+	if neg {
+		q.Neg(q)
+	}
+
+	result := new(big.Rat).SetInt(q)
+	if decimals >= 0 {
+		return result.Quo(result, new(big.Rat).SetInt(scale))
+	}
+	return result.Mul(result, new(big.Rat).SetInt(scale))
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+// Coupon is the rounding rule for published coupon amounts, where the cash
+// amount per coupon period is defined for a unit face value (e.g. the coupon
+// per 1000 face rounded to the cent) and paid per unit held
+type Coupon struct {
+	// Face is the unit face value (e.g. 1000)
+	Face float64
+	// Policy is the rounding of the coupon amount per unit face value
+	Policy Policy
+}
+
+// PerPeriod returns the coupon per period per 100 of par for the unrounded
+// coupon per period per 100 of par
+func (c Coupon) PerPeriod(coupon float64) float64 {
+	if c.Face <= 0.0 {
+		return coupon
+	}
+	return c.Policy.Round(coupon*c.Face/100.0) * 100.0 / c.Face
+}
+
+// Amount returns the coupon amount for the nominal given the unrounded
+// coupon per period per 100 of par
+func (c Coupon) Amount(nominal, coupon float64) float64 {
+	if c.Face <= 0.0 {
+		return c.Policy.Round(nominal * coupon / 100.0)
+	}
+	return c.Policy.Round(coupon*c.Face/100.0) * nominal / c.Face
+}
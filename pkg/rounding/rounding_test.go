@@ -0,0 +1,170 @@
+package rounding_test
+
+import (
+	"math"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/rounding"
+)
+
+func TestPolicy_Round(t *testing.T) {
+	testData := []struct {
+		Value    float64
+		Policy   rounding.Policy
+		Expected float64
+	}{
+		{1.005, rounding.Policy{Decimals: 2, Mode: rounding.HalfUp}, 1.01},
+		{-1.005, rounding.Policy{Decimals: 2, Mode: rounding.HalfUp}, -1.01},
+		{2.675, rounding.Policy{Decimals: 2, Mode: rounding.HalfUp}, 2.68},
+		{0.125, rounding.Policy{Decimals: 2, Mode: rounding.HalfEven}, 0.12},
+		{0.135, rounding.Policy{Decimals: 2, Mode: rounding.HalfEven}, 0.14},
+		{1.019, rounding.Policy{Decimals: 2, Mode: rounding.Down}, 1.01},
+		{1.011, rounding.Policy{Decimals: 2, Mode: rounding.Up}, 1.02},
+		{1234.5, rounding.Policy{Decimals: 0, Mode: rounding.HalfUp}, 1235.0},
+		{1250.0, rounding.Policy{Decimals: -2, Mode: rounding.HalfUp}, 1300.0},
+	}
+
+	for nr, test := range testData {
+		got := test.Policy.Round(test.Value)
+		if got != test.Expected {
+			t.Errorf("test nr %d, rounding %v failed; got: %v, expected: %v", nr, test.Value, got, test.Expected)
+		}
+	}
+}
+
+func TestPolicy_RoundBig(t *testing.T) {
+	testData := []struct {
+		Value    string
+		Policy   rounding.Policy
+		Expected string
+	}{
+		{"1.005", rounding.Policy{Decimals: 2}, "1.01"},
+		{"1.004999", rounding.Policy{Decimals: 2}, "1"},
+		{"-2.675", rounding.Policy{Decimals: 2}, "-2.68"},
+		{"1234.5", rounding.Policy{Decimals: 0}, "1235"},
+		{"0.125", rounding.Policy{Decimals: 2}, "0.13"},
+		{"0.125", rounding.Policy{Decimals: 2, Mode: rounding.HalfEven}, "0.12"},
+		{"1234.5", rounding.Policy{Decimals: 0, Mode: rounding.Down}, "1234"},
+	}
+
+	for _, test := range testData {
+		x, _, err := big.ParseFloat(test.Value, 10, cashflow.BigPrecision, big.ToNearestEven)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := test.Policy.RoundBig(x).Text('f', -1)
+		if got != test.Expected {
+			t.Errorf("rounding %s with %v failed; got: %s, expected: %s", test.Value, test.Policy, got, test.Expected)
+		}
+	}
+}
+
+func TestForCurrency(t *testing.T) {
+	if p := rounding.ForCurrency("jpy"); p.Decimals != 0 {
+		t.Errorf("wrong decimals for JPY; got: %d, expected: %d", p.Decimals, 0)
+	}
+	if p := rounding.ForCurrency("XXX"); p != rounding.Default {
+		t.Errorf("unknown currency should use default policy; got: %v", p)
+	}
+	rounding.Register("XXX", rounding.Policy{Decimals: 3})
+	t.Cleanup(func() { rounding.Unregister("XXX") })
+	if p := rounding.ForCurrency("XXX"); p.Decimals != 3 {
+		t.Errorf("registered policy not found; got: %v", p)
+	}
+}
+
+func TestPolicy_Invoice(t *testing.T) {
+	// principal 1004.445 and accrued 3.335 for a nominal of 1000
+	nominal, clean, accrued := 1000.0, 100.4445, 0.3335
+
+	perCashflow := rounding.Policy{Decimals: 2, Mode: rounding.HalfUp, PerCashflow: true}
+	if got := perCashflow.Invoice(nominal, clean, accrued); got != 1007.79 {
+		t.Errorf("wrong invoice amount with per cash flow rounding; got: %v, expected: %v", got, 1007.79)
+	}
+	final := rounding.Policy{Decimals: 2, Mode: rounding.HalfUp}
+	if got := final.Invoice(nominal, clean, accrued); got != 1007.78 {
+		t.Errorf("wrong invoice amount with final rounding; got: %v, expected: %v", got, 1007.78)
+	}
+	if got := perCashflow.Accrued(nominal, accrued); got != 3.34 {
+		t.Errorf("wrong accrued amount; got: %v, expected: %v", got, 3.34)
+	}
+
+	cfs := perCashflow.Cashflows(cashflow.Cashflows{{T: 1.0, Amount: 1.3333}}, 1000.0, 100.0)
+	if cfs[0].Amount != 13.33 {
+		t.Errorf("wrong rounded coupon amount; got: %v, expected: %v", cfs[0].Amount, 13.33)
+	}
+}
+
+func TestPolicy_Cashflows_Par(t *testing.T) {
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+			Basis:      "30E360",
+		},
+		Coupon:     1.3333,
+		Redemption: 100.0,
+	}
+	policy := rounding.Policy{Decimals: 2, Mode: rounding.HalfUp}
+
+	// coupons of 13'333 and a redemption of 1'013'333 for a nominal of 1'000'000
+	expected := []float64{13333.0, 1013333.0}
+	for _, par := range []float64{1.0, 100.0, 1000.0} {
+		b.Par = par
+		cfs := policy.Cashflows(b.Cashflows(), 1e6, b.ParValue())
+		last := len(cfs) - 1
+		if cfs[0].Amount != expected[0] || cfs[last].Amount != expected[1] {
+			t.Errorf("wrong rounded cash flows for par %v; got: %v and %v, expected: %v", par, cfs[0].Amount, cfs[last].Amount, expected)
+		}
+	}
+}
+
+func TestCoupon(t *testing.T) {
+	// 1.125% semi-annual coupon per 1000 face: 5.625 rounded to 5.63
+	rule := rounding.Coupon{Face: 1000.0, Policy: rounding.Policy{Decimals: 2, Mode: rounding.HalfUp}}
+	if got := rule.PerPeriod(0.5625); math.Abs(got-0.563) > 1e-12 {
+		t.Errorf("wrong coupon per period; got: %v, expected: %v", got, 0.563)
+	}
+	// 250 units of 1000 face
+	if got := rule.Amount(250000.0, 0.5625); math.Abs(got-1407.5) > 1e-9 {
+		t.Errorf("wrong coupon amount; got: %v, expected: %v", got, 1407.5)
+	}
+	// rounded on the nominal without a unit face value
+	direct := rounding.Coupon{Policy: rule.Policy}
+	if got := direct.Amount(250000.0, 0.5625); got != 1406.25 {
+		t.Errorf("wrong coupon amount; got: %v, expected: %v", got, 1406.25)
+	}
+	if got := direct.PerPeriod(0.5625); got != 0.5625 {
+		t.Errorf("wrong coupon per period; got: %v, expected: %v", got, 0.5625)
+	}
+}
+
+func TestRegister_Concurrent(t *testing.T) {
+	t.Cleanup(func() { rounding.Unregister("YYY") })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(decimals int) {
+			defer wg.Done()
+			rounding.Register("YYY", rounding.Policy{Decimals: decimals})
+		}(i)
+		go func() {
+			defer wg.Done()
+			rounding.ForCurrency("YYY")
+		}()
+	}
+	wg.Wait()
+
+	rounding.Unregister("YYY")
+	if p := rounding.ForCurrency("YYY"); p != rounding.Default {
+		t.Errorf("unregistered currency should use default policy; got: %v", p)
+	}
+}
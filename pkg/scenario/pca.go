@@ -0,0 +1,138 @@
+package scenario
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/term"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// ErrHistory is returned if the principal components cannot be computed from the history
+var ErrHistory = errors.New("invalid curve history")
+
+// PCA holds the principal components of the changes of the spot rates at
+// the tenors, e.g. level, slope and curvature for the first three components
+type PCA struct {
+	// Tenors are the maturities in years of the pillar rates
+	Tenors []float64
+	// Loadings are the unit factor loadings per component and tenor
+	Loadings [][]float64
+	// Volatilities are the standard deviations of the factors in percent per period
+	Volatilities []float64
+}
+
+// Sample returns the spot rates in percent at the tenors for each of the term structures
+// (e.g. a daily history of NSS curves)
+func Sample(curves []term.Structure, tenors []float64) [][]float64 {
+	rates := make([][]float64, len(curves))
+	for i, ts := range curves {
+		rates[i] = make([]float64, len(tenors))
+		for j, t := range tenors {
+			rates[i][j] = ts.Rate(t)
+		}
+	}
+	return rates
+}
+
+// NewPCA computes the first principal components of the period-to-period
+// changes of the rates history (one row of pillar rates in percent per date)
+func NewPCA(tenors []float64, history [][]float64, components int) (*PCA, error) {
+	if len(history) < 3 {
+		return nil, fmt.Errorf("%w: at least 3 observations needed, got %d", ErrHistory, len(history))
+	}
+	if components <= 0 || components > len(tenors) || components > len(history)-1 {
+		return nil, fmt.Errorf("%w: %d components for %d tenors and %d observations", ErrHistory, components, len(tenors), len(history))
+	}
+
+	changes := mat.NewDense(len(history)-1, len(tenors), nil)
+	for i := 1; i < len(history); i += 1 {
+		if len(history[i]) != len(tenors) || len(history[i-1]) != len(tenors) {
+			return nil, fmt.Errorf("%w: observation %d does not match the tenors", ErrHistory, i)
+		}
+		for j := range tenors {
+			changes.Set(i-1, j, history[i][j]-history[i-1][j])
+		}
+	}
+
+	var pc stat.PC
+	if ok := pc.PrincipalComponents(changes, nil); !ok {
+		return nil, fmt.Errorf("%w: principal components analysis failed", ErrHistory)
+	}
+	var vectors mat.Dense
+	pc.VectorsTo(&vectors)
+	variances := pc.VarsTo(nil)
+
+	p := PCA{
+		Tenors:       append([]float64{}, tenors...),
+		Loadings:     make([][]float64, components),
+		Volatilities: make([]float64, components),
+	}
+	for k := 0; k < components; k += 1 {
+		loading := mat.Col(nil, k, &vectors)
+		// orientate the factors such that a positive factor raises the long end
+		if loading[len(loading)-1] < 0.0 {
+			for j := range loading {
+				loading[j] = -loading[j]
+			}
+		}
+		p.Loadings[k] = loading
+		p.Volatilities[k] = math.Sqrt(variances[k])
+	}
+	return &p, nil
+}
+
+// Scenario returns the term structure shifted by the factor shocks given in
+// standard deviations (e.g. []float64{2.0} for a two sigma level shock)
+func (p *PCA) Scenario(ts term.Structure, shocks []float64) *term.KeyRates {
+	k := term.NewKeyRates(ts, p.Tenors)
+	for f, shock := range shocks {
+		if f >= len(p.Loadings) {
+			break
+		}
+		for j, l := range p.Loadings[f] {
+			// rates in percent, shifts in bps
+			k.Shifts[j] += shock * p.Volatilities[f] * l * 100.0
+		}
+	}
+	return k
+}
+
+// Random returns a scenario with normally distributed factor shocks
+func (p *PCA) Random(ts term.Structure, rng *rand.Rand) *term.KeyRates {
+	shocks := make([]float64, len(p.Loadings))
+	for f := range shocks {
+		shocks[f] = rng.NormFloat64()
+	}
+	return p.Scenario(ts, shocks)
+}
+
+// Exposures returns the change in value of the security for a one standard
+// deviation move of each factor (first order)
+func (p *PCA) Exposures(s fixedincome.CashflowSecurity, ts term.Structure) []float64 {
+	_, grad := fixedincome.Gradient(s, term.NewKeyRates(ts, p.Tenors))
+	exposures := make([]float64, len(p.Loadings))
+	for f, loading := range p.Loadings {
+		for j, l := range loading {
+			exposures[f] += grad[j] * p.Volatilities[f] * l * 100.0
+		}
+	}
+	return exposures
+}
+
+// VaR returns the parametric value-at-risk of the security over one period of
+// the history at the confidence level (e.g. 0.99) as a positive amount.
+// The factors are uncorrelated by construction.
+func (p *PCA) VaR(s fixedincome.CashflowSecurity, ts term.Structure, confidence float64) float64 {
+	variance := 0.0
+	for _, e := range p.Exposures(s, ts) {
+		variance += e * e
+	}
+	z := distuv.UnitNormal.Quantile(confidence)
+	return z * math.Sqrt(variance)
+}
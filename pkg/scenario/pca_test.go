@@ -0,0 +1,118 @@
+package scenario_test
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/scenario"
+	"github.com/konimarti/fixedincome/pkg/term"
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+var (
+	tenors = []float64{1.0, 2.0, 5.0, 10.0, 20.0}
+	nss    = term.NelsonSiegelSvensson{B0: -0.266372, B1: -0.471343, B2: 5.68789, B3: -5.12324, T1: 5.74881, T2: 4.14426}
+)
+
+// history generates rates with parallel moves (level) and twists (slope)
+func history(n int, level, slope float64) ([][]float64, []float64) {
+	rng := rand.New(rand.NewSource(1))
+	rates := [][]float64{}
+	levels := []float64{}
+	base := scenario.Sample([]term.Structure{&nss}, tenors)[0]
+	for i := 0; i < n; i += 1 {
+		l, s := level*rng.NormFloat64(), slope*rng.NormFloat64()
+		row := make([]float64, len(tenors))
+		for j, t := range tenors {
+			row[j] = base[j] + l + s*(t-10.0)/10.0
+		}
+		rates = append(rates, row)
+		levels = append(levels, l)
+	}
+	return rates, levels
+}
+
+func TestPCA(t *testing.T) {
+	rates, _ := history(500, 0.05, 0.01)
+	pca, err := scenario.NewPCA(tenors, rates, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// first factor is a parallel shift
+	for j, l := range pca.Loadings[0] {
+		if math.Abs(l-1.0/math.Sqrt(float64(len(tenors)))) > 0.05 {
+			t.Errorf("level loading for tenor %v is not parallel; got: %v", tenors[j], l)
+		}
+	}
+	// second factor is a twist
+	if pca.Loadings[1][0]*pca.Loadings[1][len(tenors)-1] >= 0.0 {
+		t.Errorf("slope loadings have the same sign at the short and long end: %v", pca.Loadings[1])
+	}
+	if pca.Volatilities[0] <= pca.Volatilities[1] {
+		t.Errorf("volatilities are not ordered; got: %v", pca.Volatilities)
+	}
+
+	// one sigma level scenario
+	shocked := pca.Scenario(&nss, []float64{1.0})
+	for j, tenor := range tenors {
+		expected := pca.Volatilities[0] * pca.Loadings[0][j]
+		if math.Abs(shocked.Rate(tenor)-nss.Rate(tenor)-expected) > 1e-10 {
+			t.Errorf("wrong scenario rate for tenor %v; got: %v, expected: %v", tenor, shocked.Rate(tenor)-nss.Rate(tenor), expected)
+		}
+	}
+
+	random := pca.Random(&nss, rand.New(rand.NewSource(2)))
+	if len(random.Shifts) != len(tenors) {
+		t.Errorf("wrong number of shifts in random scenario; got: %d", len(random.Shifts))
+	}
+}
+
+func TestPCA_VaR(t *testing.T) {
+	// parallel moves only
+	rates, levels := history(250, 0.05, 0.0)
+	pca, err := scenario.NewPCA(tenors, rates, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2031, 4, 1, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+		},
+		Coupon:     1.0,
+		Redemption: 100.0,
+	}
+
+	changes := []float64{}
+	for i := 1; i < len(levels); i += 1 {
+		changes = append(changes, levels[i]-levels[i-1])
+	}
+	// VaR from the DV01 and the volatility of the parallel moves in bps
+	vola := stat.StdDev(changes, nil) * 100.0
+	expected := distuv.UnitNormal.Quantile(0.99) * vola * math.Abs(fixedincome.DV01(&b, &nss))
+
+	if v := pca.VaR(&b, &nss, 0.99); math.Abs(v-expected) > 1e-6*expected {
+		t.Errorf("wrong VaR; got: %v, expected: %v", v, expected)
+	}
+}
+
+func TestNewPCA_Errors(t *testing.T) {
+	rates, _ := history(2, 0.05, 0.01)
+	if _, err := scenario.NewPCA(tenors, rates, 1); !errors.Is(err, scenario.ErrHistory) {
+		t.Errorf("expected ErrHistory for short history; got: %v", err)
+	}
+	rates, _ = history(10, 0.05, 0.01)
+	if _, err := scenario.NewPCA(tenors, rates, 6); !errors.Is(err, scenario.ErrHistory) {
+		t.Errorf("expected ErrHistory for too many components; got: %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package term
+
+// Batch is implemented by term structures with optimized evaluations over
+// many maturities (e.g. all cash flow dates of a portfolio)
+type Batch interface {
+	Rates(ts []float64) []float64
+	DiscountFactors(ts []float64) []float64
+}
+
+// Rates returns the spot rates of the term structure for the maturities
+func Rates(s Structure, ts []float64) []float64 {
+	if b, ok := s.(Batch); ok {
+		return b.Rates(ts)
+	}
+	rates := make([]float64, len(ts))
+	for i, t := range ts {
+		rates[i] = s.Rate(t)
+	}
+	return rates
+}
+
+// DiscountFactors returns the discount factors of the term structure for the maturities
+func DiscountFactors(s Structure, ts []float64) []float64 {
+	if b, ok := s.(Batch); ok {
+		return b.DiscountFactors(ts)
+	}
+	z := make([]float64, len(ts))
+	for i, t := range ts {
+		z[i] = s.Z(t)
+	}
+	return z
+}
@@ -0,0 +1,35 @@
+package term_test
+
+import (
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestBatch(t *testing.T) {
+	tenors := []float64{0.0, 0.25, 1.0, 2.5, 7.0, 10.0, 30.0}
+	testData := []struct {
+		Name string
+		Term term.Structure
+	}{
+		{"nss", &term.NelsonSiegelSvensson{B0: -0.266372, B1: -0.471343, B2: 5.68789, B3: -5.12324, T1: 5.74881, T2: 4.14426, Spread: 25.0}},
+		{"ns", &term.NelsonSiegelSvensson{B0: 2.0, B1: -1.0, B2: 0.5, T1: 2.0, T2: 2.0}},
+		{"flat", &term.Flat{R: 1.5, Spread: 10.0}},
+	}
+	for _, test := range testData {
+		rates := term.Rates(test.Term, tenors)
+		z := term.DiscountFactors(test.Term, tenors)
+		if len(rates) != len(tenors) || len(z) != len(tenors) {
+			t.Fatalf("%s: wrong number of values; got: %d and %d, expected: %d", test.Name, len(rates), len(z), len(tenors))
+		}
+		// the batch evaluation gives bit-identical results
+		for i, m := range tenors {
+			if rates[i] != test.Term.Rate(m) {
+				t.Errorf("%s: wrong rate at %v; got: %v, expected: %v", test.Name, m, rates[i], test.Term.Rate(m))
+			}
+			if z[i] != test.Term.Z(m) {
+				t.Errorf("%s: wrong discount factor at %v; got: %v, expected: %v", test.Name, m, z[i], test.Term.Z(m))
+			}
+		}
+	}
+}
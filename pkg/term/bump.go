@@ -0,0 +1,88 @@
+package term
+
+import (
+	"math"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/maturity"
+)
+
+// Bump is a localized adjustment of the overnight (forward) rates between
+// two dates, e.g. the turn of the year or a central bank meeting
+type Bump struct {
+	Start time.Time
+	End   time.Time
+	// Size is the change of the forward rates in bps within the window
+	Size float64
+}
+
+// TurnOfYear returns the bump of the forward rates over the turn of the year
+// from the last business day of the year (weekends only) to January 2
+func TurnOfYear(year int, size float64) Bump {
+	start := maturity.LastDay(year)
+	for start.Weekday() == time.Saturday || start.Weekday() == time.Sunday {
+		start = start.AddDate(0, 0, -1)
+	}
+	return Bump{Start: start, End: maturity.FirstDay(year+1).AddDate(0, 0, 1), Size: size}
+}
+
+// Bumped adds the bumps to the forward rates of the base term structure; the
+// discount factors of the cash flows after a window are reduced by the bump
+// times the length of the window. The dates of the bumps are converted to
+// maturities from the settlement date with maturity.DifferenceInYears.
+type Bumped struct {
+	Base       Structure
+	Settlement time.Time
+	Bumps      []Bump
+	Spread     float64
+}
+
+// SetSpread sets the spread in bps on top of the bumped term structure
+func (b *Bumped) SetSpread(spread float64) Structure {
+	b.Spread = spread
+	return b
+}
+
+// DiscountFactor returns the discount factor for the maturity t with an
+// additional spread in bps
+func (b *Bumped) DiscountFactor(t, spread float64) float64 {
+	return DiscountFactor(b, t, spread)
+}
+
+// Shift returns the term structure shifted in parallel by dr in bps
+func (b *Bumped) Shift(dr float64) Structure {
+	return Shift(b, dr)
+}
+
+// Copy returns a copy of the term structure; the base term structure and the
+// bumps are shared as they are only read
+func (b *Bumped) Copy() Structure {
+	c := *b
+	return &c
+}
+
+// Rate returns the continuously compounded spot rate in percent
+func (b *Bumped) Rate(t float64) float64 {
+	if t <= 0.0 {
+		return b.Base.Rate(t) + b.Spread*0.01
+	}
+	return b.Base.Rate(t) + b.adjustment(t)/t*100.0 + b.Spread*0.01
+}
+
+// Z returns the discount factor for the given maturity t
+func (b *Bumped) Z(t float64) float64 {
+	return b.Base.Z(t) * math.Exp(-b.adjustment(t)-b.Spread*0.0001*t)
+}
+
+// adjustment returns the integral of the bumps of the forward rates up to t
+func (b *Bumped) adjustment(t float64) float64 {
+	sum := 0.0
+	for _, bump := range b.Bumps {
+		start := math.Max(maturity.DifferenceInYears(b.Settlement, bump.Start), 0.0)
+		end := math.Min(maturity.DifferenceInYears(b.Settlement, bump.End), t)
+		if end > start {
+			sum += bump.Size * 0.0001 * (end - start)
+		}
+	}
+	return sum
+}
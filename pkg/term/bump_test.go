@@ -0,0 +1,59 @@
+package term_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestBumped(t *testing.T) {
+	settlement := time.Date(2021, 11, 15, 0, 0, 0, 0, time.UTC)
+	turn := term.TurnOfYear(2021, 50.0)
+	if !turn.Start.Equal(time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)) || !turn.End.Equal(time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("wrong turn of the year; got: %v - %v", turn.Start, turn.End)
+	}
+	// the last business day of 2022 is Friday, December 30
+	if turn := term.TurnOfYear(2022, 50.0); !turn.Start.Equal(time.Date(2022, 12, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("wrong last business day of the year; got: %v", turn.Start)
+	}
+
+	base := &term.Flat{R: 1.0}
+	meeting := term.Bump{
+		Start: time.Date(2022, 3, 17, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2022, 6, 16, 0, 0, 0, 0, time.UTC),
+		Size:  -25.0,
+	}
+	b := term.Bumped{Base: base, Settlement: settlement, Bumps: []term.Bump{turn, meeting}}
+
+	years := func(date time.Time) float64 { return maturity.DifferenceInYears(settlement, date) }
+	window := years(turn.End) - years(turn.Start)
+	var testData = []struct {
+		T        float64
+		Expected float64
+	}{
+		// before the turn
+		{years(turn.Start), base.Z(years(turn.Start))},
+		// within the turn
+		{years(turn.Start) + window/2.0, base.Z(years(turn.Start)+window/2.0) * math.Exp(-0.005*window/2.0)},
+		// after the turn and before the meeting
+		{0.25, base.Z(0.25) * math.Exp(-0.005*window)},
+		// after the meeting window
+		{1.0, base.Z(1.0) * math.Exp(-0.005*window+0.0025*(years(meeting.End)-years(meeting.Start)))},
+	}
+	for _, test := range testData {
+		if z := b.Z(test.T); math.Abs(z-test.Expected) > 1e-12 {
+			t.Errorf("wrong discount factor for %v; got: %v, expected: %v", test.T, z, test.Expected)
+		}
+		if r := b.Rate(test.T); math.Abs(math.Exp(-r*0.01*test.T)-test.Expected) > 1e-12 {
+			t.Errorf("wrong rate for %v; got: %v", test.T, r)
+		}
+	}
+
+	bumped := term.WithSpread(&b, 10.0)
+	if math.Abs(bumped.Z(1.0)-b.Z(1.0)*math.Exp(-0.001)) > 1e-12 || b.Spread != 0.0 {
+		t.Errorf("wrong spread; got: %v, expected: %v", bumped.Z(1.0), b.Z(1.0)*math.Exp(-0.001))
+	}
+}
@@ -0,0 +1,163 @@
+package term
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache wraps a term structure and memoizes the spot rates and discount
+// factors. Within one pricing context (same term structure and same
+// settlement date) the discount factors of cash flows are cached by their
+// payment date (see ZAt), so securities paying on the same dates reuse the
+// cached discount factors; Z and Rate cache by maturity. The values are
+// computed outside of the write lock, so concurrent cache misses do not
+// block each other. Cache is safe for concurrent use.
+type Cache struct {
+	ts    Structure
+	mu    sync.RWMutex
+	gen   uint64
+	rates map[float64]float64
+	z     map[float64]float64
+	dates map[time.Time]dated
+}
+
+// dated is a discount factor cached by payment date with the maturity it was
+// computed for
+type dated struct {
+	t, z float64
+}
+
+// NewCache returns a caching term structure on top of ts
+func NewCache(ts Structure) *Cache {
+	return &Cache{
+		ts:    ts,
+		rates: make(map[float64]float64),
+		z:     make(map[float64]float64),
+		dates: make(map[time.Time]dated),
+	}
+}
+
+// SetSpread sets the spread on the underlying term structure and
+// invalidates all cached values
+func (c *Cache) SetSpread(s float64) Structure {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ts.SetSpread(s)
+	c.clear()
+	return c
+}
+
+// DiscountFactor returns the discount factor for the maturity t with an
+// additional spread in bps
+func (c *Cache) DiscountFactor(t, spread float64) float64 {
+	return DiscountFactor(c, t, spread)
+}
+
+// Shift returns the term structure shifted in parallel by dr in bps
+func (c *Cache) Shift(dr float64) Structure {
+	return Shift(c, dr)
+}
+
+// Copy returns a new (empty) cache on top of a copy of the underlying term structure
+func (c *Cache) Copy() Structure {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return NewCache(copyOf(c.ts))
+}
+
+// Rate returns the continuously compounded spot rate in percent
+func (c *Cache) Rate(t float64) float64 {
+	return c.lookup(c.rates, t, c.ts.Rate)
+}
+
+// Z returns the discount factor for the given maturity t
+func (c *Cache) Z(t float64) float64 {
+	return c.lookup(c.z, t, c.ts.Z)
+}
+
+// ZAt returns the discount factor for the cash flow paid on date with the
+// maturity t. The discount factor is cached by the payment date; a cash flow
+// on the same date with a different maturity (e.g. from another day count
+// basis) is discounted with the underlying term structure.
+func (c *Cache) ZAt(date time.Time, t float64) float64 {
+	key := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	c.mu.RLock()
+	d, ok := c.dates[key]
+	if ok && d.t == t {
+		c.mu.RUnlock()
+		return d.z
+	}
+	gen := c.gen
+	v := c.ts.Z(t)
+	c.mu.RUnlock()
+	if ok {
+		return v
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if d, ok := c.dates[key]; ok {
+		if d.t == t {
+			return d.z
+		}
+		return v
+	}
+	if gen == c.gen {
+		c.dates[key] = dated{t: t, z: v}
+	}
+	return v
+}
+
+// Len returns the number of cached discount factors (by maturity and by date)
+func (c *Cache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.z) + len(c.dates)
+}
+
+// Reset removes all cached values
+func (c *Cache) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clear()
+}
+
+// clear empties the maps in place (callers must hold the write lock)
+func (c *Cache) clear() {
+	for t := range c.rates {
+		delete(c.rates, t)
+	}
+	for t := range c.z {
+		delete(c.z, t)
+	}
+	for d := range c.dates {
+		delete(c.dates, d)
+	}
+	c.gen++
+}
+
+// lookup returns the cached value for t or computes it under the read lock
+// (the underlying term structure is only modified under the write lock) and
+// stores it unless the cache was invalidated in the meantime
+func (c *Cache) lookup(values map[float64]float64, t float64, f func(float64) float64) float64 {
+	c.mu.RLock()
+	v, ok := values[t]
+	if ok {
+		c.mu.RUnlock()
+		return v
+	}
+	gen := c.gen
+	v = f(t)
+	c.mu.RUnlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, ok := values[t]; ok {
+		return cached
+	}
+	if gen == c.gen {
+		values[t] = v
+	}
+	return v
+}
@@ -0,0 +1,114 @@
+package term_test
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestCache(t *testing.T) {
+	ref := term.NelsonSiegelSvensson{
+		B0: -0.266372,
+		B1: -0.471343,
+		B2: 5.68789,
+		B3: -5.12324,
+		T1: 5.74881,
+		T2: 4.14426,
+	}
+	nss := ref
+	cache := term.NewCache(&nss)
+
+	maturities := []float64{0.5, 1.0, 2.0, 5.0, 10.0, 5.0, 1.0}
+	for _, m := range maturities {
+		if math.Abs(cache.Z(m)-ref.Z(m)) > 1e-12 {
+			t.Errorf("cached discount factor is wrong for maturity %v; got: %v, expected: %v", m, cache.Z(m), ref.Z(m))
+		}
+		if math.Abs(cache.Rate(m)-ref.Rate(m)) > 1e-12 {
+			t.Errorf("cached rate is wrong for maturity %v; got: %v, expected: %v", m, cache.Rate(m), ref.Rate(m))
+		}
+	}
+	if cache.Len() != 5 {
+		t.Errorf("wrong number of cached discount factors; got: %d, expected: %d", cache.Len(), 5)
+	}
+
+	// setting a spread invalidates the cache
+	cache.SetSpread(10.0)
+	if cache.Len() != 0 {
+		t.Errorf("cache was not invalidated by SetSpread; got: %d entries", cache.Len())
+	}
+	ref.SetSpread(10.0)
+	if math.Abs(cache.Z(5.0)-ref.Z(5.0)) > 1e-12 {
+		t.Errorf("cached discount factor is wrong after setting spread; got: %v, expected: %v", cache.Z(5.0), ref.Z(5.0))
+	}
+
+	cache.Reset()
+	if cache.Len() != 0 {
+		t.Errorf("cache was not reset; got: %d entries", cache.Len())
+	}
+}
+
+func TestCache_ZAt(t *testing.T) {
+	ref := term.NelsonSiegelSvensson{B0: -0.266372, B1: -0.471343, B2: 5.68789, B3: -5.12324, T1: 5.74881, T2: 4.14426}
+	nss := ref
+	cache := term.NewCache(&nss)
+
+	date := time.Date(2026, 5, 15, 0, 0, 0, 0, time.UTC)
+	if z := cache.ZAt(date, 5.0); math.Abs(z-ref.Z(5.0)) > 1e-12 {
+		t.Errorf("wrong discount factor for date; got: %v, expected: %v", z, ref.Z(5.0))
+	}
+	// the same payment date at a different time of the day shares the entry
+	if z := cache.ZAt(date.Add(12*time.Hour), 5.0); math.Abs(z-ref.Z(5.0)) > 1e-12 || cache.Len() != 1 {
+		t.Errorf("discount factor is not cached by date; got: %v with %d entries", z, cache.Len())
+	}
+	// a different maturity on the same date is not served from the cache
+	if z := cache.ZAt(date, 5.01); math.Abs(z-ref.Z(5.01)) > 1e-12 {
+		t.Errorf("wrong discount factor for a different maturity; got: %v, expected: %v", z, ref.Z(5.01))
+	}
+
+	cache.SetSpread(10.0)
+	ref.SetSpread(10.0)
+	if z := cache.ZAt(date, 5.0); math.Abs(z-ref.Z(5.0)) > 1e-12 {
+		t.Errorf("wrong discount factor after setting spread; got: %v, expected: %v", z, ref.Z(5.0))
+	}
+}
+
+func TestCache_Concurrent(t *testing.T) {
+	ref := term.NelsonSiegelSvensson{B0: -0.266372, B1: -0.471343, B2: 5.68789, B3: -5.12324, T1: 5.74881, T2: 4.14426}
+	nss := ref
+	cache := term.NewCache(&nss)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for m := 1; m <= 30; m++ {
+				if z := cache.Z(float64(m)); math.Abs(z-ref.Z(float64(m))) > 1e-12 {
+					t.Errorf("wrong cached discount factor for maturity %d; got: %v, expected: %v", m, z, ref.Z(float64(m)))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if cache.Len() != 30 {
+		t.Errorf("wrong number of cached discount factors; got: %d, expected: %d", cache.Len(), 30)
+	}
+}
+
+func BenchmarkNelsonSiegelSvensson_Z(b *testing.B) {
+	nss := term.NelsonSiegelSvensson{B0: -0.266372, B1: -0.471343, B2: 5.68789, B3: -5.12324, T1: 5.74881, T2: 4.14426}
+	for i := 0; i < b.N; i += 1 {
+		nss.Z(float64(i%30) + 0.5)
+	}
+}
+
+func BenchmarkCache_Z(b *testing.B) {
+	nss := term.NelsonSiegelSvensson{B0: -0.266372, B1: -0.471343, B2: 5.68789, B3: -5.12324, T1: 5.74881, T2: 4.14426}
+	cache := term.NewCache(&nss)
+	for i := 0; i < b.N; i += 1 {
+		cache.Z(float64(i%30) + 0.5)
+	}
+}
@@ -0,0 +1,87 @@
+package term_test
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// TestConcurrentReads checks (with go test -race) that term structures can be
+// shared across goroutines and bumped with copy-on-write semantics
+func TestConcurrentReads(t *testing.T) {
+	nss := term.NelsonSiegelSvensson{
+		B0: -0.266372,
+		B1: -0.471343,
+		B2: 5.68789,
+		B3: -5.12324,
+		T1: 5.74881,
+		T2: 4.14426,
+	}
+	maturities := []float64{0.25, 0.5, 1.0, 2.0, 3.0, 5.0, 7.0, 10.0, 15.0, 20.0}
+	z := []float64{}
+	for _, m := range maturities {
+		z = append(z, nss.Z(m))
+	}
+
+	curves := []term.Structure{
+		&nss,
+		&term.Flat{R: 1.0},
+		term.NewSpline(maturities, z, 0.0),
+		term.NewCache(&nss),
+		term.NewKeyRates(&nss, []float64{2.0, 10.0}),
+	}
+
+	for _, ts := range curves {
+		// reference values
+		expected := make([]float64, len(maturities))
+		for i, m := range maturities {
+			expected[i] = ts.Z(m)
+		}
+
+		var wg sync.WaitGroup
+		errs := make(chan string, 8*len(maturities)*2)
+		for g := 0; g < 8; g += 1 {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				bumped := term.WithSpread(ts, float64(g))
+				for i, m := range maturities {
+					if math.Abs(ts.Z(m)-expected[i]) > 1e-15 {
+						errs <- "shared term structure was modified"
+					}
+					if math.Abs(ts.Rate(m)-bumped.Rate(m)+float64(g)*0.01) > 1e-9 {
+						errs <- "bumped term structure has wrong spread"
+					}
+				}
+			}(g)
+		}
+		wg.Wait()
+		close(errs)
+		for e := range errs {
+			t.Errorf("%T: %s", ts, e)
+			break
+		}
+	}
+}
+
+func TestWithSpread(t *testing.T) {
+	base := term.Flat{R: 1.0, Spread: 5.0}
+	bumped := term.WithSpread(&base, 10.0)
+	if base.Spread != 5.0 {
+		t.Errorf("WithSpread modified the base term structure; got spread: %v", base.Spread)
+	}
+	if math.Abs(bumped.Rate(1.0)-1.1) > 1e-12 {
+		t.Errorf("wrong rate of bumped term structure; got: %v, expected: %v", bumped.Rate(1.0), 1.1)
+	}
+
+	// the spread is added on top of a term structure that cannot be copied
+	h := hump{H: 2.0, Spread: 5.0}
+	if got, expected := term.WithSpread(&h, 10.0).Rate(1.0), h.Rate(1.0)+0.1; math.Abs(got-expected) > 1e-12 {
+		t.Errorf("wrong rate of bumped custom term structure; got: %v, expected: %v", got, expected)
+	}
+	if h.Spread != 5.0 {
+		t.Errorf("WithSpread modified the custom term structure; got spread: %v", h.Spread)
+	}
+}
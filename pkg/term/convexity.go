@@ -0,0 +1,77 @@
+package term
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// HullWhite is the convexity adjustment of the one-factor Hull-White model
+// with mean reversion; it is smaller than the Ho-Lee adjustment for longer
+// futures and equals it as A approaches zero
+type HullWhite struct {
+	// A is the mean reversion speed
+	A float64 `json:"a"`
+	// Sigma is the (normal) volatility of the short rate, e.g. 0.01 for 100 bps
+	Sigma float64 `json:"sigma"`
+}
+
+// Adjustment returns the convexity adjustment in percent
+// B(t1,t2)/(t2-t1) [B(t1,t2)(1-exp(-2a t1)) + 2a B(0,t1)^2] sigma^2/(4a)
+func (h HullWhite) Adjustment(start, end float64) float64 {
+	if h.A <= 0.0 {
+		return HoLee{Sigma: h.Sigma}.Adjustment(start, end)
+	}
+	b := func(t, m float64) float64 {
+		return (1.0 - math.Exp(-h.A*(m-t))) / h.A
+	}
+	bp := b(start, end)
+	adj := bp / (end - start) * (bp*(1.0-math.Exp(-2.0*h.A*start)) + 2.0*h.A*b(0.0, start)*b(0.0, start))
+	return adj * h.Sigma * h.Sigma / (4.0 * h.A) * 100.0
+}
+
+// Convexity configures the convexity adjustment of futures rates, e.g.
+// {"model": "hullwhite", "a": 0.03, "sigma": 0.01}
+type Convexity struct {
+	// Model is "none", "holee" or "hullwhite" (default: "" for none)
+	Model string  `json:"model"`
+	A     float64 `json:"a"`
+	Sigma float64 `json:"sigma"`
+}
+
+// Adjustment returns the convexity adjustment of the model (nil for none);
+// returns an error wrapping ErrCurveUndefined for unknown models or invalid
+// parameters
+func (c Convexity) Adjustment() (Adjustment, error) {
+	if c.Sigma < 0.0 || c.A < 0.0 {
+		return nil, fmt.Errorf("%w: convexity adjustment with a=%v and sigma=%v", ErrCurveUndefined, c.A, c.Sigma)
+	}
+	switch strings.ToLower(c.Model) {
+	case "", "none":
+		return nil, nil
+	case "holee":
+		return HoLee{Sigma: c.Sigma}, nil
+	case "hullwhite":
+		return HullWhite{A: c.A, Sigma: c.Sigma}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown convexity adjustment %q", ErrCurveUndefined, c.Model)
+}
+
+// UnmarshalJSON reads the futures and the configuration of the convexity
+// adjustment, e.g. {"futures": [...], "convexity": {"model": "holee", "sigma": 0.01}}
+func (s *Strip) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Futures   []Future  `json:"futures"`
+		Convexity Convexity `json:"convexity"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	adj, err := v.Convexity.Adjustment()
+	if err != nil {
+		return err
+	}
+	s.Futures, s.Convexity = v.Futures, adj
+	return nil
+}
@@ -0,0 +1,77 @@
+package term_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestHullWhite_Adjustment(t *testing.T) {
+	holee := term.HoLee{Sigma: 0.012}
+	var testData = []struct {
+		Start, End float64
+	}{
+		{0.25, 0.5},
+		{1.0, 1.25},
+		{5.0, 5.25},
+	}
+	for _, test := range testData {
+		// small mean reversion approaches Ho-Lee
+		hw := term.HullWhite{A: 1e-6, Sigma: 0.012}
+		if a, e := hw.Adjustment(test.Start, test.End), holee.Adjustment(test.Start, test.End); math.Abs(a-e) > 1e-6 {
+			t.Errorf("wrong adjustment without mean reversion for %v; got: %v, expected: %v", test.Start, a, e)
+		}
+		// mean reversion lowers the adjustment
+		hw.A = 0.05
+		if a, e := hw.Adjustment(test.Start, test.End), holee.Adjustment(test.Start, test.End); a <= 0.0 || a >= e {
+			t.Errorf("wrong adjustment with mean reversion for %v; got: %v, expected below: %v", test.Start, a, e)
+		}
+	}
+	// zero mean reversion is Ho-Lee
+	if a, e := (term.HullWhite{Sigma: 0.012}).Adjustment(1.0, 1.25), holee.Adjustment(1.0, 1.25); a != e {
+		t.Errorf("wrong adjustment for zero mean reversion; got: %v, expected: %v", a, e)
+	}
+}
+
+func TestConvexity_Adjustment(t *testing.T) {
+	var testData = []struct {
+		Config   term.Convexity
+		Expected term.Adjustment
+		Err      error
+	}{
+		{term.Convexity{}, nil, nil},
+		{term.Convexity{Model: "none"}, nil, nil},
+		{term.Convexity{Model: "HoLee", Sigma: 0.01}, term.HoLee{Sigma: 0.01}, nil},
+		{term.Convexity{Model: "hullwhite", A: 0.03, Sigma: 0.01}, term.HullWhite{A: 0.03, Sigma: 0.01}, nil},
+		{term.Convexity{Model: "vasicek"}, nil, term.ErrCurveUndefined},
+		{term.Convexity{Model: "holee", Sigma: -0.01}, nil, term.ErrCurveUndefined},
+	}
+	for _, test := range testData {
+		adj, err := test.Config.Adjustment()
+		if !errors.Is(err, test.Err) || adj != test.Expected {
+			t.Errorf("wrong adjustment for %v; got: %v (%v), expected: %v (%v)", test.Config, adj, err, test.Expected, test.Err)
+		}
+	}
+}
+
+func TestStrip_UnmarshalJSON(t *testing.T) {
+	data := []byte(`{"futures": [{"start": 0.25, "end": 0.5, "price": 98.8}], "convexity": {"model": "hullwhite", "a": 0.03, "sigma": 0.01}}`)
+	var s term.Strip
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Futures) != 1 || s.Convexity != (term.HullWhite{A: 0.03, Sigma: 0.01}) {
+		t.Errorf("wrong strip; got: %v", s)
+	}
+	expected := 1.2 - term.HullWhite{A: 0.03, Sigma: 0.01}.Adjustment(0.25, 0.5)
+	if f := s.Forwards(); math.Abs(f[0]-expected) > 1e-12 {
+		t.Errorf("wrong forward rate; got: %v, expected: %v", f[0], expected)
+	}
+
+	if err := json.Unmarshal([]byte(`{"convexity": {"model": "unknown"}}`), &s); !errors.Is(err, term.ErrCurveUndefined) {
+		t.Errorf("wrong error; got: %v, expected: %v", err, term.ErrCurveUndefined)
+	}
+}
@@ -0,0 +1,77 @@
+package term
+
+import (
+	"fmt"
+	"math"
+)
+
+// ExtremeRate is the absolute rate in percent from which the long end of a
+// curve is reported as extreme
+var ExtremeRate = 15.0
+
+// Diagnostics reports the quality of a term structure on a grid of maturities
+type Diagnostics struct {
+	// Horizon is the longest maturity checked in years
+	Horizon float64
+	// Invalid is true if a rate or discount factor is NaN, infinite or not positive
+	Invalid bool
+	// MinForward is the lowest forward rate in percent between grid points
+	// and MinForwardAt its start maturity
+	MinForward   float64
+	MinForwardAt float64
+	// LongRate and LongForward are the spot and forward rates in percent at the horizon
+	LongRate    float64
+	LongForward float64
+}
+
+// Diagnose checks the term structure on a monthly grid up to the horizon in years
+func Diagnose(ts Structure, horizon float64) Diagnostics {
+	d := Diagnostics{Horizon: horizon, MinForward: math.Inf(1)}
+	dt := 1.0 / 12.0
+	prev := 1.0
+	for t := dt; t <= horizon+1e-9; t += dt {
+		z := ts.Z(t)
+		if math.IsNaN(z) || math.IsInf(z, 0) || z <= 0.0 {
+			d.Invalid = true
+			return d
+		}
+		f := -math.Log(z/prev) / dt * 100.0
+		if f < d.MinForward {
+			d.MinForward, d.MinForwardAt = f, t-dt
+		}
+		d.LongForward = f
+		prev = z
+	}
+	d.LongRate = ts.Rate(horizon)
+	if math.IsNaN(d.LongRate) || math.IsInf(d.LongRate, 0) {
+		d.Invalid = true
+	}
+	return d
+}
+
+// NegativeForwards returns true if the term structure implies negative forward
+// rates, i.e. the discount factors increase between grid points
+func (d Diagnostics) NegativeForwards() bool {
+	return d.MinForward < 0.0
+}
+
+// ExtremeLongEnd returns true if the spot or forward rate at the horizon is
+// beyond ExtremeRate in absolute terms
+func (d Diagnostics) ExtremeLongEnd() bool {
+	return math.Abs(d.LongRate) > ExtremeRate || math.Abs(d.LongForward) > ExtremeRate
+}
+
+// Warnings returns the findings as human readable messages
+func (d Diagnostics) Warnings() []string {
+	if d.Invalid {
+		return []string{fmt.Sprintf("curve has invalid rates or discount factors up to %.0f years", d.Horizon)}
+	}
+	warnings := []string{}
+	if d.NegativeForwards() {
+		warnings = append(warnings, fmt.Sprintf("curve implies negative forward rates (%.2f%% at %.2f years)", d.MinForward, d.MinForwardAt))
+	}
+	if d.ExtremeLongEnd() {
+		warnings = append(warnings, fmt.Sprintf("extreme long end at %.0f years (spot rate %.2f%%, forward rate %.2f%%)", d.Horizon, d.LongRate, d.LongForward))
+	}
+	return warnings
+}
@@ -0,0 +1,52 @@
+package term_test
+
+import (
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestDiagnose(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Term     term.Structure
+		Negative bool
+		Extreme  bool
+		Warnings int
+	}{
+		{Name: "flat", Term: &term.Flat{R: 2.0}, Warnings: 0},
+		{Name: "negative", Term: &term.Flat{R: -0.5}, Negative: true, Warnings: 1},
+		{
+			// upward sloping NSS curve with moderate long end
+			Name:     "nss",
+			Term:     &term.NelsonSiegelSvensson{B0: 3.0, B1: -2.0, B2: 0.0, B3: 0.0, T1: 2.0, T2: 2.0},
+			Warnings: 0,
+		},
+		{
+			// exploding rates at the long end
+			Name:     "explosive",
+			Term:     &term.NelsonSiegelSvensson{B0: 40.0, B1: -38.0, B2: 0.0, B3: 0.0, T1: 20.0, T2: 20.0},
+			Extreme:  true,
+			Warnings: 1,
+		},
+	}
+	for _, test := range testData {
+		d := term.Diagnose(test.Term, 50.0)
+		if d.Invalid {
+			t.Errorf("%s: curve reported as invalid", test.Name)
+		}
+		if d.NegativeForwards() != test.Negative {
+			t.Errorf("%s: wrong negative forwards; got: %v, expected: %v", test.Name, d.NegativeForwards(), test.Negative)
+		}
+		if d.ExtremeLongEnd() != test.Extreme {
+			t.Errorf("%s: wrong extreme long end; got: %v, expected: %v", test.Name, d.ExtremeLongEnd(), test.Extreme)
+		}
+		if w := d.Warnings(); len(w) != test.Warnings {
+			t.Errorf("%s: wrong number of warnings; got: %v, expected: %v", test.Name, w, test.Warnings)
+		}
+	}
+
+	if d := term.Diagnose(&term.Spline{}, 10.0); !d.Invalid || len(d.Warnings()) != 1 {
+		t.Errorf("expected uninitialized spline to be invalid; got: %v", d)
+	}
+}
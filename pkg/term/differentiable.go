@@ -0,0 +1,128 @@
+package term
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/konimarti/fixedincome/pkg/ad"
+)
+
+// Differentiable is a term structure whose discount factors can be evaluated
+// together with their exact derivatives with respect to the curve parameters
+type Differentiable interface {
+	Structure
+
+	// ZDual returns the discount factor for the given maturity and its
+	// gradient with respect to the parameters
+	ZDual(t float64) ad.Dual
+
+	// Parameters returns the names of the parameters in the order of the gradient
+	Parameters() []string
+}
+
+// KeyRates adds key-rate shifts (in bps) at the given tenors to a base term
+// structure. Shifts are interpolated linearly between neighbouring tenors and
+// are constant before the first and after the last tenor. The derivatives
+// with respect to the shifts give the key-rate sensitivities.
+type KeyRates struct {
+	// Base is the underlying term structure
+	Base Structure
+	// Tenors are the key-rate maturities in years and in increasing order
+	Tenors []float64
+	// Shifts are the shifts in bps for each tenor
+	Shifts []float64
+}
+
+// NewKeyRates returns key-rate term structure with zero shifts at the given tenors
+func NewKeyRates(base Structure, tenors []float64) *KeyRates {
+	t := make([]float64, len(tenors))
+	copy(t, tenors)
+	return &KeyRates{
+		Base:   base,
+		Tenors: t,
+		Shifts: make([]float64, len(tenors)),
+	}
+}
+
+// SetSpread sets the spread on the base term structure
+func (k *KeyRates) SetSpread(s float64) Structure {
+	k.Base.SetSpread(s)
+	return k
+}
+
+// DiscountFactor returns the discount factor for the maturity t with an
+// additional spread in bps
+func (k *KeyRates) DiscountFactor(t, spread float64) float64 {
+	return DiscountFactor(k, t, spread)
+}
+
+// Shift returns the term structure shifted in parallel by dr in bps
+func (k *KeyRates) Shift(dr float64) Structure {
+	return Shift(k, dr)
+}
+
+// Copy returns an independent copy of the key-rate term structure
+func (k *KeyRates) Copy() Structure {
+	c := NewKeyRates(copyOf(k.Base), k.Tenors)
+	copy(c.Shifts, k.Shifts)
+	return c
+}
+
+// Rate returns the continuously compounded spot rate in percent
+func (k *KeyRates) Rate(t float64) float64 {
+	return k.Base.Rate(t) + k.shift(t)*0.01
+}
+
+// Z returns the discount factor for the given maturity t
+func (k *KeyRates) Z(t float64) float64 {
+	return k.Base.Z(t) * math.Exp(-k.shift(t)*0.0001*t)
+}
+
+// ZDual returns the discount factor and its gradient with respect to the key-rate shifts
+func (k *KeyRates) ZDual(t float64) ad.Dual {
+	z := k.Z(t)
+	d := ad.Constant(z, len(k.Tenors))
+	for i, w := range k.weights(t) {
+		d.Grad[i] = -w * 0.0001 * t * z
+	}
+	return d
+}
+
+// Parameters returns the names of the key rates
+func (k *KeyRates) Parameters() []string {
+	names := make([]string, len(k.Tenors))
+	for i, t := range k.Tenors {
+		names[i] = fmt.Sprintf("%gy", t)
+	}
+	return names
+}
+
+func (k *KeyRates) shift(t float64) float64 {
+	s := 0.0
+	for i, w := range k.weights(t) {
+		s += w * k.Shifts[i]
+	}
+	return s
+}
+
+// weights returns the linear interpolation weights of the tenors for maturity t
+func (k *KeyRates) weights(t float64) []float64 {
+	w := make([]float64, len(k.Tenors))
+	n := len(k.Tenors)
+	switch {
+	case n == 0:
+	case t <= k.Tenors[0]:
+		w[0] = 1.0
+	case t >= k.Tenors[n-1]:
+		w[n-1] = 1.0
+	default:
+		for i := 1; i < n; i += 1 {
+			if t <= k.Tenors[i] {
+				x := (t - k.Tenors[i-1]) / (k.Tenors[i] - k.Tenors[i-1])
+				w[i-1], w[i] = 1.0-x, x
+				break
+			}
+		}
+	}
+	return w
+}
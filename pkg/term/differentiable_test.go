@@ -0,0 +1,43 @@
+package term_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestKeyRates(t *testing.T) {
+	base := term.Flat{R: 1.0}
+	k := term.NewKeyRates(&base, []float64{2.0, 5.0})
+	k.Shifts[0], k.Shifts[1] = 10.0, 40.0
+
+	data := []struct {
+		M             float64
+		ExpectedShift float64
+	}{
+		{1.0, 10.0},
+		{2.0, 10.0},
+		{3.5, 25.0},
+		{5.0, 40.0},
+		{10.0, 40.0},
+	}
+	for _, test := range data {
+		expected := base.Rate(test.M) + test.ExpectedShift*0.01
+		if math.Abs(k.Rate(test.M)-expected) > 1e-12 {
+			t.Errorf("wrong key-rate shift for maturity %v; got: %v, expected: %v", test.M, k.Rate(test.M), expected)
+		}
+		if math.Abs(k.Z(test.M)-math.Exp(-expected*0.01*test.M)) > 1e-12 {
+			t.Errorf("wrong discount factor for maturity %v", test.M)
+		}
+	}
+
+	// 3.5y is halfway between the key rates: dZ/ds_i = -0.5 * 0.0001 * t * Z
+	d := k.ZDual(3.5)
+	expected := []float64{-0.5 * 0.0001 * 3.5 * k.Z(3.5), -0.5 * 0.0001 * 3.5 * k.Z(3.5)}
+	for i := range expected {
+		if math.Abs(d.Grad[i]-expected[i]) > 1e-12 {
+			t.Errorf("wrong key-rate derivative %s; got: %v, expected: %v", k.Parameters()[i], d.Grad[i], expected[i])
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package term
 
-import "math"
+import (
+	"math"
+
+	"github.com/konimarti/fixedincome/pkg/ad"
+)
 
 // Flat represents a flat term structure, i.e. constant rate across maturities
 type Flat struct {
@@ -14,6 +18,23 @@ func (f *Flat) SetSpread(spread float64) Structure {
 	return f
 }
 
+// DiscountFactor returns the discount factor for the maturity t with an
+// additional spread in bps
+func (f *Flat) DiscountFactor(t, spread float64) float64 {
+	return DiscountFactor(f, t, spread)
+}
+
+// Shift returns the term structure shifted in parallel by dr in bps
+func (f *Flat) Shift(dr float64) Structure {
+	return Shift(f, dr)
+}
+
+// Copy returns an independent copy of the term structure
+func (f *Flat) Copy() Structure {
+	c := *f
+	return &c
+}
+
 // Rate returns the continuously compounded spot rate in percent
 func (f *Flat) Rate(t float64) float64 {
 	return f.R + f.Spread*0.01
@@ -23,3 +44,15 @@ func (f *Flat) Rate(t float64) float64 {
 func (f *Flat) Z(t float64) float64 {
 	return math.Exp(-(f.Rate(t) * 0.01) * t)
 }
+
+// ZDual returns the discount factor for the given maturity t and its gradient
+// with respect to the parameters r and spread
+func (f *Flat) ZDual(t float64) ad.Dual {
+	r, spread := ad.Variable(f.R, 0, 2), ad.Variable(f.Spread, 1, 2)
+	return ad.Exp(ad.Scale(ad.Add(r, ad.Scale(spread, 0.01)), -0.01*t))
+}
+
+// Parameters returns the names of the parameters
+func (f *Flat) Parameters() []string {
+	return []string{"r", "spread"}
+}
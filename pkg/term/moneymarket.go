@@ -0,0 +1,161 @@
+package term
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// MoneyMarket represents the short end of the term structure from deposit and
+// bill rates with simple interest. The continuously compounded spot rates are
+// linearly interpolated between the tenors and flat outside the tenors.
+type MoneyMarket struct {
+	// Tenors are the maturities in years (e.g. 1/12 for a 1 month deposit)
+	Tenors []float64 `json:"tenors"`
+	// Rates are the simple annual rates in percent
+	Rates  []float64 `json:"rates"`
+	Spread float64   `json:"spread"`
+}
+
+// SetSpread sets the spread in bps
+func (m *MoneyMarket) SetSpread(spread float64) Structure {
+	m.Spread = spread
+	return m
+}
+
+// DiscountFactor returns the discount factor for the maturity t with an
+// additional spread in bps
+func (m *MoneyMarket) DiscountFactor(t, spread float64) float64 {
+	return DiscountFactor(m, t, spread)
+}
+
+// Shift returns the term structure shifted in parallel by dr in bps
+func (m *MoneyMarket) Shift(dr float64) Structure {
+	return Shift(m, dr)
+}
+
+// Copy returns a copy of the term structure; the tenors and rates are shared
+func (m *MoneyMarket) Copy() Structure {
+	c := *m
+	return &c
+}
+
+// Init sorts the tenors and checks the rates; returns an error wrapping
+// ErrCurveUndefined if the data does not match
+func (m *MoneyMarket) Init() error {
+	if len(m.Tenors) != len(m.Rates) || len(m.Tenors) == 0 {
+		return fmt.Errorf("%w: %d tenors and %d rates", ErrCurveUndefined, len(m.Tenors), len(m.Rates))
+	}
+	sort.Sort(m)
+	for i, t := range m.Tenors {
+		if t <= 0.0 || 1.0+m.Rates[i]*0.01*t <= 0.0 {
+			return fmt.Errorf("%w: tenor %v with rate %v is not valid", ErrCurveUndefined, t, m.Rates[i])
+		}
+	}
+	return nil
+}
+
+func (m *MoneyMarket) Len() int {
+	return len(m.Tenors)
+}
+
+func (m *MoneyMarket) Less(i, j int) bool {
+	return m.Tenors[i] < m.Tenors[j]
+}
+
+func (m *MoneyMarket) Swap(i, j int) {
+	swap(m.Tenors, i, j)
+	swap(m.Rates, i, j)
+}
+
+// continuous returns the continuously compounded rate in percent of the i-th tenor
+func (m *MoneyMarket) continuous(i int) float64 {
+	t := m.Tenors[i]
+	return math.Log(1.0+m.Rates[i]*0.01*t) / t * 100.0
+}
+
+// Rate returns the continuously compounded spot rate in percent
+// (NaN if there are no rates)
+func (m *MoneyMarket) Rate(t float64) float64 {
+	n := len(m.Tenors)
+	if n == 0 || len(m.Rates) != n {
+		return math.NaN()
+	}
+	r := 0.0
+	switch i := sort.SearchFloat64s(m.Tenors, t); {
+	case i == 0:
+		r = m.continuous(0)
+	case i == n:
+		r = m.continuous(n - 1)
+	default:
+		w := (t - m.Tenors[i-1]) / (m.Tenors[i] - m.Tenors[i-1])
+		r = (1.0-w)*m.continuous(i-1) + w*m.continuous(i)
+	}
+	return r + m.Spread*0.01
+}
+
+// Z returns the discount factor for the given maturity t
+func (m *MoneyMarket) Z(t float64) float64 {
+	return math.Exp(-(m.Rate(t) * 0.01) * t)
+}
+
+// Stitched combines a money-market segment up to the cutoff with a long-end
+// model (e.g. a Nelson-Siegel-Svensson fit) beyond the cutoff plus the
+// transition; within the transition the spot rates are blended smoothly
+// (C1 smoothstep weights) from the short to the long end.
+type Stitched struct {
+	Short *MoneyMarket
+	Long  Structure
+	// Cutoff is the maturity in years up to which the money-market rates are used
+	// (e.g. 0.25 for 3 months)
+	Cutoff float64
+	// Transition is the length of the blending window in years
+	// (default: 0 for a hard switch at the cutoff)
+	Transition float64
+	Spread     float64
+}
+
+// SetSpread sets the spread in bps on top of both segments
+func (s *Stitched) SetSpread(spread float64) Structure {
+	s.Spread = spread
+	return s
+}
+
+// DiscountFactor returns the discount factor for the maturity t with an
+// additional spread in bps
+func (s *Stitched) DiscountFactor(t, spread float64) float64 {
+	return DiscountFactor(s, t, spread)
+}
+
+// Shift returns the term structure shifted in parallel by dr in bps
+func (s *Stitched) Shift(dr float64) Structure {
+	return Shift(s, dr)
+}
+
+// Copy returns a copy of the term structure; the segments are shared as
+// they are only read
+func (s *Stitched) Copy() Structure {
+	c := *s
+	return &c
+}
+
+// Rate returns the continuously compounded spot rate in percent
+func (s *Stitched) Rate(t float64) float64 {
+	var r float64
+	switch {
+	case t <= s.Cutoff:
+		r = s.Short.Rate(t)
+	case t >= s.Cutoff+s.Transition:
+		r = s.Long.Rate(t)
+	default:
+		x := (t - s.Cutoff) / s.Transition
+		w := x * x * (3.0 - 2.0*x)
+		r = (1.0-w)*s.Short.Rate(t) + w*s.Long.Rate(t)
+	}
+	return r + s.Spread*0.01
+}
+
+// Z returns the discount factor for the given maturity t
+func (s *Stitched) Z(t float64) float64 {
+	return math.Exp(-(s.Rate(t) * 0.01) * t)
+}
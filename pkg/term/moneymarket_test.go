@@ -0,0 +1,89 @@
+package term_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func moneyMarket() *term.MoneyMarket {
+	return &term.MoneyMarket{
+		Tenors: []float64{0.25, 1.0 / 12.0, 0.5},
+		Rates:  []float64{1.2, 1.0, 1.5},
+	}
+}
+
+func TestMoneyMarket(t *testing.T) {
+	m := moneyMarket()
+	if err := m.Init(); err != nil {
+		t.Fatal(err)
+	}
+	// discount factors reprice the deposits with simple interest
+	for i, tenor := range m.Tenors {
+		if expected := 1.0 / (1.0 + m.Rates[i]*0.01*tenor); math.Abs(m.Z(tenor)-expected) > 1e-12 {
+			t.Errorf("wrong discount factor for %v; got: %v, expected: %v", tenor, m.Z(tenor), expected)
+		}
+	}
+	// flat extrapolation before the first tenor
+	if math.Abs(m.Rate(0.01)-m.Rate(1.0/12.0)) > 1e-12 {
+		t.Errorf("wrong extrapolation; got: %v, expected: %v", m.Rate(0.01), m.Rate(1.0/12.0))
+	}
+	// linear interpolation of the spot rates
+	if expected := 0.5 * (m.Rate(0.25) + m.Rate(0.5)); math.Abs(m.Rate(0.375)-expected) > 1e-12 {
+		t.Errorf("wrong interpolation; got: %v, expected: %v", m.Rate(0.375), expected)
+	}
+
+	// parse with the keys of the parameters
+	ts, err := term.Parse([]byte(`{"tenors": [0.5, 1], "rates": [1.0, 1.2], "spread": 10}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ts.(*term.MoneyMarket); !ok || math.Abs(ts.Z(1.0)-math.Exp(-0.001)/1.012) > 1e-12 {
+		t.Errorf("wrong parsed term structure; got: %T %v", ts, ts.Z(1.0))
+	}
+
+	if err := (&term.MoneyMarket{Tenors: []float64{1.0}}).Init(); err == nil {
+		t.Error("expected error for missing rates")
+	}
+}
+
+func TestStitched(t *testing.T) {
+	short := moneyMarket()
+	if err := short.Init(); err != nil {
+		t.Fatal(err)
+	}
+	long := &term.Flat{R: 2.0}
+	s := term.Stitched{Short: short, Long: long, Cutoff: 0.3, Transition: 0.45}
+
+	var testData = []struct {
+		T        float64
+		Expected float64
+	}{
+		{0.1, short.Rate(0.1)},
+		{0.3, short.Rate(0.3)},
+		{0.525, 0.5 * (short.Rate(0.525) + 2.0)},
+		{0.75, 2.0},
+		{5.0, 2.0},
+	}
+	for _, test := range testData {
+		if r := s.Rate(test.T); math.Abs(r-test.Expected) > 1e-12 {
+			t.Errorf("wrong rate for %v; got: %v, expected: %v", test.T, r, test.Expected)
+		}
+	}
+
+	// smooth transition at the cutoff and the end of the window
+	for _, edge := range []float64{0.3, 0.75} {
+		h := 1e-6
+		left := (s.Rate(edge) - s.Rate(edge-h)) / h
+		right := (s.Rate(edge+h) - s.Rate(edge)) / h
+		if math.Abs(left-right) > 1e-3 {
+			t.Errorf("kink at %v; got slopes: %v, %v", edge, left, right)
+		}
+	}
+
+	bumped := term.WithSpread(&s, 100.0)
+	if math.Abs(bumped.Rate(0.5)-s.Rate(0.5)-1.0) > 1e-12 || s.Spread != 0.0 {
+		t.Errorf("wrong spread; got: %v, expected: %v", bumped.Rate(0.5), s.Rate(0.5)+1.0)
+	}
+}
@@ -1,6 +1,13 @@
 package term
 
-import "math"
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/konimarti/fixedincome/pkg/ad"
+)
 
 // NelsonSiegelSvensson represents a spot-rate term structure
 // Source: https://data.snb.ch/en/topics/ziredev#!/doc/explanations_ziredev#interest_rates_meth_par_siegel
@@ -14,6 +21,53 @@ type NelsonSiegelSvensson struct {
 	Spread float64 `json:"spread"`
 }
 
+// UnmarshalJSON parses the parameters and converts them from the units given
+// in the optional fields "units" ("percent" or "decimal" for the betas) and
+// "tau_units" ("years" or "months")
+func (nss *NelsonSiegelSvensson) UnmarshalJSON(data []byte) error {
+	type parameters NelsonSiegelSvensson
+	var p struct {
+		parameters
+		Units    string `json:"units"`
+		TauUnits string `json:"tau_units"`
+	}
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	*nss = NelsonSiegelSvensson(p.parameters)
+	return nss.Convert(p.Units, p.TauUnits)
+}
+
+// Convert converts the parameters from the given units of the betas
+// ("percent" or "decimal"; default: "" for percent) and the taus ("years" or
+// "months"; default: "" for years) to percent and years
+func (nss *NelsonSiegelSvensson) Convert(units, tauUnits string) error {
+	switch strings.ToLower(units) {
+	case "", "percent":
+	case "decimal":
+		nss.B0, nss.B1, nss.B2, nss.B3 = nss.B0*100.0, nss.B1*100.0, nss.B2*100.0, nss.B3*100.0
+	default:
+		return fmt.Errorf("%w: unknown units %q of the betas (use percent or decimal)", ErrCurveUndefined, units)
+	}
+	switch strings.ToLower(tauUnits) {
+	case "", "years":
+	case "months":
+		nss.T1, nss.T2 = nss.T1/12.0, nss.T2/12.0
+	default:
+		return fmt.Errorf("%w: unknown units %q of the taus (use years or months)", ErrCurveUndefined, tauUnits)
+	}
+	return nil
+}
+
+// Init completes the parameters of the Nelson-Siegel model, i.e. without
+// the second hump (b3 = 0), with t2 = t1
+func (nss *NelsonSiegelSvensson) Init() error {
+	if nss.B3 == 0.0 && nss.T2 == 0.0 {
+		nss.T2 = nss.T1
+	}
+	return nil
+}
+
 // SetSpread sets the constant spread that is added to the continuously
 // compounded rate over all maturities
 func (nss *NelsonSiegelSvensson) SetSpread(s float64) Structure {
@@ -21,20 +75,31 @@ func (nss *NelsonSiegelSvensson) SetSpread(s float64) Structure {
 	return nss
 }
 
+// DiscountFactor returns the discount factor for the maturity t with an
+// additional spread in bps
+func (nss *NelsonSiegelSvensson) DiscountFactor(t, spread float64) float64 {
+	return DiscountFactor(nss, t, spread)
+}
+
+// Shift returns the term structure shifted in parallel by dr in bps
+func (nss *NelsonSiegelSvensson) Shift(dr float64) Structure {
+	return Shift(nss, dr)
+}
+
+// Copy returns an independent copy of the term structure
+func (nss *NelsonSiegelSvensson) Copy() Structure {
+	c := *nss
+	return &c
+}
+
 // Rate returns the continuous compounded spot rate (in %) for a term maturity
 // of m years R_cc(0, m)
 func (nss *NelsonSiegelSvensson) Rate(m float64) float64 {
 	if m == 0.0 {
 		m = 1e-7
 	}
-	cc := nss.B0
-	cc += nss.B1 * ((1.0 - math.Exp(-m/nss.T1)) * nss.T1 / m)
-	cc += nss.B2 * (((1.0 - math.Exp(-m/nss.T1)) * nss.T1 / m) - math.Exp(-m/nss.T1))
-	cc += nss.B3 * (((1.0 - math.Exp(-m/nss.T2)) * nss.T2 / m) - math.Exp(-m/nss.T2))
-	// cc += nss.B1 * ((1.0 - math.Exp(-m/nss.T1)) / (m / nss.T1))
-	// cc += nss.B2 * (((1.0 - math.Exp(-m/nss.T1)) / (m / nss.T1)) - math.Exp(-m/nss.T1))
-	// cc += nss.B3 * (((1.0 - math.Exp(-m/nss.T2)) / (m / nss.T2)) - math.Exp(-m/nss.T2))
-	return cc + nss.Spread*0.01
+	e1, e2 := nss.exps(m)
+	return nss.rate(m, e1, e2)
 }
 
 // Z return the discount factor for a term maturity of m years Z(0, m)
@@ -42,6 +107,80 @@ func (nss *NelsonSiegelSvensson) Z(m float64) float64 {
 	return math.Exp(-nss.Rate(m) * 0.01 * m)
 }
 
+// Rates returns the spot rates (in %) for the maturities with the same
+// results as Rate
+func (nss *NelsonSiegelSvensson) Rates(ms []float64) []float64 {
+	rates := make([]float64, len(ms))
+	for i, m := range ms {
+		if m == 0.0 {
+			m = 1e-7
+		}
+		e1, e2 := nss.exps(m)
+		rates[i] = nss.rate(m, e1, e2)
+	}
+	return rates
+}
+
+// DiscountFactors returns the discount factors for the maturities with the
+// same results as Z
+func (nss *NelsonSiegelSvensson) DiscountFactors(ms []float64) []float64 {
+	z := nss.Rates(ms)
+	for i, m := range ms {
+		z[i] = math.Exp(-z[i] * 0.01 * m)
+	}
+	return z
+}
+
+// exps returns exp(-m/t1) and exp(-m/t2), where the exponential is only
+// evaluated once for the Nelson-Siegel model (t1 = t2)
+func (nss *NelsonSiegelSvensson) exps(m float64) (float64, float64) {
+	e1 := math.Exp(-m / nss.T1)
+	if nss.T2 == nss.T1 {
+		return e1, e1
+	}
+	return e1, math.Exp(-m / nss.T2)
+}
+
+// rate returns the spot rate for the maturity m given the exponentials of exps
+func (nss *NelsonSiegelSvensson) rate(m, e1, e2 float64) float64 {
+	f1 := (1.0 - e1) * nss.T1 / m
+	cc := nss.B0
+	cc += nss.B1 * f1
+	cc += nss.B2 * (f1 - e1)
+	cc += nss.B3 * ((1.0-e2)*nss.T2/m - e2)
+	return cc + nss.Spread*0.01
+}
+
+// ZDual returns the discount factor for a term maturity of m years and its
+// gradient with respect to the parameters b0, b1, b2, b3, t1, t2 and spread
+func (nss *NelsonSiegelSvensson) ZDual(m float64) ad.Dual {
+	if m == 0.0 {
+		m = 1e-7
+	}
+	n := 7
+	b0, b1, b2, b3 := ad.Variable(nss.B0, 0, n), ad.Variable(nss.B1, 1, n), ad.Variable(nss.B2, 2, n), ad.Variable(nss.B3, 3, n)
+	t1, t2 := ad.Variable(nss.T1, 4, n), ad.Variable(nss.T2, 5, n)
+	spread := ad.Variable(nss.Spread, 6, n)
+
+	// e = exp(-m/tau) and f = (1-exp(-m/tau)) * tau / m
+	e1, e2 := ad.Exp(ad.Inv(-m, t1)), ad.Exp(ad.Inv(-m, t2))
+	f1 := ad.Scale(ad.Mul(ad.Shift(ad.Scale(e1, -1.0), 1.0), t1), 1.0/m)
+	f2 := ad.Scale(ad.Mul(ad.Shift(ad.Scale(e2, -1.0), 1.0), t2), 1.0/m)
+
+	cc := b0
+	cc = ad.Add(cc, ad.Mul(b1, f1))
+	cc = ad.Add(cc, ad.Mul(b2, ad.Sub(f1, e1)))
+	cc = ad.Add(cc, ad.Mul(b3, ad.Sub(f2, e2)))
+	cc = ad.Add(cc, ad.Scale(spread, 0.01))
+
+	return ad.Exp(ad.Scale(cc, -0.01*m))
+}
+
+// Parameters returns the names of the parameters
+func (nss *NelsonSiegelSvensson) Parameters() []string {
+	return []string{"b0", "b1", "b2", "b3", "t1", "t2", "spread"}
+}
+
 // F is the forward discount factor F(0, m, m+t) for a zero-bond with maturity
 // t at the future time t
 // func (n *NelsonSiegelSvensson) F(m, t float64) float64 {
@@ -1,6 +1,7 @@
 package term_test
 
 import (
+	"errors"
 	"math"
 	"testing"
 
@@ -47,3 +48,105 @@ func TestNelsonSiegelSvensson(t *testing.T) {
 		}
 	}
 }
+
+func TestNelsonSiegelSvensson_ZDual(t *testing.T) {
+	n := term.NelsonSiegelSvensson{
+		B0:     -0.266372,
+		B1:     -0.471343,
+		B2:     5.68789,
+		B3:     -5.12324,
+		T1:     5.74881,
+		T2:     4.14426,
+		Spread: 10.0,
+	}
+	params := []*float64{&n.B0, &n.B1, &n.B2, &n.B3, &n.T1, &n.T2, &n.Spread}
+
+	for _, m := range []float64{0.5, 2.0, 7.0, 30.0} {
+		d := n.ZDual(m)
+		if math.Abs(d.Value-n.Z(m)) > 1e-12 {
+			t.Errorf("wrong discount factor for maturity %v; got: %v, expected: %v", m, d.Value, n.Z(m))
+		}
+		h := 1e-6
+		for i, p := range params {
+			*p += h
+			up := n.Z(m)
+			*p -= 2 * h
+			down := n.Z(m)
+			*p += h
+			expected := (up - down) / (2 * h)
+			if math.Abs(d.Grad[i]-expected) > 1e-8 {
+				t.Errorf("wrong derivative for %s at maturity %v; got: %v, expected: %v", n.Parameters()[i], m, d.Grad[i], expected)
+			}
+		}
+	}
+}
+
+func BenchmarkNelsonSiegelSvensson_Rate(b *testing.B) {
+	nss := term.NelsonSiegelSvensson{B0: -0.266372, B1: -0.471343, B2: 5.68789, B3: -5.12324, T1: 5.74881, T2: 4.14426}
+	for i := 0; i < b.N; i += 1 {
+		nss.Rate(float64(i%30) + 0.5)
+	}
+}
+
+func BenchmarkNelsonSiegelSvensson_Loop(b *testing.B) {
+	nss := term.NelsonSiegelSvensson{B0: -0.266372, B1: -0.471343, B2: 5.68789, B3: -5.12324, T1: 5.74881, T2: 4.14426}
+	tenors := monthly(30)
+	z := make([]float64, len(tenors))
+	b.ResetTimer()
+	for i := 0; i < b.N; i += 1 {
+		for j, m := range tenors {
+			z[j] = nss.Z(m)
+		}
+	}
+}
+
+func BenchmarkNelsonSiegelSvensson_DiscountFactors(b *testing.B) {
+	nss := term.NelsonSiegelSvensson{B0: -0.266372, B1: -0.471343, B2: 5.68789, B3: -5.12324, T1: 5.74881, T2: 4.14426}
+	tenors := monthly(30)
+	b.ResetTimer()
+	for i := 0; i < b.N; i += 1 {
+		nss.DiscountFactors(tenors)
+	}
+}
+
+// monthly returns the monthly tenors up to the given years
+func monthly(years int) []float64 {
+	tenors := make([]float64, 12*years)
+	for i := range tenors {
+		tenors[i] = float64(i+1) / 12.0
+	}
+	return tenors
+}
+
+func BenchmarkNelsonSiegelSvensson_ZDual(b *testing.B) {
+	nss := term.NelsonSiegelSvensson{B0: -0.266372, B1: -0.471343, B2: 5.68789, B3: -5.12324, T1: 5.74881, T2: 4.14426}
+	for i := 0; i < b.N; i += 1 {
+		nss.ZDual(float64(i%30) + 0.5)
+	}
+}
+
+func TestNelsonSiegelSvensson_Units(t *testing.T) {
+	expected := term.NelsonSiegelSvensson{B0: -0.266372, B1: -0.471343, B2: 5.68789, B3: -5.12324, T1: 5.74881, T2: 4.14426}
+
+	testData := [][]byte{
+		[]byte(`{"b0": -0.266372, "b1": -0.471343, "b2": 5.68789, "b3": -5.12324, "t1": 5.74881, "t2": 4.14426, "spread": 0.0}`),
+		[]byte(`{"b0": -0.00266372, "b1": -0.00471343, "b2": 0.0568789, "b3": -0.0512324, "t1": 5.74881, "t2": 4.14426, "spread": 0.0, "units": "decimal"}`),
+		[]byte(`{"b0": -0.266372, "b1": -0.471343, "b2": 5.68789, "b3": -5.12324, "t1": 68.98572, "t2": 49.73112, "spread": 0.0, "tau_units": "months"}`),
+	}
+	for i, data := range testData {
+		ts, err := term.Parse(data)
+		if err != nil {
+			t.Fatalf("test %d: %v", i+1, err)
+		}
+		for _, m := range []float64{0.5, 2.0, 10.0, 30.0} {
+			if math.Abs(ts.Rate(m)-expected.Rate(m)) > 1e-9 {
+				t.Errorf("test %d: wrong rate at %v; got: %v, expected: %v", i+1, m, ts.Rate(m), expected.Rate(m))
+			}
+		}
+	}
+
+	_, err := term.Parse([]byte(`{"b0": 1.0, "b1": 1.0, "b2": 1.0, "b3": 1.0, "t1": 1.0, "t2": 1.0, "spread": 0.0, "units": "bps"}`))
+	if !errors.Is(err, term.ErrCurveUndefined) {
+		t.Errorf("expected ErrCurveUndefined for unknown units; got: %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package term
+
+import "math"
+
+// Pair holds the nominal and the real term structure of a market
+// (e.g. fitted to nominal bonds and to inflation-linked bonds)
+type Pair struct {
+	Nominal Structure
+	Real    Structure
+}
+
+// Breakeven returns the continuously compounded breakeven inflation rate in
+// percent for the maturity t, i.e. the nominal minus the real spot rate
+func (p *Pair) Breakeven(t float64) float64 {
+	return p.Nominal.Rate(t) - p.Real.Rate(t)
+}
+
+// Breakevens returns the breakeven inflation term structure for the maturities
+func (p *Pair) Breakevens(maturities []float64) []float64 {
+	rates := make([]float64, len(maturities))
+	for i, t := range maturities {
+		rates[i] = p.Breakeven(t)
+	}
+	return rates
+}
+
+// ForwardBreakeven returns the continuously compounded forward breakeven
+// inflation rate in percent between t1 and t2 (e.g. the 5y5y forward for 5 and 10)
+func (p *Pair) ForwardBreakeven(t1, t2 float64) float64 {
+	return 100.0 * math.Log(p.Inflation(t2)/p.Inflation(t1)) / (t2 - t1)
+}
+
+// Inflation returns the breakeven growth factor of the price index up to the
+// maturity t, i.e. the ratio of the real and the nominal discount factors
+func (p *Pair) Inflation(t float64) float64 {
+	return p.Real.Z(t) / p.Nominal.Z(t)
+}
@@ -0,0 +1,31 @@
+package term_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestPair(t *testing.T) {
+	nominal := term.NelsonSiegelSvensson{B0: 4.0, B1: -2.0, B2: 1.0, B3: 0.0, T1: 2.0, T2: 2.0}
+	real := term.Flat{R: 1.0}
+	pair := term.Pair{Nominal: &nominal, Real: &real}
+
+	maturities := []float64{1.0, 5.0, 10.0}
+	for i, b := range pair.Breakevens(maturities) {
+		expected := nominal.Rate(maturities[i]) - 1.0
+		if math.Abs(b-expected) > 1e-12 {
+			t.Errorf("wrong breakeven for %v years; got: %v, expected: %v", maturities[i], b, expected)
+		}
+		if g := pair.Inflation(maturities[i]); math.Abs(g-math.Exp(b/100.0*maturities[i])) > 1e-12 {
+			t.Errorf("wrong inflation factor for %v years; got: %v, expected: %v", maturities[i], g, math.Exp(b/100.0*maturities[i]))
+		}
+	}
+
+	// the 5y5y forward combines the 5 and 10 year breakevens
+	expected := (10.0*pair.Breakeven(10.0) - 5.0*pair.Breakeven(5.0)) / 5.0
+	if got := pair.ForwardBreakeven(5.0, 10.0); math.Abs(got-expected) > 1e-10 {
+		t.Errorf("wrong forward breakeven; got: %v, expected: %v", got, expected)
+	}
+}
@@ -5,18 +5,14 @@ import (
 	"fmt"
 )
 
-var (
-	registered = map[Structure][]string{
-		&NelsonSiegelSvensson{}: []string{"b0", "b1", "b2", "b3", "t1", "t2", "spread"},
-		&Flat{}:                 []string{"r", "spread"},
-		&Spline{}:               []string{"maturities", "discountfactors", "spread"},
-	}
-)
-
 type Initer interface {
 	Init() error
 }
 
+// Parse creates the term structure from its JSON parameters; the type is
+// given by the "type" field (e.g. "nss", "ns", "spline", "bootstrapped",
+// "flat", "periodic" or "moneymarket") or detected from the keys of the
+// registered term structures (see Register)
 func Parse(data []byte) (Structure, error) {
 	// unmarshal data into map[string]interface{}
 	anonymous := make(map[string]interface{})
@@ -24,24 +20,66 @@ func Parse(data []byte) (Structure, error) {
 	if err != nil {
 		return nil, err
 	}
-	for term, keys := range registered {
-		for _, key := range keys {
-			if _, ok := anonymous[key]; !ok {
-				goto nextTerm
-			}
+	if name, ok := anonymous["type"]; ok {
+		s, ok := name.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: type %v is not a string", ErrCurveUndefined, name)
 		}
-		err = json.Unmarshal(data, term)
+		ts, err := New(s)
 		if err != nil {
 			return nil, err
 		}
-		if toInit, ok := term.(Initer); ok {
-			if err := toInit.Init(); err != nil {
-				return term, err
-			}
+		return unmarshal(data, ts)
+	}
+	for _, e := range entries() {
+		if !hasKeys(anonymous, e.keys) {
+			continue
+		}
+		// parse into a new instance of the registered type
+		return unmarshal(data, e.factory())
+	}
+	return nil, fmt.Errorf("%w: parsing into yield curve failed", ErrCurveUndefined)
+
+}
+
+// Marshal returns the JSON parameters of the term structure with the
+// registered name of its type in the "type" field
+func Marshal(ts Structure) ([]byte, error) {
+	name, ok := nameOf(ts)
+	if !ok {
+		return nil, fmt.Errorf("%w: type %T is not registered", ErrCurveUndefined, ts)
+	}
+	data, err := json.Marshal(ts)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	fields["type"], _ = json.Marshal(name)
+	return json.Marshal(fields)
+}
+
+// unmarshal parses the data into the term structure and initializes it
+func unmarshal(data []byte, ts Structure) (Structure, error) {
+	if err := json.Unmarshal(data, ts); err != nil {
+		return nil, err
+	}
+	if toInit, ok := ts.(Initer); ok {
+		if err := toInit.Init(); err != nil {
+			return ts, err
 		}
-		return term, nil
-	nextTerm:
 	}
-	return nil, fmt.Errorf("parsing into yield curve failed")
+	return ts, nil
+}
 
+// hasKeys checks that all keys are present in the data
+func hasKeys(data map[string]interface{}, keys []string) bool {
+	for _, key := range keys {
+		if _, ok := data[key]; !ok {
+			return false
+		}
+	}
+	return true
 }
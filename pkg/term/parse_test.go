@@ -1,6 +1,8 @@
 package term_test
 
 import (
+	"errors"
+	"math"
 	"reflect"
 	"testing"
 
@@ -37,3 +39,84 @@ func TestParse(t *testing.T) {
 		}
 	}
 }
+
+func TestParse_Undefined(t *testing.T) {
+	_, err := term.Parse([]byte(" { \"unknown\": 1.0 } "))
+	if !errors.Is(err, term.ErrCurveUndefined) {
+		t.Errorf("expected ErrCurveUndefined; got: %v", err)
+	}
+}
+
+func TestParse_Independent(t *testing.T) {
+	a, err := term.Parse([]byte(" { \"r\": 1.0, \"spread\": 0.0 } "))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := term.Parse([]byte(" { \"r\": 2.0, \"spread\": 0.0 } "))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Rate(1.0) != 1.0 || b.Rate(1.0) != 2.0 {
+		t.Errorf("parsed term structures are not independent; got rates: %v, %v", a.Rate(1.0), b.Rate(1.0))
+	}
+}
+
+func TestParse_Type(t *testing.T) {
+	testData := []struct {
+		Data []byte
+		Type interface{}
+	}{
+		{
+			Data: []byte(`{"type": "nss", "b0": -0.266372, "b1": -0.471343, "b2": 5.68789, "b3": -5.12324, "t1": 5.74881, "t2": 4.14426}`),
+			Type: &term.NelsonSiegelSvensson{},
+		},
+		{
+			Data: []byte(`{"type": "ns", "b0": 1.0, "b1": -0.5, "b2": 2.0, "t1": 3.0}`),
+			Type: &term.NelsonSiegelSvensson{},
+		},
+		{
+			Data: []byte(`{"type": "bootstrapped", "maturities": [0.5, 1.0, 2.0, 5.0], "discountfactors": [0.995, 0.99, 0.978, 0.94]}`),
+			Type: &term.Spline{},
+		},
+		{
+			Data: []byte(`{"type": "Flat", "r": 1.5}`),
+			Type: &term.Flat{},
+		},
+		{
+			Data: []byte(`{"type": "periodic", "y": 2.0, "frequency": 2}`),
+			Type: &term.Periodic{},
+		},
+	}
+	for i, test := range testData {
+		ts, err := term.Parse(test.Data)
+		if err != nil {
+			t.Fatalf("test %d: %v", i+1, err)
+		}
+		if reflect.TypeOf(ts) != reflect.TypeOf(test.Type) {
+			t.Errorf("test %d: parse returned wrong type: got: %T, expected %T", i+1, ts, test.Type)
+		}
+		if z := ts.Z(3.0); math.IsNaN(z) || z <= 0.0 {
+			t.Errorf("test %d: wrong discount factor; got: %v", i+1, z)
+		}
+
+		// round trip
+		data, err := term.Marshal(ts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := term.Parse(data)
+		if err != nil {
+			t.Fatalf("test %d: %v in %s", i+1, err, data)
+		}
+		if reflect.TypeOf(parsed) != reflect.TypeOf(ts) || math.Abs(parsed.Z(3.0)-ts.Z(3.0)) > 1e-15 {
+			t.Errorf("test %d: round trip failed; got: %s", i+1, data)
+		}
+	}
+
+	if _, err := term.Parse([]byte(`{"type": "unknown", "r": 1.0, "spread": 0.0}`)); !errors.Is(err, term.ErrCurveUndefined) {
+		t.Errorf("expected ErrCurveUndefined for unknown type; got: %v", err)
+	}
+	if _, err := term.Marshal(term.NewCache(&term.Flat{})); !errors.Is(err, term.ErrCurveUndefined) {
+		t.Errorf("expected ErrCurveUndefined for unregistered type; got: %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package term
+
+import "math"
+
+// Stub is the discounting convention for the fractional first period
+type Stub int
+
+const (
+	// Compound discounts the fractional period with compound interest (ISMA)
+	Compound Stub = iota
+	// Simple discounts the fractional period with simple interest (street convention)
+	Simple
+)
+
+// Periodic represents a flat term structure of a periodically compounded yield,
+// e.g. a semi-annual yield to maturity. The time to a cash flow t in years
+// is split into whole compounding periods and the fractional first period,
+// which is discounted according to the Stub convention.
+type Periodic struct {
+	Y         float64 `json:"y"`
+	Frequency int     `json:"frequency"`
+	Stub      Stub    `json:"stub"`
+	Spread    float64 `json:"spread"`
+}
+
+// SetSpread sets the spread in bps
+func (p *Periodic) SetSpread(spread float64) Structure {
+	p.Spread = spread
+	return p
+}
+
+// DiscountFactor returns the discount factor for the maturity t with an
+// additional spread in bps
+func (p *Periodic) DiscountFactor(t, spread float64) float64 {
+	return DiscountFactor(p, t, spread)
+}
+
+// Shift returns the term structure shifted in parallel by dr in bps
+func (p *Periodic) Shift(dr float64) Structure {
+	return Shift(p, dr)
+}
+
+// Copy returns an independent copy of the term structure
+func (p *Periodic) Copy() Structure {
+	c := *p
+	return &c
+}
+
+// Rate returns the continuously compounded spot rate in percent
+func (p *Periodic) Rate(t float64) float64 {
+	if t <= 0.0 {
+		n := float64(p.Compounding())
+		return n * math.Log(1.0+p.yield()/n) * 100.0
+	}
+	return -math.Log(p.Z(t)) / t * 100.0
+}
+
+// Z returns the discount factor for the given maturity t
+func (p *Periodic) Z(t float64) float64 {
+	n := float64(p.Compounding())
+	y := p.yield() / n
+	periods := t * n
+	if p.Stub == Simple && periods > 0.0 {
+		whole := math.Floor(periods)
+		return 1.0 / ((1.0 + (periods-whole)*y) * math.Pow(1.0+y, whole))
+	}
+	return math.Pow(1.0+y, -periods)
+}
+
+// Compounding returns the number of compounding periods per year (default: 1)
+func (p *Periodic) Compounding() int {
+	if p.Frequency <= 0 {
+		return 1
+	}
+	return p.Frequency
+}
+
+// yield returns the yield including the spread as a decimal
+func (p *Periodic) yield() float64 {
+	return (p.Y + p.Spread*0.01) * 0.01
+}
@@ -0,0 +1,47 @@
+package term_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestPeriodic(t *testing.T) {
+	compound := term.Periodic{Y: 4.0, Frequency: 2, Stub: term.Compound}
+	simple := term.Periodic{Y: 4.0, Frequency: 2, Stub: term.Simple}
+
+	testData := []struct {
+		T        float64
+		Compound float64
+		Simple   float64
+	}{
+		{T: 0.0, Compound: 1.0, Simple: 1.0},
+		{T: 0.5, Compound: 1.0 / 1.02, Simple: 1.0 / 1.02},
+		{T: 0.25, Compound: math.Pow(1.02, -0.5), Simple: 1.0 / 1.01},
+		{T: 2.25, Compound: math.Pow(1.02, -4.5), Simple: 1.0 / (1.01 * math.Pow(1.02, 4))},
+		{T: 3.0, Compound: math.Pow(1.02, -6), Simple: math.Pow(1.02, -6)},
+	}
+	for _, test := range testData {
+		if z := compound.Z(test.T); math.Abs(z-test.Compound) > 1e-12 {
+			t.Errorf("wrong compound discount factor for t=%v; got: %v, expected: %v", test.T, z, test.Compound)
+		}
+		if z := simple.Z(test.T); math.Abs(z-test.Simple) > 1e-12 {
+			t.Errorf("wrong simple discount factor for t=%v; got: %v, expected: %v", test.T, z, test.Simple)
+		}
+		if test.T > 0.0 {
+			if z := math.Exp(-compound.Rate(test.T) * 0.01 * test.T); math.Abs(z-test.Compound) > 1e-12 {
+				t.Errorf("rate inconsistent with discount factor for t=%v; got: %v, expected: %v", test.T, z, test.Compound)
+			}
+		}
+	}
+
+	// spread in bps on top of the yield
+	spreaded := term.WithSpread(&compound, 100.0)
+	if z := spreaded.Z(1.0); math.Abs(z-1.0/(1.025*1.025)) > 1e-12 {
+		t.Errorf("wrong discount factor with spread; got: %v, expected: %v", z, 1.0/(1.025*1.025))
+	}
+	if compound.Spread != 0.0 {
+		t.Errorf("spread modified the base curve")
+	}
+}
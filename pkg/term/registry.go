@@ -0,0 +1,103 @@
+package term
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Factory returns a new (zero) instance of a term structure
+type Factory func() Structure
+
+// entry is a registered term structure type
+type entry struct {
+	name    string
+	keys    []string
+	factory Factory
+	// alias is only used for the type field of the JSON parameters
+	alias bool
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]entry{}
+)
+
+func init() {
+	Register("nss", []string{"b0", "b1", "b2", "b3", "t1", "t2", "spread"}, func() Structure { return &NelsonSiegelSvensson{} })
+	Register("flat", []string{"r", "spread"}, func() Structure { return &Flat{} })
+	Register("spline", []string{"maturities", "discountfactors", "spread"}, func() Structure { return &Spline{} })
+	Register("periodic", []string{"y", "frequency", "stub", "spread"}, func() Structure { return &Periodic{} })
+	Register("moneymarket", []string{"tenors", "rates", "spread"}, func() Structure { return &MoneyMarket{} })
+	// Nelson-Siegel is the Svensson model without the second hump (b3 = 0)
+	Alias("ns", "nss")
+	// bootstrapped discount factors are interpolated with cubic splines
+	Alias("bootstrapped", "spline")
+}
+
+// Register adds a term structure type under the name with the JSON keys
+// that identify its parameters, so it can be created by New and Parse
+func Register(name string, keys []string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[strings.ToLower(name)] = entry{name: strings.ToLower(name), keys: keys, factory: factory}
+}
+
+// Alias registers an alternative name for the type field of the JSON
+// parameters of a registered term structure type
+func Alias(alias, name string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if e, ok := registry[strings.ToLower(name)]; ok {
+		registry[strings.ToLower(alias)] = entry{name: strings.ToLower(alias), factory: e.factory, alias: true}
+	}
+}
+
+// New returns a new instance of the term structure type registered under the name
+func New(name string) (Structure, error) {
+	registryMu.RLock()
+	e, ok := registry[strings.ToLower(name)]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown term structure %q (available: %s)", ErrCurveUndefined, name, strings.Join(Names(), ", "))
+	}
+	return e.factory(), nil
+}
+
+// Names returns the sorted names of the registered term structure types
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// entries returns the registered types (without aliases) ordered by name
+func entries() []entry {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	list := make([]entry, 0, len(registry))
+	for _, e := range registry {
+		if !e.alias {
+			list = append(list, e)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].name < list[j].name })
+	return list
+}
+
+// nameOf returns the registered name of the type of the term structure
+func nameOf(ts Structure) (string, bool) {
+	for _, e := range entries() {
+		if reflect.TypeOf(e.factory()) == reflect.TypeOf(ts) {
+			return e.name, true
+		}
+	}
+	return "", false
+}
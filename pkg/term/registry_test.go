@@ -0,0 +1,83 @@
+package term_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// hump is a custom term structure for the registry test
+type hump struct {
+	H      float64 `json:"hump"`
+	Spread float64 `json:"spread"`
+}
+
+func (h *hump) Rate(t float64) float64 { return h.H*t*math.Exp(-t) + h.Spread*0.01 }
+func (h *hump) Z(t float64) float64    { return math.Exp(-h.Rate(t) * 0.01 * t) }
+func (h *hump) SetSpread(s float64) term.Structure {
+	h.Spread = s
+	return h
+}
+
+func (h *hump) DiscountFactor(t, spread float64) float64 {
+	return term.DiscountFactor(h, t, spread)
+}
+
+func (h *hump) Shift(dr float64) term.Structure {
+	return term.Shift(h, dr)
+}
+
+func TestRegistry(t *testing.T) {
+	for _, name := range []string{"nss", "flat", "spline", "periodic"} {
+		if _, err := term.New(name); err != nil {
+			t.Errorf("term structure %s not registered: %v", name, err)
+		}
+	}
+	if _, err := term.New("unknown"); !errors.Is(err, term.ErrCurveUndefined) {
+		t.Errorf("expected ErrCurveUndefined; got: %v", err)
+	}
+
+	term.Register("hump", []string{"hump", "spread"}, func() term.Structure { return &hump{} })
+	ts, err := term.Parse([]byte(`{"hump": 2.0, "spread": 0.0}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reflect.TypeOf(ts) != reflect.TypeOf(&hump{}) || math.Abs(ts.Rate(1.0)-2.0*math.Exp(-1.0)) > 1e-12 {
+		t.Errorf("custom term structure not parsed; got: %T %v", ts, ts)
+	}
+}
+
+func TestShift(t *testing.T) {
+	ts := term.Flat{R: 1.0, Spread: 10.0}
+	shifted := term.Shift(&ts, 25.0)
+	if math.Abs(shifted.Rate(2.0)-1.35) > 1e-12 {
+		t.Errorf("wrong shifted rate; got: %v, expected: %v", shifted.Rate(2.0), 1.35)
+	}
+	if math.Abs(shifted.Z(2.0)-math.Exp(-0.0135*2.0)) > 1e-12 {
+		t.Errorf("wrong shifted discount factor; got: %v, expected: %v", shifted.Z(2.0), math.Exp(-0.0135*2.0))
+	}
+	if z := term.DiscountFactor(&ts, 2.0, 25.0); math.Abs(z-shifted.Z(2.0)) > 1e-12 {
+		t.Errorf("wrong discount factor with spread; got: %v, expected: %v", z, shifted.Z(2.0))
+	}
+	if ts.Spread != 10.0 || ts.Rate(2.0) != 1.1 {
+		t.Errorf("base term structure modified")
+	}
+
+	// the methods of the interface
+	for _, s := range []term.Structure{
+		&ts,
+		&term.NelsonSiegelSvensson{B0: 2.0, B1: -1.0, B2: 0.5, T1: 2.0, T2: 2.0},
+		&term.Periodic{Y: 2.0, Frequency: 2},
+		&hump{H: 2.0},
+	} {
+		if z := s.Shift(25.0).Z(2.0); math.Abs(z-s.Z(2.0)*math.Exp(-0.0025*2.0)) > 1e-12 {
+			t.Errorf("%T: wrong shifted discount factor; got: %v, expected: %v", s, z, s.Z(2.0)*math.Exp(-0.0025*2.0))
+		}
+		if z := s.DiscountFactor(2.0, 25.0); math.Abs(z-s.Shift(25.0).Z(2.0)) > 1e-12 {
+			t.Errorf("%T: wrong discount factor with spread; got: %v, expected: %v", s, z, s.Shift(25.0).Z(2.0))
+		}
+	}
+}
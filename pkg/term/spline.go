@@ -1,6 +1,7 @@
 package term
 
 import (
+	"fmt"
 	"math"
 	"sort"
 
@@ -21,20 +22,49 @@ func (s *Spline) SetSpread(spread float64) Structure {
 	return s
 }
 
+// DiscountFactor returns the discount factor for the maturity t with an
+// additional spread in bps
+func (s *Spline) DiscountFactor(t, spread float64) float64 {
+	return DiscountFactor(s, t, spread)
+}
+
+// Shift returns the term structure shifted in parallel by dr in bps
+func (s *Spline) Shift(dr float64) Structure {
+	return Shift(s, dr)
+}
+
+// Copy returns a copy of the term structure; the fitted splines and the
+// data are shared as they are not modified after initialization
+func (s *Spline) Copy() Structure {
+	c := *s
+	return &c
+}
+
 // Rate returns the continuously compounded spot rate in percent
 func (s *Spline) Rate(t float64) float64 {
 	return -math.Log(s.Z(t)) / t * 100.0
 }
 
 // Z returns the discount factor for the given maturity t
+// (NaN if the spline is not initialized)
 func (s *Spline) Z(t float64) float64 {
 	if s.spline == nil {
-		panic("term structure is not properly initialized")
+		return math.NaN()
 	}
-	return s.spline.At(t) * math.Exp(s.Spread*0.0001*t)
+	return s.spline.At(t) * math.Exp(-s.Spread*0.0001*t)
 }
 
+// Init sorts the maturities and fits the cubic splines; returns an error
+// wrapping ErrCurveUndefined if the data does not match. With less than two
+// maturities the spline remains undefined (Z returns NaN).
 func (s *Spline) Init() error {
+	if len(s.Maturities) != len(s.DiscountFactors) {
+		return fmt.Errorf("%w: %d maturities but %d discount factors", ErrCurveUndefined, len(s.Maturities), len(s.DiscountFactors))
+	}
+	if len(s.Maturities) < 2 {
+		s.spline = nil
+		return nil
+	}
 	sort.Sort(s)
 	s.spline = gospline.NewCubicSpline(s.Maturities, s.DiscountFactors)
 	return nil
@@ -1,6 +1,7 @@
 package term_test
 
 import (
+	"errors"
 	"math"
 	"testing"
 
@@ -51,3 +52,29 @@ func TestSpline(t *testing.T) {
 		t.Errorf("splines do not accurately interpolte discount factors Z of yield curve; got: %v, expected: %v", sum, 0.0)
 	}
 }
+
+func TestSpline_Undefined(t *testing.T) {
+	s := term.Spline{
+		Maturities:      []float64{1.0, 2.0},
+		DiscountFactors: []float64{0.99},
+	}
+	if err := s.Init(); !errors.Is(err, term.ErrCurveUndefined) {
+		t.Errorf("expected ErrCurveUndefined for mismatched data; got: %v", err)
+	}
+	if z := s.Z(1.0); !math.IsNaN(z) {
+		t.Errorf("discount factor of uninitialized spline should be NaN; got: %v", z)
+	}
+}
+
+func TestSpline_Spread(t *testing.T) {
+	spline := term.NewSpline([]float64{1.0, 2.0, 5.0}, []float64{0.99, 0.97, 0.9}, 50.0)
+
+	// a positive spread lowers the discount factors: 0.97 * exp(-0.005 * 2)
+	if z := spline.Z(2.0); math.Abs(z-0.960348338736693) > 1e-12 {
+		t.Errorf("wrong discount factor with spread; got: %v, expected: %v", z, 0.960348338736693)
+	}
+	// and adds to the spot rates: -ln(0.97) / 2 * 100 + 0.5
+	if r := spline.Rate(2.0); math.Abs(r-2.0229603742354287) > 1e-10 {
+		t.Errorf("wrong spot rate with spread; got: %v, expected: %v", r, 2.0229603742354287)
+	}
+}
@@ -0,0 +1,121 @@
+package term
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/maturity"
+)
+
+// Step is the expected policy rate in percent from the meeting date on
+type Step struct {
+	Date time.Time
+	Rate float64
+}
+
+// Stepped represents the short end with piecewise constant overnight forward
+// rates that change at the central bank meeting dates and a long-end model
+// beyond the horizon whose forward rates continue the curve. The dates are
+// converted to maturities from the settlement date with
+// maturity.DifferenceInYears.
+type Stepped struct {
+	Settlement time.Time
+	// Overnight is the current continuously compounded overnight rate in percent
+	Overnight float64
+	// Steps are the policy rates expected after the meetings
+	Steps []Step
+	// Long is the long-end model (default: nil for the last policy rate)
+	Long Structure
+	// Horizon is the maturity in years from which the forward rates of the
+	// long-end model are used (default: 0 for the last meeting date)
+	Horizon float64
+	Spread  float64
+}
+
+// SetSpread sets the spread in bps
+func (s *Stepped) SetSpread(spread float64) Structure {
+	s.Spread = spread
+	return s
+}
+
+// DiscountFactor returns the discount factor for the maturity t with an
+// additional spread in bps
+func (s *Stepped) DiscountFactor(t, spread float64) float64 {
+	return DiscountFactor(s, t, spread)
+}
+
+// Shift returns the term structure shifted in parallel by dr in bps
+func (s *Stepped) Shift(dr float64) Structure {
+	return Shift(s, dr)
+}
+
+// Copy returns a copy of the term structure; the steps and the long-end model
+// are shared as they are only read
+func (s *Stepped) Copy() Structure {
+	c := *s
+	return &c
+}
+
+// Forward returns the overnight forward rate in percent at the maturity t
+// within the horizon (without the spread)
+func (s *Stepped) Forward(t float64) float64 {
+	rate := s.Overnight
+	for _, step := range s.sorted() {
+		if maturity.DifferenceInYears(s.Settlement, step.Date) > t {
+			break
+		}
+		rate = step.Rate
+	}
+	return rate
+}
+
+// Rate returns the continuously compounded spot rate in percent
+func (s *Stepped) Rate(t float64) float64 {
+	if t <= 0.0 {
+		return s.Forward(0.0) + s.Spread*0.01
+	}
+	return -math.Log(s.Z(t)) / t * 100.0
+}
+
+// Z returns the discount factor for the given maturity t
+func (s *Stepped) Z(t float64) float64 {
+	h := s.horizon()
+	if s.Long == nil || t <= h {
+		return math.Exp(-s.integral(t)*0.01 - s.Spread*0.0001*t)
+	}
+	return s.Z(h) * s.Long.Z(t) / s.Long.Z(h) * math.Exp(-s.Spread*0.0001*(t-h))
+}
+
+// integral returns the integral of the overnight forward rates up to t
+func (s *Stepped) integral(t float64) float64 {
+	sum, last, rate := 0.0, 0.0, s.Overnight
+	for _, step := range s.sorted() {
+		m := math.Max(maturity.DifferenceInYears(s.Settlement, step.Date), 0.0)
+		if m >= t {
+			break
+		}
+		sum += rate * (m - last)
+		last, rate = m, step.Rate
+	}
+	return sum + rate*(t-last)
+}
+
+// horizon returns the maturity from which the long-end model is used
+func (s *Stepped) horizon() float64 {
+	if s.Horizon > 0.0 || len(s.Steps) == 0 {
+		return s.Horizon
+	}
+	steps := s.sorted()
+	return math.Max(maturity.DifferenceInYears(s.Settlement, steps[len(steps)-1].Date), 0.0)
+}
+
+// sorted returns the steps ordered by date
+func (s *Stepped) sorted() []Step {
+	if sort.SliceIsSorted(s.Steps, func(i, j int) bool { return s.Steps[i].Date.Before(s.Steps[j].Date) }) {
+		return s.Steps
+	}
+	steps := append([]Step(nil), s.Steps...)
+	sort.Slice(steps, func(i, j int) bool { return steps[i].Date.Before(steps[j].Date) })
+	return steps
+}
@@ -0,0 +1,58 @@
+package term_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestStepped(t *testing.T) {
+	settlement := time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC)
+	march := time.Date(2022, 3, 17, 0, 0, 0, 0, time.UTC)
+	june := time.Date(2022, 6, 16, 0, 0, 0, 0, time.UTC)
+	long := &term.Flat{R: 2.0}
+	s := term.Stepped{
+		Settlement: settlement,
+		Overnight:  0.25,
+		// unordered steps
+		Steps: []term.Step{{Date: june, Rate: 1.0}, {Date: march, Rate: 0.5}},
+		Long:  long,
+	}
+	t1, t2 := maturity.DifferenceInYears(settlement, march), maturity.DifferenceInYears(settlement, june)
+
+	var testData = []struct {
+		T        float64
+		Expected float64
+	}{
+		{0.1, math.Exp(-0.0025 * 0.1)},
+		{0.3, math.Exp(-0.0025*t1 - 0.005*(0.3-t1))},
+		{t2, math.Exp(-0.0025*t1 - 0.005*(t2-t1))},
+		// forward rates of the long-end model beyond the last meeting
+		{2.0, math.Exp(-0.0025*t1-0.005*(t2-t1)) * math.Exp(-0.02*(2.0-t2))},
+	}
+	for _, test := range testData {
+		if z := s.Z(test.T); math.Abs(z-test.Expected) > 1e-12 {
+			t.Errorf("wrong discount factor for %v; got: %v, expected: %v", test.T, z, test.Expected)
+		}
+	}
+	if f := s.Forward(0.3); f != 0.5 {
+		t.Errorf("wrong forward rate; got: %v, expected: %v", f, 0.5)
+	}
+	if r := s.Rate(0.1); math.Abs(r-0.25) > 1e-12 {
+		t.Errorf("wrong spot rate; got: %v, expected: %v", r, 0.25)
+	}
+
+	// without a long-end model the last policy rate is kept
+	s.Long = nil
+	if expected := math.Exp(-0.0025*t1 - 0.005*(t2-t1) - 0.01*(2.0-t2)); math.Abs(s.Z(2.0)-expected) > 1e-12 {
+		t.Errorf("wrong discount factor without long end; got: %v, expected: %v", s.Z(2.0), expected)
+	}
+
+	bumped := term.WithSpread(&s, 10.0)
+	if math.Abs(bumped.Z(2.0)-s.Z(2.0)*math.Exp(-0.002)) > 1e-12 || s.Spread != 0.0 {
+		t.Errorf("wrong spread; got: %v, expected: %v", bumped.Z(2.0), s.Z(2.0)*math.Exp(-0.002))
+	}
+}
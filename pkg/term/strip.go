@@ -0,0 +1,133 @@
+package term
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Future is a money-market futures contract (e.g. a three-month Euribor or
+// SOFR future) on the simple rate of the period from Start to End
+type Future struct {
+	// Start and End of the reference period in years
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	// Price is the futures price quoted as 100 less the rate in percent
+	Price float64 `json:"price"`
+}
+
+// Rate returns the implied futures rate in percent
+func (f Future) Rate() float64 {
+	return 100.0 - f.Price
+}
+
+// Adjustment is the convexity adjustment in percent between the futures rate
+// and the forward rate of the period from start to end in years
+type Adjustment interface {
+	Adjustment(start, end float64) float64
+}
+
+// HoLee is the convexity adjustment 1/2 sigma^2 t1 t2 of the Ho-Lee model
+type HoLee struct {
+	// Sigma is the (normal) volatility of the short rate, e.g. 0.01 for 100 bps
+	Sigma float64 `json:"sigma"`
+}
+
+// Adjustment returns the convexity adjustment in percent
+func (h HoLee) Adjustment(start, end float64) float64 {
+	return 0.5 * h.Sigma * h.Sigma * start * end * 100.0
+}
+
+// Strip is a strip of money-market futures that is converted into simple
+// forward rates and discount factors for the short end of the curve
+type Strip struct {
+	Futures []Future
+	// Convexity adjusts the futures rates to forward rates (e.g. HoLee or HullWhite)
+	// (default: nil for unadjusted futures rates)
+	Convexity Adjustment
+}
+
+// Forwards returns the simple forward rates in percent of the futures
+// periods, i.e. the futures rates less the convexity adjustments
+func (s *Strip) Forwards() []float64 {
+	forwards := make([]float64, len(s.Futures))
+	for i, f := range s.Futures {
+		forwards[i] = f.Rate()
+		if s.Convexity != nil {
+			forwards[i] -= s.Convexity.Adjustment(f.Start, f.End)
+		}
+	}
+	return forwards
+}
+
+// DiscountFactors returns the maturities (the start of the first future and
+// the end of each futures period) and the discount factors chained by the
+// forward rates; the discount factor to the start of the strip is taken from
+// the short term structure (e.g. a MoneyMarket of deposit rates). Gaps
+// between the futures periods are bridged with the last forward rate and
+// overlaps are interpolated log-linearly.
+func (s *Strip) DiscountFactors(short Structure) ([]float64, []float64, error) {
+	futures := make([]Future, len(s.Futures))
+	copy(futures, s.Futures)
+	sort.SliceStable(futures, func(i, j int) bool { return futures[i].Start < futures[j].Start })
+	if len(futures) == 0 {
+		return nil, nil, fmt.Errorf("%w: no futures in strip", ErrCurveUndefined)
+	}
+	strip := Strip{Futures: futures, Convexity: s.Convexity}
+
+	t0, z0 := futures[0].Start, 1.0
+	if t0 > 0.0 {
+		z0 = short.Z(t0)
+	}
+	if t0 < 0.0 || math.IsNaN(z0) || z0 <= 0.0 {
+		return nil, nil, fmt.Errorf("%w: discount factor %v at start %v of strip", ErrCurveUndefined, z0, t0)
+	}
+	maturities, factors := []float64{t0}, []float64{z0}
+	for i, r := range strip.Forwards() {
+		f := futures[i]
+		tau := f.End - f.Start
+		last := maturities[len(maturities)-1]
+		if tau <= 0.0 || f.End <= last || 1.0+r*0.01*tau <= 0.0 {
+			return nil, nil, fmt.Errorf("%w: future from %v to %v with rate %v after %v", ErrCurveUndefined, f.Start, f.End, r, last)
+		}
+		z := interpolate(maturities, factors, f.Start) / (1.0 + r*0.01*tau)
+		maturities = append(maturities, f.End)
+		factors = append(factors, z)
+	}
+	return maturities, factors, nil
+}
+
+// Curve returns the term structure of the discount factors of the strip
+// interpolated with cubic splines from the start of the first future; the
+// deposits before the strip can be combined with Stitched
+func (s *Strip) Curve(short Structure) (*Spline, error) {
+	maturities, factors, err := s.DiscountFactors(short)
+	if err != nil {
+		return nil, err
+	}
+	curve := &Spline{Maturities: maturities, DiscountFactors: factors}
+	if err := curve.Init(); err != nil {
+		return nil, err
+	}
+	return curve, nil
+}
+
+// interpolate returns the log-linearly interpolated discount factor at t;
+// beyond the last maturity the last forward rate is extended
+func interpolate(maturities, factors []float64, t float64) float64 {
+	n := len(maturities)
+	i := sort.SearchFloat64s(maturities, t)
+	switch {
+	case i < n && maturities[i] == t:
+		return factors[i]
+	case i == 0:
+		i = 1
+	case i == n:
+		if n == 1 {
+			return factors[0]
+		}
+		i = n - 1
+	}
+	w := (t - maturities[i-1]) / (maturities[i] - maturities[i-1])
+	return math.Exp((1.0-w)*math.Log(factors[i-1]) + w*math.Log(factors[i]))
+}
@@ -0,0 +1,95 @@
+package term_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func strip() *term.Strip {
+	return &term.Strip{
+		Futures: []term.Future{
+			{Start: 0.5, End: 0.75, Price: 98.5},
+			{Start: 0.25, End: 0.5, Price: 98.8},
+			{Start: 0.8, End: 1.05, Price: 98.2},
+		},
+	}
+}
+
+func TestStrip_Forwards(t *testing.T) {
+	s := strip()
+	s.Convexity = term.HoLee{Sigma: 0.01}
+	forwards := s.Forwards()
+	for i, f := range s.Futures {
+		expected := f.Rate() - 0.5*0.0001*f.Start*f.End*100.0
+		if math.Abs(forwards[i]-expected) > 1e-12 {
+			t.Errorf("wrong forward rate for %v; got: %v, expected: %v", f.Start, forwards[i], expected)
+		}
+	}
+	if math.Abs(s.Futures[0].Rate()-1.5) > 1e-12 {
+		t.Errorf("wrong futures rate; got: %v, expected: %v", s.Futures[0].Rate(), 1.5)
+	}
+}
+
+func TestStrip_DiscountFactors(t *testing.T) {
+	short := term.Flat{R: 1.0}
+	s := strip()
+	maturities, factors, err := s.DiscountFactors(&short)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	z25 := short.Z(0.25)
+	z50 := z25 / (1.0 + 0.012*0.25)
+	z75 := z50 / (1.0 + 0.015*0.25)
+	// the gap from 0.75 to 0.8 is bridged with the last forward rate
+	z80 := z75 * math.Pow(z75/z50, 0.05/0.25)
+	z105 := z80 / (1.0 + 0.018*0.25)
+
+	var testData = []struct {
+		Maturity float64
+		Z        float64
+	}{
+		{0.25, z25},
+		{0.5, z50},
+		{0.75, z75},
+		{1.05, z105},
+	}
+	if len(maturities) != len(testData) {
+		t.Fatalf("wrong number of maturities; got: %v, expected: %v", len(maturities), len(testData))
+	}
+	curve, err := s.Curve(&short)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, test := range testData {
+		if maturities[i] != test.Maturity || math.Abs(factors[i]-test.Z) > 1e-12 {
+			t.Errorf("wrong discount factor for %v; got: %v at %v, expected: %v", test.Maturity, factors[i], maturities[i], test.Z)
+		}
+		if math.Abs(curve.Z(test.Maturity)-test.Z) > 1e-9 {
+			t.Errorf("wrong curve discount factor for %v; got: %v, expected: %v", test.Maturity, curve.Z(test.Maturity), test.Z)
+		}
+	}
+
+	// the convexity adjustment lowers the forward rates
+	s.Convexity = term.HoLee{Sigma: 0.01}
+	_, adjusted, _ := s.DiscountFactors(&short)
+	if adjusted[3] <= factors[3] {
+		t.Errorf("wrong adjusted discount factor; got: %v, expected above: %v", adjusted[3], factors[3])
+	}
+}
+
+func TestStrip_Errors(t *testing.T) {
+	var testData = []term.Strip{
+		{},
+		{Futures: []term.Future{{Start: 0.25, End: 0.25, Price: 99.0}}},
+		{Futures: []term.Future{{Start: 0.25, End: 0.75, Price: 99.0}, {Start: 0.5, End: 0.75, Price: 99.0}}},
+	}
+	for _, s := range testData {
+		if _, _, err := s.DiscountFactors(&term.Flat{R: 1.0}); !errors.Is(err, term.ErrCurveUndefined) {
+			t.Errorf("wrong error for %v; got: %v, expected: %v", s.Futures, err, term.ErrCurveUndefined)
+		}
+	}
+}
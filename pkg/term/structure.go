@@ -1,6 +1,22 @@
 package term
 
-// Structure implements the interface for the spot rate term structure of interest
+import (
+	"errors"
+	"math"
+)
+
+// ErrCurveUndefined is returned when a term structure is not (properly) defined
+var ErrCurveUndefined = errors.New("term structure undefined")
+
+// Structure implements the interface for the spot rate term structure of interest.
+//
+// Rate and Z only read the term structure; all implementations in this package
+// are safe for concurrent reads. SetSpread modifies the term structure in place
+// and must not be called while the term structure is shared across goroutines;
+// use WithSpread to bump a shared curve with copy-on-write semantics instead.
+// DiscountFactor and Shift do not modify the term structure either; term
+// structures implemented outside this package can delegate them to the
+// functions DiscountFactor and Shift.
 type Structure interface {
 
 	// Rate is the continuously compounded spot rate for the given maturity
@@ -9,6 +25,84 @@ type Structure interface {
 	// Z returns the discount factor for the given maturity
 	Z(t float64) float64
 
+	// DiscountFactor returns the discount factor for the given maturity with
+	// an additional spread (in bps)
+	DiscountFactor(t, spread float64) float64
+
+	// Shift returns the term structure shifted in parallel by dr (in bps) on
+	// top of its rates (including its spread)
+	Shift(dr float64) Structure
+
 	// SetSpread sets the risk spread (in bps) on-top of term structure
 	SetSpread(s float64) Structure
 }
+
+// Copier is implemented by term structures that can return an independent copy
+type Copier interface {
+	Copy() Structure
+}
+
+// WithSpread returns a copy of the term structure with the spread (in bps) set
+// and leaves ts unchanged, so the base curve can be shared across goroutines
+// for scenario runs.
+//
+// The two kinds of term structures treat an existing spread differently: for
+// a Copier the spread replaces the spread of ts (SetSpread on the copy), while
+// other term structures are wrapped and the spread is added on top of their
+// rates including their own spread. Both agree for term structures without a
+// spread; use Shift to add the spread on top of any term structure.
+func WithSpread(ts Structure, spread float64) Structure {
+	return copyOf(ts).SetSpread(spread)
+}
+
+// copyOf returns a copy of the term structure or wraps it if it cannot be copied
+func copyOf(ts Structure) Structure {
+	if c, ok := ts.(Copier); ok {
+		return c.Copy()
+	}
+	return &spreaded{base: ts}
+}
+
+// spreaded adds a spread on top of a term structure that cannot be copied
+type spreaded struct {
+	base   Structure
+	spread float64
+}
+
+func (s *spreaded) SetSpread(spread float64) Structure {
+	s.spread = spread
+	return s
+}
+
+func (s *spreaded) DiscountFactor(t, spread float64) float64 {
+	return DiscountFactor(s, t, spread)
+}
+
+func (s *spreaded) Shift(dr float64) Structure {
+	return Shift(s, dr)
+}
+
+func (s *spreaded) Rate(t float64) float64 {
+	return s.base.Rate(t) + s.spread*0.01
+}
+
+func (s *spreaded) Z(t float64) float64 {
+	return s.base.Z(t) * math.Exp(-s.spread*0.0001*t)
+}
+
+func (s *spreaded) Copy() Structure {
+	c := *s
+	return &c
+}
+
+// DiscountFactor returns the discount factor of the term structure for the
+// maturity t with an additional spread in bps; ts is not modified
+func DiscountFactor(ts Structure, t, spread float64) float64 {
+	return ts.Z(t) * math.Exp(-spread*0.0001*t)
+}
+
+// Shift returns the term structure shifted in parallel by dr in bps on top
+// of its rates (including its spread); ts is not modified
+func Shift(ts Structure, dr float64) Structure {
+	return &spreaded{base: ts, spread: dr}
+}
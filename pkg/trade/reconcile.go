@@ -0,0 +1,77 @@
+package trade
+
+import (
+	"math"
+
+	"github.com/konimarti/fixedincome/pkg/rounding"
+)
+
+// Statement holds the figures of the counterparty for a trade
+type Statement struct {
+	// Clean is the clean price per 100 of par (default: 0 for the price of the trade)
+	Clean float64
+	// Days is the number of accrued days (default: 0 for the days of the trade)
+	Days float64
+	// Accrued is the accrued interest amount
+	Accrued float64
+	// Amount is the settlement amount including fees
+	Amount float64
+}
+
+// Break is the difference of the counterparty's figures less the computed
+// figures of a trade, decomposed into price, accrued days and rounding
+type Break struct {
+	// Amount is the difference in the settlement amounts
+	Amount float64
+	// Accrued is the difference in the accrued interest amounts
+	Accrued float64
+	// Price is the part of the break due to a different clean price
+	Price float64
+	// Days is the part of the break due to a different number of accrued days
+	Days float64
+	// Rounding is the part of the break within the rounding of the currency
+	Rounding float64
+	// Other is the part of the break that is not explained
+	Other float64
+}
+
+// Within reports whether the break in the settlement amount is within the tolerance
+func (b Break) Within(tolerance float64) bool {
+	return math.Abs(b.Amount) <= tolerance
+}
+
+// Explained reports whether the unexplained part of the break is within the tolerance
+func (b Break) Explained(tolerance float64) bool {
+	return math.Abs(b.Other) <= tolerance
+}
+
+// Reconcile compares the settlement and accrued amounts of the trade with the
+// statement of the counterparty, where days is the number of accrued days of
+// the trade. The remainder of the break after the price and accrued days
+// components is attributed to rounding if it is within one unit of the
+// rounding of the currency of the trade.
+func Reconcile(t Trade, days float64, s Statement) Break {
+	b := Break{
+		Amount:  s.Amount - t.Amount(),
+		Accrued: s.Accrued - t.AccruedAmount(),
+	}
+	if s.Clean != 0.0 {
+		b.Price = t.Nominal * (s.Clean - t.Clean) / 100.0
+	}
+	if s.Days != 0.0 && days > 0.0 {
+		b.Days = t.Nominal * t.Accrued / days * (s.Days - days) / 100.0
+	}
+
+	residual := b.Amount - b.Price - b.Days
+	if t.Currency != "" && math.Abs(residual) <= unit(t.Currency)+1e-9 {
+		b.Rounding = residual
+	} else {
+		b.Other = residual
+	}
+	return b
+}
+
+// unit returns the smallest amount of the rounding of the currency
+func unit(currency string) float64 {
+	return math.Pow(10.0, -float64(rounding.ForCurrency(currency).Decimals))
+}
@@ -0,0 +1,84 @@
+package trade_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/trade"
+)
+
+func TestReconcile(t *testing.T) {
+	tr := trade.Trade{
+		Settlement: time.Date(2021, 4, 17, 0, 0, 0, 0, time.UTC),
+		Nominal:    100000.0,
+		Clean:      109.70,
+		Accrued:    1.25 * 319.0 / 360.0,
+		Fees:       25.0,
+		Currency:   "CHF",
+	}
+	// computed: principal 109700.00, accrued 1107.64, amount 110832.64
+	perDay := 100000.0 * 1.25 / 360.0 / 100.0
+
+	testData := []struct {
+		Name      string
+		Statement trade.Statement
+		Expected  trade.Break
+		Within    bool
+		Explained bool
+	}{
+		{
+			Name:      "matched",
+			Statement: trade.Statement{Accrued: 1107.64, Amount: 110832.64},
+			Within:    true,
+			Explained: true,
+		},
+		{
+			Name:      "rounding",
+			Statement: trade.Statement{Accrued: 1107.63, Amount: 110832.63},
+			Expected:  trade.Break{Amount: -0.01, Accrued: -0.01, Rounding: -0.01},
+			Within:    true,
+			Explained: true,
+		},
+		{
+			Name:      "price",
+			Statement: trade.Statement{Clean: 109.71, Accrued: 1107.64, Amount: 110842.64},
+			Expected:  trade.Break{Amount: 10.0, Price: 10.0},
+			Explained: true,
+		},
+		{
+			Name:      "accrued days",
+			Statement: trade.Statement{Days: 320.0, Accrued: 1111.11, Amount: 110836.11},
+			Expected:  trade.Break{Amount: 3.47, Accrued: 3.47, Days: perDay, Rounding: 3.47 - perDay},
+			Explained: true,
+		},
+		{
+			Name:      "unexplained",
+			Statement: trade.Statement{Accrued: 1107.64, Amount: 110857.64},
+			Expected:  trade.Break{Amount: 25.0, Other: 25.0},
+		},
+	}
+
+	for _, test := range testData {
+		b := trade.Reconcile(tr, 319.0, test.Statement)
+		values := [][3]interface{}{
+			{"amount", b.Amount, test.Expected.Amount},
+			{"accrued", b.Accrued, test.Expected.Accrued},
+			{"price", b.Price, test.Expected.Price},
+			{"days", b.Days, test.Expected.Days},
+			{"rounding", b.Rounding, test.Expected.Rounding},
+			{"other", b.Other, test.Expected.Other},
+		}
+		for _, v := range values {
+			if math.Abs(v[1].(float64)-v[2].(float64)) > 1e-6 {
+				t.Errorf("%s: wrong %s break; got: %v, expected: %v", test.Name, v[0], v[1], v[2])
+			}
+		}
+		if b.Within(0.01) != test.Within {
+			t.Errorf("%s: wrong tolerance check; got: %v, expected: %v", test.Name, b.Within(0.01), test.Within)
+		}
+		if b.Explained(0.01) != test.Explained {
+			t.Errorf("%s: wrong explained check; got: %v, expected: %v", test.Name, b.Explained(0.01), test.Explained)
+		}
+	}
+}
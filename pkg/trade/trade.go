@@ -0,0 +1,96 @@
+package trade
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/rounding"
+)
+
+// Side is the direction of a trade
+type Side int
+
+const (
+	// Buy is a purchase: the fees are paid on top of the invoice amount
+	Buy Side = iota
+	// Sell is a sale: the fees are deducted from the invoice amount
+	Sell
+)
+
+// Trade represents a bond trade with its economics at the settlement date
+type Trade struct {
+	// Side is the direction of the trade (default: Buy)
+	Side Side
+	// Settlement is the settlement date of the trade
+	Settlement time.Time
+	// Nominal is the face value traded
+	Nominal float64
+	// Clean is the clean price per 100 of par
+	Clean float64
+	// Accrued is the accrued interest per 100 of par
+	Accrued float64
+	// Fees are the commissions, taxes and other charges as an amount
+	Fees float64
+	// Currency is used to round the amounts (optional, see rounding.ForCurrency)
+	Currency string
+}
+
+// Principal returns the clean amount of the trade
+func (t *Trade) Principal() float64 {
+	return t.round(t.Nominal * t.Clean / 100.0)
+}
+
+// AccruedAmount returns the accrued interest amount of the trade
+func (t *Trade) AccruedAmount() float64 {
+	return t.round(t.Nominal * t.Accrued / 100.0)
+}
+
+// Invoice returns the invoice amount (principal plus accrued interest) before fees
+func (t *Trade) Invoice() float64 {
+	return t.Principal() + t.AccruedAmount()
+}
+
+// Amount returns the settlement amount including fees, i.e. the amount paid
+// for a purchase and the amount received for a sale
+func (t *Trade) Amount() float64 {
+	if t.Side == Sell {
+		return t.Invoice() - t.round(t.Fees)
+	}
+	return t.Invoice() + t.round(t.Fees)
+}
+
+func (t *Trade) round(x float64) float64 {
+	if t.Currency == "" {
+		return x
+	}
+	return rounding.ForCurrency(t.Currency).Round(x)
+}
+
+// RealizedYield returns the annually compounded yield in percent realized over
+// the holding period from the purchase to the sale, where income are the
+// coupon payments (and redemptions) received as amounts in between, e.g.
+// the bond's cash flows scaled to the nominal. Time is measured in ACT/365.
+// For a bond held to maturity, pass the redemption in the income and a sale
+// with zero nominal at the maturity date.
+func RealizedYield(buy, sell Trade, income cashflow.Cashflows) (float64, error) {
+	if !buy.Settlement.Before(sell.Settlement) {
+		return 0.0, fmt.Errorf("sale on %s is not after purchase on %s", sell.Settlement.Format("2006-01-02"), buy.Settlement.Format("2006-01-02"))
+	}
+	invested := buy.Amount()
+	if invested <= 0.0 {
+		return 0.0, fmt.Errorf("purchase amount %v is not positive", invested)
+	}
+
+	flows := cashflow.Cashflows{
+		{Date: buy.Settlement, Amount: -invested},
+		{Date: sell.Settlement, Amount: sell.Amount()},
+	}
+	for _, cf := range income {
+		if cf.Date.After(buy.Settlement) && !cf.Date.After(sell.Settlement) {
+			flows = append(flows, cashflow.Cashflow{Date: cf.Date, Amount: cf.Amount})
+		}
+	}
+	return fixedincome.Xirr(flows)
+}
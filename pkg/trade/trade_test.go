@@ -0,0 +1,100 @@
+package trade_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/trade"
+)
+
+func TestTrade(t *testing.T) {
+	testData := []struct {
+		Trade     trade.Trade
+		Principal float64
+		Accrued   float64
+		Amount    float64
+	}{
+		{
+			Trade: trade.Trade{
+				Settlement: time.Date(2021, 4, 17, 0, 0, 0, 0, time.UTC),
+				Nominal:    100000.0,
+				Clean:      109.70,
+				Accrued:    1.25 * 319.0 / 360.0,
+				Fees:       25.0,
+			},
+			Principal: 109700.0,
+			Accrued:   1107.6388888888889,
+			Amount:    110832.63888888889,
+		},
+		{
+			Trade: trade.Trade{
+				Side:       trade.Sell,
+				Settlement: time.Date(2021, 4, 17, 0, 0, 0, 0, time.UTC),
+				Nominal:    100000.0,
+				Clean:      109.70,
+				Accrued:    1.25 * 319.0 / 360.0,
+				Fees:       25.0,
+				Currency:   "CHF",
+			},
+			Principal: 109700.0,
+			Accrued:   1107.64,
+			Amount:    110782.64,
+		},
+	}
+
+	for nr, test := range testData {
+		if p := test.Trade.Principal(); math.Abs(p-test.Principal) > 1e-8 {
+			t.Errorf("test nr %d: wrong principal; got: %v, expected: %v", nr, p, test.Principal)
+		}
+		if a := test.Trade.AccruedAmount(); math.Abs(a-test.Accrued) > 1e-8 {
+			t.Errorf("test nr %d: wrong accrued amount; got: %v, expected: %v", nr, a, test.Accrued)
+		}
+		if a := test.Trade.Amount(); math.Abs(a-test.Amount) > 1e-8 {
+			t.Errorf("test nr %d: wrong settlement amount; got: %v, expected: %v", nr, a, test.Amount)
+		}
+	}
+}
+
+func TestRealizedYield(t *testing.T) {
+	buy := trade.Trade{
+		Settlement: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		Nominal:    1000.0,
+		Clean:      100.0,
+	}
+
+	// holding a 5% annual coupon bond bought and sold at par for two years
+	sell := buy
+	sell.Side = trade.Sell
+	sell.Settlement = time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)
+	income := cashflow.Cashflows{
+		{Date: time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC), Amount: 50.0},
+		{Date: time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC), Amount: 50.0},
+		// not received anymore
+		{Date: time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC), Amount: 50.0},
+	}
+	y, err := trade.RealizedYield(buy, sell, income)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(y-5.0) > 1e-4 {
+		t.Errorf("wrong realized yield; got: %v, expected: %v", y, 5.0)
+	}
+
+	// buying at 95 and selling at 100 after one year without income
+	buy.Clean = 95.0
+	sell.Settlement = time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)
+	y, err = trade.RealizedYield(buy, sell, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := (100.0/95.0 - 1.0) * 100.0; math.Abs(y-expected) > 1e-4 {
+		t.Errorf("wrong realized yield; got: %v, expected: %v", y, expected)
+	}
+
+	// sale before purchase
+	if _, err := trade.RealizedYield(sell, buy, nil); err == nil {
+		t.Errorf("expected an error for a sale before the purchase")
+	}
+}
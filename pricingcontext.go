@@ -0,0 +1,110 @@
+package fixedincome
+
+import (
+	"time"
+
+	"github.com/konimarti/fixedincome/pkg/calendar"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// DatedSecurity is a security that can be valued on an arbitrary settlement
+// date without modifying the security itself
+type DatedSecurity interface {
+	PresentValueAt(settlement time.Time, ts term.Structure) float64
+}
+
+// AccruingSecurity is a security with accrued interest on an arbitrary
+// settlement date
+type AccruingSecurity interface {
+	AccruedAt(settlement time.Time) float64
+}
+
+// Convention derives the settlement date of a trade from the trade date
+// with the business days of the calendar (see conventions.Convention)
+type Convention interface {
+	Settlement(trade time.Time, cal *calendar.Calendar) time.Time
+}
+
+// PricingContext carries the valuation date, market data and solver options
+// that are passed explicitly to the pricing functions. The same instrument
+// can thus be valued in many contexts (e.g. for different valuation dates)
+// and concurrently.
+type PricingContext struct {
+	// Settlement is the valuation (or settlement) date
+	Settlement time.Time
+	// Trade is the trade date; if set, the settlement date is derived from
+	// the trade date with the market convention and the calendar
+	Trade time.Time
+	// Calendar are the business days for the settlement
+	// (default: nil for weekends only)
+	Calendar *calendar.Calendar
+	// Convention is the market convention for the settlement of trades
+	// (default: nil for settlement on the trade date (T+0) adjusted to the
+	// following business day)
+	Convention Convention
+	// Term is the spot-rate term structure used for discounting
+	Term term.Structure
+	// Precision is the number of digits for the root finding solvers
+	// (default: 0 for the package-level Precision)
+	Precision int
+}
+
+// SettlementDate returns the settlement date of the context, i.e. Settlement
+// or the date derived from the trade date if Trade is set
+func (c *PricingContext) SettlementDate() time.Time {
+	if c.Trade.IsZero() {
+		return c.Settlement
+	}
+	cal := c.Calendar
+	if cal == nil {
+		cal = calendar.New()
+	}
+	if c.Convention == nil {
+		return cal.Adjust(c.Trade, calendar.Following)
+	}
+	return c.Convention.Settlement(c.Trade, cal)
+}
+
+// PresentValue returns the value of the security in the pricing context
+func (c *PricingContext) PresentValue(s DatedSecurity) float64 {
+	return s.PresentValueAt(c.SettlementDate(), c.Term)
+}
+
+// Accrued returns the accrued interest of the security at the settlement
+// date of the context
+func (c *PricingContext) Accrued(s AccruingSecurity) float64 {
+	return s.AccruedAt(c.SettlementDate())
+}
+
+// Irr calculates the internal rate of return of a security at the settlement date of the context
+func (c *PricingContext) Irr(investment float64, s DatedSecurity) (float64, error) {
+	return irr(investment, c.security(s), c.precision())
+}
+
+// Spread calculates the implied static (zero-volatility) spread over the
+// term structure of the context
+func (c *PricingContext) Spread(investment float64, s DatedSecurity) (float64, error) {
+	return spread(investment, c.security(s), c.Term, c.precision())
+}
+
+func (c *PricingContext) precision() int {
+	if c.Precision > 0 {
+		return c.Precision
+	}
+	return Precision
+}
+
+// security binds the settlement date of the context to the security
+func (c *PricingContext) security(s DatedSecurity) Security {
+	return &settled{s, c.SettlementDate()}
+}
+
+// settled adapts a dated security to the Security interface
+type settled struct {
+	s          DatedSecurity
+	settlement time.Time
+}
+
+func (d *settled) PresentValue(ts term.Structure) float64 {
+	return d.s.PresentValueAt(d.settlement, ts)
+}
@@ -0,0 +1,122 @@
+package fixedincome_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/calendar"
+	"github.com/konimarti/fixedincome/pkg/conventions"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestPricingContext(t *testing.T) {
+	// term structure (parameters per 2021-04-01 for CH govt bonds)
+	ts := term.NelsonSiegelSvensson{
+		B0: -0.266372,
+		B1: -0.471343,
+		B2: 5.68789,
+		B3: -5.12324,
+		T1: 5.74881,
+		T2: 4.14426,
+	}
+
+	// one instrument definition
+	instrument := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2026, 5, 28, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+		},
+		Coupon:     1.25,
+		Redemption: 100.0,
+	}
+
+	for _, date := range []time.Time{
+		time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2022, 6, 15, 0, 0, 0, 0, time.UTC),
+	} {
+		ctx := fixedincome.PricingContext{Settlement: date, Term: &ts}
+
+		// reference: rebuild the bond for the settlement date
+		ref := instrument
+		ref.Settlement = date
+
+		value := ctx.PresentValue(&instrument)
+		if math.Abs(value-ref.PresentValue(&ts)) > 1e-12 {
+			t.Errorf("wrong value for %v; got: %v, expected: %v", date, value, ref.PresentValue(&ts))
+		}
+		if math.Abs(instrument.AccruedAt(date)-ref.Accrued()) > 1e-12 {
+			t.Errorf("wrong accrued interest for %v; got: %v, expected: %v", date, instrument.AccruedAt(date), ref.Accrued())
+		}
+
+		irr, err := ctx.Irr(value, &instrument)
+		if err != nil {
+			t.Error(err)
+		}
+		refIrr, _ := fixedincome.Irr(value, &ref)
+		if math.Abs(irr-refIrr) > 1e-6 {
+			t.Errorf("wrong irr for %v; got: %v, expected: %v", date, irr, refIrr)
+		}
+
+		spread, err := ctx.Spread(value-1.0, &instrument)
+		if err != nil {
+			t.Error(err)
+		}
+		refSpread, _ := fixedincome.Spread(value-1.0, &ref, &ts)
+		if math.Abs(spread-refSpread) > 1e-4 {
+			t.Errorf("wrong spread for %v; got: %v, expected: %v", date, spread, refSpread)
+		}
+		ts.SetSpread(0.0)
+	}
+
+	// instrument is not modified
+	if !instrument.Settlement.Equal(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("pricing context modified the instrument")
+	}
+}
+
+func TestPricingContext_Settlement(t *testing.T) {
+	ts := term.Flat{R: 1.0}
+	instrument := bond.Straight{
+		Schedule: maturity.Schedule{
+			Maturity:  time.Date(2026, 5, 28, 0, 0, 0, 0, time.UTC),
+			Frequency: 1,
+		},
+		Coupon:     1.25,
+		Redemption: 100.0,
+	}
+
+	// trade on Thursday before Easter with Good Friday and Easter Monday as holidays
+	cal := calendar.New(time.Date(2021, 4, 2, 0, 0, 0, 0, time.UTC), time.Date(2021, 4, 5, 0, 0, 0, 0, time.UTC))
+	trade := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	data := []struct {
+		Context  fixedincome.PricingContext
+		Expected time.Time
+	}{
+		{fixedincome.PricingContext{Settlement: trade, Term: &ts}, trade},
+		// without a convention the trade settles T+0 on the following business day
+		{fixedincome.PricingContext{Trade: trade, Term: &ts}, trade},
+		{fixedincome.PricingContext{Trade: trade.AddDate(0, 0, 2), Term: &ts}, time.Date(2021, 4, 5, 0, 0, 0, 0, time.UTC)},
+		{fixedincome.PricingContext{Trade: trade, Term: &ts, Convention: conventions.CH}, time.Date(2021, 4, 5, 0, 0, 0, 0, time.UTC)},
+		{fixedincome.PricingContext{Trade: trade, Term: &ts, Calendar: cal, Convention: conventions.CH}, time.Date(2021, 4, 7, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range data {
+		if date := test.Context.SettlementDate(); !date.Equal(test.Expected) {
+			t.Errorf("wrong settlement date; got: %v, expected: %v", date, test.Expected)
+		}
+
+		ref := instrument
+		ref.Settlement = test.Expected
+		if value := test.Context.PresentValue(&instrument); math.Abs(value-ref.PresentValue(&ts)) > 1e-12 {
+			t.Errorf("wrong value for %v; got: %v, expected: %v", test.Expected, value, ref.PresentValue(&ts))
+		}
+		if accrued := test.Context.Accrued(&instrument); math.Abs(accrued-ref.Accrued()) > 1e-12 {
+			t.Errorf("wrong accrued interest for %v; got: %v, expected: %v", test.Expected, accrued, ref.Accrued())
+		}
+	}
+}
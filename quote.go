@@ -0,0 +1,125 @@
+package fixedincome
+
+import (
+	"fmt"
+
+	"github.com/konimarti/fixedincome/pkg/rate"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// QuoteType specifies how a security is quoted in the market
+type QuoteType int
+
+const (
+	// Price quotes the clean price per 100 of par
+	Price QuoteType = iota
+	// Yield quotes the yield-to-maturity in percent
+	Yield
+	// Discount quotes the discount rate in percent (e.g. for bills),
+	// applied to the redemption value over the years to maturity in the
+	// money market basis of the quote
+	Discount
+)
+
+var quoteTypes = map[QuoteType]string{
+	Price:    "price",
+	Yield:    "yield",
+	Discount: "discount",
+}
+
+// String returns the name of the quote type
+func (q QuoteType) String() string {
+	if name, ok := quoteTypes[q]; ok {
+		return name
+	}
+	return fmt.Sprintf("QuoteType(%d)", int(q))
+}
+
+// ParseQuoteType returns the quote type for the name ("price", "yield" or "discount")
+func ParseQuoteType(name string) (QuoteType, error) {
+	for q, n := range quoteTypes {
+		if n == name {
+			return q, nil
+		}
+	}
+	return Price, fmt.Errorf("unknown quote type %q (use price, yield or discount)", name)
+}
+
+// QuotedSecurity is a security with accrued interest and a maturity that can
+// be quoted in price, yield or discount rate
+type QuotedSecurity interface {
+	Security
+	Accrued() float64
+	Last() float64
+}
+
+// Quote is a market quote of a security
+type Quote struct {
+	// Type is the quoting convention (default: Price)
+	Type QuoteType
+	// Value is the quoted price or rate in percent
+	Value float64
+	// Compounding is the compounding frequency per year of a yield quote
+	// (default: 0 for continuous compounding as returned by Irr)
+	Compounding int
+	// Basis is the money market day count basis of a discount quote, e.g.
+	// ACT365 for markets quoting bills on 365 days (default: "" for ACT360)
+	Basis string
+}
+
+// DirtyPrice converts the quote to the dirty price of the security
+func (q Quote) DirtyPrice(s QuotedSecurity) float64 {
+	switch q.Type {
+	case Yield:
+		r := q.Value
+		if q.Compounding > 0 {
+			r = rate.Continuous(q.Value, q.Compounding)
+		}
+		return s.PresentValue(&term.Flat{R: r})
+	case Discount:
+		return redemptionValue(s) * (1.0 - q.Value/100.0*q.years(s))
+	default:
+		return q.Value + s.Accrued()
+	}
+}
+
+// CleanPrice converts the quote to the clean price of the security
+func (q Quote) CleanPrice(s QuotedSecurity) float64 {
+	if q.Type == Price {
+		return q.Value
+	}
+	return q.DirtyPrice(s) - s.Accrued()
+}
+
+// years returns the time to maturity of the security in the money market
+// basis of a discount quote (the years to maturity in the basis of the
+// security if its dates are not known)
+func (q Quote) years(s QuotedSecurity) float64 {
+	m, ok := s.(interface {
+		YearsToMaturity(basis string) (float64, error)
+	})
+	if !ok {
+		return s.Last()
+	}
+	basis := q.Basis
+	if basis == "" {
+		basis = "ACT360"
+	}
+	years, err := m.YearsToMaturity(basis)
+	if err != nil {
+		return s.Last()
+	}
+	return years
+}
+
+// redemptionValue returns the redemption amount of the security in the
+// notional base of the prices (default: the par value or 100)
+func redemptionValue(s QuotedSecurity) float64 {
+	switch v := s.(type) {
+	case interface{ RedemptionValue() float64 }:
+		return v.RedemptionValue()
+	case interface{ ParValue() float64 }:
+		return v.ParValue()
+	}
+	return 100.0
+}
@@ -0,0 +1,99 @@
+package fixedincome_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/rate"
+)
+
+func TestQuote(t *testing.T) {
+	// ISIN CH0224396983 (quote per 2021-04-01)
+	b := &bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2026, 5, 28, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+		},
+		Redemption: 100.0,
+		Coupon:     1.25,
+	}
+	irr, err := fixedincome.Irr(109.70+b.Accrued(), b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testData := []fixedincome.Quote{
+		{Type: fixedincome.Price, Value: 109.70},
+		{Type: fixedincome.Yield, Value: irr},
+		{Type: fixedincome.Yield, Value: rate.Annual(irr, 1), Compounding: 1},
+		{Type: fixedincome.Yield, Value: rate.Annual(irr, 2), Compounding: 2},
+	}
+	for nr, q := range testData {
+		if clean := q.CleanPrice(b); math.Abs(clean-109.70) > 1e-4 {
+			t.Errorf("test nr %d: wrong clean price; got: %v, expected: %v", nr, clean, 109.70)
+		}
+		if dirty := q.DirtyPrice(b); math.Abs(dirty-109.70-b.Accrued()) > 1e-4 {
+			t.Errorf("test nr %d: wrong dirty price; got: %v, expected: %v", nr, dirty, 109.70+b.Accrued())
+		}
+	}
+
+	// discount rate for a zero coupon bill with 182 days to maturity
+	bill := &bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2021, 9, 30, 0, 0, 0, 0, time.UTC),
+			Basis:      "ACT360",
+		},
+		Redemption: 100.0,
+	}
+	q := fixedincome.Quote{Type: fixedincome.Discount, Value: 2.0}
+	if expected := 100.0 * (1.0 - 0.02*182.0/360.0); math.Abs(q.CleanPrice(bill)-expected) > 1e-10 {
+		t.Errorf("wrong price for discount quote; got: %v, expected: %v", q.CleanPrice(bill), expected)
+	}
+
+	// the discount applies to the redemption value of the bill
+	bill.Redemption = 101.0
+	if expected := 101.0 * (1.0 - 0.02*182.0/360.0); math.Abs(q.CleanPrice(bill)-expected) > 1e-10 {
+		t.Errorf("wrong price for discount quote with redemption at 101; got: %v, expected: %v", q.CleanPrice(bill), expected)
+	}
+	bill.Par, bill.Factor = 1000.0, 0.5
+	if expected := 505.0 * (1.0 - 0.02*182.0/360.0); math.Abs(q.CleanPrice(bill)-expected) > 1e-10 {
+		t.Errorf("wrong price for discount quote on a par of 1000 and factor 0.5; got: %v, expected: %v", q.CleanPrice(bill), expected)
+	}
+	// a Treasury bill with 182 days to maturity quoted at a discount rate of
+	// 5.100% on ACT/360 regardless of the day count basis of the security
+	tbill := &bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2021, 9, 30, 0, 0, 0, 0, time.UTC),
+			Basis:      "ACTACT",
+		},
+		Redemption: 100.0,
+	}
+	q = fixedincome.Quote{Type: fixedincome.Discount, Value: 5.1}
+	if price := q.CleanPrice(tbill); math.Abs(price-97.421667) > 5e-7 {
+		t.Errorf("wrong price for the bill; got: %v, expected: %v", price, 97.421667)
+	}
+	// markets quoting bills on 365 days
+	q.Basis = "ACT365"
+	if expected := 100.0 * (1.0 - 0.051*182.0/365.0); math.Abs(q.CleanPrice(tbill)-expected) > 1e-10 {
+		t.Errorf("wrong price for the bill on ACT/365; got: %v, expected: %v", q.CleanPrice(tbill), expected)
+	}
+}
+
+func TestParseQuoteType(t *testing.T) {
+	for _, q := range []fixedincome.QuoteType{fixedincome.Price, fixedincome.Yield, fixedincome.Discount} {
+		parsed, err := fixedincome.ParseQuoteType(q.String())
+		if err != nil || parsed != q {
+			t.Errorf("parsing %s failed; got: %v, %v", q, parsed, err)
+		}
+	}
+	if _, err := fixedincome.ParseQuoteType("spread"); err == nil {
+		t.Errorf("expected an error for unknown quote type")
+	}
+}
@@ -1,6 +1,12 @@
 package fixedincome
 
-import "github.com/konimarti/fixedincome/pkg/term"
+import (
+	"fmt"
+
+	"github.com/konimarti/fixedincome/pkg/ad"
+	"github.com/konimarti/fixedincome/pkg/term"
+	"gonum.org/v1/gonum/mat"
+)
 
 // PVBP calculates the price value of a base point (bps)
 // dp = - p * D * dr + 0.5 * p * convex * dr^2
@@ -13,3 +19,88 @@ func PVBP(s TermSecurity, ts term.Structure) float64 {
 func InterestSensitivity(dr float64, s TermSecurity, ts term.Structure) float64 {
 	return s.Duration(ts)*dr + 0.5*s.Convexity(ts)*dr*dr
 }
+
+// Gradient returns the present value of the security and its exact gradient
+// with respect to the parameters of the term structure (see ts.Parameters()),
+// computed in a single pass with algorithmic differentiation
+func Gradient(s CashflowSecurity, ts term.Differentiable) (float64, []float64) {
+	pv := ad.Constant(0.0, len(ts.Parameters()))
+	for _, cf := range s.Cashflows() {
+		pv = ad.Add(pv, ad.Scale(ts.ZDual(cf.T), cf.Amount))
+	}
+	return pv.Value, pv.Grad
+}
+
+// DV01 returns the exact change in value for a parallel increase of the
+// continuously compounded spot rates by one basis point
+func DV01(s CashflowSecurity, ts term.Structure) float64 {
+	_, grad := Gradient(s, term.NewKeyRates(ts, []float64{1.0}))
+	return grad[0]
+}
+
+// KeyRateDurations calculates the durations for the key-rate tenors with the
+// same sign convention as Duration (dP/P = D_i * dr_i). The key-rate durations
+// add up to the duration of the security.
+func KeyRateDurations(s CashflowSecurity, ts term.Structure, tenors []float64) []float64 {
+	pv, grad := Gradient(s, term.NewKeyRates(ts, tenors))
+	durations := make([]float64, len(grad))
+	if pv == 0.0 {
+		return durations
+	}
+	for i, g := range grad {
+		durations[i] = g / 0.0001 / pv
+	}
+	return durations
+}
+
+// ParameterSensitivities returns the exact sensitivities dP/dp of the present
+// value of the security to the parameters p of the term structure by name,
+// e.g. "b0" to "t2" of the Nelson-Siegel-Svensson model
+func ParameterSensitivities(s CashflowSecurity, ts term.Differentiable) map[string]float64 {
+	_, grad := Gradient(s, ts)
+	sensitivities := make(map[string]float64, len(grad))
+	for i, name := range ts.Parameters() {
+		sensitivities[name] = grad[i]
+	}
+	return sensitivities
+}
+
+// ParameterHedge returns the quantities of the hedge securities that offset
+// the sensitivities of the security to the given parameters of the term
+// structure (least squares if there are more parameters than hedges)
+func ParameterHedge(s CashflowSecurity, hedges []CashflowSecurity, ts term.Differentiable, parameters []string) ([]float64, error) {
+	index := map[string]int{}
+	for i, name := range ts.Parameters() {
+		index[name] = i
+	}
+	rows := make([]int, len(parameters))
+	for i, name := range parameters {
+		j, ok := index[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown parameter %q of the term structure", name)
+		}
+		rows[i] = j
+	}
+	if len(hedges) == 0 || len(hedges) > len(parameters) {
+		return nil, fmt.Errorf("%d hedges for %d parameters", len(hedges), len(parameters))
+	}
+
+	// solve A x = -b with the hedge sensitivities in the columns of A
+	a := mat.NewDense(len(parameters), len(hedges), nil)
+	for j, h := range hedges {
+		_, grad := Gradient(h, ts)
+		for i, row := range rows {
+			a.Set(i, j, grad[row])
+		}
+	}
+	_, grad := Gradient(s, ts)
+	b := mat.NewVecDense(len(parameters), nil)
+	for i, row := range rows {
+		b.SetVec(i, -grad[row])
+	}
+	var x mat.VecDense
+	if err := x.SolveVec(a, b); err != nil {
+		return nil, fmt.Errorf("parameter hedge: %w", err)
+	}
+	return x.RawVector().Data, nil
+}
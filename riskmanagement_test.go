@@ -38,3 +38,138 @@ func TestInterestSensitivity(t *testing.T) {
 		t.Errorf("pvbp calculation failed; got: %v, expected: %v", pvbp, pvbpRef)
 	}
 }
+
+func TestGradient(t *testing.T) {
+	bond := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2026, 5, 28, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+		},
+		Coupon:     1.25,
+		Redemption: 100.0,
+	}
+	ts := term.NelsonSiegelSvensson{
+		B0: -0.266372,
+		B1: -0.471343,
+		B2: 5.68789,
+		B3: -5.12324,
+		T1: 5.74881,
+		T2: 4.14426,
+	}
+
+	pv, grad := fixedincome.Gradient(&bond, &ts)
+	if math.Abs(pv-bond.PresentValue(&ts)) > 1e-9 {
+		t.Errorf("wrong present value; got: %v, expected: %v", pv, bond.PresentValue(&ts))
+	}
+
+	// compare with central differences of the present value in each parameter
+	params := []*float64{&ts.B0, &ts.B1, &ts.B2, &ts.B3, &ts.T1, &ts.T2, &ts.Spread}
+	h := 1e-6
+	for i, p := range params {
+		*p += h
+		up := bond.PresentValue(&ts)
+		*p -= 2 * h
+		down := bond.PresentValue(&ts)
+		*p += h
+		expected := (up - down) / (2 * h)
+		if math.Abs(grad[i]-expected) > 1e-5 {
+			t.Errorf("wrong derivative for %s; got: %v, expected: %v", ts.Parameters()[i], grad[i], expected)
+		}
+	}
+}
+
+func TestKeyRateDurations(t *testing.T) {
+	bond := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+			Basis:      "30E360",
+		},
+		Coupon:     2.0,
+		Redemption: 100.0,
+	}
+	ts := term.Flat{R: 2.0}
+
+	krd := fixedincome.KeyRateDurations(&bond, &ts, []float64{2.0, 5.0, 10.0})
+	sum := 0.0
+	for _, d := range krd {
+		sum += d
+	}
+	if math.Abs(sum-bond.Duration(&ts)) > 1e-9 {
+		t.Errorf("key-rate durations do not add up to duration; got: %v, expected: %v", sum, bond.Duration(&ts))
+	}
+
+	// redemption in 10y dominates the 10y key rate
+	if krd[2] > krd[0] || krd[2] > krd[1] {
+		t.Errorf("10y key-rate duration should be the largest; got: %v", krd)
+	}
+
+	dv01 := fixedincome.DV01(&bond, &ts)
+	expected := bond.Duration(&ts) * bond.PresentValue(&ts) * 0.0001
+	if math.Abs(dv01-expected) > 1e-9 {
+		t.Errorf("wrong dv01; got: %v, expected: %v", dv01, expected)
+	}
+}
+
+func TestParameterSensitivities(t *testing.T) {
+	settlement := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	straight := func(years int, coupon float64) *bond.Straight {
+		return &bond.Straight{
+			Schedule:   maturity.Schedule{Settlement: settlement, Maturity: settlement.AddDate(years, 0, 0), Frequency: 1},
+			Coupon:     coupon,
+			Redemption: 100.0,
+		}
+	}
+	ts := term.NelsonSiegelSvensson{B0: -0.266372, B1: -0.471343, B2: 5.68789, B3: -5.12324, T1: 5.74881, T2: 4.14426}
+
+	b := straight(7, 1.5)
+	sensitivities := fixedincome.ParameterSensitivities(b, &ts)
+	for _, name := range ts.Parameters() {
+		bumped := ts
+		h := 1e-6
+		switch name {
+		case "b0":
+			bumped.B0 += h
+		case "b1":
+			bumped.B1 += h
+		case "b2":
+			bumped.B2 += h
+		case "b3":
+			bumped.B3 += h
+		case "t1":
+			bumped.T1 += h
+		case "t2":
+			bumped.T2 += h
+		case "spread":
+			bumped.Spread += h
+		}
+		// forward difference of the present value in the parameter
+		expected := (b.PresentValue(&bumped) - b.PresentValue(&ts)) / h
+		if math.Abs(sensitivities[name]-expected) > 1e-4 {
+			t.Errorf("wrong sensitivity to %s; got: %v, expected: %v", name, sensitivities[name], expected)
+		}
+	}
+
+	// hedge the level, slope and curvature parameters with 2, 5 and 10 year bonds
+	hedges := []fixedincome.CashflowSecurity{straight(2, 0.5), straight(5, 1.0), straight(10, 2.0)}
+	parameters := []string{"b0", "b1", "b2"}
+	quantities, err := fixedincome.ParameterHedge(b, hedges, &ts, parameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range parameters {
+		total := sensitivities[name]
+		for i, h := range hedges {
+			total += quantities[i] * fixedincome.ParameterSensitivities(h, &ts)[name]
+		}
+		if math.Abs(total) > 1e-8 {
+			t.Errorf("hedged sensitivity to %s is not zero; got: %v", name, total)
+		}
+	}
+
+	if _, err := fixedincome.ParameterHedge(b, hedges, &ts, []string{"b0", "x"}); err == nil {
+		t.Errorf("expected error for unknown parameter")
+	}
+}
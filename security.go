@@ -1,6 +1,9 @@
 package fixedincome
 
-import "github.com/konimarti/fixedincome/pkg/term"
+import (
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
 
 type Security interface {
 	PresentValue(ts term.Structure) float64
@@ -12,6 +15,16 @@ type TermSecurity interface {
 	Convexity(ts term.Structure) float64
 }
 
+type CashflowSecurity interface {
+	Security
+	Cashflows() cashflow.Cashflows
+}
+
+// Validator is implemented by securities that can check their terms before pricing
+type Validator interface {
+	Validate() error
+}
+
 type Option interface {
 	Security
 	SetVola(float64)
@@ -0,0 +1,79 @@
+package fixedincome
+
+import (
+	"fmt"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Benchmark is the yield to maturity in percent of an on-the-run benchmark
+// bond with the given years to maturity
+type Benchmark struct {
+	Maturity float64
+	Yield    float64
+}
+
+// YieldSpread calculates the simple yield spread in bps of the security over
+// the yield to maturity of a benchmark (in percent)
+func YieldSpread(investment float64, s Security, benchmark float64) (float64, error) {
+	y, err := Irr(investment, s)
+	if err != nil {
+		return 0.0, err
+	}
+	return (y - benchmark) * 100.0, nil
+}
+
+// BenchmarkSpread calculates the simple yield spread in bps of the security
+// over the benchmark security, both given for their dirty prices
+func BenchmarkSpread(investment float64, s Security, benchmarkInvestment float64, benchmark Security) (float64, error) {
+	y, err := Irr(benchmarkInvestment, benchmark)
+	if err != nil {
+		return 0.0, fmt.Errorf("benchmark yield: %w", err)
+	}
+	return YieldSpread(investment, s, y)
+}
+
+// InterpolatedSpread calculates the yield spread in bps of the security with
+// the given years to maturity over the benchmark yield linearly interpolated
+// between two on-the-run benchmarks (flat extrapolation outside)
+func InterpolatedSpread(investment float64, s Security, maturity float64, short, long Benchmark) (float64, error) {
+	if short.Maturity > long.Maturity {
+		short, long = long, short
+	}
+	benchmark := short.Yield
+	switch {
+	case maturity >= long.Maturity:
+		benchmark = long.Yield
+	case maturity > short.Maturity:
+		w := (maturity - short.Maturity) / (long.Maturity - short.Maturity)
+		benchmark = (1.0-w)*short.Yield + w*long.Yield
+	}
+	return YieldSpread(investment, s, benchmark)
+}
+
+// BreakevenInflation calculates the breakeven inflation rate in percent, i.e.
+// the yield to maturity of the nominal bond minus the real yield to maturity of
+// the inflation-linked bond (for its real dirty price)
+func BreakevenInflation(realInvestment float64, linker Security, nominalInvestment float64, nominal Security) (float64, error) {
+	n, err := Irr(nominalInvestment, nominal)
+	if err != nil {
+		return 0.0, fmt.Errorf("nominal yield: %w", err)
+	}
+	r, err := Irr(realInvestment, linker)
+	if err != nil {
+		return 0.0, fmt.Errorf("real yield: %w", err)
+	}
+	return n - r, nil
+}
+
+// BreakevenSpread calculates the relative value in bps of the inflation-linked
+// bond versus the nominal bond as the market breakeven inflation minus the
+// breakeven of the curve pair at the maturity in years (positive if the linker
+// is rich versus the curves, i.e. its real yield is below the real curve)
+func BreakevenSpread(realInvestment float64, linker Security, nominalInvestment float64, nominal Security, pair *term.Pair, maturity float64) (float64, error) {
+	b, err := BreakevenInflation(realInvestment, linker, nominalInvestment, nominal)
+	if err != nil {
+		return 0.0, err
+	}
+	return (b - pair.Breakeven(maturity)) * 100.0, nil
+}
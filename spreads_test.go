@@ -0,0 +1,92 @@
+package fixedincome_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestYieldSpread(t *testing.T) {
+	settlement := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	corporate := bond.Straight{
+		Schedule:   maturity.Schedule{Settlement: settlement, Maturity: settlement.AddDate(7, 0, 0), Frequency: 1},
+		Redemption: 100.0,
+		Coupon:     3.0,
+	}
+	govt := bond.Straight{
+		Schedule:   maturity.Schedule{Settlement: settlement, Maturity: settlement.AddDate(5, 0, 0), Frequency: 1},
+		Redemption: 100.0,
+		Coupon:     1.0,
+	}
+	dirty := corporate.PresentValue(&term.Flat{R: 2.5})
+	benchmark := govt.PresentValue(&term.Flat{R: 1.0})
+
+	spread, err := fixedincome.YieldSpread(dirty, &corporate, 1.0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(spread-150.0) > 1e-2 {
+		t.Errorf("wrong yield spread; got: %v, expected: %v", spread, 150.0)
+	}
+
+	spread, err = fixedincome.BenchmarkSpread(dirty, &corporate, benchmark, &govt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(spread-150.0) > 1e-2 {
+		t.Errorf("wrong benchmark spread; got: %v, expected: %v", spread, 150.0)
+	}
+
+	testData := []struct {
+		Maturity float64
+		Expected float64
+	}{
+		{Maturity: 7.0, Expected: 150.0 - 40.0}, // 1.0% at 5y, 2.0% at 10y
+		{Maturity: 2.0, Expected: 150.0},
+		{Maturity: 12.0, Expected: 150.0 - 100.0},
+	}
+	five, ten := fixedincome.Benchmark{Maturity: 5.0, Yield: 1.0}, fixedincome.Benchmark{Maturity: 10.0, Yield: 2.0}
+	for _, test := range testData {
+		spread, err := fixedincome.InterpolatedSpread(dirty, &corporate, test.Maturity, ten, five)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(spread-test.Expected) > 1e-2 {
+			t.Errorf("wrong interpolated spread at %v years; got: %v, expected: %v", test.Maturity, spread, test.Expected)
+		}
+	}
+}
+
+func TestBreakevenInflation(t *testing.T) {
+	settlement := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	schedule := maturity.Schedule{Settlement: settlement, Maturity: settlement.AddDate(10, 0, 0), Frequency: 1}
+	linker := bond.Straight{Schedule: schedule, Redemption: 100.0, Coupon: 0.5}
+	nominal := bond.Straight{Schedule: schedule, Redemption: 100.0, Coupon: 2.0}
+	pair := term.Pair{Nominal: &term.Flat{R: 2.5}, Real: &term.Flat{R: 0.25}}
+
+	// priced on the curve pair: breakeven of 2.25% and no relative value
+	real := linker.PresentValue(pair.Real)
+	dirty := nominal.PresentValue(pair.Nominal)
+	b, err := fixedincome.BreakevenInflation(real, &linker, dirty, &nominal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(b-2.25) > 1e-4 {
+		t.Errorf("wrong breakeven inflation; got: %v, expected: %v", b, 2.25)
+	}
+
+	// linker 10 bps rich in real yield
+	rich := linker.PresentValue(&term.Flat{R: 0.15})
+	spread, err := fixedincome.BreakevenSpread(rich, &linker, dirty, &nominal, &pair, linker.Last())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(spread-10.0) > 1e-2 {
+		t.Errorf("wrong breakeven spread; got: %v, expected: %v", spread, 10.0)
+	}
+}
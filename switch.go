@@ -0,0 +1,73 @@
+package fixedincome
+
+import (
+	"math"
+
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+// Switch is a proposed trade that sells a nominal of one security and buys
+// a nominal of another security
+type Switch struct {
+	Sell        Priced
+	SellNominal float64
+	Buy         Priced
+	BuyNominal  float64
+}
+
+// SwitchAnalysis are the analytics of a switch; amounts are in the currency
+// of the nominals
+type SwitchAnalysis struct {
+	Comparison
+	// YieldPickup is the yield of the bought less the yield of the sold security in bps
+	YieldPickup float64
+	// DurationChange is the change of the modified duration in years
+	DurationChange float64
+	// DV01Change is the change of the value of a basis point of the position
+	DV01Change float64
+	// Proceeds is the cash of the sale less the cost of the purchase
+	// (dirty prices, positive if the switch releases cash)
+	Proceeds float64
+	// CarryChange is the change of the carry of the position over the horizon
+	CarryChange float64
+	// BreakevenSpread is the widening of the spread of the bought security
+	// relative to the sold security in bps over the horizon that offsets the
+	// change of the carry
+	BreakevenSpread float64
+}
+
+// Analyze returns the analytics of the switch with the carry over the horizon in years
+func (s *Switch) Analyze(ts term.Structure, horizon float64) (SwitchAnalysis, error) {
+	c, err := Compare(s.Sell, s.Buy, ts, horizon)
+	if err != nil {
+		return SwitchAnalysis{}, err
+	}
+	a := SwitchAnalysis{
+		Comparison:     c,
+		YieldPickup:    c.Difference.Yield * 100.0,
+		DurationChange: c.Difference.Duration,
+	}
+	sell := s.SellNominal / par(s.Sell.Security)
+	buy := s.BuyNominal / par(s.Buy.Security)
+	a.Proceeds = sell*c.First.Price - buy*c.Second.Price
+	a.DV01Change = dv01(buy, c.Second) - dv01(sell, c.First)
+	a.CarryChange = buy*c.Second.Carry - sell*c.First.Carry
+	if dv := dv01(buy, c.Second); dv != 0.0 {
+		a.BreakevenSpread = a.CarryChange / dv
+	}
+	return a, nil
+}
+
+// dv01 returns the value of a basis point of the units of the security
+func dv01(units float64, a Analytics) float64 {
+	return units * a.Price * math.Abs(a.Duration) * 0.0001
+}
+
+// par returns the notional base of the prices of the security (100 unless
+// the security has a different par value, e.g. bond.Straight.Par)
+func par(s Security) float64 {
+	if p, ok := s.(interface{ ParValue() float64 }); ok {
+		return p.ParValue()
+	}
+	return 100.0
+}
@@ -0,0 +1,62 @@
+package fixedincome_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/instrument/bond"
+	"github.com/konimarti/fixedincome/pkg/maturity"
+	"github.com/konimarti/fixedincome/pkg/term"
+)
+
+func TestSwitch_Analyze(t *testing.T) {
+	settlement := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	sell := bond.Straight{
+		Schedule:   maturity.Schedule{Settlement: settlement, Maturity: settlement.AddDate(5, 0, 0), Frequency: 1},
+		Redemption: 100.0,
+		Coupon:     1.0,
+	}
+	buy := bond.Straight{
+		Schedule:   maturity.Schedule{Settlement: settlement, Maturity: settlement.AddDate(7, 0, 0), Frequency: 1},
+		Redemption: 100.0,
+		Coupon:     2.0,
+	}
+	ts := term.Flat{R: 1.0}
+	s := fixedincome.Switch{
+		Sell:        fixedincome.Priced{Security: &sell},
+		SellNominal: 1e6,
+		Buy:         fixedincome.Priced{Security: &buy, Price: buy.PresentValue(&term.Flat{R: 1.2})},
+		BuyNominal:  8e5,
+	}
+
+	a, err := s.Analyze(&ts, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sellDV01 := 1e4 * a.First.Price * math.Abs(a.First.Duration) * 0.0001
+	buyDV01 := 8e3 * a.Second.Price * math.Abs(a.Second.Duration) * 0.0001
+
+	var testData = []struct {
+		Name     string
+		Value    float64
+		Expected float64
+	}{
+		{"yield pickup", a.YieldPickup, 20.0},
+		{"duration change", a.DurationChange, buy.Duration(&term.Flat{R: 1.2}) - sell.Duration(&ts)},
+		{"proceeds", a.Proceeds, 1e4*sell.PresentValue(&ts) - 8e3*a.Second.Price},
+		{"dv01 change", a.DV01Change, buyDV01 - sellDV01},
+		{"carry change", a.CarryChange, 8e3 * a.Second.Carry},
+		{"breakeven spread", a.BreakevenSpread, 8e3 * a.Second.Carry / buyDV01},
+	}
+	for _, test := range testData {
+		if math.Abs(test.Value-test.Expected) > 1e-3 {
+			t.Errorf("wrong %s; got: %v, expected: %v", test.Name, test.Value, test.Expected)
+		}
+	}
+	// the buy has a positive carry over the sold bond at the funding rate
+	if a.BreakevenSpread <= 0.0 || a.Proceeds <= 0.0 {
+		t.Errorf("wrong switch analysis; got: %v", a)
+	}
+}
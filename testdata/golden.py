@@ -0,0 +1,165 @@
+#!/usr/bin/env python3
+"""Reference values for golden.json.
+
+The expected accrued interest, yields, clean prices and spreads are computed
+here independently of the Go code from the conventions of each bond: coupon
+dates rolled back from the maturity date, accrued interest with the day count
+of the market and prices discounted at continuously compounded rates. The
+quotes and curves are inputs. Run `python3 testdata/golden.py > testdata/golden.json`
+after adding an entry.
+
+The corpus checks the library against this second implementation of the same
+conventions; it is not validated against prices, yields or accrued interest
+published by issuers or exchanges.
+"""
+import calendar
+import datetime
+import json
+import math
+
+D = datetime.date
+
+
+def last_of_february(d):
+    return d.month == 2 and d.day == calendar.monthrange(d.year, 2)[1]
+
+
+def days_30e360(a, b):
+    d1 = 30 if a.day == 31 or last_of_february(a) else a.day
+    d2 = 30 if b.day == 31 or last_of_february(b) else b.day
+    return 360 * (b.year - a.year) + 30 * (b.month - a.month) + (d2 - d1)
+
+
+def days_bondbasis(a, b):
+    d1 = 30 if a.day == 31 else a.day
+    d2 = 30 if b.day == 31 and d1 >= 30 else b.day
+    return 360 * (b.year - a.year) + 30 * (b.month - a.month) + (d2 - d1)
+
+
+def days_actual(a, b):
+    return (b - a).days
+
+
+DAYS = {"30E360": days_30e360, "BONDBASIS": days_bondbasis, "ACTACT": days_actual}
+
+
+def add_year(d):
+    # 29 February rolls over to 1 March
+    try:
+        return d.replace(year=d.year + 1)
+    except ValueError:
+        return D(d.year + 1, 3, 1)
+
+
+def fraction(basis, start, end, following):
+    """Accrued fraction of the period from start to following at end."""
+    days = DAYS[basis]
+    return days(start, end) / days(start, following)
+
+
+def coupon_date(maturity, k, step):
+    """k-th coupon date before maturity with step months between coupons."""
+    m = maturity.month - k * step
+    year, month = maturity.year + (m - 1) // 12, (m - 1) % 12 + 1
+    return D(year, month, min(maturity.day, calendar.monthrange(year, month)[1]))
+
+
+def bond(settlement, maturity, coupon, frequency, basis, redemption=100.0):
+    """Accrued interest and cash flows (time in years, amount) per 100 of par."""
+    step, dates, k, current = 12 // frequency, [], 0, maturity
+    while current > settlement:
+        dates.append(current)
+        k += 1
+        current = coupon_date(maturity, k, step)
+    accrued = coupon * fraction(basis, current, settlement, dates[-1]) / frequency
+    cashflows = []
+    for d in dates:
+        t = fraction(basis, settlement, d, add_year(settlement))
+        cashflows.append((t, coupon / frequency + (redemption if d == maturity else 0.0)))
+    return accrued, cashflows
+
+
+def pv(cashflows, z):
+    return sum(amount * z(t) for t, amount in cashflows)
+
+
+def solve(f, lo, hi):
+    """Root of f by bisection."""
+    flo = f(lo)
+    for _ in range(200):
+        mid = (lo + hi) / 2.0
+        fmid = f(mid)
+        if (fmid > 0.0) == (flo > 0.0):
+            lo, flo = mid, fmid
+        else:
+            hi = mid
+    return (lo + hi) / 2.0
+
+
+def nss(p, spread=0.0):
+    """Discount factors of the Nelson-Siegel-Svensson spot rates in percent plus spread in bps."""
+    def rate(m):
+        m = m or 1e-7
+        e1, e2 = math.exp(-m / p["t1"]), math.exp(-m / p["t2"])
+        f1 = (1.0 - e1) * p["t1"] / m
+        return (p["b0"] + p["b1"] * f1 + p["b2"] * (f1 - e1)
+                + p["b3"] * ((1.0 - e2) * p["t2"] / m - e2) + spread * 0.01)
+    return lambda m: math.exp(-rate(m) * 0.01 * m)
+
+
+def flat(r, spread=0.0):
+    """Discount factors of a flat rate in percent plus spread in bps."""
+    return lambda m: math.exp(-(r + spread * 0.01) * 0.01 * m)
+
+
+NSS = {"b0": -0.266372, "b1": -0.471343, "b2": 5.68789, "b3": -5.12324, "t1": 5.74881, "t2": 4.14426}
+
+ENTRIES = [
+    dict(isin="CH0224396983", description="1.25% Swiss Confederation bond 2026",
+         source="quote per 2021-04-01; NSS parameters per 2021-04-01 for CH govt bonds (SNB)",
+         settlement="2021-04-01", maturity="2026-05-28", coupon=1.25, frequency=1, basis="30E360",
+         quote=109.70, term=dict(NSS, spread=0.0)),
+    dict(isin="CH0193265995", description="1.00% Swiss Confederation bond 2022",
+         source="quote per 2021-04-16; NSS parameters per 2021-04-01 for CH govt bonds (SNB)",
+         settlement="2021-04-15", maturity="2022-09-21", coupon=1.00, frequency=1, basis="30E360",
+         quote=102.22, term=dict(NSS, spread=0.0)),
+    dict(isin="", description="6.25% German Bund 2030-01-04",
+         source="terms of the Bund (annual, ACT/ACT ICMA); illustrative quote and flat curve",
+         settlement="2021-04-01", maturity="2030-01-04", coupon=6.25, frequency=1, basis="ACTACT",
+         quote=151.40, term={"type": "flat", "r": -0.30, "spread": 0.0}),
+    dict(isin="", description="1.50% US Treasury note 2030-02-15",
+         source="terms of the note (semi-annual, ACT/ACT); illustrative quote and flat curve",
+         settlement="2021-04-01", maturity="2030-02-15", coupon=1.50, frequency=2, basis="ACTACT",
+         quote=97.25, term={"type": "flat", "r": 1.70, "spread": 0.0}),
+    dict(isin="", description="3.85% Apple Inc. senior note 2043-05-04",
+         source="terms of the note (semi-annual, 30/360); illustrative quote and flat curve",
+         settlement="2021-04-01", maturity="2043-05-04", coupon=3.85, frequency=2, basis="BONDBASIS",
+         quote=112.60, term={"type": "flat", "r": 2.40, "spread": 0.0}),
+]
+
+
+def curve(term, spread):
+    if term.get("type") == "flat":
+        return flat(term["r"], spread)
+    return nss(term, spread)
+
+
+def expected(e):
+    settle, mat = D.fromisoformat(e["settlement"]), D.fromisoformat(e["maturity"])
+    acc, cfs = bond(settle, mat, e["coupon"], e["frequency"], e["basis"])
+    dirty = e["quote"] + acc
+    return {
+        "price": {"value": round(pv(cfs, curve(e["term"], 0.0)) - acc, 8), "tolerance": 1e-4},
+        "accrued": {"value": round(acc, 10), "tolerance": 1e-8},
+        "yield": {"value": round(solve(lambda y: pv(cfs, flat(y)) - dirty, -20.0, 20.0), 8), "tolerance": 1e-4},
+        "spread": {"value": round(solve(lambda s: pv(cfs, curve(e["term"], s)) - dirty, -1000.0, 1000.0), 6), "tolerance": 1e-3},
+    }
+
+
+if __name__ == "__main__":
+    corpus = []
+    for e in ENTRIES:
+        entry = dict(e, redemption=100.0)
+        entry["expected"] = expected(e)
+        corpus.append(entry)
+    print(json.dumps(corpus, indent=2))
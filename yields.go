@@ -1,7 +1,14 @@
 package fixedincome
 
 import (
+	"errors"
+	"fmt"
+	"math"
+
 	"github.com/khezen/rootfinding"
+	"github.com/konimarti/fixedincome/pkg/calendar"
+	"github.com/konimarti/fixedincome/pkg/cashflow"
+	"github.com/konimarti/fixedincome/pkg/logging"
 	"github.com/konimarti/fixedincome/pkg/term"
 )
 
@@ -9,25 +16,102 @@ var (
 	Precision = 6
 )
 
+// ErrNoConvergence is returned when a solver does not find a solution
+var ErrNoConvergence = errors.New("solver did not converge")
+
 // Irr calculates the internal rate of return of a security
 func Irr(investment float64, s Security) (float64, error) {
+	if err := validate(s); err != nil {
+		return 0.0, err
+	}
+	return irr(investment, s, Precision)
+}
+
+func irr(investment float64, s Security, precision int) (float64, error) {
 	f := func(irr float64) float64 {
 		return s.PresentValue(&term.Flat{irr, 0.0}) - investment
 	}
 
-	root, err := rootfinding.Brent(f, -20.0, 20.0, Precision)
-	return root, err
+	return solve(f, -20.0, 20.0, precision)
+}
+
+// Xirr calculates the annually compounded internal rate of return in percent
+// of dated cash flows (negative amounts for investments, positive amounts for
+// income) with the time measured in ACT/365 from the earliest date;
+// the times T of the cash flows are ignored
+func Xirr(flows cashflow.Cashflows) (float64, error) {
+	if len(flows) == 0 {
+		return 0.0, fmt.Errorf("%w: no cash flows", ErrNoConvergence)
+	}
+	start := flows[0].Date
+	positive, negative := false, false
+	for _, cf := range flows {
+		if cf.Date.Before(start) {
+			start = cf.Date
+		}
+		positive = positive || cf.Amount > 0.0
+		negative = negative || cf.Amount < 0.0
+	}
+	if !positive || !negative {
+		return 0.0, fmt.Errorf("%w: cash flows do not change sign", ErrNoConvergence)
+	}
+
+	years := make([]float64, len(flows))
+	for i, cf := range flows {
+		years[i] = cf.Date.Sub(start).Hours() / 24.0 / 365.0
+	}
+	f := func(y float64) float64 {
+		value := 0.0
+		for i, cf := range flows {
+			value += cf.Amount * math.Pow(1.0+y/100.0, -years[i])
+		}
+		return value
+	}
+	return solve(f, -99.0, 1000.0, Precision)
+}
+
+// TrueYield calculates the internal rate of return of the security with the
+// cash flows moved to business days of the calendar (following business day
+// convention); Irr returns the street yield on the unadjusted schedule
+func TrueYield(investment float64, s CashflowSecurity, cal *calendar.Calendar) (float64, error) {
+	if err := validate(s); err != nil {
+		return 0.0, err
+	}
+	return irr(investment, cal.Cashflows(s.Cashflows(), calendar.Following), Precision)
 }
 
-// Spread calculates the implied static (zero-volatility) spread
+// PeriodicYield calculates the yield to maturity in percent compounded with the
+// given frequency per year; the fractional first period is discounted with
+// compound or simple interest according to the stub convention
+func PeriodicYield(investment float64, s Security, frequency int, stub term.Stub) (float64, error) {
+	if err := validate(s); err != nil {
+		return 0.0, err
+	}
+	ts := term.Periodic{Frequency: frequency, Stub: stub}
+	f := func(y float64) float64 {
+		ts.Y = y
+		return s.PresentValue(&ts) - investment
+	}
+	// the yield per period must be above -100%
+	return solve(f, -99.0*float64(ts.Compounding()), 1000.0, Precision)
+}
+
+// Spread calculates the implied static (zero-volatility) spread;
+// the term structure ts is not modified
 func Spread(investment float64, s Security, ts term.Structure) (float64, error) {
+	if err := validate(s); err != nil {
+		return 0.0, err
+	}
+	return spread(investment, s, ts, Precision)
+}
+
+func spread(investment float64, s Security, ts term.Structure, precision int) (float64, error) {
 	f := func(spread float64) float64 {
-		value := s.PresentValue(ts.SetSpread(spread))
+		value := s.PresentValue(term.WithSpread(ts, spread))
 		return value - investment
 	}
 
-	root, err := rootfinding.Brent(f, -10000.0, 10000.0, Precision)
-	return root, err
+	return solve(f, -10000.0, 10000.0, precision)
 }
 
 // ImpliedVola calculates the implied volatility for a given option price
@@ -38,6 +122,84 @@ func ImpliedVola(price float64, o Option, ts term.Structure) (float64, error) {
 		return value - price
 	}
 
-	root, err := rootfinding.Brent(f, 0.0, 1000.0, Precision)
-	return root, err
+	return solve(f, 0.0, 1000.0, Precision)
+}
+
+// validate checks the security if it implements the Validator interface
+func validate(s Security) error {
+	if v, ok := s.(Validator); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+// solve finds the root of f in the interval [a, b] and wraps solver errors with ErrNoConvergence
+func solve(f func(float64) float64, a, b float64, precision int) (float64, error) {
+	// trace the solver iterations at the debug level
+	evaluations := 0
+	if logging.Debug() {
+		g := f
+		f = func(x float64) float64 {
+			evaluations += 1
+			v := g(x)
+			logging.Logger().Debug("solver iteration", "n", evaluations, "x", x, "f", v)
+			return v
+		}
+	}
+	root, err := rootfinding.Brent(f, a, b, precision)
+	if err != nil {
+		logging.Logger().Debug("solver failed", "a", a, "b", b, "error", err)
+		return root, fmt.Errorf("%w: %v", ErrNoConvergence, err)
+	}
+	if v := f(root); math.IsNaN(v) || math.IsInf(v, 0) {
+		return root, fmt.Errorf("%w: function value at root is %v", ErrNoConvergence, v)
+	}
+	logging.Logger().Debug("solver converged", "root", root, "evaluations", evaluations)
+	return root, nil
+}
+
+// RealizedCompoundYield calculates the continuously compounded yield in percent
+// realized to maturity when the cash flows are reinvested along the forward
+// rates of the reinvestment term structure (e.g. &term.Flat{R: r} for a
+// constant reinvestment rate r)
+func RealizedCompoundYield(investment float64, s CashflowSecurity, reinvestment term.Structure) (float64, error) {
+	if err := validate(s); err != nil {
+		return 0.0, err
+	}
+	cfs := s.Cashflows()
+	if len(cfs) == 0 || investment <= 0.0 {
+		return 0.0, fmt.Errorf("%w: no cash flows or investment %v not positive", ErrNoConvergence, investment)
+	}
+	horizon := 0.0
+	for _, cf := range cfs {
+		horizon = math.Max(horizon, cf.T)
+	}
+	if horizon <= 0.0 {
+		return 0.0, fmt.Errorf("%w: horizon %v not positive", ErrNoConvergence, horizon)
+	}
+
+	// future value at maturity
+	value := 0.0
+	for _, cf := range cfs {
+		value += cf.Amount * reinvestment.Z(cf.T) / reinvestment.Z(horizon)
+	}
+	if value <= 0.0 {
+		return 0.0, fmt.Errorf("%w: future value %v not positive", ErrNoConvergence, value)
+	}
+	return math.Log(value/investment) / horizon * 100.0, nil
+}
+
+// ReinvestmentRisk returns the difference in bps between the realized
+// compound yield for the reinvestment term structure and the yield to maturity
+// (which assumes reinvestment at the yield to maturity)
+func ReinvestmentRisk(investment float64, s CashflowSecurity, reinvestment term.Structure) (float64, error) {
+	realized, err := RealizedCompoundYield(investment, s, reinvestment)
+	if err != nil {
+		return 0.0, err
+	}
+	ytm, err := Irr(investment, s)
+	if err != nil {
+		return 0.0, err
+	}
+	return (realized - ytm) * 100.0, nil
 }
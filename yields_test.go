@@ -1,12 +1,15 @@
 package fixedincome_test
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"testing"
 	"time"
 
 	"github.com/konimarti/fixedincome"
+	"github.com/konimarti/fixedincome/pkg/calendar"
+	"github.com/konimarti/fixedincome/pkg/cashflow"
 	"github.com/konimarti/fixedincome/pkg/instrument/bond"
 	"github.com/konimarti/fixedincome/pkg/instrument/option"
 	"github.com/konimarti/fixedincome/pkg/maturity"
@@ -131,3 +134,298 @@ func TestImpliedVola(t *testing.T) {
 		}
 	}
 }
+
+func TestIrr_NoConvergence(t *testing.T) {
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2026, 5, 28, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+		},
+		Redemption: 100.0,
+		Coupon:     1.25,
+	}
+	_, err := fixedincome.Irr(-10.0, &b)
+	if !errors.Is(err, fixedincome.ErrNoConvergence) {
+		t.Errorf("expected ErrNoConvergence for negative price; got: %v", err)
+	}
+	ts := term.Flat{R: 1.0}
+	_, err = fixedincome.Spread(-10.0, &b, &ts)
+	if !errors.Is(err, fixedincome.ErrNoConvergence) {
+		t.Errorf("expected ErrNoConvergence for negative price; got: %v", err)
+	}
+}
+
+func TestIrr_InvalidSchedule(t *testing.T) {
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2026, 5, 28, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Redemption: 100.0,
+		Coupon:     1.25,
+	}
+	if _, err := fixedincome.Irr(100.0, &b); !errors.Is(err, maturity.ErrInvalidSchedule) {
+		t.Errorf("expected ErrInvalidSchedule; got: %v", err)
+	}
+}
+
+func TestSpread_TermUnchanged(t *testing.T) {
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2026, 5, 28, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+		},
+		Redemption: 100.0,
+		Coupon:     1.25,
+	}
+	ts := term.Flat{R: 1.0, Spread: 0.0}
+	if _, err := fixedincome.Spread(100.0, &b, &ts); err != nil {
+		t.Error(err)
+	}
+	if ts.Spread != 0.0 {
+		t.Errorf("spread calculation modified the term structure; got spread: %v", ts.Spread)
+	}
+}
+
+func benchmarkBond() *bond.Straight {
+	// ISIN CH0224396983 (quote per 2021-04-01)
+	return &bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2026, 5, 28, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+		},
+		Redemption: 100.0,
+		Coupon:     1.25,
+	}
+}
+
+func BenchmarkIrr(b *testing.B) {
+	bond := benchmarkBond()
+	quote := 109.70 + bond.Accrued()
+	b.ResetTimer()
+	for i := 0; i < b.N; i += 1 {
+		if _, err := fixedincome.Irr(quote, bond); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSpread(b *testing.B) {
+	bond := benchmarkBond()
+	quote := 109.70 + bond.Accrued()
+	ts := term.NelsonSiegelSvensson{B0: -0.266372, B1: -0.471343, B2: 5.68789, B3: -5.12324, T1: 5.74881, T2: 4.14426}
+	b.ResetTimer()
+	for i := 0; i < b.N; i += 1 {
+		if _, err := fixedincome.Spread(quote, bond, &ts); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func fuzzBond(years, frequency int, coupon float64) *bond.Straight {
+	settlement := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	return &bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: settlement,
+			Maturity:   settlement.AddDate(0, 0, years),
+			Frequency:  frequency,
+		},
+		Redemption: 100.0,
+		Coupon:     coupon,
+	}
+}
+
+func FuzzIrr(f *testing.F) {
+	f.Add(109.70, 1.25, 1883, 1)
+	f.Add(1e-9, 1.25, 365, 1)
+	f.Add(100.0, 1e9, 3650, 2)
+	f.Add(100.0, 1.0, 0, 1)
+	f.Add(0.0, 0.0, 1, 12)
+
+	f.Fuzz(func(t *testing.T, quote, coupon float64, days, frequency int) {
+		if days < -36500 || days > 36500 {
+			return
+		}
+		b := fuzzBond(days, frequency, coupon)
+		irr, err := fixedincome.Irr(quote, b)
+		if err != nil {
+			return
+		}
+		if math.IsNaN(irr) || math.IsInf(irr, 0) {
+			t.Fatalf("got %v without an error", irr)
+		}
+	})
+}
+
+func FuzzSpread(f *testing.F) {
+	f.Add(109.70, 1.25, 1883, 1)
+	f.Add(1e-9, 1.25, 365, 1)
+	f.Add(100.0, 1e9, 3650, 2)
+	f.Add(100.0, 1.0, 0, 1)
+
+	ts := term.NelsonSiegelSvensson{B0: -0.266372, B1: -0.471343, B2: 5.68789, B3: -5.12324, T1: 5.74881, T2: 4.14426}
+	f.Fuzz(func(t *testing.T, quote, coupon float64, days, frequency int) {
+		if days < -36500 || days > 36500 {
+			return
+		}
+		b := fuzzBond(days, frequency, coupon)
+		spread, err := fixedincome.Spread(quote, b, &ts)
+		if err != nil {
+			return
+		}
+		if math.IsNaN(spread) || math.IsInf(spread, 0) {
+			t.Fatalf("got %v without an error", spread)
+		}
+	})
+}
+
+func TestPeriodicYield(t *testing.T) {
+	// settlement between coupon dates
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2026, 5, 28, 0, 0, 0, 0, time.UTC),
+			Frequency:  2,
+		},
+		Redemption: 100.0,
+		Coupon:     3.0,
+	}
+
+	for _, stub := range []term.Stub{term.Compound, term.Simple} {
+		ts := term.Periodic{Y: 2.5, Frequency: 2, Stub: stub}
+		y, err := fixedincome.PeriodicYield(b.PresentValue(&ts), &b, 2, stub)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(y-2.5) > 1e-4 {
+			t.Errorf("wrong periodic yield for stub %v; got: %v, expected: %v", stub, y, 2.5)
+		}
+	}
+
+	// simple interest in the stub discounts more, thus a lower yield for the same price
+	dirty := 101.0 + b.Accrued()
+	compound, err := fixedincome.PeriodicYield(dirty, &b, 2, term.Compound)
+	if err != nil {
+		t.Fatal(err)
+	}
+	simple, err := fixedincome.PeriodicYield(dirty, &b, 2, term.Simple)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if simple >= compound {
+		t.Errorf("expected lower street yield; got simple: %v, compound: %v", simple, compound)
+	}
+}
+
+func TestTrueYield(t *testing.T) {
+	// coupon dates on 28 May; 2022-05-28 and 2023-05-28 fall on weekends
+	b := benchmarkBond()
+	dirty := 109.70 + b.Accrued()
+
+	street, err := fixedincome.Irr(dirty, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	weekends, err := fixedincome.TrueYield(dirty, b, calendar.New(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	adjusted, err := fixedincome.TrueYield(dirty, b, calendar.New(time.Date(2024, 5, 28, 0, 0, 0, 0, time.UTC), time.Date(2026, 5, 28, 0, 0, 0, 0, time.UTC)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(weekends-street) < 1e-9 || math.Abs(adjusted-weekends) < 1e-9 {
+		t.Errorf("expected different yields for delayed payments; got street: %v, true: %v, %v", street, weekends, adjusted)
+	}
+	// later payments at a premium price (negative yield) increase the yield
+	if adjusted < weekends || weekends < street {
+		t.Errorf("wrong ordering of yields; got street: %v, true: %v, %v", street, weekends, adjusted)
+	}
+}
+
+func TestXirr(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	testData := []struct {
+		Flows    cashflow.Cashflows
+		Expected float64
+	}{
+		{
+			// one year (365 days) at 10%
+			Flows:    cashflow.Cashflows{{Date: date(2021, 1, 1), Amount: -100.0}, {Date: date(2022, 1, 1), Amount: 110.0}},
+			Expected: 10.0,
+		},
+		{
+			// unordered flows with a second purchase and a partial sale
+			Flows: cashflow.Cashflows{
+				{Date: date(2023, 1, 1), Amount: 110.25 + 52.5},
+				{Date: date(2021, 1, 1), Amount: -100.0},
+				{Date: date(2022, 1, 1), Amount: -50.0},
+			},
+			Expected: 5.0,
+		},
+	}
+	for nr, test := range testData {
+		y, err := fixedincome.Xirr(test.Flows)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(y-test.Expected) > 1e-4 {
+			t.Errorf("wrong xirr for test nr %d; got: %v, expected: %v", nr, y, test.Expected)
+		}
+	}
+
+	if _, err := fixedincome.Xirr(cashflow.Cashflows{{Date: date(2021, 1, 1), Amount: 100.0}}); !errors.Is(err, fixedincome.ErrNoConvergence) {
+		t.Errorf("expected ErrNoConvergence without sign change; got: %v", err)
+	}
+}
+
+func TestRealizedCompoundYield(t *testing.T) {
+	b := bond.Straight{
+		Schedule: maturity.Schedule{
+			Settlement: time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC),
+			Maturity:   time.Date(2031, 4, 1, 0, 0, 0, 0, time.UTC),
+			Frequency:  1,
+		},
+		Redemption: 100.0,
+		Coupon:     5.0,
+	}
+	dirty := b.PresentValue(&term.Flat{R: 4.0})
+
+	testData := []struct {
+		Reinvestment float64
+		Sign         float64
+	}{
+		{Reinvestment: 4.0, Sign: 0.0},
+		{Reinvestment: 1.0, Sign: -1.0},
+		{Reinvestment: 7.0, Sign: 1.0},
+	}
+	for _, test := range testData {
+		risk, err := fixedincome.ReinvestmentRisk(dirty, &b, &term.Flat{R: test.Reinvestment})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.Sign == 0.0 && math.Abs(risk) > 1e-2 {
+			t.Errorf("expected no reinvestment risk at the yield to maturity; got: %v", risk)
+		}
+		if risk*test.Sign < 0.0 || (test.Sign != 0.0 && math.Abs(risk) < 1.0) {
+			t.Errorf("wrong reinvestment risk for rate %v; got: %v bps", test.Reinvestment, risk)
+		}
+	}
+
+	// zero coupon bonds have no reinvestment risk
+	zero := b
+	zero.Coupon = 0.0
+	realized, err := fixedincome.RealizedCompoundYield(zero.PresentValue(&term.Flat{R: 3.0}), &zero, &term.Flat{R: 1.0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(realized-3.0) > 1e-9 {
+		t.Errorf("wrong realized yield of zero coupon bond; got: %v, expected: %v", realized, 3.0)
+	}
+}